@@ -51,9 +51,9 @@ func main() {
 	handler.Use(middleware.RequestID)
 	handler.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:8080"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
-		ExposedHeaders:   []string{"Content-Length", "Content-Type"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Cart-Token"},
+		ExposedHeaders:   []string{"Content-Length", "Content-Type", "X-Cart-Token"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -68,6 +68,7 @@ func main() {
 	// repositories
 	userRepo := sqlitedb.NewUserRepo(db)
 	tokenRepo := sqlitedb.NewTokenRepo(db)
+	addressRepo := sqlitedb.NewAddressRepo(db)
 
 	// order
 	grpcClientOrder, err := grpc.NewClient(cfg.OrderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -86,14 +87,17 @@ func main() {
 	errChecker(err)
 
 	// services
-	authService := service.NewAuthService(userRepo, tokenRepo, cfg.TokenSecret)
+	orderServiceClient := gen.NewOrderServiceClient(grpcClientOrder)
+	authService := service.NewAuthService(userRepo, tokenRepo, cfg.TokenSecret, orderServiceClient)
 	productService := service.NewProductService(gen.NewProductServiceClient(grpcClientProduct), gen.NewShopServiceClient(grpcClientShop))
-	orderService := service.NewOrderService(gen.NewOrderServiceClient(grpcClientOrder))
+	orderService := service.NewOrderService(orderServiceClient, addressRepo)
+	addressService := service.NewAddressService(addressRepo)
 	warehouseService := service.NewWarehouseService(gen.NewWarehouseServiceClient(grpcClientWarehouse))
 
 	rest.NewAuthHandler(handler, authService)
 	rest.NewProductHandler(handler, productService)
 	rest.NewOrderHandler(handler, authService, orderService)
+	rest.NewAddressHandler(handler, authService, addressService)
 	rest.NewWarehouseHandler(handler, authService, warehouseService)
 
 	addr := fmt.Sprintf(":%s", cfg.ServicePort)
@@ -3,5 +3,8 @@ package constanta
 type Locals string
 
 const (
-	LocalUserID Locals = "local-user-id"
+	LocalUserID    Locals = "local-user-id"
+	LocalCartToken Locals = "local-cart-token"
+	LocalCurrency  Locals = "local-currency"
+	LocalRole      Locals = "local-role"
 )
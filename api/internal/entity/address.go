@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Address is the entry of the address book of the user, it is copied into the order when the order is created
+type Address struct {
+	ID            uuid.UUID `db:"id"`
+	UserID        uuid.UUID `db:"user_id"`
+	Label         string    `db:"label"`
+	RecipientName string    `db:"recipient_name"`
+	Phone         string    `db:"phone"`
+	Street        string    `db:"street"`
+	City          string    `db:"city"`
+	Region        string    `db:"region"`
+	PostalCode    string    `db:"postal_code"`
+	IsDefault     bool      `db:"is_default"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func NewAddress(userID uuid.UUID) (*Address, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Address{
+		ID:     id,
+		UserID: userID,
+	}, nil
+}
@@ -3,6 +3,8 @@ package entity
 import (
 	"time"
 
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -13,6 +15,12 @@ type User struct {
 	Name     string    `db:"name"`
 	password []byte    `db:"password"`
 
+	// Currency is the display and settlement currency, empty follows the default of the order service
+	Currency string `db:"currency"`
+
+	// Role is CUSTOMER by default, the ADMIN can access the back-office endpoints, e.g. the warehouse management
+	Role globalcontanta.Role `db:"role"`
+
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -33,6 +41,7 @@ func NewUser(email, password, name string) (*User, error) {
 		Email:    email,
 		Name:     name,
 		password: pass,
+		Role:     globalcontanta.RoleCustomer,
 	}, nil
 }
 
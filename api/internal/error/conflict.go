@@ -0,0 +1,24 @@
+package errs
+
+import (
+	"net/http"
+)
+
+// Conflict is returned when the request cannot be processed with the current state,
+// Details explains what must be resolved by the client
+type Conflict struct {
+	Message string
+	Details any
+}
+
+func (e Conflict) Error() string {
+	if e.Message == "" {
+		return "conflict"
+	}
+
+	return e.Message
+}
+
+func (a Conflict) HttpStatusCode() int {
+	return http.StatusConflict
+}
@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"net/http"
+)
+
+type Forbidden struct {
+	Message string
+}
+
+func (e Forbidden) Error() string {
+	if e.Message == "" {
+		return "forbidden"
+	}
+
+	return e.Message
+}
+
+func (a Forbidden) HttpStatusCode() int {
+	return http.StatusForbidden
+}
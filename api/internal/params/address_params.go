@@ -0,0 +1,65 @@
+package params
+
+import (
+	"strings"
+
+	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/google/uuid"
+)
+
+type (
+	AddressRequest struct {
+		AddressID     string `json:"-"`
+		Label         string `json:"label"`
+		RecipientName string `json:"recipient_name"`
+		Phone         string `json:"phone"`
+		Street        string `json:"street"`
+		City          string `json:"city"`
+		// Region is the region code used for the shipping fee, e.g., JKT
+		Region     string `json:"region"`
+		PostalCode string `json:"postal_code"`
+		IsDefault  bool   `json:"is_default"`
+	}
+
+	AddressResponse struct {
+		AddressID     string `json:"address_id"`
+		Label         string `json:"label,omitempty"`
+		RecipientName string `json:"recipient_name"`
+		Phone         string `json:"phone,omitempty"`
+		Street        string `json:"street"`
+		City          string `json:"city"`
+		Region        string `json:"region"`
+		PostalCode    string `json:"postal_code,omitempty"`
+		IsDefault     bool   `json:"is_default"`
+	}
+)
+
+func (a *AddressRequest) Validate() error {
+	if a.AddressID != "" {
+		if _, err := uuid.Parse(a.AddressID); err != nil {
+			return errs.ValidationError{Message: "not valid address_id"}
+		}
+	}
+
+	if strings.TrimSpace(a.RecipientName) == "" {
+		return errs.ValidationError{Message: "recipient_name is required"}
+	}
+
+	if strings.TrimSpace(a.Street) == "" {
+		return errs.ValidationError{Message: "street is required"}
+	}
+
+	if strings.TrimSpace(a.City) == "" {
+		return errs.ValidationError{Message: "city is required"}
+	}
+
+	if strings.TrimSpace(a.Region) == "" {
+		return errs.ValidationError{Message: "region is required"}
+	}
+
+	if len(a.Label) > 50 {
+		return errs.ValidationError{Message: "label must be at most 50 characters"}
+	}
+
+	return nil
+}
@@ -3,7 +3,11 @@ package params
 import (
 	"strings"
 
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+	"github.com/elangreza/e-commerce/pkg/money"
+
 	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/google/uuid"
 )
 
 type RegisterUserRequest struct {
@@ -31,6 +35,8 @@ func (rur *RegisterUserRequest) Validate() error {
 type LoginUserRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// CartToken is taken from the X-Cart-Token header, the guest cart is merged after login
+	CartToken string `json:"-"`
 }
 
 func (lur *LoginUserRequest) Validate() error {
@@ -62,6 +68,24 @@ func isValidEmail(email string) bool {
 }
 
 type ProcessTokenResponse struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	UserID   uuid.UUID           `json:"user_id"`
+	Currency string              `json:"currency"`
+	Role     globalcontanta.Role `json:"role"`
+}
+
+type UpdateCurrencyRequest struct {
+	Currency string `json:"currency"`
+}
+
+func (ucr *UpdateCurrencyRequest) Validate() error {
+	ucr.Currency = strings.ToUpper(strings.TrimSpace(ucr.Currency))
+	if ucr.Currency == "" {
+		return errs.ValidationError{Message: "currency is required"}
+	}
+
+	if !money.IsKnownCurrency(ucr.Currency) {
+		return errs.ValidationError{Message: "currency is not valid"}
+	}
+
+	return nil
 }
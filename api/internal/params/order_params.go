@@ -1,6 +1,8 @@
 package params
 
 import (
+	"strings"
+
 	errs "github.com/elangreza/e-commerce/api/internal/error"
 	"github.com/google/uuid"
 )
@@ -22,21 +24,92 @@ func (a *AddToCartRequest) Validate() error {
 	return nil
 }
 
+type SetCartItemQuantityRequest struct {
+	ProductID string `json:"-"`
+	Quantity  int64  `json:"quantity"`
+}
+
+func (a *SetCartItemQuantityRequest) Validate() error {
+	if a.ProductID == "" {
+		return errs.ValidationError{Message: "product_id is required"}
+	}
+
+	if a.Quantity < 0 {
+		return errs.ValidationError{Message: "quantity cannot be negative"}
+	}
+
+	return nil
+}
+
+type ApplyCouponRequest struct {
+	Code string `json:"code"`
+}
+
+func (a *ApplyCouponRequest) Validate() error {
+	if strings.TrimSpace(a.Code) == "" {
+		return errs.ValidationError{Message: "code is required"}
+	}
+
+	return nil
+}
+
 type (
 	GetCartItemsResponse struct {
-		ProductID string `json:"product_id"`
-		Quantity  int64  `json:"quantity"`
+		ProductID         string `json:"product_id"`
+		Quantity          int64  `json:"quantity"`
+		Name              string `json:"name,omitempty"`
+		Price             *Money `json:"price,omitempty"`
+		CurrentPrice      *Money `json:"current_price,omitempty"`
+		ActualStock       int64  `json:"actual_stock,omitempty"`
+		PriceChanged      bool   `json:"price_changed,omitempty"`
+		InsufficientStock bool   `json:"insufficient_stock,omitempty"`
+		Unavailable       bool   `json:"unavailable,omitempty"`
+		// SettlementTotal is the line total in the currency of the user
+		SettlementTotal *Money `json:"settlement_total,omitempty"`
+		ExchangeRate    string `json:"exchange_rate,omitempty"`
+		// Discount is the part of the coupon discount for the item
+		Discount *Money `json:"discount,omitempty"`
+		// Tax is only available on the order, TaxRate is in percent
+		Tax          *Money `json:"tax,omitempty"`
+		TaxRate      string `json:"tax_rate,omitempty"`
+		TaxInclusive bool   `json:"tax_inclusive,omitempty"`
 	}
 
 	GetCartResponse struct {
-		CartID string                 `json:"cart_id"`
-		Items  []GetCartItemsResponse `json:"items"`
+		CartID     string                 `json:"cart_id"`
+		Items      []GetCartItemsResponse `json:"items"`
+		Subtotal   *Money                 `json:"subtotal,omitempty"`
+		CouponCode string                 `json:"coupon_code,omitempty"`
+		Discount   *Money                 `json:"discount,omitempty"`
+		// Total is Subtotal - Discount of the coupon
+		Total *Money `json:"total,omitempty"`
+		// CouponIssue is the reason the coupon cannot be applied anymore
+		CouponIssue string `json:"coupon_issue,omitempty"`
+	}
+
+	// CartIssue is the item that must be reviewed before the cart can be ordered
+	CartIssue struct {
+		ProductID   string `json:"product_id"`
+		Reason      string `json:"reason"`
+		OldPrice    *Money `json:"old_price,omitempty"`
+		NewPrice    *Money `json:"new_price,omitempty"`
+		Quantity    int64  `json:"quantity"`
+		ActualStock int64  `json:"actual_stock,omitempty"`
+		CouponCode  string `json:"coupon_code,omitempty"`
+		Message     string `json:"message,omitempty"`
 	}
 )
 
 type (
 	CreateOrderRequest struct {
 		IdempotencyKey string `json:"idempotency_key"`
+		// AddressID is the address of the address book the order is shipped to, the default address is used when it is empty
+		AddressID string `json:"address_id"`
+		// PaymentMethod is the method of the first payment, CARD when it is empty
+		PaymentMethod string `json:"payment_method"`
+		// PaymentAmount is the amount of the first payment, the total amount of the order when it is empty.
+		// the rest of the order is paid by another payment
+		PaymentAmount *Money `json:"payment_amount"`
 	}
 
 	CreateOrderItemsResponse struct {
@@ -52,6 +125,79 @@ type (
 		Status        string                 `json:"status"`
 		Items         []GetCartItemsResponse `json:"items,omitempty"`
 		TransactionID string                 `json:"transaction_id"`
+		CancelReason  string                 `json:"cancel_reason,omitempty"`
+		// TotalAmount is SubtotalAmount - DiscountAmount of the coupon + exclusive tax + ShippingAmount
+		SubtotalAmount *Money `json:"subtotal_amount,omitempty"`
+		DiscountAmount *Money `json:"discount_amount,omitempty"`
+		CouponCode     string `json:"coupon_code,omitempty"`
+		TaxAmount      *Money `json:"tax_amount,omitempty"`
+		ShippingAmount *Money `json:"shipping_amount,omitempty"`
+		ShippingRegion string `json:"shipping_region,omitempty"`
+		// Shipment is the address and the fulfillment progress of the order
+		Shipment *ShipmentResponse `json:"shipment,omitempty"`
+		// StatusHistory is every status of the order, oldest first. only available on the detail of the order
+		StatusHistory []OrderStatusHistoryResponse `json:"status_history,omitempty"`
+		// Refunds is every refund of the paid order, oldest first. only available on the detail of the order
+		Refunds []RefundResponse `json:"refunds,omitempty"`
+		// Returns is every return request of the order, oldest first. only available on the detail of the order
+		Returns []ReturnResponse `json:"returns,omitempty"`
+		// Payments is every payment of the order, oldest first. only available on the detail of the order
+		Payments []OrderPaymentResponse `json:"payments,omitempty"`
+		// PaidAmount is the sum of the paid payments, the order is completed once it covers TotalAmount
+		PaidAmount *Money `json:"paid_amount,omitempty"`
+	}
+
+	OrderPaymentResponse struct {
+		TransactionID  string `json:"transaction_id"`
+		Method         string `json:"method"`
+		Status         string `json:"status"`
+		Amount         *Money `json:"amount"`
+		RefundedAmount *Money `json:"refunded_amount,omitempty"`
+		PaymentURL     string `json:"payment_url,omitempty"`
+		CreatedAt      string `json:"created_at"`
+	}
+
+	RefundResponse struct {
+		RefundID  string               `json:"refund_id"`
+		Status    string               `json:"status"`
+		Amount    *Money               `json:"amount"`
+		Reason    string               `json:"reason,omitempty"`
+		Items     []RefundItemResponse `json:"items"`
+		Restocked bool                 `json:"restocked"`
+		CreatedAt string               `json:"created_at"`
+		ReturnID  string               `json:"return_id,omitempty"`
+	}
+
+	RefundItemResponse struct {
+		ProductID string `json:"product_id"`
+		Quantity  int64  `json:"quantity"`
+		Amount    *Money `json:"amount"`
+	}
+
+	OrderStatusHistoryResponse struct {
+		FromStatus string `json:"from_status,omitempty"`
+		ToStatus   string `json:"to_status"`
+		Reason     string `json:"reason,omitempty"`
+		Actor      string `json:"actor"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	ShipmentResponse struct {
+		Address        ShippingAddressResponse `json:"address"`
+		Carrier        string                  `json:"carrier,omitempty"`
+		TrackingNumber string                  `json:"tracking_number,omitempty"`
+		PackedAt       string                  `json:"packed_at,omitempty"`
+		ShippedAt      string                  `json:"shipped_at,omitempty"`
+		DeliveredAt    string                  `json:"delivered_at,omitempty"`
+	}
+
+	ShippingAddressResponse struct {
+		RecipientName string `json:"recipient_name"`
+		Phone         string `json:"phone,omitempty"`
+		Street        string `json:"street"`
+		City          string `json:"city"`
+		Region        string `json:"region"`
+		PostalCode    string `json:"postal_code,omitempty"`
 	}
 )
 
@@ -65,6 +211,16 @@ func (a *CreateOrderRequest) Validate() error {
 		return errs.ValidationError{Message: "not valid idempotency_key"}
 	}
 
+	if a.AddressID != "" {
+		if _, err := uuid.Parse(a.AddressID); err != nil {
+			return errs.ValidationError{Message: "not valid address_id"}
+		}
+	}
+
+	if a.PaymentAmount != nil && a.PaymentAmount.Units < 1 {
+		return errs.ValidationError{Message: "payment_amount must be positive"}
+	}
+
 	return nil
 }
 
@@ -79,3 +235,122 @@ type (
 		OrderList []OrderResponse `json:"order_list"`
 	}
 )
+
+type CancelOrderRequest struct {
+	OrderID string `json:"-"`
+	Reason  string `json:"reason"`
+}
+
+func (a *CancelOrderRequest) Validate() error {
+	if a.OrderID == "" {
+		return errs.ValidationError{Message: "order_id is required"}
+	}
+
+	_, err := uuid.Parse(a.OrderID)
+	if err != nil {
+		return errs.ValidationError{Message: "not valid order_id"}
+	}
+
+	if len(a.Reason) > 255 {
+		return errs.ValidationError{Message: "reason must be at most 255 characters"}
+	}
+
+	return nil
+}
+
+type AddOrderPaymentRequest struct {
+	OrderID string `json:"-"`
+	// Method is CARD, BANK_TRANSFER, E_WALLET or STORE_CREDIT, CARD when it is empty
+	Method string `json:"method"`
+	// Amount is the unpaid amount of the order when it is empty
+	Amount *Money `json:"amount"`
+}
+
+func (a *AddOrderPaymentRequest) Validate() error {
+	if _, err := uuid.Parse(a.OrderID); err != nil {
+		return errs.ValidationError{Message: "not valid order_id"}
+	}
+
+	if a.Amount != nil && a.Amount.Units < 1 {
+		return errs.ValidationError{Message: "amount must be positive"}
+	}
+
+	return nil
+}
+
+type (
+	ReturnItem struct {
+		ProductID string `json:"product_id"`
+		Quantity  int64  `json:"quantity"`
+	}
+
+	CreateReturnRequest struct {
+		OrderID string       `json:"-"`
+		Items   []ReturnItem `json:"items"`
+		Reason  string       `json:"reason"`
+	}
+
+	ReturnResponse struct {
+		ReturnID string       `json:"return_id"`
+		OrderID  string       `json:"order_id"`
+		Status   string       `json:"status"`
+		Reason   string       `json:"reason"`
+		Items    []ReturnItem `json:"items"`
+		// Note is written by the admin when the return is reviewed
+		Note string `json:"note,omitempty"`
+		// WarehouseID is the warehouse that received the items
+		WarehouseID int64  `json:"warehouse_id,omitempty"`
+		RefundID    string `json:"refund_id,omitempty"`
+		CreatedAt   string `json:"created_at"`
+		UpdatedAt   string `json:"updated_at"`
+	}
+)
+
+func (a *CreateReturnRequest) Validate() error {
+	if _, err := uuid.Parse(a.OrderID); err != nil {
+		return errs.ValidationError{Message: "not valid order_id"}
+	}
+
+	if len(a.Items) == 0 {
+		return errs.ValidationError{Message: "items are required"}
+	}
+
+	for _, item := range a.Items {
+		if item.ProductID == "" {
+			return errs.ValidationError{Message: "product_id is required"}
+		}
+
+		if item.Quantity < 1 {
+			return errs.ValidationError{Message: "quantity must be positive"}
+		}
+	}
+
+	if strings.TrimSpace(a.Reason) == "" {
+		return errs.ValidationError{Message: "reason is required"}
+	}
+
+	if len(a.Reason) > 255 {
+		return errs.ValidationError{Message: "reason must be at most 255 characters"}
+	}
+
+	return nil
+}
+
+type ReviewReturnRequest struct {
+	ReturnID string `json:"-"`
+	Approve  bool   `json:"approve"`
+	// Note is required to reject the return
+	Note string `json:"note"`
+}
+
+func (a *ReviewReturnRequest) Validate() error {
+	if _, err := uuid.Parse(a.ReturnID); err != nil {
+		return errs.ValidationError{Message: "not valid return_id"}
+	}
+
+	if !a.Approve && strings.TrimSpace(a.Note) == "" {
+		return errs.ValidationError{Message: "note is required to reject the return"}
+	}
+
+	return nil
+}
@@ -1,6 +1,12 @@
 package params
 
-import errs "github.com/elangreza/e-commerce/api/internal/error"
+import (
+	"strings"
+	"time"
+
+	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/google/uuid"
+)
 
 type SetWarehouseStatusRequest struct {
 	WarehouseID int64 `json:"warehouse_id"`
@@ -35,3 +41,153 @@ func (rur *TransferStockBetweenWarehouseRequest) Validate() error {
 
 	return nil
 }
+
+type FulfillOrderRequest struct {
+	OrderID     string `json:"-"`
+	WarehouseID int64  `json:"warehouse_id"`
+	// Status is one of PACKED, SHIPPED or DELIVERED, the carrier and the tracking number are required to ship
+	Status         string `json:"status"`
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+func (rur *FulfillOrderRequest) Validate() error {
+	if _, err := uuid.Parse(rur.OrderID); err != nil {
+		return errs.ValidationError{Message: "not valid order_id"}
+	}
+
+	if rur.WarehouseID < 1 {
+		return errs.ValidationError{Message: "warehouse_id must be larger than 0"}
+	}
+
+	switch strings.ToUpper(rur.Status) {
+	case "PACKED", "DELIVERED":
+	case "SHIPPED":
+		if strings.TrimSpace(rur.Carrier) == "" || strings.TrimSpace(rur.TrackingNumber) == "" {
+			return errs.ValidationError{Message: "carrier and tracking_number are required to ship the order"}
+		}
+	default:
+		return errs.ValidationError{Message: "status must be one of PACKED, SHIPPED or DELIVERED"}
+	}
+
+	return nil
+}
+
+type ReceiveReturnedStockRequest struct {
+	ReturnID    string `json:"-"`
+	WarehouseID int64  `json:"warehouse_id"`
+}
+
+func (rur *ReceiveReturnedStockRequest) Validate() error {
+	if _, err := uuid.Parse(rur.ReturnID); err != nil {
+		return errs.ValidationError{Message: "not valid return_id"}
+	}
+
+	if rur.WarehouseID < 1 {
+		return errs.ValidationError{Message: "warehouse_id must be larger than 0"}
+	}
+
+	return nil
+}
+
+type ReceiveStockRequest struct {
+	ProductID   string `json:"product_id"`
+	WarehouseID int64  `json:"warehouse_id"`
+	ShopID      int64  `json:"shop_id"`
+	Quantity    int64  `json:"quantity"`
+	LotNumber   string `json:"lot_number"`
+	// ReceivedAt is RFC3339, default is now
+	ReceivedAt string `json:"received_at"`
+	// ExpiresAt is RFC3339, empty when the goods do not expire
+	ExpiresAt string `json:"expires_at"`
+}
+
+func (rur *ReceiveStockRequest) Validate() error {
+	if _, err := uuid.Parse(rur.ProductID); err != nil {
+		return errs.ValidationError{Message: "not valid product_id"}
+	}
+
+	if rur.WarehouseID < 1 {
+		return errs.ValidationError{Message: "warehouse_id must be larger than 0"}
+	}
+
+	if rur.ShopID < 1 {
+		return errs.ValidationError{Message: "shop_id must be larger than 0"}
+	}
+
+	if rur.Quantity < 1 {
+		return errs.ValidationError{Message: "quantity must be larger than 0"}
+	}
+
+	if rur.ReceivedAt != "" {
+		if _, err := time.Parse(time.RFC3339, rur.ReceivedAt); err != nil {
+			return errs.ValidationError{Message: "received_at must be RFC3339"}
+		}
+	}
+
+	if rur.ExpiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, rur.ExpiresAt); err != nil {
+			return errs.ValidationError{Message: "expires_at must be RFC3339"}
+		}
+	}
+
+	return nil
+}
+
+type AdjustStockRequest struct {
+	ProductID   string `json:"product_id"`
+	WarehouseID int64  `json:"warehouse_id"`
+	ShopID      int64  `json:"shop_id"`
+	// LotNumber is empty for the stock that is not tracked by lot
+	LotNumber string `json:"lot_number"`
+	// Quantity is negative when it is taken from the stock
+	Quantity int64 `json:"quantity"`
+	// Reason is one of DAMAGE, LOSS or CYCLE_COUNT
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+func (rur *AdjustStockRequest) Validate() error {
+	if _, err := uuid.Parse(rur.ProductID); err != nil {
+		return errs.ValidationError{Message: "not valid product_id"}
+	}
+
+	if rur.WarehouseID < 1 {
+		return errs.ValidationError{Message: "warehouse_id must be larger than 0"}
+	}
+
+	if rur.ShopID < 1 {
+		return errs.ValidationError{Message: "shop_id must be larger than 0"}
+	}
+
+	if rur.Quantity == 0 {
+		return errs.ValidationError{Message: "quantity cannot be 0"}
+	}
+
+	switch strings.ToUpper(rur.Reason) {
+	case "DAMAGE", "LOSS":
+		if rur.Quantity > 0 {
+			return errs.ValidationError{Message: "quantity of damaged or lost stock must be negative"}
+		}
+	case "CYCLE_COUNT":
+	default:
+		return errs.ValidationError{Message: "reason must be one of DAMAGE, LOSS or CYCLE_COUNT"}
+	}
+
+	return nil
+}
+
+type StockMovementResponse struct {
+	ID          int64  `json:"id"`
+	StockID     int64  `json:"stock_id"`
+	WarehouseID int64  `json:"warehouse_id"`
+	ProductID   string `json:"product_id"`
+	Type        string `json:"type"`
+	// Quantity is the change of the quantity of the stock
+	Quantity int64 `json:"quantity"`
+	// Balance is the quantity of the stock after the movement
+	Balance   int64  `json:"balance"`
+	Actor     string `json:"actor"`
+	Reference string `json:"reference"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	AddressService interface {
+		ListAddresses(ctx context.Context) ([]params.AddressResponse, error)
+		CreateAddress(ctx context.Context, req params.AddressRequest) (*params.AddressResponse, error)
+		UpdateAddress(ctx context.Context, req params.AddressRequest) (*params.AddressResponse, error)
+		DeleteAddress(ctx context.Context, addressID string) error
+	}
+
+	addressHandler struct {
+		svc AddressService
+	}
+)
+
+func NewAddressHandler(
+	publicRoute chi.Router,
+	authService AuthService,
+	svc AddressService,
+) {
+
+	authMiddleware := AuthMiddleware{
+		svc: authService,
+	}
+
+	ah := addressHandler{
+		svc: svc,
+	}
+
+	publicRoute.Group(func(r chi.Router) {
+		r.Use(authMiddleware.MustAuthMiddleware())
+		r.Get("/addresses", ah.ListAddresses())
+		r.Post("/addresses", ah.CreateAddress())
+		r.Put("/addresses/{address_id}", ah.UpdateAddress())
+		r.Delete("/addresses/{address_id}", ah.DeleteAddress())
+	})
+}
+
+func (ah *addressHandler) ListAddresses() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		addresses, err := ah.svc.ListAddresses(ctx)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, addresses)
+	}
+}
+
+func (ah *addressHandler) CreateAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.AddressRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		address, err := ah.svc.CreateAddress(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, address)
+	}
+}
+
+func (ah *addressHandler) UpdateAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.AddressRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.AddressID = chi.URLParam(r, "address_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		address, err := ah.svc.UpdateAddress(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, address)
+	}
+}
+
+func (ah *addressHandler) DeleteAddress() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := ah.svc.DeleteAddress(ctx, chi.URLParam(r, "address_id"))
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
@@ -12,8 +12,10 @@ import (
 
 type (
 	AutService interface {
+		AuthService
 		RegisterUser(ctx context.Context, req params.RegisterUserRequest) error
 		LoginUser(ctx context.Context, req params.LoginUserRequest) (string, error)
+		UpdateCurrency(ctx context.Context, req params.UpdateCurrencyRequest) error
 	}
 
 	AuthHandler struct {
@@ -27,10 +29,19 @@ func NewAuthHandler(ar chi.Router, authService AutService) {
 		svc: authService,
 	}
 
+	authMiddleware := AuthMiddleware{
+		svc: authService,
+	}
+
 	ar.Route("/auth", func(r chi.Router) {
 		r.Post("/register", authHandler.RegisterUser)
 		r.Post("/login", authHandler.LoginUser)
 	})
+
+	ar.Group(func(r chi.Router) {
+		r.Use(authMiddleware.MustAuthMiddleware())
+		r.Put("/users/currency", authHandler.UpdateCurrency)
+	})
 }
 
 // RegisterUser handles user registration.
@@ -69,14 +80,15 @@ func (ah *AuthHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 // LoginUser handles user login.
 //
 //	@Summary		Login User
-//	@Description	Authenticate a user with email and password.
+//	@Description	Authenticate a user with email and password. The guest cart of X-Cart-Token is merged into the user cart.
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		params.LoginUserRequest	true	"Login User Request"
-//	@Success		200		{string}	string					"ok"
-//	@Failure		400		{object}	errs.ValidationError
-//	@Failure		500		{object}	APIError
+//	@Param			body			body		params.LoginUserRequest	true	"Login User Request"
+//	@Param			X-Cart-Token	header		string					false	"Guest cart token"
+//	@Success		200				{string}	string					"ok"
+//	@Failure		400				{object}	errs.ValidationError
+//	@Failure		500				{object}	APIError
 //	@Router			/auth/login [post]
 func (ah *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	body := params.LoginUserRequest{}
@@ -90,6 +102,8 @@ func (ah *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body.CartToken = r.Header.Get(cartTokenHeader)
+
 	res, err := ah.svc.LoginUser(r.Context(), body)
 	if err != nil {
 		sendErrorResponse(w, http.StatusInternalServerError, err)
@@ -98,3 +112,36 @@ func (ah *AuthHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	sendSuccessResponse(w, http.StatusOK, map[string]string{"token": res})
 }
+
+// UpdateCurrency handles the currency preference of the user.
+//
+//	@Summary		Update Currency
+//	@Description	Set the display and settlement currency of the cart and the next orders.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		params.UpdateCurrencyRequest	true	"Update Currency Request"
+//	@Success		200		{string}	string							"ok"
+//	@Failure		400		{object}	errs.ValidationError
+//	@Failure		500		{object}	APIError
+//	@Router			/users/currency [put]
+func (ah *AuthHandler) UpdateCurrency(w http.ResponseWriter, r *http.Request) {
+	body := params.UpdateCurrencyRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := ah.svc.UpdateCurrency(r.Context(), body)
+	if err != nil {
+		sendErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendSuccessResponse(w, http.StatusOK, "ok")
+}
@@ -1,3 +1,4 @@
+//go:generate mockgen -source=auth_middleware.go -destination=mock/mock_auth_middleware.go -package=mock
 package rest
 
 import (
@@ -6,13 +7,20 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+
 	"github.com/elangreza/e-commerce/api/internal/constanta"
+	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/elangreza/e-commerce/api/internal/params"
 	"github.com/google/uuid"
 )
 
+// cartTokenHeader carries the opaque token of the guest cart
+const cartTokenHeader = "X-Cart-Token"
+
 type (
 	AuthService interface {
-		ProcessToken(ctx context.Context, reqToken string) (uuid.UUID, error)
+		ProcessToken(ctx context.Context, reqToken string) (*params.ProcessTokenResponse, error)
 	}
 
 	AuthMiddleware struct {
@@ -46,13 +54,58 @@ func (am *AuthMiddleware) MustAuthMiddleware() func(next http.Handler) http.Hand
 
 			token := rawToken[1]
 
-			userID, err := am.svc.ProcessToken(r.Context(), token)
+			res, err := am.svc.ProcessToken(r.Context(), token)
 			if err != nil {
 				sendErrorResponse(w, http.StatusUnauthorized, errors.New("unauthorize user"))
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), constanta.LocalUserID, userID)
+			ctx := context.WithValue(r.Context(), constanta.LocalUserID, res.UserID)
+			ctx = context.WithValue(ctx, constanta.LocalCurrency, res.Currency)
+			ctx = context.WithValue(ctx, constanta.LocalRole, res.Role)
+
+			r = r.WithContext(ctx)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MustAdminMiddleware only lets the admin user through, it must be used after the MustAuthMiddleware.
+// The back-office endpoints, e.g. the warehouse management, are grouped behind it
+func (am *AuthMiddleware) MustAdminMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := r.Context().Value(constanta.LocalRole).(globalcontanta.Role)
+			if !ok || role != globalcontanta.RoleAdmin {
+				sendErrorResponse(w, http.StatusForbidden, errs.Forbidden{Message: "admin role is required"})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CartSessionMiddleware authenticates the user when the authorization header is sent,
+// otherwise the request is served as a guest with the cart token from the X-Cart-Token header.
+// A new cart token is issued in the response header when the guest does not have a valid one
+func (am *AuthMiddleware) CartSessionMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(r.Header["Authorization"]) > 0 {
+				am.MustAuthMiddleware()(next).ServeHTTP(w, r)
+				return
+			}
+
+			cartToken := r.Header.Get(cartTokenHeader)
+			if _, err := uuid.Parse(cartToken); err != nil {
+				cartToken = uuid.NewString()
+			}
+
+			w.Header().Set(cartTokenHeader, cartToken)
+
+			ctx := context.WithValue(r.Context(), constanta.LocalCartToken, cartToken)
 
 			r = r.WithContext(ctx)
 
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_handler.go -destination=mock/mock_order_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/e-commerce/api/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// AddOrderPayment mocks base method.
+func (m *MockOrderService) AddOrderPayment(ctx context.Context, req params.AddOrderPaymentRequest) (*params.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderPayment", ctx, req)
+	ret0, _ := ret[0].(*params.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrderPayment indicates an expected call of AddOrderPayment.
+func (mr *MockOrderServiceMockRecorder) AddOrderPayment(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderPayment", reflect.TypeOf((*MockOrderService)(nil).AddOrderPayment), ctx, req)
+}
+
+// AddProductToCart mocks base method.
+func (m *MockOrderService) AddProductToCart(ctx context.Context, req params.AddToCartRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductToCart", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProductToCart indicates an expected call of AddProductToCart.
+func (mr *MockOrderServiceMockRecorder) AddProductToCart(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductToCart", reflect.TypeOf((*MockOrderService)(nil).AddProductToCart), ctx, req)
+}
+
+// ApplyCoupon mocks base method.
+func (m *MockOrderService) ApplyCoupon(ctx context.Context, req params.ApplyCouponRequest) (*params.GetCartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCoupon", ctx, req)
+	ret0, _ := ret[0].(*params.GetCartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCoupon indicates an expected call of ApplyCoupon.
+func (mr *MockOrderServiceMockRecorder) ApplyCoupon(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCoupon", reflect.TypeOf((*MockOrderService)(nil).ApplyCoupon), ctx, req)
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderService) CancelOrder(ctx context.Context, req params.CancelOrderRequest) (*params.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, req)
+	ret0, _ := ret[0].(*params.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderServiceMockRecorder) CancelOrder(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderService)(nil).CancelOrder), ctx, req)
+}
+
+// ClearCart mocks base method.
+func (m *MockOrderService) ClearCart(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockOrderServiceMockRecorder) ClearCart(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockOrderService)(nil).ClearCart), ctx)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderService) CreateOrder(ctx context.Context, req params.CreateOrderRequest) (*params.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, req)
+	ret0, _ := ret[0].(*params.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderServiceMockRecorder) CreateOrder(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), ctx, req)
+}
+
+// CreateReturn mocks base method.
+func (m *MockOrderService) CreateReturn(ctx context.Context, req params.CreateReturnRequest) (*params.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReturn", ctx, req)
+	ret0, _ := ret[0].(*params.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockOrderServiceMockRecorder) CreateReturn(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockOrderService)(nil).CreateReturn), ctx, req)
+}
+
+// GetCart mocks base method.
+func (m *MockOrderService) GetCart(ctx context.Context) (*params.GetCartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", ctx)
+	ret0, _ := ret[0].(*params.GetCartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockOrderServiceMockRecorder) GetCart(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockOrderService)(nil).GetCart), ctx)
+}
+
+// GetOrderDetail mocks base method.
+func (m *MockOrderService) GetOrderDetail(ctx context.Context, orderID string) (*params.OrderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderDetail", ctx, orderID)
+	ret0, _ := ret[0].(*params.OrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderDetail indicates an expected call of GetOrderDetail.
+func (mr *MockOrderServiceMockRecorder) GetOrderDetail(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetail", reflect.TypeOf((*MockOrderService)(nil).GetOrderDetail), ctx, orderID)
+}
+
+// GetOrderList mocks base method.
+func (m *MockOrderService) GetOrderList(ctx context.Context, req params.GetOrderListRequest) (*params.GetOrderListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderList", ctx, req)
+	ret0, _ := ret[0].(*params.GetOrderListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderList indicates an expected call of GetOrderList.
+func (mr *MockOrderServiceMockRecorder) GetOrderList(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderList", reflect.TypeOf((*MockOrderService)(nil).GetOrderList), ctx, req)
+}
+
+// RemoveCartItem mocks base method.
+func (m *MockOrderService) RemoveCartItem(ctx context.Context, productID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCartItem", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCartItem indicates an expected call of RemoveCartItem.
+func (mr *MockOrderServiceMockRecorder) RemoveCartItem(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockOrderService)(nil).RemoveCartItem), ctx, productID)
+}
+
+// RemoveCoupon mocks base method.
+func (m *MockOrderService) RemoveCoupon(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCoupon", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCoupon indicates an expected call of RemoveCoupon.
+func (mr *MockOrderServiceMockRecorder) RemoveCoupon(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoupon", reflect.TypeOf((*MockOrderService)(nil).RemoveCoupon), ctx)
+}
+
+// ReviewReturn mocks base method.
+func (m *MockOrderService) ReviewReturn(ctx context.Context, req params.ReviewReturnRequest) (*params.ReturnResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewReturn", ctx, req)
+	ret0, _ := ret[0].(*params.ReturnResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewReturn indicates an expected call of ReviewReturn.
+func (mr *MockOrderServiceMockRecorder) ReviewReturn(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewReturn", reflect.TypeOf((*MockOrderService)(nil).ReviewReturn), ctx, req)
+}
+
+// SetCartItemQuantity mocks base method.
+func (m *MockOrderService) SetCartItemQuantity(ctx context.Context, req params.SetCartItemQuantityRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartItemQuantity", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartItemQuantity indicates an expected call of SetCartItemQuantity.
+func (mr *MockOrderServiceMockRecorder) SetCartItemQuantity(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartItemQuantity", reflect.TypeOf((*MockOrderService)(nil).SetCartItemQuantity), ctx, req)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: warehouse_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=warehouse_handler.go -destination=mock/mock_warehouse_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/e-commerce/api/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWarehouseService is a mock of WarehouseService interface.
+type MockWarehouseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWarehouseServiceMockRecorder is the mock recorder for MockWarehouseService.
+type MockWarehouseServiceMockRecorder struct {
+	mock *MockWarehouseService
+}
+
+// NewMockWarehouseService creates a new mock instance.
+func NewMockWarehouseService(ctrl *gomock.Controller) *MockWarehouseService {
+	mock := &MockWarehouseService{ctrl: ctrl}
+	mock.recorder = &MockWarehouseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouseService) EXPECT() *MockWarehouseServiceMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockWarehouseService) AdjustStock(ctx context.Context, req params.AdjustStockRequest) (*params.StockMovementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, req)
+	ret0, _ := ret[0].(*params.StockMovementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockWarehouseServiceMockRecorder) AdjustStock(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockWarehouseService)(nil).AdjustStock), ctx, req)
+}
+
+// FulfillOrder mocks base method.
+func (m *MockWarehouseService) FulfillOrder(ctx context.Context, req params.FulfillOrderRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FulfillOrder", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FulfillOrder indicates an expected call of FulfillOrder.
+func (mr *MockWarehouseServiceMockRecorder) FulfillOrder(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FulfillOrder", reflect.TypeOf((*MockWarehouseService)(nil).FulfillOrder), ctx, req)
+}
+
+// ReceiveReturnedStock mocks base method.
+func (m *MockWarehouseService) ReceiveReturnedStock(ctx context.Context, req params.ReceiveReturnedStockRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveReturnedStock", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveReturnedStock indicates an expected call of ReceiveReturnedStock.
+func (mr *MockWarehouseServiceMockRecorder) ReceiveReturnedStock(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturnedStock", reflect.TypeOf((*MockWarehouseService)(nil).ReceiveReturnedStock), ctx, req)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockWarehouseService) ReceiveStock(ctx context.Context, req params.ReceiveStockRequest) (*params.StockMovementResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveStock", ctx, req)
+	ret0, _ := ret[0].(*params.StockMovementResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockWarehouseServiceMockRecorder) ReceiveStock(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockWarehouseService)(nil).ReceiveStock), ctx, req)
+}
+
+// SetWarehouseStatus mocks base method.
+func (m *MockWarehouseService) SetWarehouseStatus(ctx context.Context, req params.SetWarehouseStatusRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWarehouseStatus", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWarehouseStatus indicates an expected call of SetWarehouseStatus.
+func (mr *MockWarehouseServiceMockRecorder) SetWarehouseStatus(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWarehouseStatus", reflect.TypeOf((*MockWarehouseService)(nil).SetWarehouseStatus), ctx, req)
+}
+
+// TransferStockBetweenWarehouse mocks base method.
+func (m *MockWarehouseService) TransferStockBetweenWarehouse(ctx context.Context, req params.TransferStockBetweenWarehouseRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferStockBetweenWarehouse", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferStockBetweenWarehouse indicates an expected call of TransferStockBetweenWarehouse.
+func (mr *MockWarehouseServiceMockRecorder) TransferStockBetweenWarehouse(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferStockBetweenWarehouse", reflect.TypeOf((*MockWarehouseService)(nil).TransferStockBetweenWarehouse), ctx, req)
+}
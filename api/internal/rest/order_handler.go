@@ -1,8 +1,11 @@
+//go:generate mockgen -source=order_handler.go -destination=mock/mock_order_handler.go -package=mock
 package rest
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	errs "github.com/elangreza/e-commerce/api/internal/error"
@@ -14,9 +17,18 @@ type (
 	OrderService interface {
 		AddProductToCart(ctx context.Context, req params.AddToCartRequest) error
 		GetCart(ctx context.Context) (*params.GetCartResponse, error)
+		RemoveCartItem(ctx context.Context, productID string) error
+		SetCartItemQuantity(ctx context.Context, req params.SetCartItemQuantityRequest) error
+		ClearCart(ctx context.Context) error
+		ApplyCoupon(ctx context.Context, req params.ApplyCouponRequest) (*params.GetCartResponse, error)
+		RemoveCoupon(ctx context.Context) error
 		CreateOrder(ctx context.Context, req params.CreateOrderRequest) (*params.OrderResponse, error)
 		GetOrderList(ctx context.Context, req params.GetOrderListRequest) (*params.GetOrderListResponse, error)
 		GetOrderDetail(ctx context.Context, orderID string) (*params.OrderResponse, error)
+		CancelOrder(ctx context.Context, req params.CancelOrderRequest) (*params.OrderResponse, error)
+		AddOrderPayment(ctx context.Context, req params.AddOrderPaymentRequest) (*params.OrderResponse, error)
+		CreateReturn(ctx context.Context, req params.CreateReturnRequest) (*params.ReturnResponse, error)
+		ReviewReturn(ctx context.Context, req params.ReviewReturnRequest) (*params.ReturnResponse, error)
 	}
 
 	orderHandler struct {
@@ -38,13 +50,32 @@ func NewOrderHandler(
 		svc: svc,
 	}
 
+	// cart is available for guest with cart token
 	publicRoute.Group(func(r chi.Router) {
-		r.Use(authMiddleware.MustAuthMiddleware())
+		r.Use(authMiddleware.CartSessionMiddleware())
 		r.Post("/cart", oh.AddProductToCart())
 		r.Get("/cart", oh.GetCart())
+		r.Delete("/cart", oh.ClearCart())
+		r.Post("/cart/coupon", oh.ApplyCoupon())
+		r.Delete("/cart/coupon", oh.RemoveCoupon())
+		r.Delete("/cart/{product_id}", oh.RemoveCartItem())
+		r.Patch("/cart/{product_id}", oh.SetCartItemQuantity())
+	})
+
+	publicRoute.Group(func(r chi.Router) {
+		r.Use(authMiddleware.MustAuthMiddleware())
 		r.Post("/orders", oh.CreateOrder())
 		r.Get("/orders", oh.GetOrderList())
 		r.Get("/orders/{order_id}", oh.GetOrderDetail())
+		r.Post("/orders/{order_id}/cancel", oh.CancelOrder())
+		r.Post("/orders/{order_id}/payments", oh.AddOrderPayment())
+		r.Post("/orders/{order_id}/returns", oh.CreateReturn())
+	})
+
+	publicRoute.Group(func(r chi.Router) {
+		r.Use(authMiddleware.MustAuthMiddleware())
+		r.Use(authMiddleware.MustAdminMiddleware())
+		r.Post("/returns/{return_id}/review", oh.ReviewReturn())
 	})
 }
 
@@ -87,6 +118,105 @@ func (oh *orderHandler) GetCart() http.HandlerFunc {
 	}
 }
 
+func (oh *orderHandler) RemoveCartItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		productID := chi.URLParam(r, "product_id")
+		if productID == "" {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: "product_id is required"})
+			return
+		}
+
+		ctx := r.Context()
+
+		err := oh.svc.RemoveCartItem(ctx, productID)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
+func (oh *orderHandler) SetCartItemQuantity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.SetCartItemQuantityRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.ProductID = chi.URLParam(r, "product_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		err := oh.svc.SetCartItemQuantity(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
+func (oh *orderHandler) ClearCart() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := oh.svc.ClearCart(ctx)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
+func (oh *orderHandler) ApplyCoupon() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.ApplyCouponRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		cart, err := oh.svc.ApplyCoupon(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, cart)
+	}
+}
+
+func (oh *orderHandler) RemoveCoupon() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := oh.svc.RemoveCoupon(ctx)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
 func (oh *orderHandler) CreateOrder() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		body := params.CreateOrderRequest{}
@@ -144,3 +274,109 @@ func (oh *orderHandler) GetOrderDetail() http.HandlerFunc {
 		sendSuccessResponse(w, http.StatusOK, order)
 	}
 }
+
+func (oh *orderHandler) CancelOrder() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the body is optional, it only carries the reason
+		body := params.CancelOrderRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.OrderID = chi.URLParam(r, "order_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		order, err := oh.svc.CancelOrder(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, order)
+	}
+}
+
+func (oh *orderHandler) AddOrderPayment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the body is optional, the whole unpaid amount is paid by card without it
+		body := params.AddOrderPaymentRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.OrderID = chi.URLParam(r, "order_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		order, err := oh.svc.AddOrderPayment(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, order)
+	}
+}
+
+func (oh *orderHandler) CreateReturn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.CreateReturnRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.OrderID = chi.URLParam(r, "order_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		ret, err := oh.svc.CreateReturn(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, ret)
+	}
+}
+
+func (oh *orderHandler) ReviewReturn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.ReviewReturnRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.ReturnID = chi.URLParam(r, "return_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		ret, err := oh.svc.ReviewReturn(ctx, body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, ret)
+	}
+}
@@ -0,0 +1,115 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/elangreza/e-commerce/api/internal/rest"
+	"github.com/elangreza/e-commerce/api/internal/rest/mock"
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OrderHandlerTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	router           *chi.Mux
+	mockAuthService  *mock.MockAuthService
+	mockOrderService *mock.MockOrderService
+}
+
+func (s *OrderHandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+
+	s.mockAuthService = mock.NewMockAuthService(s.ctrl)
+	s.mockOrderService = mock.NewMockOrderService(s.ctrl)
+
+	s.router = chi.NewRouter()
+	rest.NewOrderHandler(s.router, s.mockAuthService, s.mockOrderService)
+}
+
+func (s *OrderHandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestOrderHandlerSuite(t *testing.T) {
+	suite.Run(t, new(OrderHandlerTestSuite))
+}
+
+func (s *OrderHandlerTestSuite) TestReviewReturn() {
+	returnID := uuid.NewString()
+
+	tests := []struct {
+		name           string
+		token          string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:  "Admin approves the return",
+			token: "admin-token",
+			body:  `{"approve":true}`,
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "admin-token").
+					Return(&params.ProcessTokenResponse{
+						UserID: uuid.New(),
+						Role:   globalcontanta.RoleAdmin,
+					}, nil)
+				s.mockOrderService.EXPECT().
+					ReviewReturn(gomock.Any(), params.ReviewReturnRequest{ReturnID: returnID, Approve: true}).
+					Return(&params.ReturnResponse{ReturnID: returnID, Status: "APPROVED"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Customer is forbidden",
+			token: "customer-token",
+			body:  `{"approve":true}`,
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "customer-token").
+					Return(&params.ProcessTokenResponse{
+						UserID: uuid.New(),
+						Role:   globalcontanta.RoleCustomer,
+					}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "Note is required to reject",
+			token: "admin-token",
+			body:  `{"approve":false}`,
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "admin-token").
+					Return(&params.ProcessTokenResponse{
+						UserID: uuid.New(),
+						Role:   globalcontanta.RoleAdmin,
+					}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/returns/"+returnID+"/review", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			rec := httptest.NewRecorder()
+			s.router.ServeHTTP(rec, req)
+
+			s.Equal(tt.expectedStatus, rec.Code)
+		})
+	}
+}
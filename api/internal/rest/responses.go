@@ -23,15 +23,21 @@ func sendSuccessResponse(w http.ResponseWriter, status int, res any) {
 
 type APIError struct {
 	Message string `json:"error"`
+	Details any    `json:"details,omitempty"`
 }
 
 func sendErrorResponse(w http.ResponseWriter, status int, err error) {
 	var apiErr APIError
+	var conflict errs.Conflict
 	switch {
 	case errors.As(err, &errs.InvalidCredential{}):
 		slog.Error("handler", "service", err.Error())
 		status = errs.InvalidCredential{}.HttpStatusCode()
 		apiErr.Message = err.Error()
+	case errors.As(err, &errs.Forbidden{}):
+		slog.Error("handler", "request", err.Error())
+		status = errs.Forbidden{}.HttpStatusCode()
+		apiErr.Message = err.Error()
 	case errors.As(err, &errs.AlreadyExist{}):
 		slog.Error("handler", "service", err.Error())
 		status = errs.AlreadyExist{}.HttpStatusCode()
@@ -40,6 +46,11 @@ func sendErrorResponse(w http.ResponseWriter, status int, err error) {
 		slog.Error("handler", "service", err.Error())
 		status = errs.NotFound{}.HttpStatusCode()
 		apiErr.Message = err.Error()
+	case errors.As(err, &conflict):
+		slog.Error("handler", "request", err.Error())
+		status = conflict.HttpStatusCode()
+		apiErr.Message = conflict.Error()
+		apiErr.Details = conflict.Details
 	case errors.As(err, &errs.ValidationError{}):
 		slog.Error("handler", "request", err.Error())
 		status = errs.ValidationError{}.HttpStatusCode()
@@ -1,3 +1,4 @@
+//go:generate mockgen -source=warehouse_handler.go -destination=mock/mock_warehouse_handler.go -package=mock
 package rest
 
 import (
@@ -14,6 +15,10 @@ type (
 	WarehouseService interface {
 		SetWarehouseStatus(ctx context.Context, req params.SetWarehouseStatusRequest) error
 		TransferStockBetweenWarehouse(ctx context.Context, req params.TransferStockBetweenWarehouseRequest) error
+		FulfillOrder(ctx context.Context, req params.FulfillOrderRequest) error
+		ReceiveReturnedStock(ctx context.Context, req params.ReceiveReturnedStockRequest) error
+		ReceiveStock(ctx context.Context, req params.ReceiveStockRequest) (*params.StockMovementResponse, error)
+		AdjustStock(ctx context.Context, req params.AdjustStockRequest) (*params.StockMovementResponse, error)
 	}
 
 	WarehouseHandler struct {
@@ -37,8 +42,13 @@ func NewWarehouseHandler(
 
 	publicRoute.Group(func(r chi.Router) {
 		r.Use(authMiddleware.MustAuthMiddleware())
+		r.Use(authMiddleware.MustAdminMiddleware())
 		r.Post("/warehouse/status", oh.SetWarehouseStatus())
 		r.Post("/warehouse/transfer", oh.TransferStockBetweenWarehouse)
+		r.Post("/warehouse/orders/{order_id}/fulfillment", oh.FulfillOrder())
+		r.Post("/warehouse/returns/{return_id}/receive", oh.ReceiveReturnedStock())
+		r.Post("/warehouse/stocks/receive", oh.ReceiveStock())
+		r.Post("/warehouse/stocks/adjust", oh.AdjustStock())
 	})
 }
 
@@ -82,3 +92,97 @@ func (ah *WarehouseHandler) TransferStockBetweenWarehouse(w http.ResponseWriter,
 
 	sendSuccessResponse(w, http.StatusOK, "ok")
 }
+
+func (oh *WarehouseHandler) FulfillOrder() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.FulfillOrderRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.OrderID = chi.URLParam(r, "order_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		err := oh.svc.FulfillOrder(r.Context(), body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
+func (oh *WarehouseHandler) ReceiveReturnedStock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.ReceiveReturnedStockRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		body.ReturnID = chi.URLParam(r, "return_id")
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		err := oh.svc.ReceiveReturnedStock(r.Context(), body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusOK, "ok")
+	}
+}
+
+func (oh *WarehouseHandler) ReceiveStock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.ReceiveStockRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		movement, err := oh.svc.ReceiveStock(r.Context(), body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, movement)
+	}
+}
+
+func (oh *WarehouseHandler) AdjustStock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := params.AdjustStockRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+
+		movement, err := oh.svc.AdjustStock(r.Context(), body)
+		if err != nil {
+			sendErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		sendSuccessResponse(w, http.StatusCreated, movement)
+	}
+}
@@ -0,0 +1,154 @@
+package rest_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/elangreza/e-commerce/api/internal/rest"
+	"github.com/elangreza/e-commerce/api/internal/rest/mock"
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WarehouseHandlerTestSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	router               *chi.Mux
+	mockAuthService      *mock.MockAuthService
+	mockWarehouseService *mock.MockWarehouseService
+}
+
+func (s *WarehouseHandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+
+	s.mockAuthService = mock.NewMockAuthService(s.ctrl)
+	s.mockWarehouseService = mock.NewMockWarehouseService(s.ctrl)
+
+	s.router = chi.NewRouter()
+	rest.NewWarehouseHandler(s.router, s.mockAuthService, s.mockWarehouseService)
+}
+
+func (s *WarehouseHandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestWarehouseHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WarehouseHandlerTestSuite))
+}
+
+func (s *WarehouseHandlerTestSuite) serve(path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *WarehouseHandlerTestSuite) TestNonAdminIsForbidden() {
+	orderID := uuid.NewString()
+	returnID := uuid.NewString()
+
+	paths := []string{
+		"/warehouse/status",
+		"/warehouse/transfer",
+		"/warehouse/orders/" + orderID + "/fulfillment",
+		"/warehouse/returns/" + returnID + "/receive",
+		"/warehouse/stocks/receive",
+		"/warehouse/stocks/adjust",
+	}
+
+	for _, path := range paths {
+		s.Run(path, func() {
+			s.mockAuthService.EXPECT().
+				ProcessToken(gomock.Any(), "customer-token").
+				Return(&params.ProcessTokenResponse{
+					UserID: uuid.New(),
+					Role:   globalcontanta.RoleCustomer,
+				}, nil)
+
+			// the warehouse service must not be called
+			rec := s.serve(path, "customer-token", `{}`)
+
+			s.Equal(http.StatusForbidden, rec.Code)
+			s.Contains(rec.Body.String(), "admin role is required")
+		})
+	}
+}
+
+func (s *WarehouseHandlerTestSuite) TestReceiveStock() {
+	body := `{"product_id":"` + uuid.NewString() + `","warehouse_id":1,"shop_id":1,"quantity":10}`
+
+	tests := []struct {
+		name           string
+		token          string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name:  "Admin receives the stock",
+			token: "admin-token",
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "admin-token").
+					Return(&params.ProcessTokenResponse{
+						UserID: uuid.New(),
+						Role:   globalcontanta.RoleAdmin,
+					}, nil)
+				s.mockWarehouseService.EXPECT().
+					ReceiveStock(gomock.Any(), gomock.Any()).
+					Return(&params.StockMovementResponse{ID: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:  "Customer is forbidden",
+			token: "customer-token",
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "customer-token").
+					Return(&params.ProcessTokenResponse{
+						UserID: uuid.New(),
+						Role:   globalcontanta.RoleCustomer,
+					}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "Invalid token is unauthorized",
+			token: "invalid-token",
+			setupMock: func() {
+				s.mockAuthService.EXPECT().
+					ProcessToken(gomock.Any(), "invalid-token").
+					Return(nil, errors.New("token is expired"))
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Missing token",
+			token:          "",
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			rec := s.serve("/warehouse/stocks/receive", tt.token, body)
+
+			s.Equal(tt.expectedStatus, rec.Code)
+		})
+	}
+}
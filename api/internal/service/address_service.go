@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/elangreza/e-commerce/api/internal/constanta"
+	"github.com/elangreza/e-commerce/api/internal/entity"
+	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/google/uuid"
+)
+
+type (
+	addressRepo interface {
+		ListAddresses(ctx context.Context, userID uuid.UUID) ([]entity.Address, error)
+		GetAddressByID(ctx context.Context, userID, id uuid.UUID) (*entity.Address, error)
+		GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*entity.Address, error)
+		CreateAddress(ctx context.Context, address entity.Address) error
+		UpdateAddress(ctx context.Context, address entity.Address) error
+		DeleteAddress(ctx context.Context, userID, id uuid.UUID) error
+	}
+
+	AddressService struct {
+		addressRepo addressRepo
+	}
+)
+
+func NewAddressService(addressRepo addressRepo) *AddressService {
+	return &AddressService{
+		addressRepo: addressRepo,
+	}
+}
+
+func (s *AddressService) ListAddresses(ctx context.Context) ([]params.AddressResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	addresses, err := s.addressRepo.ListAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []params.AddressResponse{}
+	for _, address := range addresses {
+		res = append(res, convertAddress(address))
+	}
+
+	return res, nil
+}
+
+// CreateAddress adds the address into the address book, the first address of the user is always the default
+func (s *AddressService) CreateAddress(ctx context.Context, req params.AddressRequest) (*params.AddressResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	address, err := entity.NewAddress(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	setAddress(address, req)
+
+	if !address.IsDefault {
+		_, err = s.addressRepo.GetDefaultAddress(ctx, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		address.IsDefault = errors.Is(err, sql.ErrNoRows)
+	}
+
+	err = s.addressRepo.CreateAddress(ctx, *address)
+	if err != nil {
+		return nil, err
+	}
+
+	res := convertAddress(*address)
+	return &res, nil
+}
+
+func (s *AddressService) UpdateAddress(ctx context.Context, req params.AddressRequest) (*params.AddressResponse, error) {
+	address, err := s.getAddress(ctx, req.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the default address can only be replaced by setting another address as the default
+	isDefault := address.IsDefault
+	setAddress(address, req)
+	address.IsDefault = address.IsDefault || isDefault
+
+	err = s.addressRepo.UpdateAddress(ctx, *address)
+	if err != nil {
+		return nil, err
+	}
+
+	res := convertAddress(*address)
+	return &res, nil
+}
+
+func (s *AddressService) DeleteAddress(ctx context.Context, addressID string) error {
+	address, err := s.getAddress(ctx, addressID)
+	if err != nil {
+		return err
+	}
+
+	return s.addressRepo.DeleteAddress(ctx, address.UserID, address.ID)
+}
+
+func (s *AddressService) getAddress(ctx context.Context, addressID string) (*entity.Address, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	id, err := uuid.Parse(addressID)
+	if err != nil {
+		return nil, errs.ValidationError{Message: "not valid address_id"}
+	}
+
+	address, err := s.addressRepo.GetAddressByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: "address"}
+		}
+		return nil, err
+	}
+
+	return address, nil
+}
+
+func setAddress(address *entity.Address, req params.AddressRequest) {
+	address.Label = strings.TrimSpace(req.Label)
+	address.RecipientName = strings.TrimSpace(req.RecipientName)
+	address.Phone = strings.TrimSpace(req.Phone)
+	address.Street = strings.TrimSpace(req.Street)
+	address.City = strings.TrimSpace(req.City)
+	address.Region = strings.ToUpper(strings.TrimSpace(req.Region))
+	address.PostalCode = strings.TrimSpace(req.PostalCode)
+	address.IsDefault = req.IsDefault
+}
+
+func convertAddress(address entity.Address) params.AddressResponse {
+	return params.AddressResponse{
+		AddressID:     address.ID.String(),
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Street:        address.Street,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		IsDefault:     address.IsDefault,
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/elangreza/e-commerce/pkg/contextrequest"
+
+	"github.com/elangreza/e-commerce/api/internal/constanta"
 	"github.com/elangreza/e-commerce/api/internal/entity"
 	errs "github.com/elangreza/e-commerce/api/internal/error"
 	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/elangreza/e-commerce/gen"
 	"github.com/google/uuid"
 )
 
@@ -17,6 +21,7 @@ type (
 		CreateUser(ctx context.Context, user entity.User) error
 		GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
 		GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+		UpdateUserCurrency(ctx context.Context, id uuid.UUID, currency string) error
 	}
 
 	tokenRepo interface {
@@ -29,14 +34,16 @@ type (
 		UserRepo                 userRepo
 		TokenRepo                tokenRepo
 		AuthenticationSigningKey string
+		OrderServiceClient       gen.OrderServiceClient
 	}
 )
 
-func NewAuthService(userRepo userRepo, tokenRepo tokenRepo, AuthenticationSigningKey string) *AuthService {
+func NewAuthService(userRepo userRepo, tokenRepo tokenRepo, AuthenticationSigningKey string, orderServiceClient gen.OrderServiceClient) *AuthService {
 	return &AuthService{
 		UserRepo:                 userRepo,
 		TokenRepo:                tokenRepo,
 		AuthenticationSigningKey: AuthenticationSigningKey,
+		OrderServiceClient:       orderServiceClient,
 	}
 }
 
@@ -85,6 +92,7 @@ func (as *AuthService) LoginUser(ctx context.Context, req params.LoginUserReques
 	if token != nil {
 		_, err = token.IsTokenValid([]byte(as.AuthenticationSigningKey))
 		if err == nil {
+			as.mergeGuestCart(user.ID, req.CartToken)
 			return token.Token, nil
 		}
 	}
@@ -99,24 +107,65 @@ func (as *AuthService) LoginUser(ctx context.Context, req params.LoginUserReques
 		return "", err
 	}
 
+	as.mergeGuestCart(user.ID, req.CartToken)
+
 	return token.Token, nil
 }
 
-func (as *AuthService) ProcessToken(ctx context.Context, reqToken string) (uuid.UUID, error) {
+// mergeGuestCart folds the guest cart into the user cart,
+// the login is not failed when the merge is failed since the guest cart is still kept
+func (as *AuthService) mergeGuestCart(userID uuid.UUID, cartToken string) {
+	if cartToken == "" {
+		return
+	}
+
+	ctx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+	_, err := as.OrderServiceClient.MergeCart(ctx, &gen.MergeCartRequest{
+		CartToken: cartToken,
+	})
+	if err != nil {
+		fmt.Printf("failed to merge guest cart into cart of user %s: %v\n", userID, err)
+	}
+}
+
+func (as *AuthService) ProcessToken(ctx context.Context, reqToken string) (*params.ProcessTokenResponse, error) {
 	token := &entity.Token{Token: reqToken}
 
 	tokenID, err := token.IsTokenValid([]byte(as.AuthenticationSigningKey))
 	if err != nil {
-		return uuid.UUID{}, err
+		return nil, err
 	}
 
 	token, err = as.TokenRepo.GetTokenByTokenID(ctx, tokenID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return uuid.UUID{}, errs.NotFound{Message: "token"}
+			return nil, errs.NotFound{Message: "token"}
+		}
+		return nil, err
+	}
+
+	user, err := as.UserRepo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: "user"}
 		}
-		return uuid.UUID{}, err
+		return nil, err
+	}
+
+	return &params.ProcessTokenResponse{
+		UserID:   user.ID,
+		Currency: user.Currency,
+		Role:     user.Role,
+	}, nil
+}
+
+// UpdateCurrency sets the display and settlement currency of the user,
+// it is used by the next cart and order requests
+func (as *AuthService) UpdateCurrency(ctx context.Context, req params.UpdateCurrencyRequest) error {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return errors.New("error when parsing userID")
 	}
 
-	return token.UserID, nil
+	return as.UserRepo.UpdateUserCurrency(ctx, userID, req.Currency)
 }
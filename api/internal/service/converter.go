@@ -2,14 +2,23 @@ package service
 
 import (
 	errs "github.com/elangreza/e-commerce/api/internal/error"
+	"github.com/elangreza/e-commerce/api/internal/params"
+	"github.com/elangreza/e-commerce/gen"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func convertErrGrpc(err error) error {
 	if st, ok := status.FromError(err); ok {
+		if issues := getCartIssues(st); issues != nil {
+			return errs.Conflict{
+				Message: st.Message(),
+				Details: issues,
+			}
+		}
+
 		switch st.Code() {
-		case codes.InvalidArgument:
+		case codes.InvalidArgument, codes.FailedPrecondition:
 			return errs.ValidationError{
 				Message: st.Message(),
 			}
@@ -19,8 +28,176 @@ func convertErrGrpc(err error) error {
 			}
 		case codes.Unauthenticated:
 			return errs.InvalidCredential{}
+		case codes.PermissionDenied:
+			return errs.Forbidden{
+				Message: st.Message(),
+			}
 		}
 	}
 
 	return err
 }
+
+func getCartIssues(st *status.Status) []params.CartIssue {
+	for _, detail := range st.Details() {
+		cartIssues, ok := detail.(*gen.CartIssues)
+		if !ok {
+			continue
+		}
+
+		res := []params.CartIssue{}
+		for _, issue := range cartIssues.GetIssues() {
+			res = append(res, params.CartIssue{
+				ProductID:   issue.GetProductId(),
+				Reason:      issue.GetReason(),
+				OldPrice:    convertMoney(issue.GetOldPrice()),
+				NewPrice:    convertMoney(issue.GetNewPrice()),
+				Quantity:    issue.GetQuantity(),
+				ActualStock: issue.GetActualStock(),
+				CouponCode:  issue.GetCouponCode(),
+				Message:     issue.GetMessage(),
+			})
+		}
+		return res
+	}
+
+	return nil
+}
+
+func convertMoney(m *gen.Money) *params.Money {
+	if m == nil {
+		return nil
+	}
+
+	return &params.Money{
+		Units:        m.GetUnits(),
+		CurrencyCode: m.GetCurrencyCode(),
+	}
+}
+
+func convertShipment(shipment *gen.Shipment) *params.ShipmentResponse {
+	if shipment == nil {
+		return nil
+	}
+
+	address := shipment.GetAddress()
+	return &params.ShipmentResponse{
+		Address: params.ShippingAddressResponse{
+			RecipientName: address.GetRecipientName(),
+			Phone:         address.GetPhone(),
+			Street:        address.GetStreet(),
+			City:          address.GetCity(),
+			Region:        address.GetRegion(),
+			PostalCode:    address.GetPostalCode(),
+		},
+		Carrier:        shipment.GetCarrier(),
+		TrackingNumber: shipment.GetTrackingNumber(),
+		PackedAt:       shipment.GetPackedAt(),
+		ShippedAt:      shipment.GetShippedAt(),
+		DeliveredAt:    shipment.GetDeliveredAt(),
+	}
+}
+
+func convertRefund(refund *gen.Refund) params.RefundResponse {
+	res := params.RefundResponse{
+		RefundID:  refund.GetId(),
+		Status:    refund.GetStatus(),
+		Amount:    convertMoney(refund.GetAmount()),
+		Reason:    refund.GetReason(),
+		Items:     []params.RefundItemResponse{},
+		Restocked: refund.GetRestocked(),
+		CreatedAt: refund.GetCreatedAt(),
+		ReturnID:  refund.GetReturnId(),
+	}
+
+	for _, item := range refund.GetItems() {
+		res.Items = append(res.Items, params.RefundItemResponse{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+			Amount:    convertMoney(item.GetAmount()),
+		})
+	}
+
+	return res
+}
+
+func convertOrderPayment(payment *gen.OrderPayment) params.OrderPaymentResponse {
+	return params.OrderPaymentResponse{
+		TransactionID:  payment.GetTransactionId(),
+		Method:         payment.GetMethod(),
+		Status:         payment.GetStatus(),
+		Amount:         convertMoney(payment.GetAmount()),
+		RefundedAmount: convertMoney(payment.GetRefundedAmount()),
+		PaymentURL:     payment.GetPaymentUrl(),
+		CreatedAt:      payment.GetCreatedAt(),
+	}
+}
+
+func convertReturn(ret *gen.Return) *params.ReturnResponse {
+	res := &params.ReturnResponse{
+		ReturnID:    ret.GetId(),
+		OrderID:     ret.GetOrderId(),
+		Status:      ret.GetStatus(),
+		Reason:      ret.GetReason(),
+		Items:       []params.ReturnItem{},
+		Note:        ret.GetNote(),
+		WarehouseID: ret.GetWarehouseId(),
+		RefundID:    ret.GetRefundId(),
+		CreatedAt:   ret.GetCreatedAt(),
+		UpdatedAt:   ret.GetUpdatedAt(),
+	}
+
+	for _, item := range ret.GetItems() {
+		res.Items = append(res.Items, params.ReturnItem{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		})
+	}
+
+	return res
+}
+
+func convertCart(cart *gen.Cart) *params.GetCartResponse {
+	res := &params.GetCartResponse{
+		CartID:      cart.GetId(),
+		Items:       []params.GetCartItemsResponse{},
+		Subtotal:    convertMoney(cart.GetSubtotal()),
+		CouponCode:  cart.GetCouponCode(),
+		Discount:    convertMoney(cart.GetDiscount()),
+		Total:       convertMoney(cart.GetTotal()),
+		CouponIssue: cart.GetCouponIssue(),
+	}
+
+	for _, item := range cart.GetItems() {
+		res.Items = append(res.Items, params.GetCartItemsResponse{
+			ProductID:         item.GetProductId(),
+			Quantity:          item.GetQuantity(),
+			Name:              item.GetName(),
+			Price:             convertMoney(item.GetPrice()),
+			CurrentPrice:      convertMoney(item.GetCurrentPrice()),
+			ActualStock:       item.GetActualStock(),
+			PriceChanged:      item.GetPriceChanged(),
+			InsufficientStock: item.GetInsufficientStock(),
+			Unavailable:       item.GetUnavailable(),
+			SettlementTotal:   convertMoney(item.GetSettlementTotal()),
+			Discount:          convertMoney(item.GetDiscount()),
+		})
+	}
+
+	return res
+}
+
+func convertStockMovement(movement *gen.StockMovement) *params.StockMovementResponse {
+	return &params.StockMovementResponse{
+		ID:          movement.GetId(),
+		StockID:     movement.GetStockId(),
+		WarehouseID: movement.GetWarehouseId(),
+		ProductID:   movement.GetProductId(),
+		Type:        movement.GetType(),
+		Quantity:    movement.GetQuantity(),
+		Balance:     movement.GetBalance(),
+		Actor:       movement.GetActor(),
+		Reference:   movement.GetReference(),
+		CreatedAt:   movement.GetCreatedAt(),
+	}
+}
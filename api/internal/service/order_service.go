@@ -4,36 +4,39 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/elangreza/e-commerce/pkg/contextrequest"
 
 	"github.com/elangreza/e-commerce/api/internal/constanta"
+	"github.com/elangreza/e-commerce/api/internal/entity"
+	errs "github.com/elangreza/e-commerce/api/internal/error"
 	params "github.com/elangreza/e-commerce/api/internal/params"
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/google/uuid"
 )
 
-func NewOrderService(pClient gen.OrderServiceClient) *orderService {
+func NewOrderService(pClient gen.OrderServiceClient, addressRepo addressRepo) *orderService {
 	return &orderService{
 		orderServiceClient: pClient,
+		addressRepo:        addressRepo,
 	}
 }
 
 type orderService struct {
 	orderServiceClient gen.OrderServiceClient
+	addressRepo        addressRepo
 }
 
 func (s *orderService) AddProductToCart(ctx context.Context, req params.AddToCartRequest) error {
 
-	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
-	if !ok {
-		return errors.New("error when parsing userID")
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return err
 	}
 
-	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
-
-	_, err := s.orderServiceClient.AddProductToCart(newCtx, &gen.AddCartItemRequest{
+	_, err = s.orderServiceClient.AddProductToCart(newCtx, &gen.AddCartItemRequest{
 		ProductId: req.ProductID,
 		Quantity:  req.Quantity,
 	})
@@ -47,31 +50,94 @@ func (s *orderService) AddProductToCart(ctx context.Context, req params.AddToCar
 
 func (s *orderService) GetCart(ctx context.Context) (*params.GetCartResponse, error) {
 
-	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
-	if !ok {
-		return nil, errors.New("error when parsing userID")
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
-
 	cart, err := s.orderServiceClient.GetCart(newCtx, &gen.Empty{})
 	if err != nil {
 		return nil, convertErrGrpc(err)
 	}
 
-	res := &params.GetCartResponse{
-		CartID: cart.Id,
-		Items:  []params.GetCartItemsResponse{},
+	return convertCart(cart), nil
+}
+
+func (s *orderService) ApplyCoupon(ctx context.Context, req params.ApplyCouponRequest) (*params.GetCartResponse, error) {
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, item := range cart.Items {
-		res.Items = append(res.Items, params.GetCartItemsResponse{
-			ProductID: item.ProductId,
-			Quantity:  item.Quantity,
-		})
+	cart, err := s.orderServiceClient.ApplyCoupon(newCtx, &gen.ApplyCouponRequest{
+		Code: req.Code,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
 	}
 
-	return res, nil
+	return convertCart(cart), nil
+}
+
+func (s *orderService) RemoveCoupon(ctx context.Context) error {
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.orderServiceClient.RemoveCoupon(newCtx, &gen.Empty{})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
+}
+
+func (s *orderService) RemoveCartItem(ctx context.Context, productID string) error {
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.orderServiceClient.RemoveCartItem(newCtx, &gen.RemoveCartItemRequest{
+		ProductId: productID,
+	})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
+}
+
+func (s *orderService) SetCartItemQuantity(ctx context.Context, req params.SetCartItemQuantityRequest) error {
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.orderServiceClient.SetCartItemQuantity(newCtx, &gen.SetCartItemQuantityRequest{
+		ProductId: req.ProductID,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
+}
+
+func (s *orderService) ClearCart(ctx context.Context) error {
+	newCtx, err := cartContextGrpcClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.orderServiceClient.ClearCart(newCtx, &gen.Empty{})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
 }
 
 func (s *orderService) CreateOrder(ctx context.Context, req params.CreateOrderRequest) (*params.OrderResponse, error) {
@@ -81,10 +147,34 @@ func (s *orderService) CreateOrder(ctx context.Context, req params.CreateOrderRe
 		return nil, errors.New("error when parsing userID")
 	}
 
+	address, err := s.getShippingAddress(ctx, userID, req.AddressID)
+	if err != nil {
+		return nil, err
+	}
+
 	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+	newCtx = appendCurrency(ctx, newCtx)
+
+	var paymentAmount *gen.Money
+	if req.PaymentAmount != nil {
+		paymentAmount = &gen.Money{
+			Units:        req.PaymentAmount.Units,
+			CurrencyCode: req.PaymentAmount.CurrencyCode,
+		}
+	}
 
 	order, err := s.orderServiceClient.CreateOrder(newCtx, &gen.CreateOrderRequest{
 		IdempotencyKey: req.IdempotencyKey,
+		PaymentMethod:  req.PaymentMethod,
+		PaymentAmount:  paymentAmount,
+		ShippingAddress: &gen.ShippingAddress{
+			RecipientName: address.RecipientName,
+			Phone:         address.Phone,
+			Street:        address.Street,
+			City:          address.City,
+			Region:        address.Region,
+			PostalCode:    address.PostalCode,
+		},
 	})
 
 	if err != nil {
@@ -98,14 +188,30 @@ func (s *orderService) CreateOrder(ctx context.Context, req params.CreateOrderRe
 			Units:        order.GetTotalAmount().GetUnits(),
 			CurrencyCode: order.GetTotalAmount().GetCurrencyCode(),
 		},
-		Status:        order.GetStatus(),
-		TransactionID: order.GetTransactionId(),
+		Status:         order.GetStatus(),
+		TransactionID:  order.GetTransactionId(),
+		CancelReason:   order.GetCancelReason(),
+		SubtotalAmount: convertMoney(order.GetSubtotalAmount()),
+		DiscountAmount: convertMoney(order.GetDiscountAmount()),
+		CouponCode:     order.GetCouponCode(),
+		TaxAmount:      convertMoney(order.GetTaxAmount()),
+		ShippingAmount: convertMoney(order.GetShippingAmount()),
+		ShippingRegion: order.GetShippingRegion(),
+		Shipment:       convertShipment(order.GetShipment()),
 	}
 
 	for _, item := range order.Items {
 		res.Items = append(res.Items, params.GetCartItemsResponse{
-			ProductID: item.GetProductId(),
-			Quantity:  item.GetQuantity(),
+			ProductID:       item.GetProductId(),
+			Quantity:        item.GetQuantity(),
+			Name:            item.GetName(),
+			Price:           convertMoney(item.GetPricePerUnit()),
+			SettlementTotal: convertMoney(item.GetSettlementTotal()),
+			ExchangeRate:    item.GetExchangeRate(),
+			Discount:        convertMoney(item.GetDiscount()),
+			Tax:             convertMoney(item.GetTax()),
+			TaxRate:         item.GetTaxRate(),
+			TaxInclusive:    item.GetTaxInclusive(),
 		})
 	}
 
@@ -141,9 +247,17 @@ func (s *orderService) GetOrderList(ctx context.Context, req params.GetOrderList
 				Units:        item.GetTotalAmount().GetUnits(),
 				CurrencyCode: item.GetTotalAmount().GetCurrencyCode(),
 			},
-			Status:        item.GetStatus(),
-			TransactionID: item.GetTransactionId(),
-			Items:         nil,
+			Status:         item.GetStatus(),
+			TransactionID:  item.GetTransactionId(),
+			CancelReason:   item.GetCancelReason(),
+			SubtotalAmount: convertMoney(item.GetSubtotalAmount()),
+			DiscountAmount: convertMoney(item.GetDiscountAmount()),
+			CouponCode:     item.GetCouponCode(),
+			TaxAmount:      convertMoney(item.GetTaxAmount()),
+			ShippingAmount: convertMoney(item.GetShippingAmount()),
+			ShippingRegion: item.GetShippingRegion(),
+			Shipment:       convertShipment(item.GetShipment()),
+			Items:          nil,
 		})
 	}
 
@@ -173,16 +287,251 @@ func (s *orderService) GetOrderDetail(ctx context.Context, orderID string) (*par
 			Units:        order.GetTotalAmount().GetUnits(),
 			CurrencyCode: order.GetTotalAmount().GetCurrencyCode(),
 		},
-		Status:        order.GetStatus(),
-		TransactionID: order.GetTransactionId(),
+		Status:         order.GetStatus(),
+		TransactionID:  order.GetTransactionId(),
+		CancelReason:   order.GetCancelReason(),
+		SubtotalAmount: convertMoney(order.GetSubtotalAmount()),
+		DiscountAmount: convertMoney(order.GetDiscountAmount()),
+		CouponCode:     order.GetCouponCode(),
+		TaxAmount:      convertMoney(order.GetTaxAmount()),
+		ShippingAmount: convertMoney(order.GetShippingAmount()),
+		ShippingRegion: order.GetShippingRegion(),
+		Shipment:       convertShipment(order.GetShipment()),
+		StatusHistory:  []params.OrderStatusHistoryResponse{},
+		PaidAmount:     convertMoney(order.GetPaidAmount()),
+	}
+
+	for _, history := range order.GetStatusHistory() {
+		res.StatusHistory = append(res.StatusHistory, params.OrderStatusHistoryResponse{
+			FromStatus: history.GetFromStatus(),
+			ToStatus:   history.GetToStatus(),
+			Reason:     history.GetReason(),
+			Actor:      history.GetActor(),
+			CreatedAt:  history.GetCreatedAt(),
+		})
+	}
+
+	for _, refund := range order.GetRefunds() {
+		res.Refunds = append(res.Refunds, convertRefund(refund))
+	}
+
+	for _, ret := range order.GetReturns() {
+		res.Returns = append(res.Returns, *convertReturn(ret))
+	}
+
+	for _, payment := range order.GetPayments() {
+		res.Payments = append(res.Payments, convertOrderPayment(payment))
 	}
 
 	for _, item := range order.Items {
 		res.Items = append(res.Items, params.GetCartItemsResponse{
-			ProductID: item.GetProductId(),
-			Quantity:  item.GetQuantity(),
+			ProductID:       item.GetProductId(),
+			Quantity:        item.GetQuantity(),
+			Name:            item.GetName(),
+			Price:           convertMoney(item.GetPricePerUnit()),
+			SettlementTotal: convertMoney(item.GetSettlementTotal()),
+			ExchangeRate:    item.GetExchangeRate(),
+			Discount:        convertMoney(item.GetDiscount()),
+			Tax:             convertMoney(item.GetTax()),
+			TaxRate:         item.GetTaxRate(),
+			TaxInclusive:    item.GetTaxInclusive(),
 		})
 	}
 
 	return res, nil
 }
+
+func (s *orderService) CancelOrder(ctx context.Context, req params.CancelOrderRequest) (*params.OrderResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+
+	order, err := s.orderServiceClient.CancelOrder(newCtx, &gen.CancelOrderRequest{
+		Id:     req.OrderID,
+		Reason: req.Reason,
+	})
+
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	res := &params.OrderResponse{
+		OrderID: order.GetId(),
+		Items:   []params.GetCartItemsResponse{},
+		TotalAmount: &params.Money{
+			Units:        order.GetTotalAmount().GetUnits(),
+			CurrencyCode: order.GetTotalAmount().GetCurrencyCode(),
+		},
+		Status:         order.GetStatus(),
+		TransactionID:  order.GetTransactionId(),
+		CancelReason:   order.GetCancelReason(),
+		SubtotalAmount: convertMoney(order.GetSubtotalAmount()),
+		DiscountAmount: convertMoney(order.GetDiscountAmount()),
+		CouponCode:     order.GetCouponCode(),
+		TaxAmount:      convertMoney(order.GetTaxAmount()),
+		ShippingAmount: convertMoney(order.GetShippingAmount()),
+		ShippingRegion: order.GetShippingRegion(),
+		Shipment:       convertShipment(order.GetShipment()),
+	}
+
+	for _, item := range order.Items {
+		res.Items = append(res.Items, params.GetCartItemsResponse{
+			ProductID:       item.GetProductId(),
+			Quantity:        item.GetQuantity(),
+			Name:            item.GetName(),
+			Price:           convertMoney(item.GetPricePerUnit()),
+			SettlementTotal: convertMoney(item.GetSettlementTotal()),
+			ExchangeRate:    item.GetExchangeRate(),
+			Discount:        convertMoney(item.GetDiscount()),
+			Tax:             convertMoney(item.GetTax()),
+			TaxRate:         item.GetTaxRate(),
+			TaxInclusive:    item.GetTaxInclusive(),
+		})
+	}
+
+	return res, nil
+}
+
+// AddOrderPayment pays the reserved order with another payment, it returns the order with every payment
+func (s *orderService) AddOrderPayment(ctx context.Context, req params.AddOrderPaymentRequest) (*params.OrderResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+
+	var amount *gen.Money
+	if req.Amount != nil {
+		amount = &gen.Money{
+			Units:        req.Amount.Units,
+			CurrencyCode: req.Amount.CurrencyCode,
+		}
+	}
+
+	order, err := s.orderServiceClient.AddOrderPayment(newCtx, &gen.AddOrderPaymentRequest{
+		OrderId: req.OrderID,
+		Method:  req.Method,
+		Amount:  amount,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	res := &params.OrderResponse{
+		OrderID:       order.GetId(),
+		TotalAmount:   convertMoney(order.GetTotalAmount()),
+		Status:        order.GetStatus(),
+		TransactionID: order.GetTransactionId(),
+		PaidAmount:    convertMoney(order.GetPaidAmount()),
+		Payments:      []params.OrderPaymentResponse{},
+	}
+
+	for _, payment := range order.GetPayments() {
+		res.Payments = append(res.Payments, convertOrderPayment(payment))
+	}
+
+	return res, nil
+}
+
+func (s *orderService) CreateReturn(ctx context.Context, req params.CreateReturnRequest) (*params.ReturnResponse, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+
+	items := []*gen.ReturnItem{}
+	for _, item := range req.Items {
+		items = append(items, &gen.ReturnItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	ret, err := s.orderServiceClient.CreateReturn(newCtx, &gen.CreateReturnRequest{
+		OrderId: req.OrderID,
+		Items:   items,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	return convertReturn(ret), nil
+}
+
+// ReviewReturn approves or rejects the requested return on behalf of the admin
+func (s *orderService) ReviewReturn(ctx context.Context, req params.ReviewReturnRequest) (*params.ReturnResponse, error) {
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.orderServiceClient.ReviewReturn(newCtx, &gen.ReviewReturnRequest{
+		ReturnId: req.ReturnID,
+		Approve:  req.Approve,
+		Note:     req.Note,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	return convertReturn(ret), nil
+}
+
+// getShippingAddress returns the selected address of the address book, or the default address when none is selected
+func (s *orderService) getShippingAddress(ctx context.Context, userID uuid.UUID, addressID string) (*entity.Address, error) {
+	if addressID == "" {
+		address, err := s.addressRepo.GetDefaultAddress(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, errs.ValidationError{Message: "address_id is required, the address book has no default address"}
+			}
+			return nil, err
+		}
+		return address, nil
+	}
+
+	id, err := uuid.Parse(addressID)
+	if err != nil {
+		return nil, errs.ValidationError{Message: "not valid address_id"}
+	}
+
+	address, err := s.addressRepo.GetAddressByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFound{Message: "address"}
+		}
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// cartContextGrpcClient forwards the user when logged in, otherwise the cart token of the guest
+func cartContextGrpcClient(ctx context.Context) (context.Context, error) {
+	if userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID); ok {
+		newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+		return appendCurrency(ctx, newCtx), nil
+	}
+
+	if cartToken, ok := ctx.Value(constanta.LocalCartToken).(string); ok {
+		return contextrequest.AppendCartTokenIntoContextGrpcClient(context.Background(), cartToken), nil
+	}
+
+	return nil, errors.New("error when parsing userID or cart token")
+}
+
+// appendCurrency forwards the currency of the user, the order service uses its default currency when it is not set
+func appendCurrency(ctx, newCtx context.Context) context.Context {
+	if currency, ok := ctx.Value(constanta.LocalCurrency).(string); ok && currency != "" {
+		return contextrequest.AppendCurrencyIntoContextGrpcClient(newCtx, currency)
+	}
+
+	return newCtx
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/elangreza/e-commerce/pkg/contextrequest"
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
 
 	"github.com/elangreza/e-commerce/api/internal/constanta"
 	params "github.com/elangreza/e-commerce/api/internal/params"
@@ -25,14 +26,12 @@ type WarehouseService struct {
 }
 
 func (s *WarehouseService) SetWarehouseStatus(ctx context.Context, req params.SetWarehouseStatusRequest) error {
-	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
-	if !ok {
-		return errors.New("error when parsing userID")
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return err
 	}
 
-	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
-
-	_, err := s.WarehouseServiceClient.SetWarehouseStatus(newCtx, &gen.SetWarehouseStatusRequest{
+	_, err = s.WarehouseServiceClient.SetWarehouseStatus(newCtx, &gen.SetWarehouseStatusRequest{
 		WarehouseId: req.WarehouseID,
 		IsActive:    req.IsActive,
 	})
@@ -44,14 +43,12 @@ func (s *WarehouseService) SetWarehouseStatus(ctx context.Context, req params.Se
 }
 
 func (s *WarehouseService) TransferStockBetweenWarehouse(ctx context.Context, req params.TransferStockBetweenWarehouseRequest) error {
-	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
-	if !ok {
-		return errors.New("error when parsing userID")
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return err
 	}
 
-	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
-
-	_, err := s.WarehouseServiceClient.TransferStockBetweenWarehouse(newCtx, &gen.TransferStockBetweenWarehouseRequest{
+	_, err = s.WarehouseServiceClient.TransferStockBetweenWarehouse(newCtx, &gen.TransferStockBetweenWarehouseRequest{
 		FromWarehouseId: req.FromWarehouseId,
 		ToWarehouseId:   req.ToWarehouseId,
 		ProductId:       req.ProductId,
@@ -63,3 +60,98 @@ func (s *WarehouseService) TransferStockBetweenWarehouse(ctx context.Context, re
 
 	return nil
 }
+
+func (s *WarehouseService) FulfillOrder(ctx context.Context, req params.FulfillOrderRequest) error {
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.WarehouseServiceClient.FulfillOrder(newCtx, &gen.FulfillOrderRequest{
+		WarehouseId:    req.WarehouseID,
+		OrderId:        req.OrderID,
+		Status:         req.Status,
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
+}
+
+func (s *WarehouseService) ReceiveReturnedStock(ctx context.Context, req params.ReceiveReturnedStockRequest) error {
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.WarehouseServiceClient.ReceiveReturnedStock(newCtx, &gen.ReceiveReturnedStockRequest{
+		WarehouseId: req.WarehouseID,
+		ReturnId:    req.ReturnID,
+	})
+	if err != nil {
+		return convertErrGrpc(err)
+	}
+
+	return nil
+}
+
+func (s *WarehouseService) ReceiveStock(ctx context.Context, req params.ReceiveStockRequest) (*params.StockMovementResponse, error) {
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := s.WarehouseServiceClient.ReceiveStock(newCtx, &gen.ReceiveStockRequest{
+		ProductId:   req.ProductID,
+		WarehouseId: req.WarehouseID,
+		ShopId:      req.ShopID,
+		Quantity:    req.Quantity,
+		LotNumber:   req.LotNumber,
+		ReceivedAt:  req.ReceivedAt,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	return convertStockMovement(movement), nil
+}
+
+func (s *WarehouseService) AdjustStock(ctx context.Context, req params.AdjustStockRequest) (*params.StockMovementResponse, error) {
+	newCtx, err := newBackOfficeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	movement, err := s.WarehouseServiceClient.AdjustStock(newCtx, &gen.AdjustStockRequest{
+		ProductId:   req.ProductID,
+		WarehouseId: req.WarehouseID,
+		ShopId:      req.ShopID,
+		LotNumber:   req.LotNumber,
+		Quantity:    req.Quantity,
+		Reason:      req.Reason,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return nil, convertErrGrpc(err)
+	}
+
+	return convertStockMovement(movement), nil
+}
+
+// newBackOfficeContext sends the user and the role of the user to the back-office methods,
+// the role is checked again by the service
+func newBackOfficeContext(ctx context.Context) (context.Context, error) {
+	userID, ok := ctx.Value(constanta.LocalUserID).(uuid.UUID)
+	if !ok {
+		return nil, errors.New("error when parsing userID")
+	}
+
+	role, _ := ctx.Value(constanta.LocalRole).(globalcontanta.Role)
+
+	newCtx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), userID)
+	return contextrequest.AppendRoleIntoContextGrpcClient(newCtx, role), nil
+}
@@ -0,0 +1,234 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/e-commerce/pkg/dbsql"
+
+	"github.com/elangreza/e-commerce/api/internal/entity"
+	"github.com/google/uuid"
+)
+
+type (
+	AddressRepo struct {
+		db *sql.DB
+	}
+)
+
+func NewAddressRepo(db *sql.DB) *AddressRepo {
+	return &AddressRepo{
+		db: db,
+	}
+}
+
+const (
+	selectAddressQuery = `SELECT
+		id,
+		user_id,
+		label,
+		recipient_name,
+		phone,
+		street,
+		city,
+		region,
+		postal_code,
+		is_default,
+		created_at,
+		updated_at
+	FROM
+		user_addresses`
+
+	listAddressesQuery = selectAddressQuery + `
+	WHERE
+		user_id=?
+	ORDER BY is_default DESC, created_at ASC;`
+
+	getAddressByIDQuery = selectAddressQuery + `
+	WHERE
+		id=? AND user_id=?;`
+
+	getDefaultAddressQuery = selectAddressQuery + `
+	WHERE
+		user_id=? AND is_default=TRUE;`
+)
+
+// ListAddresses implements addressRepo.
+func (u *AddressRepo) ListAddresses(ctx context.Context, userID uuid.UUID) ([]entity.Address, error) {
+	rows, err := u.db.QueryContext(ctx, listAddressesQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := []entity.Address{}
+	for rows.Next() {
+		address, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, *address)
+	}
+
+	return addresses, nil
+}
+
+// GetAddressByID implements addressRepo.
+func (u *AddressRepo) GetAddressByID(ctx context.Context, userID, id uuid.UUID) (*entity.Address, error) {
+	return scanAddress(u.db.QueryRowContext(ctx, getAddressByIDQuery, id, userID))
+}
+
+// GetDefaultAddress implements addressRepo.
+func (u *AddressRepo) GetDefaultAddress(ctx context.Context, userID uuid.UUID) (*entity.Address, error) {
+	return scanAddress(u.db.QueryRowContext(ctx, getDefaultAddressQuery, userID))
+}
+
+const (
+	createAddressQuery = `INSERT INTO user_addresses
+	(id, user_id, label, recipient_name, phone, street, city, region, postal_code, is_default)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	clearDefaultAddressQuery = `UPDATE user_addresses
+	SET is_default=FALSE, updated_at=CURRENT_TIMESTAMP
+	WHERE user_id=? AND is_default=TRUE;`
+)
+
+// CreateAddress implements addressRepo.
+// The other default address of the user is cleared when the address is the default
+func (u *AddressRepo) CreateAddress(ctx context.Context, address entity.Address) error {
+	return dbsql.WithTransaction(u.db, func(tx *sql.Tx) error {
+		if address.IsDefault {
+			_, err := tx.ExecContext(ctx, clearDefaultAddressQuery, address.UserID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, createAddressQuery,
+			address.ID,
+			address.UserID,
+			address.Label,
+			address.RecipientName,
+			address.Phone,
+			address.Street,
+			address.City,
+			address.Region,
+			address.PostalCode,
+			address.IsDefault,
+		)
+		return err
+	})
+}
+
+const (
+	updateAddressQuery = `UPDATE user_addresses
+	SET label=?, recipient_name=?, phone=?, street=?, city=?, region=?, postal_code=?, is_default=?, updated_at=CURRENT_TIMESTAMP
+	WHERE id=? AND user_id=?;`
+)
+
+// UpdateAddress implements addressRepo.
+// The other default address of the user is cleared when the address is the default
+func (u *AddressRepo) UpdateAddress(ctx context.Context, address entity.Address) error {
+	return dbsql.WithTransaction(u.db, func(tx *sql.Tx) error {
+		if address.IsDefault {
+			_, err := tx.ExecContext(ctx, clearDefaultAddressQuery, address.UserID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, updateAddressQuery,
+			address.Label,
+			address.RecipientName,
+			address.Phone,
+			address.Street,
+			address.City,
+			address.Region,
+			address.PostalCode,
+			address.IsDefault,
+			address.ID,
+			address.UserID,
+		)
+		if err != nil {
+			return err
+		}
+
+		return checkRowsAffected(result)
+	})
+}
+
+const (
+	deleteAddressQuery = `DELETE FROM user_addresses
+	WHERE id=? AND user_id=?;`
+
+	// the latest address becomes the default when the default address is deleted
+	promoteDefaultAddressQuery = `UPDATE user_addresses
+	SET is_default=TRUE, updated_at=CURRENT_TIMESTAMP
+	WHERE id=(
+		SELECT id FROM user_addresses
+		WHERE user_id=?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	) AND NOT EXISTS (
+		SELECT 1 FROM user_addresses
+		WHERE user_id=? AND is_default=TRUE
+	);`
+)
+
+// DeleteAddress implements addressRepo.
+func (u *AddressRepo) DeleteAddress(ctx context.Context, userID, id uuid.UUID) error {
+	return dbsql.WithTransaction(u.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, deleteAddressQuery, id, userID)
+		if err != nil {
+			return err
+		}
+
+		err = checkRowsAffected(result)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, promoteDefaultAddressQuery, userID, userID)
+		return err
+	})
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAddress(row rowScanner) (*entity.Address, error) {
+	address := &entity.Address{}
+	err := row.Scan(
+		&address.ID,
+		&address.UserID,
+		&address.Label,
+		&address.RecipientName,
+		&address.Phone,
+		&address.Street,
+		&address.City,
+		&address.Region,
+		&address.PostalCode,
+		&address.IsDefault,
+		&address.CreatedAt,
+		&address.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+func checkRowsAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
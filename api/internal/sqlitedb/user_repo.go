@@ -22,8 +22,8 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 
 const (
 	createUserQuery = `INSERT INTO users
-	(id, "name", email, "password")
-	VALUES(?, ?, ?, ?);`
+	(id, "name", email, "password", role)
+	VALUES(?, ?, ?, ?, ?);`
 )
 
 // CreateUser implements userRepo.
@@ -32,7 +32,8 @@ func (u *UserRepo) CreateUser(ctx context.Context, user entity.User) error {
 		user.ID,
 		user.Name,
 		user.Email,
-		user.GetPassword())
+		user.GetPassword(),
+		user.Role)
 	if err != nil {
 		return err
 	}
@@ -46,6 +47,8 @@ const (
 		"name", 
 		email, 
 		"password",
+		currency,
+		role,
 		created_at,
 		updated_at
 	FROM 
@@ -64,6 +67,8 @@ func (u *UserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.Us
 		&user.Name,
 		&user.Email,
 		&password,
+		&user.Currency,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -82,6 +87,8 @@ const (
 		"name", 
 		email, 
 		"password",
+		currency,
+		role,
 		created_at,
 		updated_at
 	FROM 
@@ -99,6 +106,8 @@ func (u *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User,
 		&user.Name,
 		&user.Email,
 		&password,
+		&user.Currency,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -110,3 +119,19 @@ func (u *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User,
 
 	return user, nil
 }
+
+const (
+	updateUserCurrencyQuery = `UPDATE users
+	SET currency=?, updated_at=CURRENT_TIMESTAMP
+	WHERE id=?;`
+)
+
+// UpdateUserCurrency implements userRepo.
+func (u *UserRepo) UpdateUserCurrency(ctx context.Context, id uuid.UUID, currency string) error {
+	_, err := u.db.ExecContext(ctx, updateUserCurrencyQuery, currency, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN currency;
\ No newline at end of file
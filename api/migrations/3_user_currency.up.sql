@@ -0,0 +1,2 @@
+-- empty currency follows the default currency of the order service
+ALTER TABLE users ADD COLUMN currency TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "user_addresses" (
+    "id" TEXT PRIMARY KEY,
+    "user_id" TEXT NOT NULL,
+    "label" TEXT NOT NULL DEFAULT '',
+    "recipient_name" TEXT NOT NULL,
+    "phone" TEXT NOT NULL DEFAULT '',
+    "street" TEXT NOT NULL,
+    "city" TEXT NOT NULL,
+    -- region is the region code used for the shipping fee, e.g., JKT
+    "region" TEXT NOT NULL,
+    "postal_code" TEXT NOT NULL DEFAULT '',
+    -- the default address is used when the order is created without address_id
+    "is_default" BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+);
+
+CREATE INDEX "user_addresses_user_id_index" ON "user_addresses" ("user_id");
@@ -0,0 +1,2 @@
+-- the admin is promoted manually, e.g. UPDATE users SET role='ADMIN' WHERE email='admin@example.com'
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'CUSTOMER';
@@ -68,12 +68,186 @@ func (m *AddCartItemRequest) GetQuantity() int64 {
 	return 0
 }
 
-type CartItem struct {
+type RemoveCartItemRequest struct {
+	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveCartItemRequest) Reset()         { *m = RemoveCartItemRequest{} }
+func (m *RemoveCartItemRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveCartItemRequest) ProtoMessage()    {}
+func (*RemoveCartItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{1}
+}
+
+func (m *RemoveCartItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveCartItemRequest.Unmarshal(m, b)
+}
+func (m *RemoveCartItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveCartItemRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveCartItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveCartItemRequest.Merge(m, src)
+}
+func (m *RemoveCartItemRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveCartItemRequest.Size(m)
+}
+func (m *RemoveCartItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveCartItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveCartItemRequest proto.InternalMessageInfo
+
+func (m *RemoveCartItemRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+type SetCartItemQuantityRequest struct {
 	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity             int64    `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Price                *Money   `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
-	ActualStock          int64    `protobuf:"varint,5,opt,name=actual_stock,json=actualStock,proto3" json:"actual_stock,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetCartItemQuantityRequest) Reset()         { *m = SetCartItemQuantityRequest{} }
+func (m *SetCartItemQuantityRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCartItemQuantityRequest) ProtoMessage()    {}
+func (*SetCartItemQuantityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{2}
+}
+
+func (m *SetCartItemQuantityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCartItemQuantityRequest.Unmarshal(m, b)
+}
+func (m *SetCartItemQuantityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCartItemQuantityRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCartItemQuantityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCartItemQuantityRequest.Merge(m, src)
+}
+func (m *SetCartItemQuantityRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCartItemQuantityRequest.Size(m)
+}
+func (m *SetCartItemQuantityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCartItemQuantityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCartItemQuantityRequest proto.InternalMessageInfo
+
+func (m *SetCartItemQuantityRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *SetCartItemQuantityRequest) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+type MergeCartRequest struct {
+	CartToken            string   `protobuf:"bytes,1,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MergeCartRequest) Reset()         { *m = MergeCartRequest{} }
+func (m *MergeCartRequest) String() string { return proto.CompactTextString(m) }
+func (*MergeCartRequest) ProtoMessage()    {}
+func (*MergeCartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{3}
+}
+
+func (m *MergeCartRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MergeCartRequest.Unmarshal(m, b)
+}
+func (m *MergeCartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MergeCartRequest.Marshal(b, m, deterministic)
+}
+func (m *MergeCartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeCartRequest.Merge(m, src)
+}
+func (m *MergeCartRequest) XXX_Size() int {
+	return xxx_messageInfo_MergeCartRequest.Size(m)
+}
+func (m *MergeCartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeCartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MergeCartRequest proto.InternalMessageInfo
+
+func (m *MergeCartRequest) GetCartToken() string {
+	if m != nil {
+		return m.CartToken
+	}
+	return ""
+}
+
+type ApplyCouponRequest struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyCouponRequest) Reset()         { *m = ApplyCouponRequest{} }
+func (m *ApplyCouponRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyCouponRequest) ProtoMessage()    {}
+func (*ApplyCouponRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{4}
+}
+
+func (m *ApplyCouponRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyCouponRequest.Unmarshal(m, b)
+}
+func (m *ApplyCouponRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyCouponRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplyCouponRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyCouponRequest.Merge(m, src)
+}
+func (m *ApplyCouponRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyCouponRequest.Size(m)
+}
+func (m *ApplyCouponRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyCouponRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyCouponRequest proto.InternalMessageInfo
+
+func (m *ApplyCouponRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type CartItem struct {
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int64  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// price when the product was added to the cart
+	Price       *Money `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	ActualStock int64  `protobuf:"varint,5,opt,name=actual_stock,json=actualStock,proto3" json:"actual_stock,omitempty"`
+	// price in the catalog, empty when the product is unavailable
+	CurrentPrice      *Money `protobuf:"bytes,6,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	PriceChanged      bool   `protobuf:"varint,7,opt,name=price_changed,json=priceChanged,proto3" json:"price_changed,omitempty"`
+	InsufficientStock bool   `protobuf:"varint,8,opt,name=insufficient_stock,json=insufficientStock,proto3" json:"insufficient_stock,omitempty"`
+	Unavailable       bool   `protobuf:"varint,9,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+	// price * quantity in the settlement currency of the user
+	SettlementTotal *Money `protobuf:"bytes,10,opt,name=settlement_total,json=settlementTotal,proto3" json:"settlement_total,omitempty"`
+	// part of the coupon discount for this item, in the settlement currency of the user
+	Discount             *Money   `protobuf:"bytes,11,opt,name=discount,proto3" json:"discount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -83,7 +257,7 @@ func (m *CartItem) Reset()         { *m = CartItem{} }
 func (m *CartItem) String() string { return proto.CompactTextString(m) }
 func (*CartItem) ProtoMessage()    {}
 func (*CartItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{1}
+	return fileDescriptor_cd01338c35d87077, []int{5}
 }
 
 func (m *CartItem) XXX_Unmarshal(b []byte) error {
@@ -139,19 +313,69 @@ func (m *CartItem) GetActualStock() int64 {
 	return 0
 }
 
+func (m *CartItem) GetCurrentPrice() *Money {
+	if m != nil {
+		return m.CurrentPrice
+	}
+	return nil
+}
+
+func (m *CartItem) GetPriceChanged() bool {
+	if m != nil {
+		return m.PriceChanged
+	}
+	return false
+}
+
+func (m *CartItem) GetInsufficientStock() bool {
+	if m != nil {
+		return m.InsufficientStock
+	}
+	return false
+}
+
+func (m *CartItem) GetUnavailable() bool {
+	if m != nil {
+		return m.Unavailable
+	}
+	return false
+}
+
+func (m *CartItem) GetSettlementTotal() *Money {
+	if m != nil {
+		return m.SettlementTotal
+	}
+	return nil
+}
+
+func (m *CartItem) GetDiscount() *Money {
+	if m != nil {
+		return m.Discount
+	}
+	return nil
+}
+
 type Cart struct {
-	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Items                []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Id    string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// sum of the current price of the available items in the settlement currency of the user
+	Subtotal   *Money `protobuf:"bytes,3,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	CouponCode string `protobuf:"bytes,4,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	Discount   *Money `protobuf:"bytes,5,opt,name=discount,proto3" json:"discount,omitempty"`
+	// subtotal - discount
+	Total *Money `protobuf:"bytes,6,opt,name=total,proto3" json:"total,omitempty"`
+	// reason the coupon cannot be applied anymore, the order is refused until it is reviewed
+	CouponIssue          string   `protobuf:"bytes,7,opt,name=coupon_issue,json=couponIssue,proto3" json:"coupon_issue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Cart) Reset()         { *m = Cart{} }
 func (m *Cart) String() string { return proto.CompactTextString(m) }
 func (*Cart) ProtoMessage()    {}
 func (*Cart) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{2}
+	return fileDescriptor_cd01338c35d87077, []int{6}
 }
 
 func (m *Cart) XXX_Unmarshal(b []byte) error {
@@ -186,433 +410,2327 @@ func (m *Cart) GetItems() []*CartItem {
 	return nil
 }
 
-type OrderItem struct {
-	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	PricePerUnit         *Money   `protobuf:"bytes,4,opt,name=price_per_unit,json=pricePerUnit,proto3" json:"price_per_unit,omitempty"`
-	Quantity             int64    `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *OrderItem) Reset()         { *m = OrderItem{} }
-func (m *OrderItem) String() string { return proto.CompactTextString(m) }
-func (*OrderItem) ProtoMessage()    {}
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{3}
-}
-
-func (m *OrderItem) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
-}
-func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
-}
-func (m *OrderItem) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OrderItem.Merge(m, src)
-}
-func (m *OrderItem) XXX_Size() int {
-	return xxx_messageInfo_OrderItem.Size(m)
-}
-func (m *OrderItem) XXX_DiscardUnknown() {
-	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+func (m *Cart) GetSubtotal() *Money {
+	if m != nil {
+		return m.Subtotal
+	}
+	return nil
 }
 
-var xxx_messageInfo_OrderItem proto.InternalMessageInfo
-
-func (m *OrderItem) GetProductId() string {
+func (m *Cart) GetCouponCode() string {
 	if m != nil {
-		return m.ProductId
+		return m.CouponCode
 	}
 	return ""
 }
 
-func (m *OrderItem) GetName() string {
+func (m *Cart) GetDiscount() *Money {
 	if m != nil {
-		return m.Name
+		return m.Discount
 	}
-	return ""
+	return nil
 }
 
-func (m *OrderItem) GetPricePerUnit() *Money {
+func (m *Cart) GetTotal() *Money {
 	if m != nil {
-		return m.PricePerUnit
+		return m.Total
 	}
 	return nil
 }
 
-func (m *OrderItem) GetQuantity() int64 {
+func (m *Cart) GetCouponIssue() string {
 	if m != nil {
-		return m.Quantity
+		return m.CouponIssue
 	}
-	return 0
+	return ""
 }
 
-type Order struct {
-	IdempotencyKey       string       `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	Id                   string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	UserId               string       `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items                []*OrderItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
-	TotalAmount          *Money       `protobuf:"bytes,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status               string       `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	TransactionId        string       `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type CartIssue struct {
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// PRICE_CHANGED, INSUFFICIENT_STOCK, UNAVAILABLE or COUPON_NOT_APPLICABLE
+	Reason      string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	OldPrice    *Money `protobuf:"bytes,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice    *Money `protobuf:"bytes,4,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	Quantity    int64  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ActualStock int64  `protobuf:"varint,6,opt,name=actual_stock,json=actualStock,proto3" json:"actual_stock,omitempty"`
+	// only for COUPON_NOT_APPLICABLE, product_id is empty
+	CouponCode           string   `protobuf:"bytes,7,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	Message              string   `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Order) Reset()         { *m = Order{} }
-func (m *Order) String() string { return proto.CompactTextString(m) }
-func (*Order) ProtoMessage()    {}
-func (*Order) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{4}
+func (m *CartIssue) Reset()         { *m = CartIssue{} }
+func (m *CartIssue) String() string { return proto.CompactTextString(m) }
+func (*CartIssue) ProtoMessage()    {}
+func (*CartIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{7}
 }
 
-func (m *Order) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Order.Unmarshal(m, b)
+func (m *CartIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CartIssue.Unmarshal(m, b)
 }
-func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+func (m *CartIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CartIssue.Marshal(b, m, deterministic)
 }
-func (m *Order) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Order.Merge(m, src)
+func (m *CartIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CartIssue.Merge(m, src)
 }
-func (m *Order) XXX_Size() int {
-	return xxx_messageInfo_Order.Size(m)
+func (m *CartIssue) XXX_Size() int {
+	return xxx_messageInfo_CartIssue.Size(m)
 }
-func (m *Order) XXX_DiscardUnknown() {
-	xxx_messageInfo_Order.DiscardUnknown(m)
+func (m *CartIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_CartIssue.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Order proto.InternalMessageInfo
+var xxx_messageInfo_CartIssue proto.InternalMessageInfo
 
-func (m *Order) GetIdempotencyKey() string {
+func (m *CartIssue) GetProductId() string {
 	if m != nil {
-		return m.IdempotencyKey
+		return m.ProductId
 	}
 	return ""
 }
 
-func (m *Order) GetId() string {
+func (m *CartIssue) GetReason() string {
 	if m != nil {
-		return m.Id
+		return m.Reason
 	}
 	return ""
 }
 
-func (m *Order) GetUserId() string {
+func (m *CartIssue) GetOldPrice() *Money {
 	if m != nil {
-		return m.UserId
+		return m.OldPrice
 	}
-	return ""
+	return nil
 }
 
-func (m *Order) GetItems() []*OrderItem {
+func (m *CartIssue) GetNewPrice() *Money {
 	if m != nil {
-		return m.Items
+		return m.NewPrice
 	}
 	return nil
 }
 
-func (m *Order) GetTotalAmount() *Money {
+func (m *CartIssue) GetQuantity() int64 {
 	if m != nil {
-		return m.TotalAmount
+		return m.Quantity
 	}
-	return nil
+	return 0
 }
 
-func (m *Order) GetStatus() string {
+func (m *CartIssue) GetActualStock() int64 {
 	if m != nil {
-		return m.Status
+		return m.ActualStock
+	}
+	return 0
+}
+
+func (m *CartIssue) GetCouponCode() string {
+	if m != nil {
+		return m.CouponCode
 	}
 	return ""
 }
 
-func (m *Order) GetTransactionId() string {
+func (m *CartIssue) GetMessage() string {
 	if m != nil {
-		return m.TransactionId
+		return m.Message
 	}
 	return ""
 }
 
-type CreateOrderRequest struct {
-	IdempotencyKey       string   `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// CartIssues is attached as detail of the FAILED_PRECONDITION error of CreateOrder
+type CartIssues struct {
+	Issues               []*CartIssue `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *CreateOrderRequest) Reset()         { *m = CreateOrderRequest{} }
-func (m *CreateOrderRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateOrderRequest) ProtoMessage()    {}
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{5}
+func (m *CartIssues) Reset()         { *m = CartIssues{} }
+func (m *CartIssues) String() string { return proto.CompactTextString(m) }
+func (*CartIssues) ProtoMessage()    {}
+func (*CartIssues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{8}
 }
 
-func (m *CreateOrderRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateOrderRequest.Unmarshal(m, b)
+func (m *CartIssues) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CartIssues.Unmarshal(m, b)
 }
-func (m *CreateOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateOrderRequest.Marshal(b, m, deterministic)
+func (m *CartIssues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CartIssues.Marshal(b, m, deterministic)
 }
-func (m *CreateOrderRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateOrderRequest.Merge(m, src)
+func (m *CartIssues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CartIssues.Merge(m, src)
 }
-func (m *CreateOrderRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateOrderRequest.Size(m)
+func (m *CartIssues) XXX_Size() int {
+	return xxx_messageInfo_CartIssues.Size(m)
 }
-func (m *CreateOrderRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateOrderRequest.DiscardUnknown(m)
+func (m *CartIssues) XXX_DiscardUnknown() {
+	xxx_messageInfo_CartIssues.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateOrderRequest proto.InternalMessageInfo
+var xxx_messageInfo_CartIssues proto.InternalMessageInfo
 
-func (m *CreateOrderRequest) GetIdempotencyKey() string {
+func (m *CartIssues) GetIssues() []*CartIssue {
 	if m != nil {
-		return m.IdempotencyKey
+		return m.Issues
 	}
-	return ""
+	return nil
 }
 
-type CallbackTransactionRequest struct {
-	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	PaymentStatus        string   `protobuf:"bytes,2,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+type OrderItem struct {
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// price in the original currency of the product
+	PricePerUnit *Money `protobuf:"bytes,4,opt,name=price_per_unit,json=pricePerUnit,proto3" json:"price_per_unit,omitempty"`
+	Quantity     int64  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// price_per_unit * quantity converted into the currency of total_amount
+	SettlementTotal *Money `protobuf:"bytes,6,opt,name=settlement_total,json=settlementTotal,proto3" json:"settlement_total,omitempty"`
+	// rate of one major unit of the original currency, as decimal string
+	ExchangeRate string `protobuf:"bytes,7,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	// part of the coupon discount in the currency of total_amount
+	Discount *Money `protobuf:"bytes,8,opt,name=discount,proto3" json:"discount,omitempty"`
+	// tax of the item after the discount in the currency of total_amount
+	Tax *Money `protobuf:"bytes,9,opt,name=tax,proto3" json:"tax,omitempty"`
+	// percent of the tax rule, as decimal string
+	TaxRate string `protobuf:"bytes,10,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	// the tax is already included in the price and is not added to total_amount
+	TaxInclusive         bool     `protobuf:"varint,11,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CallbackTransactionRequest) Reset()         { *m = CallbackTransactionRequest{} }
-func (m *CallbackTransactionRequest) String() string { return proto.CompactTextString(m) }
-func (*CallbackTransactionRequest) ProtoMessage()    {}
-func (*CallbackTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{6}
-}
+func (m *OrderItem) Reset()         { *m = OrderItem{} }
+func (m *OrderItem) String() string { return proto.CompactTextString(m) }
+func (*OrderItem) ProtoMessage()    {}
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{9}
+}
+
+func (m *OrderItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderItem.Unmarshal(m, b)
+}
+func (m *OrderItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderItem.Marshal(b, m, deterministic)
+}
+func (m *OrderItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderItem.Merge(m, src)
+}
+func (m *OrderItem) XXX_Size() int {
+	return xxx_messageInfo_OrderItem.Size(m)
+}
+func (m *OrderItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderItem proto.InternalMessageInfo
+
+func (m *OrderItem) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *OrderItem) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *OrderItem) GetPricePerUnit() *Money {
+	if m != nil {
+		return m.PricePerUnit
+	}
+	return nil
+}
+
+func (m *OrderItem) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *OrderItem) GetSettlementTotal() *Money {
+	if m != nil {
+		return m.SettlementTotal
+	}
+	return nil
+}
+
+func (m *OrderItem) GetExchangeRate() string {
+	if m != nil {
+		return m.ExchangeRate
+	}
+	return ""
+}
+
+func (m *OrderItem) GetDiscount() *Money {
+	if m != nil {
+		return m.Discount
+	}
+	return nil
+}
+
+func (m *OrderItem) GetTax() *Money {
+	if m != nil {
+		return m.Tax
+	}
+	return nil
+}
+
+func (m *OrderItem) GetTaxRate() string {
+	if m != nil {
+		return m.TaxRate
+	}
+	return ""
+}
+
+func (m *OrderItem) GetTaxInclusive() bool {
+	if m != nil {
+		return m.TaxInclusive
+	}
+	return false
+}
+
+type Order struct {
+	IdempotencyKey string       `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Id             string       `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string       `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items          []*OrderItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// amount to be paid in the settlement currency of the user,
+	// subtotal_amount - discount_amount + exclusive tax + shipping_amount
+	TotalAmount   *Money `protobuf:"bytes,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status        string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId string `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	CancelReason  string `protobuf:"bytes,8,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	// sum of the settlement total of the items before the discount
+	SubtotalAmount *Money `protobuf:"bytes,9,opt,name=subtotal_amount,json=subtotalAmount,proto3" json:"subtotal_amount,omitempty"`
+	DiscountAmount *Money `protobuf:"bytes,10,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	CouponCode     string `protobuf:"bytes,11,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	// sum of the tax of the items, both inclusive and exclusive
+	TaxAmount      *Money `protobuf:"bytes,12,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	ShippingAmount *Money `protobuf:"bytes,13,opt,name=shipping_amount,json=shippingAmount,proto3" json:"shipping_amount,omitempty"`
+	// region code the order is shipped to
+	ShippingRegion string    `protobuf:"bytes,14,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	Shipment       *Shipment `protobuf:"bytes,15,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	// every status of the order from the creation, oldest first. only filled by GetOrder
+	StatusHistory []*OrderStatusHistory `protobuf:"bytes,16,rep,name=status_history,json=statusHistory,proto3" json:"status_history,omitempty"`
+	// every refund of the order, oldest first. only filled by GetOrder and RefundOrder
+	Refunds []*Refund `protobuf:"bytes,17,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	// every return request of the order, oldest first. only filled by GetOrder
+	Returns []*Return `protobuf:"bytes,18,rep,name=returns,proto3" json:"returns,omitempty"`
+	// every payment of the order, oldest first. only filled by GetOrder and AddOrderPayment
+	Payments []*OrderPayment `protobuf:"bytes,19,rep,name=payments,proto3" json:"payments,omitempty"`
+	// sum of the paid payments. only filled by GetOrder and AddOrderPayment
+	PaidAmount           *Money   `protobuf:"bytes,20,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Order) Reset()         { *m = Order{} }
+func (m *Order) String() string { return proto.CompactTextString(m) }
+func (*Order) ProtoMessage()    {}
+func (*Order) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{10}
+}
+
+func (m *Order) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Order.Unmarshal(m, b)
+}
+func (m *Order) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Order.Marshal(b, m, deterministic)
+}
+func (m *Order) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Order.Merge(m, src)
+}
+func (m *Order) XXX_Size() int {
+	return xxx_messageInfo_Order.Size(m)
+}
+func (m *Order) XXX_DiscardUnknown() {
+	xxx_messageInfo_Order.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Order proto.InternalMessageInfo
+
+func (m *Order) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *Order) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Order) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Order) GetItems() []*OrderItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Order) GetTotalAmount() *Money {
+	if m != nil {
+		return m.TotalAmount
+	}
+	return nil
+}
+
+func (m *Order) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Order) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *Order) GetCancelReason() string {
+	if m != nil {
+		return m.CancelReason
+	}
+	return ""
+}
+
+func (m *Order) GetSubtotalAmount() *Money {
+	if m != nil {
+		return m.SubtotalAmount
+	}
+	return nil
+}
+
+func (m *Order) GetDiscountAmount() *Money {
+	if m != nil {
+		return m.DiscountAmount
+	}
+	return nil
+}
+
+func (m *Order) GetCouponCode() string {
+	if m != nil {
+		return m.CouponCode
+	}
+	return ""
+}
+
+func (m *Order) GetTaxAmount() *Money {
+	if m != nil {
+		return m.TaxAmount
+	}
+	return nil
+}
+
+func (m *Order) GetShippingAmount() *Money {
+	if m != nil {
+		return m.ShippingAmount
+	}
+	return nil
+}
+
+func (m *Order) GetShippingRegion() string {
+	if m != nil {
+		return m.ShippingRegion
+	}
+	return ""
+}
+
+func (m *Order) GetShipment() *Shipment {
+	if m != nil {
+		return m.Shipment
+	}
+	return nil
+}
+
+func (m *Order) GetStatusHistory() []*OrderStatusHistory {
+	if m != nil {
+		return m.StatusHistory
+	}
+	return nil
+}
+
+func (m *Order) GetRefunds() []*Refund {
+	if m != nil {
+		return m.Refunds
+	}
+	return nil
+}
+
+func (m *Order) GetReturns() []*Return {
+	if m != nil {
+		return m.Returns
+	}
+	return nil
+}
+
+func (m *Order) GetPayments() []*OrderPayment {
+	if m != nil {
+		return m.Payments
+	}
+	return nil
+}
+
+func (m *Order) GetPaidAmount() *Money {
+	if m != nil {
+		return m.PaidAmount
+	}
+	return nil
+}
+
+// one payment of the order, the order can be paid by several payments
+type OrderPayment struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Method        string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// WAITING, PAID, FAILED, CANCELLED, PARTIALLY_REFUNDED or REFUNDED
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Amount               *Money   `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	RefundedAmount       *Money   `protobuf:"bytes,5,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	PaymentUrl           string   `protobuf:"bytes,6,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderPayment) Reset()         { *m = OrderPayment{} }
+func (m *OrderPayment) String() string { return proto.CompactTextString(m) }
+func (*OrderPayment) ProtoMessage()    {}
+func (*OrderPayment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{11}
+}
+
+func (m *OrderPayment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderPayment.Unmarshal(m, b)
+}
+func (m *OrderPayment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderPayment.Marshal(b, m, deterministic)
+}
+func (m *OrderPayment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderPayment.Merge(m, src)
+}
+func (m *OrderPayment) XXX_Size() int {
+	return xxx_messageInfo_OrderPayment.Size(m)
+}
+func (m *OrderPayment) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderPayment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderPayment proto.InternalMessageInfo
+
+func (m *OrderPayment) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *OrderPayment) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *OrderPayment) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *OrderPayment) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *OrderPayment) GetRefundedAmount() *Money {
+	if m != nil {
+		return m.RefundedAmount
+	}
+	return nil
+}
+
+func (m *OrderPayment) GetPaymentUrl() string {
+	if m != nil {
+		return m.PaymentUrl
+	}
+	return ""
+}
+
+func (m *OrderPayment) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type AddOrderPaymentRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// CARD, BANK_TRANSFER, E_WALLET or STORE_CREDIT, CARD when it is empty
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// the unpaid amount of the order when it is empty
+	Amount               *Money   `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddOrderPaymentRequest) Reset()         { *m = AddOrderPaymentRequest{} }
+func (m *AddOrderPaymentRequest) String() string { return proto.CompactTextString(m) }
+func (*AddOrderPaymentRequest) ProtoMessage()    {}
+func (*AddOrderPaymentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{12}
+}
+
+func (m *AddOrderPaymentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddOrderPaymentRequest.Unmarshal(m, b)
+}
+func (m *AddOrderPaymentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddOrderPaymentRequest.Marshal(b, m, deterministic)
+}
+func (m *AddOrderPaymentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddOrderPaymentRequest.Merge(m, src)
+}
+func (m *AddOrderPaymentRequest) XXX_Size() int {
+	return xxx_messageInfo_AddOrderPaymentRequest.Size(m)
+}
+func (m *AddOrderPaymentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddOrderPaymentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddOrderPaymentRequest proto.InternalMessageInfo
+
+func (m *AddOrderPaymentRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *AddOrderPaymentRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *AddOrderPaymentRequest) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+// transition of the order status, from_status is empty when the order is created
+type OrderStatusHistory struct {
+	FromStatus string `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus   string `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// who changed the status, e.g., CUSTOMER, PAYMENT_SERVICE, WAREHOUSE_SERVICE or SYSTEM
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// RFC3339
+	CreatedAt            string   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderStatusHistory) Reset()         { *m = OrderStatusHistory{} }
+func (m *OrderStatusHistory) String() string { return proto.CompactTextString(m) }
+func (*OrderStatusHistory) ProtoMessage()    {}
+func (*OrderStatusHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{13}
+}
+
+func (m *OrderStatusHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderStatusHistory.Unmarshal(m, b)
+}
+func (m *OrderStatusHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderStatusHistory.Marshal(b, m, deterministic)
+}
+func (m *OrderStatusHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderStatusHistory.Merge(m, src)
+}
+func (m *OrderStatusHistory) XXX_Size() int {
+	return xxx_messageInfo_OrderStatusHistory.Size(m)
+}
+func (m *OrderStatusHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderStatusHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderStatusHistory proto.InternalMessageInfo
+
+func (m *OrderStatusHistory) GetFromStatus() string {
+	if m != nil {
+		return m.FromStatus
+	}
+	return ""
+}
+
+func (m *OrderStatusHistory) GetToStatus() string {
+	if m != nil {
+		return m.ToStatus
+	}
+	return ""
+}
+
+func (m *OrderStatusHistory) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *OrderStatusHistory) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *OrderStatusHistory) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+// snapshot of the address book entry of the user when the order is created
+type ShippingAddress struct {
+	RecipientName string `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Street        string `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	City          string `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	// region code, e.g., JKT, used for the shipping fee
+	Region               string   `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode           string   `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShippingAddress) Reset()         { *m = ShippingAddress{} }
+func (m *ShippingAddress) String() string { return proto.CompactTextString(m) }
+func (*ShippingAddress) ProtoMessage()    {}
+func (*ShippingAddress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{14}
+}
+
+func (m *ShippingAddress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShippingAddress.Unmarshal(m, b)
+}
+func (m *ShippingAddress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShippingAddress.Marshal(b, m, deterministic)
+}
+func (m *ShippingAddress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShippingAddress.Merge(m, src)
+}
+func (m *ShippingAddress) XXX_Size() int {
+	return xxx_messageInfo_ShippingAddress.Size(m)
+}
+func (m *ShippingAddress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShippingAddress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShippingAddress proto.InternalMessageInfo
+
+func (m *ShippingAddress) GetRecipientName() string {
+	if m != nil {
+		return m.RecipientName
+	}
+	return ""
+}
+
+func (m *ShippingAddress) GetPhone() string {
+	if m != nil {
+		return m.Phone
+	}
+	return ""
+}
+
+func (m *ShippingAddress) GetStreet() string {
+	if m != nil {
+		return m.Street
+	}
+	return ""
+}
+
+func (m *ShippingAddress) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *ShippingAddress) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *ShippingAddress) GetPostalCode() string {
+	if m != nil {
+		return m.PostalCode
+	}
+	return ""
+}
+
+// delivery information and history of the order, the time is RFC3339 and empty until the step is done
+type Shipment struct {
+	Address              *ShippingAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Carrier              string           `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber       string           `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	PackedAt             string           `protobuf:"bytes,4,opt,name=packed_at,json=packedAt,proto3" json:"packed_at,omitempty"`
+	ShippedAt            string           `protobuf:"bytes,5,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	DeliveredAt          string           `protobuf:"bytes,6,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Shipment) Reset()         { *m = Shipment{} }
+func (m *Shipment) String() string { return proto.CompactTextString(m) }
+func (*Shipment) ProtoMessage()    {}
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{15}
+}
+
+func (m *Shipment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Shipment.Unmarshal(m, b)
+}
+func (m *Shipment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Shipment.Marshal(b, m, deterministic)
+}
+func (m *Shipment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Shipment.Merge(m, src)
+}
+func (m *Shipment) XXX_Size() int {
+	return xxx_messageInfo_Shipment.Size(m)
+}
+func (m *Shipment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Shipment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Shipment proto.InternalMessageInfo
+
+func (m *Shipment) GetAddress() *ShippingAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *Shipment) GetCarrier() string {
+	if m != nil {
+		return m.Carrier
+	}
+	return ""
+}
+
+func (m *Shipment) GetTrackingNumber() string {
+	if m != nil {
+		return m.TrackingNumber
+	}
+	return ""
+}
+
+func (m *Shipment) GetPackedAt() string {
+	if m != nil {
+		return m.PackedAt
+	}
+	return ""
+}
+
+func (m *Shipment) GetShippedAt() string {
+	if m != nil {
+		return m.ShippedAt
+	}
+	return ""
+}
+
+func (m *Shipment) GetDeliveredAt() string {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return ""
+}
+
+type CreateOrderRequest struct {
+	IdempotencyKey string `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// region code the order is shipped to, e.g., JKT,
+	// it is ignored when shipping_address is set
+	ShippingRegion  string           `protobuf:"bytes,2,opt,name=shipping_region,json=shippingRegion,proto3" json:"shipping_region,omitempty"`
+	ShippingAddress *ShippingAddress `protobuf:"bytes,3,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	// the method of the first payment, CARD when it is empty
+	PaymentMethod string `protobuf:"bytes,4,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	// the amount of the first payment, the total amount of the order when it is empty.
+	// the rest is paid by AddOrderPayment
+	PaymentAmount        *Money   `protobuf:"bytes,5,opt,name=payment_amount,json=paymentAmount,proto3" json:"payment_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateOrderRequest) Reset()         { *m = CreateOrderRequest{} }
+func (m *CreateOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateOrderRequest) ProtoMessage()    {}
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{16}
+}
+
+func (m *CreateOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateOrderRequest.Unmarshal(m, b)
+}
+func (m *CreateOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateOrderRequest.Merge(m, src)
+}
+func (m *CreateOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateOrderRequest.Size(m)
+}
+func (m *CreateOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateOrderRequest proto.InternalMessageInfo
+
+func (m *CreateOrderRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *CreateOrderRequest) GetShippingRegion() string {
+	if m != nil {
+		return m.ShippingRegion
+	}
+	return ""
+}
+
+func (m *CreateOrderRequest) GetShippingAddress() *ShippingAddress {
+	if m != nil {
+		return m.ShippingAddress
+	}
+	return nil
+}
+
+func (m *CreateOrderRequest) GetPaymentMethod() string {
+	if m != nil {
+		return m.PaymentMethod
+	}
+	return ""
+}
+
+func (m *CreateOrderRequest) GetPaymentAmount() *Money {
+	if m != nil {
+		return m.PaymentAmount
+	}
+	return nil
+}
+
+type CallbackTransactionRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	PaymentStatus string `protobuf:"bytes,2,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`
+	OrderId       string `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// sum of the paid payments of the order, the order is completed when it covers the total amount
+	PaidAmount           *Money   `protobuf:"bytes,4,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CallbackTransactionRequest) Reset()         { *m = CallbackTransactionRequest{} }
+func (m *CallbackTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*CallbackTransactionRequest) ProtoMessage()    {}
+func (*CallbackTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{17}
+}
+
+func (m *CallbackTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallbackTransactionRequest.Unmarshal(m, b)
+}
+func (m *CallbackTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallbackTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *CallbackTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallbackTransactionRequest.Merge(m, src)
+}
+func (m *CallbackTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_CallbackTransactionRequest.Size(m)
+}
+func (m *CallbackTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallbackTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallbackTransactionRequest proto.InternalMessageInfo
+
+func (m *CallbackTransactionRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *CallbackTransactionRequest) GetPaymentStatus() string {
+	if m != nil {
+		return m.PaymentStatus
+	}
+	return ""
+}
+
+func (m *CallbackTransactionRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *CallbackTransactionRequest) GetPaidAmount() *Money {
+	if m != nil {
+		return m.PaidAmount
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
+func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderRequest) ProtoMessage()    {}
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{18}
+}
+
+func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+}
+func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+}
+func (m *GetOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderRequest.Size(m)
+}
+func (m *GetOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+
+func (m *GetOrderRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Orders struct {
+	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Orders) Reset()         { *m = Orders{} }
+func (m *Orders) String() string { return proto.CompactTextString(m) }
+func (*Orders) ProtoMessage()    {}
+func (*Orders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{19}
+}
+
+func (m *Orders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Orders.Unmarshal(m, b)
+}
+func (m *Orders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Orders.Marshal(b, m, deterministic)
+}
+func (m *Orders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Orders.Merge(m, src)
+}
+func (m *Orders) XXX_Size() int {
+	return xxx_messageInfo_Orders.Size(m)
+}
+func (m *Orders) XXX_DiscardUnknown() {
+	xxx_messageInfo_Orders.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Orders proto.InternalMessageInfo
+
+func (m *Orders) GetOrders() []*Order {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+type GetOrderListRequest struct {
+	StartDate            string   `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate              string   `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOrderListRequest) Reset()         { *m = GetOrderListRequest{} }
+func (m *GetOrderListRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderListRequest) ProtoMessage()    {}
+func (*GetOrderListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{20}
+}
+
+func (m *GetOrderListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderListRequest.Unmarshal(m, b)
+}
+func (m *GetOrderListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderListRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderListRequest.Merge(m, src)
+}
+func (m *GetOrderListRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderListRequest.Size(m)
+}
+func (m *GetOrderListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderListRequest proto.InternalMessageInfo
+
+func (m *GetOrderListRequest) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *GetOrderListRequest) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
+func (m *GetOrderListRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
+func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderRequest) ProtoMessage()    {}
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{21}
+}
+
+func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOrderRequest.Unmarshal(m, b)
+}
+func (m *CancelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderRequest.Merge(m, src)
+}
+func (m *CancelOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOrderRequest.Size(m)
+}
+func (m *CancelOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderRequest.DiscardUnknown(m)
+}
 
-func (m *CallbackTransactionRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CallbackTransactionRequest.Unmarshal(m, b)
+var xxx_messageInfo_CancelOrderRequest proto.InternalMessageInfo
+
+func (m *CancelOrderRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type CancelReservedOrderRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// the warehouse that holds the reserved stock of the order
+	WarehouseId          int64    `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelReservedOrderRequest) Reset()         { *m = CancelReservedOrderRequest{} }
+func (m *CancelReservedOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelReservedOrderRequest) ProtoMessage()    {}
+func (*CancelReservedOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{22}
+}
+
+func (m *CancelReservedOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelReservedOrderRequest.Unmarshal(m, b)
+}
+func (m *CancelReservedOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelReservedOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelReservedOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelReservedOrderRequest.Merge(m, src)
+}
+func (m *CancelReservedOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelReservedOrderRequest.Size(m)
+}
+func (m *CancelReservedOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelReservedOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelReservedOrderRequest proto.InternalMessageInfo
+
+func (m *CancelReservedOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *CancelReservedOrderRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *CancelReservedOrderRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type UpdateFulfillmentRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// PACKED, SHIPPED or DELIVERED
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// required for SHIPPED
+	Carrier              string   `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber       string   `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateFulfillmentRequest) Reset()         { *m = UpdateFulfillmentRequest{} }
+func (m *UpdateFulfillmentRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateFulfillmentRequest) ProtoMessage()    {}
+func (*UpdateFulfillmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{23}
+}
+
+func (m *UpdateFulfillmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFulfillmentRequest.Unmarshal(m, b)
+}
+func (m *UpdateFulfillmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFulfillmentRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateFulfillmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFulfillmentRequest.Merge(m, src)
+}
+func (m *UpdateFulfillmentRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateFulfillmentRequest.Size(m)
+}
+func (m *UpdateFulfillmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFulfillmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFulfillmentRequest proto.InternalMessageInfo
+
+func (m *UpdateFulfillmentRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *UpdateFulfillmentRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UpdateFulfillmentRequest) GetCarrier() string {
+	if m != nil {
+		return m.Carrier
+	}
+	return ""
+}
+
+func (m *UpdateFulfillmentRequest) GetTrackingNumber() string {
+	if m != nil {
+		return m.TrackingNumber
+	}
+	return ""
+}
+
+type RefundItem struct {
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int64  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// part of the refund amount in the currency of the order total amount, ignored in the request
+	Amount               *Money   `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundItem) Reset()         { *m = RefundItem{} }
+func (m *RefundItem) String() string { return proto.CompactTextString(m) }
+func (*RefundItem) ProtoMessage()    {}
+func (*RefundItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{24}
+}
+
+func (m *RefundItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundItem.Unmarshal(m, b)
+}
+func (m *RefundItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundItem.Marshal(b, m, deterministic)
+}
+func (m *RefundItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundItem.Merge(m, src)
+}
+func (m *RefundItem) XXX_Size() int {
+	return xxx_messageInfo_RefundItem.Size(m)
+}
+func (m *RefundItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundItem proto.InternalMessageInfo
+
+func (m *RefundItem) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *RefundItem) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *RefundItem) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+type RefundOrderRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// items to be refunded, every item that is not refunded yet and the shipping are refunded when empty
+	Items                []*RefundItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason               string        `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RefundOrderRequest) Reset()         { *m = RefundOrderRequest{} }
+func (m *RefundOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundOrderRequest) ProtoMessage()    {}
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{25}
+}
+
+func (m *RefundOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundOrderRequest.Unmarshal(m, b)
+}
+func (m *RefundOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *RefundOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundOrderRequest.Merge(m, src)
+}
+func (m *RefundOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundOrderRequest.Size(m)
+}
+func (m *RefundOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundOrderRequest proto.InternalMessageInfo
+
+func (m *RefundOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *RefundOrderRequest) GetItems() []*RefundItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *RefundOrderRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// money paid back to the customer for the returned items of the order
+type Refund struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// PENDING, COMPLETED or FAILED
+	Status string        `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Amount *Money        `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason string        `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items  []*RefundItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// the refunded items are returned into the warehouse
+	Restocked bool `protobuf:"varint,6,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	// RFC3339
+	CreatedAt string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// return request the refund is for, empty when it is refunded by the customer service directly
+	ReturnId             string   `protobuf:"bytes,8,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Refund) Reset()         { *m = Refund{} }
+func (m *Refund) String() string { return proto.CompactTextString(m) }
+func (*Refund) ProtoMessage()    {}
+func (*Refund) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{26}
+}
+
+func (m *Refund) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Refund.Unmarshal(m, b)
+}
+func (m *Refund) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Refund.Marshal(b, m, deterministic)
+}
+func (m *Refund) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Refund.Merge(m, src)
+}
+func (m *Refund) XXX_Size() int {
+	return xxx_messageInfo_Refund.Size(m)
+}
+func (m *Refund) XXX_DiscardUnknown() {
+	xxx_messageInfo_Refund.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Refund proto.InternalMessageInfo
+
+func (m *Refund) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Refund) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Refund) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *Refund) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Refund) GetItems() []*RefundItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Refund) GetRestocked() bool {
+	if m != nil {
+		return m.Restocked
+	}
+	return false
+}
+
+func (m *Refund) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Refund) GetReturnId() string {
+	if m != nil {
+		return m.ReturnId
+	}
+	return ""
+}
+
+type ReturnItem struct {
+	ProductId            string   `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity             int64    `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReturnItem) Reset()         { *m = ReturnItem{} }
+func (m *ReturnItem) String() string { return proto.CompactTextString(m) }
+func (*ReturnItem) ProtoMessage()    {}
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{27}
+}
+
+func (m *ReturnItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnItem.Unmarshal(m, b)
+}
+func (m *ReturnItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnItem.Marshal(b, m, deterministic)
+}
+func (m *ReturnItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnItem.Merge(m, src)
+}
+func (m *ReturnItem) XXX_Size() int {
+	return xxx_messageInfo_ReturnItem.Size(m)
+}
+func (m *ReturnItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReturnItem proto.InternalMessageInfo
+
+func (m *ReturnItem) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *ReturnItem) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+type CreateReturnRequest struct {
+	OrderId              string        `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items                []*ReturnItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason               string        `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateReturnRequest) Reset()         { *m = CreateReturnRequest{} }
+func (m *CreateReturnRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateReturnRequest) ProtoMessage()    {}
+func (*CreateReturnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{28}
+}
+
+func (m *CreateReturnRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateReturnRequest.Unmarshal(m, b)
+}
+func (m *CreateReturnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateReturnRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateReturnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateReturnRequest.Merge(m, src)
+}
+func (m *CreateReturnRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateReturnRequest.Size(m)
+}
+func (m *CreateReturnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateReturnRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateReturnRequest proto.InternalMessageInfo
+
+func (m *CreateReturnRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *CreateReturnRequest) GetItems() []*ReturnItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *CreateReturnRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ReviewReturnRequest struct {
+	ReturnId string `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	// the return is rejected when false
+	Approve bool `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	// required to reject the return
+	Note                 string   `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReviewReturnRequest) Reset()         { *m = ReviewReturnRequest{} }
+func (m *ReviewReturnRequest) String() string { return proto.CompactTextString(m) }
+func (*ReviewReturnRequest) ProtoMessage()    {}
+func (*ReviewReturnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{29}
+}
+
+func (m *ReviewReturnRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewReturnRequest.Unmarshal(m, b)
 }
-func (m *CallbackTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CallbackTransactionRequest.Marshal(b, m, deterministic)
+func (m *ReviewReturnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewReturnRequest.Marshal(b, m, deterministic)
 }
-func (m *CallbackTransactionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CallbackTransactionRequest.Merge(m, src)
+func (m *ReviewReturnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewReturnRequest.Merge(m, src)
 }
-func (m *CallbackTransactionRequest) XXX_Size() int {
-	return xxx_messageInfo_CallbackTransactionRequest.Size(m)
+func (m *ReviewReturnRequest) XXX_Size() int {
+	return xxx_messageInfo_ReviewReturnRequest.Size(m)
 }
-func (m *CallbackTransactionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CallbackTransactionRequest.DiscardUnknown(m)
+func (m *ReviewReturnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewReturnRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CallbackTransactionRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReviewReturnRequest proto.InternalMessageInfo
 
-func (m *CallbackTransactionRequest) GetTransactionId() string {
+func (m *ReviewReturnRequest) GetReturnId() string {
 	if m != nil {
-		return m.TransactionId
+		return m.ReturnId
 	}
 	return ""
 }
 
-func (m *CallbackTransactionRequest) GetPaymentStatus() string {
+func (m *ReviewReturnRequest) GetApprove() bool {
 	if m != nil {
-		return m.PaymentStatus
+		return m.Approve
+	}
+	return false
+}
+
+func (m *ReviewReturnRequest) GetNote() string {
+	if m != nil {
+		return m.Note
 	}
 	return ""
 }
 
-type GetOrderRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type ReceiveReturnRequest struct {
+	ReturnId string `protobuf:"bytes,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	// warehouse the returned items are put into
+	WarehouseId          int64    `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetOrderRequest) Reset()         { *m = GetOrderRequest{} }
-func (m *GetOrderRequest) String() string { return proto.CompactTextString(m) }
-func (*GetOrderRequest) ProtoMessage()    {}
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{7}
+func (m *ReceiveReturnRequest) Reset()         { *m = ReceiveReturnRequest{} }
+func (m *ReceiveReturnRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveReturnRequest) ProtoMessage()    {}
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{30}
 }
 
-func (m *GetOrderRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetOrderRequest.Unmarshal(m, b)
+func (m *ReceiveReturnRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveReturnRequest.Unmarshal(m, b)
 }
-func (m *GetOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetOrderRequest.Marshal(b, m, deterministic)
+func (m *ReceiveReturnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveReturnRequest.Marshal(b, m, deterministic)
 }
-func (m *GetOrderRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOrderRequest.Merge(m, src)
+func (m *ReceiveReturnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveReturnRequest.Merge(m, src)
 }
-func (m *GetOrderRequest) XXX_Size() int {
-	return xxx_messageInfo_GetOrderRequest.Size(m)
+func (m *ReceiveReturnRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveReturnRequest.Size(m)
 }
-func (m *GetOrderRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOrderRequest.DiscardUnknown(m)
+func (m *ReceiveReturnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveReturnRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetOrderRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReceiveReturnRequest proto.InternalMessageInfo
 
-func (m *GetOrderRequest) GetId() string {
+func (m *ReceiveReturnRequest) GetReturnId() string {
+	if m != nil {
+		return m.ReturnId
+	}
+	return ""
+}
+
+func (m *ReceiveReturnRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+// items of the paid order that are sent back by the customer
+type Return struct {
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// REQUESTED, APPROVED, REJECTED, RECEIVED or REFUNDED
+	Status string        `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason string        `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items  []*ReturnItem `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	// note of the admin review
+	Note string `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	// warehouse that received the items, 0 until RECEIVED
+	WarehouseId int64 `protobuf:"varint,7,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// refund of the items, empty until REFUNDED
+	RefundId string `protobuf:"bytes,8,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	// RFC3339
+	CreatedAt            string   `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            string   `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Return) Reset()         { *m = Return{} }
+func (m *Return) String() string { return proto.CompactTextString(m) }
+func (*Return) ProtoMessage()    {}
+func (*Return) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{31}
+}
+
+func (m *Return) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Return.Unmarshal(m, b)
+}
+func (m *Return) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Return.Marshal(b, m, deterministic)
+}
+func (m *Return) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Return.Merge(m, src)
+}
+func (m *Return) XXX_Size() int {
+	return xxx_messageInfo_Return.Size(m)
+}
+func (m *Return) XXX_DiscardUnknown() {
+	xxx_messageInfo_Return.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Return proto.InternalMessageInfo
+
+func (m *Return) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-type Orders struct {
-	Orders               []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+func (m *Return) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *Return) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Return) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Return) GetItems() []*ReturnItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *Return) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *Return) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *Return) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *Return) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *Return) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+// compensation that kept failing after all retry attempts
+type DeadLetterCompensation struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId              string   `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Step                 string   `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	Attempt              int64    `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	LastError            string   `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Orders) Reset()         { *m = Orders{} }
-func (m *Orders) String() string { return proto.CompactTextString(m) }
-func (*Orders) ProtoMessage()    {}
-func (*Orders) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{8}
+func (m *DeadLetterCompensation) Reset()         { *m = DeadLetterCompensation{} }
+func (m *DeadLetterCompensation) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterCompensation) ProtoMessage()    {}
+func (*DeadLetterCompensation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{32}
 }
 
-func (m *Orders) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Orders.Unmarshal(m, b)
+func (m *DeadLetterCompensation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterCompensation.Unmarshal(m, b)
 }
-func (m *Orders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Orders.Marshal(b, m, deterministic)
+func (m *DeadLetterCompensation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterCompensation.Marshal(b, m, deterministic)
 }
-func (m *Orders) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Orders.Merge(m, src)
+func (m *DeadLetterCompensation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterCompensation.Merge(m, src)
 }
-func (m *Orders) XXX_Size() int {
-	return xxx_messageInfo_Orders.Size(m)
+func (m *DeadLetterCompensation) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterCompensation.Size(m)
 }
-func (m *Orders) XXX_DiscardUnknown() {
-	xxx_messageInfo_Orders.DiscardUnknown(m)
+func (m *DeadLetterCompensation) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterCompensation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Orders proto.InternalMessageInfo
+var xxx_messageInfo_DeadLetterCompensation proto.InternalMessageInfo
 
-func (m *Orders) GetOrders() []*Order {
+func (m *DeadLetterCompensation) GetId() string {
 	if m != nil {
-		return m.Orders
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeadLetterCompensation) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *DeadLetterCompensation) GetStep() string {
+	if m != nil {
+		return m.Step
+	}
+	return ""
+}
+
+func (m *DeadLetterCompensation) GetAttempt() int64 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *DeadLetterCompensation) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *DeadLetterCompensation) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type DeadLetterCompensations struct {
+	Compensations        []*DeadLetterCompensation `protobuf:"bytes,1,rep,name=compensations,proto3" json:"compensations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *DeadLetterCompensations) Reset()         { *m = DeadLetterCompensations{} }
+func (m *DeadLetterCompensations) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterCompensations) ProtoMessage()    {}
+func (*DeadLetterCompensations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{33}
+}
+
+func (m *DeadLetterCompensations) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterCompensations.Unmarshal(m, b)
+}
+func (m *DeadLetterCompensations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterCompensations.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterCompensations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterCompensations.Merge(m, src)
+}
+func (m *DeadLetterCompensations) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterCompensations.Size(m)
+}
+func (m *DeadLetterCompensations) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterCompensations.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterCompensations proto.InternalMessageInfo
+
+func (m *DeadLetterCompensations) GetCompensations() []*DeadLetterCompensation {
+	if m != nil {
+		return m.Compensations
 	}
 	return nil
 }
 
-type GetOrderListRequest struct {
-	StartDate            string   `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate              string   `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+type Promotion struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// coupon code entered by the customer, stored in upper case
+	Code        string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// PERCENTAGE, FIXED_AMOUNT or BUY_X_GET_Y
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// ORDER, SHOP or PRODUCT
+	Scope string `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+	// shop id or product id of the scope, empty for ORDER
+	ScopeId string `protobuf:"bytes,6,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty"`
+	// for PERCENTAGE, as decimal string e.g., "12.5"
+	Percentage string `protobuf:"bytes,7,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// for FIXED_AMOUNT, converted into the settlement currency of the user
+	Amount *Money `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	// for BUY_X_GET_Y, every buy_quantity + get_quantity units of a product get get_quantity units free
+	BuyQuantity int64 `protobuf:"varint,9,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity int64 `protobuf:"varint,10,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	// 0 is unlimited, orders that are failed or cancelled are not counted
+	UsageLimit        int64 `protobuf:"varint,11,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	UsageLimitPerUser int64 `protobuf:"varint,12,opt,name=usage_limit_per_user,json=usageLimitPerUser,proto3" json:"usage_limit_per_user,omitempty"`
+	// RFC3339
+	StartsAt             string   `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt               string   `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	IsActive             bool     `protobuf:"varint,15,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetOrderListRequest) Reset()         { *m = GetOrderListRequest{} }
-func (m *GetOrderListRequest) String() string { return proto.CompactTextString(m) }
-func (*GetOrderListRequest) ProtoMessage()    {}
-func (*GetOrderListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cd01338c35d87077, []int{9}
+func (m *Promotion) Reset()         { *m = Promotion{} }
+func (m *Promotion) String() string { return proto.CompactTextString(m) }
+func (*Promotion) ProtoMessage()    {}
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cd01338c35d87077, []int{34}
 }
 
-func (m *GetOrderListRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetOrderListRequest.Unmarshal(m, b)
+func (m *Promotion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Promotion.Unmarshal(m, b)
 }
-func (m *GetOrderListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetOrderListRequest.Marshal(b, m, deterministic)
+func (m *Promotion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Promotion.Marshal(b, m, deterministic)
 }
-func (m *GetOrderListRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOrderListRequest.Merge(m, src)
+func (m *Promotion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Promotion.Merge(m, src)
 }
-func (m *GetOrderListRequest) XXX_Size() int {
-	return xxx_messageInfo_GetOrderListRequest.Size(m)
+func (m *Promotion) XXX_Size() int {
+	return xxx_messageInfo_Promotion.Size(m)
 }
-func (m *GetOrderListRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOrderListRequest.DiscardUnknown(m)
+func (m *Promotion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Promotion.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetOrderListRequest proto.InternalMessageInfo
+var xxx_messageInfo_Promotion proto.InternalMessageInfo
 
-func (m *GetOrderListRequest) GetStartDate() string {
+func (m *Promotion) GetId() string {
 	if m != nil {
-		return m.StartDate
+		return m.Id
 	}
 	return ""
 }
 
-func (m *GetOrderListRequest) GetEndDate() string {
+func (m *Promotion) GetCode() string {
 	if m != nil {
-		return m.EndDate
+		return m.Code
 	}
 	return ""
 }
 
-func (m *GetOrderListRequest) GetStatus() string {
+func (m *Promotion) GetDescription() string {
 	if m != nil {
-		return m.Status
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Promotion) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Promotion) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *Promotion) GetScopeId() string {
+	if m != nil {
+		return m.ScopeId
+	}
+	return ""
+}
+
+func (m *Promotion) GetPercentage() string {
+	if m != nil {
+		return m.Percentage
+	}
+	return ""
+}
+
+func (m *Promotion) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *Promotion) GetBuyQuantity() int64 {
+	if m != nil {
+		return m.BuyQuantity
+	}
+	return 0
+}
+
+func (m *Promotion) GetGetQuantity() int64 {
+	if m != nil {
+		return m.GetQuantity
+	}
+	return 0
+}
+
+func (m *Promotion) GetUsageLimit() int64 {
+	if m != nil {
+		return m.UsageLimit
+	}
+	return 0
+}
+
+func (m *Promotion) GetUsageLimitPerUser() int64 {
+	if m != nil {
+		return m.UsageLimitPerUser
+	}
+	return 0
+}
+
+func (m *Promotion) GetStartsAt() string {
+	if m != nil {
+		return m.StartsAt
+	}
+	return ""
+}
+
+func (m *Promotion) GetEndsAt() string {
+	if m != nil {
+		return m.EndsAt
 	}
 	return ""
 }
 
+func (m *Promotion) GetIsActive() bool {
+	if m != nil {
+		return m.IsActive
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*AddCartItemRequest)(nil), "gen.AddCartItemRequest")
+	proto.RegisterType((*RemoveCartItemRequest)(nil), "gen.RemoveCartItemRequest")
+	proto.RegisterType((*SetCartItemQuantityRequest)(nil), "gen.SetCartItemQuantityRequest")
+	proto.RegisterType((*MergeCartRequest)(nil), "gen.MergeCartRequest")
+	proto.RegisterType((*ApplyCouponRequest)(nil), "gen.ApplyCouponRequest")
 	proto.RegisterType((*CartItem)(nil), "gen.CartItem")
 	proto.RegisterType((*Cart)(nil), "gen.Cart")
+	proto.RegisterType((*CartIssue)(nil), "gen.CartIssue")
+	proto.RegisterType((*CartIssues)(nil), "gen.CartIssues")
 	proto.RegisterType((*OrderItem)(nil), "gen.OrderItem")
 	proto.RegisterType((*Order)(nil), "gen.Order")
+	proto.RegisterType((*OrderPayment)(nil), "gen.OrderPayment")
+	proto.RegisterType((*AddOrderPaymentRequest)(nil), "gen.AddOrderPaymentRequest")
+	proto.RegisterType((*OrderStatusHistory)(nil), "gen.OrderStatusHistory")
+	proto.RegisterType((*ShippingAddress)(nil), "gen.ShippingAddress")
+	proto.RegisterType((*Shipment)(nil), "gen.Shipment")
 	proto.RegisterType((*CreateOrderRequest)(nil), "gen.CreateOrderRequest")
 	proto.RegisterType((*CallbackTransactionRequest)(nil), "gen.CallbackTransactionRequest")
 	proto.RegisterType((*GetOrderRequest)(nil), "gen.GetOrderRequest")
 	proto.RegisterType((*Orders)(nil), "gen.Orders")
 	proto.RegisterType((*GetOrderListRequest)(nil), "gen.GetOrderListRequest")
+	proto.RegisterType((*CancelOrderRequest)(nil), "gen.CancelOrderRequest")
+	proto.RegisterType((*CancelReservedOrderRequest)(nil), "gen.CancelReservedOrderRequest")
+	proto.RegisterType((*UpdateFulfillmentRequest)(nil), "gen.UpdateFulfillmentRequest")
+	proto.RegisterType((*RefundItem)(nil), "gen.RefundItem")
+	proto.RegisterType((*RefundOrderRequest)(nil), "gen.RefundOrderRequest")
+	proto.RegisterType((*Refund)(nil), "gen.Refund")
+	proto.RegisterType((*ReturnItem)(nil), "gen.ReturnItem")
+	proto.RegisterType((*CreateReturnRequest)(nil), "gen.CreateReturnRequest")
+	proto.RegisterType((*ReviewReturnRequest)(nil), "gen.ReviewReturnRequest")
+	proto.RegisterType((*ReceiveReturnRequest)(nil), "gen.ReceiveReturnRequest")
+	proto.RegisterType((*Return)(nil), "gen.Return")
+	proto.RegisterType((*DeadLetterCompensation)(nil), "gen.DeadLetterCompensation")
+	proto.RegisterType((*DeadLetterCompensations)(nil), "gen.DeadLetterCompensations")
+	proto.RegisterType((*Promotion)(nil), "gen.Promotion")
 }
 
 func init() { proto.RegisterFile("order.proto", fileDescriptor_cd01338c35d87077) }
 
 var fileDescriptor_cd01338c35d87077 = []byte{
-	// 674 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x4a,
-	0x10, 0x8e, 0xf3, 0x9f, 0x71, 0x9a, 0x1e, 0x6d, 0x8f, 0x4e, 0x7d, 0x2c, 0x21, 0xd2, 0x05, 0x44,
-	0x10, 0x34, 0x41, 0x05, 0x09, 0x21, 0xc4, 0x45, 0x69, 0x51, 0x15, 0x01, 0x6a, 0x95, 0x96, 0x1b,
-	0x6e, 0xac, 0xad, 0x77, 0x14, 0x4c, 0xe3, 0x75, 0xba, 0x1e, 0x23, 0x85, 0x67, 0xe0, 0x19, 0x78,
-	0x28, 0xde, 0x83, 0x77, 0x40, 0x5e, 0x3b, 0x89, 0x93, 0x06, 0xa9, 0x12, 0x77, 0xde, 0xf9, 0xbc,
-	0xb3, 0xdf, 0xcf, 0xce, 0x82, 0x1d, 0x69, 0x89, 0xba, 0x3f, 0xd5, 0x11, 0x45, 0xac, 0x32, 0x46,
-	0xe5, 0xda, 0x61, 0xa4, 0x70, 0x96, 0x55, 0x5c, 0x1b, 0xc3, 0x29, 0xe5, 0x0b, 0x7e, 0x0a, 0xec,
-	0x50, 0xca, 0x23, 0xa1, 0x69, 0x48, 0x18, 0x8e, 0xf0, 0x3a, 0xc1, 0x98, 0xd8, 0x1d, 0x80, 0xa9,
-	0x8e, 0x64, 0xe2, 0x93, 0x17, 0x48, 0xc7, 0xea, 0x5a, 0xbd, 0xd6, 0xa8, 0x95, 0x57, 0x86, 0x92,
-	0xb9, 0xd0, 0xbc, 0x4e, 0x84, 0xa2, 0x80, 0x66, 0x4e, 0xb9, 0x6b, 0xf5, 0x2a, 0xa3, 0xc5, 0x9a,
-	0xff, 0xb0, 0xa0, 0x39, 0x6f, 0xf7, 0x17, 0x7d, 0x18, 0x83, 0xaa, 0x12, 0x21, 0x3a, 0x15, 0xb3,
-	0xc9, 0x7c, 0xb3, 0x2e, 0xd4, 0xa6, 0x3a, 0xf0, 0xd1, 0xa9, 0x76, 0xad, 0x9e, 0x7d, 0x00, 0xfd,
-	0x31, 0xaa, 0xfe, 0x87, 0x54, 0xda, 0x28, 0x03, 0xd8, 0x1e, 0xb4, 0x85, 0x4f, 0x89, 0x98, 0x78,
-	0x31, 0x45, 0xfe, 0x95, 0x53, 0x33, 0x5d, 0xed, 0xac, 0x76, 0x9e, 0x96, 0xf8, 0x2b, 0xa8, 0xa6,
-	0xfc, 0x58, 0x07, 0xca, 0x0b, 0x4e, 0xe5, 0x40, 0xb2, 0x7b, 0x50, 0x0b, 0x08, 0xc3, 0xd8, 0x29,
-	0x77, 0x2b, 0x3d, 0xfb, 0x60, 0xcb, 0x34, 0x5f, 0x18, 0x93, 0x61, 0xfc, 0xbb, 0x05, 0xad, 0xd3,
-	0xd4, 0xdd, 0xdb, 0xc8, 0xdb, 0x24, 0xe1, 0x29, 0x74, 0x0c, 0x53, 0x6f, 0x8a, 0xda, 0x4b, 0x54,
-	0x40, 0x1b, 0xb4, 0xb4, 0xcd, 0x1f, 0x67, 0xa8, 0x3f, 0xaa, 0x80, 0x56, 0x4c, 0xaa, 0xad, 0x99,
-	0xfd, 0xcb, 0x82, 0x9a, 0xa1, 0xc3, 0x1e, 0xc2, 0x76, 0x20, 0x31, 0x9c, 0x46, 0x84, 0xca, 0x9f,
-	0x79, 0x57, 0x38, 0xcb, 0xf9, 0x74, 0x0a, 0xe5, 0x77, 0x38, 0xcb, 0x65, 0x97, 0x17, 0xb2, 0x77,
-	0xa1, 0x91, 0xc4, 0xa8, 0x53, 0x01, 0x19, 0xcf, 0x7a, 0xba, 0x1c, 0x4a, 0x76, 0x7f, 0xee, 0x47,
-	0xd5, 0xf8, 0xd1, 0x31, 0x04, 0x17, 0xda, 0x73, 0x43, 0xd8, 0x3e, 0xb4, 0x29, 0x22, 0x31, 0xf1,
-	0x44, 0x18, 0x25, 0x8a, 0x0c, 0xc3, 0x55, 0x35, 0xb6, 0xc1, 0x0f, 0x0d, 0xcc, 0xfe, 0x83, 0x7a,
-	0x4c, 0x82, 0x92, 0xd8, 0xa9, 0x67, 0x87, 0x65, 0x2b, 0xf6, 0x00, 0x3a, 0xa4, 0x85, 0x8a, 0x85,
-	0x4f, 0x41, 0xa4, 0x52, 0x32, 0x0d, 0x83, 0x6f, 0x15, 0xaa, 0x43, 0xc9, 0x5f, 0x03, 0x3b, 0xd2,
-	0x28, 0x08, 0x0d, 0x8f, 0xf9, 0x6d, 0xbd, 0xad, 0x76, 0xfe, 0x05, 0xdc, 0x23, 0x31, 0x99, 0x5c,
-	0x0a, 0xff, 0xea, 0x62, 0xd9, 0x77, 0xde, 0xe6, 0x26, 0x07, 0x6b, 0x03, 0x87, 0xf4, 0xb7, 0xa9,
-	0x98, 0x85, 0xa8, 0xc8, 0xcb, 0xa5, 0x64, 0x66, 0x6e, 0xe5, 0xd5, 0x73, 0x53, 0xe4, 0x7b, 0xb0,
-	0x7d, 0x82, 0xb4, 0xc2, 0x73, 0xed, 0xc6, 0xf1, 0x27, 0x50, 0x37, 0x78, 0xcc, 0x38, 0xd4, 0xcd,
-	0xcc, 0xc6, 0x8e, 0x65, 0xcc, 0x86, 0xa5, 0xd9, 0xa3, 0x1c, 0xe1, 0x63, 0xd8, 0x99, 0x37, 0x7c,
-	0x1f, 0xc4, 0x54, 0x18, 0xd5, 0x98, 0x84, 0x26, 0x4f, 0x0a, 0xc2, 0xf9, 0x1d, 0x34, 0x95, 0x63,
-	0x41, 0xc8, 0xfe, 0x87, 0x26, 0x2a, 0x99, 0x81, 0x19, 0xcf, 0x06, 0x2a, 0x69, 0xa0, 0x65, 0x16,
-	0x95, 0x62, 0x16, 0x07, 0x3f, 0xcb, 0xd0, 0x36, 0xc7, 0x9c, 0xa3, 0xfe, 0x9a, 0x0e, 0xd5, 0x4b,
-	0xf8, 0xe7, 0x50, 0xca, 0xb3, 0xec, 0x5e, 0x5f, 0x44, 0x66, 0x7a, 0x76, 0x0d, 0xc3, 0x9b, 0x4f,
-	0x87, 0x9b, 0x51, 0x7f, 0x9b, 0x3e, 0x31, 0xbc, 0xc4, 0x38, 0x34, 0x4e, 0x90, 0xcc, 0x8e, 0x02,
-	0xe0, 0xb6, 0x16, 0xc3, 0xc5, 0x4b, 0xec, 0x39, 0xd8, 0x85, 0x50, 0xf3, 0xce, 0x37, 0x63, 0x76,
-	0x0b, 0xa6, 0xf0, 0x12, 0x3b, 0x86, 0x9d, 0x0d, 0x59, 0xb2, 0xbb, 0x79, 0xe7, 0x3f, 0xa5, 0xbc,
-	0xc6, 0xaf, 0x0f, 0xcd, 0xb9, 0xa9, 0xec, 0x5f, 0x83, 0xac, 0x85, 0xb6, 0x76, 0xea, 0x0b, 0x68,
-	0x17, 0x43, 0x60, 0xce, 0xca, 0x9e, 0x42, 0x2e, 0xae, 0xbd, 0xdc, 0x17, 0xf3, 0xd2, 0x9b, 0xc7,
-	0x9f, 0x1e, 0x8d, 0x03, 0xfa, 0x9c, 0x5c, 0xf6, 0xfd, 0x28, 0x1c, 0xe0, 0x44, 0xa8, 0xb1, 0xc6,
-	0x6f, 0x62, 0x80, 0xfb, 0x7e, 0x14, 0x86, 0xa8, 0x7d, 0x1c, 0x98, 0x07, 0x79, 0x30, 0x46, 0x75,
-	0x59, 0x37, 0x9f, 0xcf, 0x7e, 0x07, 0x00, 0x00, 0xff, 0xff, 0x29, 0x8f, 0x54, 0x45, 0xc9, 0x05,
-	0x00, 0x00,
+	// 2448 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xdd, 0x6e, 0x1c, 0x49,
+	0xf5, 0xdf, 0x99, 0xb1, 0xc7, 0xdd, 0x67, 0x3e, 0x9c, 0x54, 0xbc, 0xc9, 0x64, 0x92, 0xfc, 0xe3,
+	0x74, 0xfe, 0x21, 0x8e, 0x96, 0xd8, 0xbb, 0x49, 0x00, 0x2d, 0x5a, 0x01, 0x83, 0xb3, 0x04, 0x8b,
+	0x64, 0x37, 0xdb, 0x4e, 0x40, 0x42, 0x48, 0x43, 0xb9, 0xfb, 0x64, 0xdc, 0xf2, 0x4c, 0x77, 0x6f,
+	0x55, 0xb5, 0x63, 0x73, 0xc7, 0x0b, 0x20, 0x1e, 0x00, 0x89, 0x1b, 0xee, 0x91, 0xe0, 0x92, 0x6b,
+	0xde, 0x82, 0x37, 0x40, 0x20, 0xf1, 0x02, 0x08, 0xd5, 0x57, 0x4f, 0x7f, 0xcc, 0xd8, 0x0e, 0xe1,
+	0xae, 0xeb, 0x77, 0x4e, 0x55, 0x9d, 0xef, 0x3a, 0x55, 0x0d, 0x9d, 0x84, 0x85, 0xc8, 0xb6, 0x53,
+	0x96, 0x88, 0x84, 0xb4, 0x26, 0x18, 0x0f, 0x3b, 0xb3, 0x24, 0xc6, 0x53, 0x8d, 0x0c, 0x3b, 0x38,
+	0x4b, 0x85, 0x19, 0x78, 0x5f, 0x02, 0x19, 0x85, 0xe1, 0x2e, 0x65, 0x62, 0x4f, 0xe0, 0xcc, 0xc7,
+	0xaf, 0x33, 0xe4, 0x82, 0xdc, 0x02, 0x48, 0x59, 0x12, 0x66, 0x81, 0x18, 0x47, 0xe1, 0xa0, 0xb1,
+	0xd9, 0xd8, 0x72, 0x7d, 0xd7, 0x20, 0x7b, 0x21, 0x19, 0x82, 0xf3, 0x75, 0x46, 0x63, 0x11, 0x89,
+	0xd3, 0x41, 0x73, 0xb3, 0xb1, 0xd5, 0xf2, 0xf3, 0xb1, 0xf7, 0x6d, 0xf8, 0xd0, 0xc7, 0x59, 0x72,
+	0x8c, 0xef, 0xb6, 0xa6, 0xf7, 0x33, 0x18, 0xee, 0xa3, 0xb0, 0x93, 0xbe, 0x32, 0xcb, 0xfd, 0x0f,
+	0x04, 0xfa, 0x04, 0x2e, 0xbd, 0x40, 0x36, 0x51, 0xf2, 0x14, 0x96, 0x0b, 0x28, 0x13, 0x63, 0x91,
+	0x1c, 0x61, 0x6c, 0x97, 0x93, 0xc8, 0x2b, 0x09, 0x78, 0x5b, 0x40, 0x46, 0x69, 0x3a, 0x3d, 0xdd,
+	0x4d, 0xb2, 0x34, 0x89, 0xed, 0x24, 0x02, 0x2b, 0x41, 0x12, 0xa2, 0x61, 0x57, 0xdf, 0xde, 0x1f,
+	0x5a, 0xe0, 0x58, 0x99, 0xdf, 0x43, 0x48, 0xb9, 0x76, 0x4c, 0x67, 0x38, 0x68, 0xe9, 0xb5, 0xe5,
+	0x37, 0xd9, 0x84, 0xd5, 0x94, 0x45, 0x01, 0x0e, 0x56, 0x36, 0x1b, 0x5b, 0x9d, 0x47, 0xb0, 0x3d,
+	0xc1, 0x78, 0xfb, 0x85, 0x74, 0xa4, 0xaf, 0x09, 0xe4, 0x0e, 0x74, 0x69, 0x20, 0x32, 0x3a, 0x1d,
+	0x73, 0x91, 0x04, 0x47, 0x83, 0x55, 0xb5, 0x6a, 0x47, 0x63, 0xfb, 0x12, 0x22, 0x3b, 0xd0, 0x0b,
+	0x32, 0xc6, 0x30, 0x16, 0x63, 0xbd, 0x58, 0xbb, 0xb6, 0x58, 0xd7, 0x30, 0xbc, 0x54, 0x6b, 0xde,
+	0x85, 0x9e, 0x62, 0x1c, 0x07, 0x87, 0x34, 0x9e, 0x60, 0x38, 0x58, 0xdb, 0x6c, 0x6c, 0x39, 0x7e,
+	0x57, 0x81, 0xbb, 0x1a, 0x23, 0x0f, 0x81, 0x44, 0x31, 0xcf, 0xde, 0xbc, 0x89, 0x82, 0x48, 0x2e,
+	0xad, 0xb7, 0x77, 0x14, 0xe7, 0xe5, 0x22, 0x45, 0x0b, 0xb1, 0x09, 0x9d, 0x2c, 0xa6, 0xc7, 0x34,
+	0x9a, 0xd2, 0x83, 0x29, 0x0e, 0x5c, 0xc5, 0x57, 0x84, 0xc8, 0xb7, 0xe0, 0x12, 0x47, 0x21, 0xa6,
+	0x38, 0x93, 0xcb, 0x89, 0x44, 0xd0, 0xe9, 0x00, 0x6a, 0x92, 0xae, 0xcf, 0x79, 0x5e, 0x49, 0x16,
+	0xf2, 0x0d, 0x70, 0xc2, 0x88, 0x07, 0x49, 0x16, 0x8b, 0x41, 0xa7, 0xc6, 0x9e, 0xd3, 0xbc, 0x7f,
+	0x35, 0x60, 0x45, 0xba, 0x89, 0xf4, 0xa1, 0x99, 0xbb, 0xa6, 0x19, 0x85, 0xe4, 0x2e, 0xac, 0x46,
+	0x02, 0x67, 0x7c, 0xd0, 0xdc, 0x6c, 0x6d, 0x75, 0x1e, 0xf5, 0xd4, 0xec, 0x3c, 0x72, 0x35, 0x4d,
+	0xee, 0xc2, 0xb3, 0x03, 0x2d, 0x54, 0xab, 0xbe, 0x8b, 0xa5, 0x91, 0xdb, 0xd0, 0x09, 0x54, 0xc4,
+	0x8c, 0x55, 0x9c, 0xac, 0xa8, 0x5d, 0x40, 0x43, 0xbb, 0x49, 0x88, 0x25, 0x71, 0x57, 0x97, 0x8b,
+	0x2b, 0x3d, 0xaf, 0x77, 0xab, 0x3b, 0x4b, 0x13, 0xa4, 0xe7, 0xcd, 0x56, 0x11, 0xe7, 0x19, 0x2a,
+	0x27, 0xb9, 0xbe, 0xd9, 0x7e, 0x4f, 0x42, 0xde, 0x6f, 0x9a, 0xe0, 0x2a, 0x4d, 0xe4, 0xe8, 0xbc,
+	0xd8, 0xbc, 0x0a, 0x6d, 0x86, 0x94, 0x27, 0xb1, 0x8a, 0x4c, 0xd7, 0x37, 0x23, 0x72, 0x1f, 0xdc,
+	0x64, 0x1a, 0x9a, 0xd0, 0x59, 0xa0, 0x7b, 0x32, 0x0d, 0x75, 0xd8, 0xdc, 0x07, 0x37, 0xc6, 0xb7,
+	0xe3, 0x65, 0x01, 0xeb, 0xc4, 0xf8, 0x56, 0x33, 0x16, 0xb3, 0x60, 0xb5, 0x92, 0x05, 0xd5, 0x78,
+	0x6e, 0xd7, 0xe3, 0xb9, 0x62, 0xe3, 0xb5, 0x9a, 0x8d, 0x07, 0xb0, 0x36, 0x43, 0xce, 0xe9, 0x04,
+	0x55, 0x3c, 0xba, 0xbe, 0x1d, 0x7a, 0x4f, 0x00, 0x72, 0x7b, 0x48, 0xa7, 0xb6, 0x95, 0xe9, 0xf8,
+	0xa0, 0xa1, 0x5c, 0xdf, 0x9f, 0xbb, 0x5e, 0xc2, 0xbe, 0xa1, 0x7a, 0xff, 0x6c, 0x82, 0xfb, 0xa5,
+	0xac, 0xa7, 0x17, 0x49, 0xf1, 0x45, 0x69, 0xfc, 0x31, 0xf4, 0x75, 0x42, 0xa5, 0xc8, 0xc6, 0x59,
+	0x1c, 0x89, 0x05, 0xe6, 0xd1, 0xd9, 0xf5, 0x12, 0xd9, 0xeb, 0x38, 0x12, 0x67, 0x9a, 0x68, 0x51,
+	0xa2, 0xb4, 0xcf, 0x4f, 0x94, 0xbb, 0xd0, 0xc3, 0x13, 0x9d, 0xd1, 0x63, 0x46, 0x85, 0x35, 0x5c,
+	0xd7, 0x82, 0x3e, 0x15, 0xe5, 0xf0, 0x74, 0xce, 0x08, 0xcf, 0x9b, 0xd0, 0x12, 0xf4, 0x64, 0xe0,
+	0xd6, 0x58, 0x24, 0x4c, 0xae, 0x83, 0x23, 0xe8, 0x89, 0xde, 0x05, 0xb4, 0x07, 0x04, 0x3d, 0x51,
+	0x1b, 0xdc, 0x85, 0x9e, 0x24, 0x45, 0x71, 0x30, 0xcd, 0x78, 0x74, 0x8c, 0x2a, 0x67, 0x1d, 0xbf,
+	0x2b, 0xe8, 0xc9, 0x9e, 0xc5, 0xbc, 0xbf, 0xb4, 0x61, 0x55, 0x19, 0x9c, 0xdc, 0x87, 0xf5, 0x28,
+	0xc4, 0x59, 0x9a, 0x08, 0x8c, 0x83, 0xd3, 0xf1, 0x11, 0x9e, 0x1a, 0x8b, 0xf7, 0x0b, 0xf0, 0x4f,
+	0xf0, 0xd4, 0x64, 0x75, 0x33, 0xcf, 0xea, 0x6b, 0xb0, 0x96, 0x71, 0x64, 0xd2, 0x45, 0xda, 0x13,
+	0x6d, 0x39, 0xdc, 0x0b, 0xc9, 0xff, 0xdb, 0x74, 0x5f, 0x29, 0xf8, 0x3c, 0xf7, 0xae, 0xcd, 0xf7,
+	0x87, 0xd0, 0x55, 0x86, 0x1d, 0xd3, 0xd9, 0x92, 0x54, 0xed, 0x28, 0xfa, 0x48, 0x91, 0x65, 0xee,
+	0x70, 0x41, 0x45, 0xc6, 0x95, 0x23, 0x5c, 0xdf, 0x8c, 0xc8, 0x3d, 0xe8, 0x0b, 0x46, 0x63, 0x4e,
+	0x03, 0x11, 0xc9, 0x44, 0x0d, 0x8d, 0xd1, 0x7b, 0x05, 0x74, 0x4f, 0x96, 0xa0, 0x5e, 0x40, 0xe3,
+	0x00, 0xa7, 0x63, 0x93, 0x81, 0x3a, 0x6c, 0xbb, 0x1a, 0xf4, 0x15, 0x46, 0x1e, 0xc3, 0xba, 0x2d,
+	0x33, 0x56, 0xaa, 0xba, 0xf9, 0xfb, 0x96, 0xc5, 0x08, 0xf6, 0x18, 0xd6, 0xad, 0xcf, 0xec, 0xa4,
+	0x7a, 0x4d, 0xed, 0x5b, 0x16, 0x33, 0xa9, 0x92, 0x60, 0x9d, 0x5a, 0x82, 0x3d, 0x00, 0x90, 0x4e,
+	0x34, 0x0b, 0x76, 0x6b, 0x0b, 0xba, 0x82, 0x9e, 0xcc, 0x05, 0xe0, 0x87, 0x51, 0x9a, 0x46, 0xf1,
+	0xc4, 0xf2, 0xf7, 0x16, 0x48, 0x6d, 0x58, 0xcc, 0xa4, 0xfb, 0x85, 0x49, 0x0c, 0x27, 0x51, 0x12,
+	0x0f, 0xfa, 0xda, 0xeb, 0x16, 0xf6, 0x15, 0x4a, 0x1e, 0x80, 0x23, 0x11, 0x19, 0xe4, 0x83, 0xf5,
+	0xcd, 0x46, 0x5e, 0xbe, 0xf7, 0x0d, 0xe8, 0xe7, 0x64, 0xf2, 0x3d, 0xe8, 0x6b, 0xa7, 0x8c, 0x0f,
+	0x23, 0x2e, 0x12, 0x76, 0x3a, 0xb8, 0xa4, 0x02, 0xe0, 0xda, 0x3c, 0x00, 0xf6, 0x15, 0xfd, 0xc7,
+	0x9a, 0xec, 0xf7, 0x78, 0x71, 0x48, 0xee, 0xc1, 0x1a, 0xc3, 0x37, 0x59, 0x1c, 0xf2, 0xc1, 0x65,
+	0x35, 0xb1, 0xa3, 0x26, 0xfa, 0x0a, 0xf3, 0x2d, 0x4d, 0xb3, 0x89, 0x8c, 0xc5, 0x7c, 0x40, 0x4a,
+	0x6c, 0x12, 0xf3, 0x2d, 0x8d, 0x3c, 0x04, 0x27, 0xa5, 0xa7, 0x52, 0x30, 0x3e, 0xb8, 0xa2, 0xf8,
+	0x2e, 0xcf, 0xe5, 0x78, 0xa9, 0x29, 0x7e, 0xce, 0x42, 0x3e, 0x82, 0x4e, 0x4a, 0xa3, 0xd0, 0x5a,
+	0x70, 0xa3, 0x66, 0x41, 0x90, 0x64, 0x6d, 0x3d, 0xef, 0xdf, 0x0d, 0xe8, 0x16, 0xd7, 0x59, 0x10,
+	0x85, 0x8d, 0x45, 0x51, 0x78, 0x15, 0xda, 0x33, 0x14, 0x87, 0x89, 0x4d, 0x23, 0x33, 0x2a, 0x04,
+	0x77, 0xab, 0x14, 0xdc, 0x1e, 0xb4, 0x8d, 0x3c, 0xf5, 0x6a, 0xd6, 0xa6, 0xb9, 0xfb, 0xb5, 0x65,
+	0x30, 0x5c, 0x9e, 0x4a, 0x7d, 0xcb, 0x32, 0x8f, 0x3f, 0xa3, 0xf9, 0x38, 0x63, 0x53, 0x93, 0x52,
+	0x60, 0xa0, 0xd7, 0x6c, 0xaa, 0x7a, 0x37, 0x86, 0x54, 0xc8, 0x45, 0x85, 0x49, 0x29, 0xd7, 0x20,
+	0x23, 0xe1, 0x25, 0x70, 0x75, 0x14, 0x86, 0x25, 0x53, 0x9a, 0xfe, 0xed, 0x3a, 0x38, 0xaa, 0x31,
+	0x9e, 0xdb, 0x60, 0x4d, 0x8d, 0xcf, 0xd0, 0x7e, 0xae, 0x65, 0x6b, 0x99, 0x96, 0xde, 0xef, 0x1b,
+	0x40, 0xea, 0x11, 0x24, 0xf5, 0x78, 0xc3, 0x92, 0xd9, 0xd8, 0x58, 0x4f, 0x6f, 0x08, 0x12, 0xd2,
+	0x7c, 0xe4, 0x06, 0xb8, 0x22, 0xb1, 0x64, 0xbd, 0xad, 0x23, 0x12, 0x43, 0x9c, 0x9f, 0xc7, 0xad,
+	0xd2, 0x79, 0xbc, 0x01, 0xab, 0x34, 0x10, 0x09, 0x33, 0xcd, 0x85, 0x1e, 0x54, 0x4c, 0xb2, 0x5a,
+	0x35, 0xc9, 0x9f, 0x1b, 0xb0, 0xbe, 0x6f, 0x93, 0x2c, 0x0c, 0x19, 0x72, 0x55, 0x9c, 0x18, 0x06,
+	0x51, 0xaa, 0xda, 0x37, 0x75, 0x66, 0x99, 0xb0, 0xc8, 0xd1, 0x2f, 0xe4, 0xe1, 0xb5, 0x01, 0xab,
+	0xe9, 0x61, 0x12, 0xa3, 0x11, 0x50, 0x0f, 0x74, 0x50, 0x30, 0x44, 0x31, 0x0f, 0x0a, 0x39, 0x52,
+	0x1d, 0xb2, 0x3c, 0xb4, 0x56, 0x4c, 0x87, 0x2c, 0x0f, 0x2c, 0xa5, 0x89, 0xca, 0xe2, 0x55, 0xab,
+	0x89, 0x1c, 0x29, 0x3f, 0x27, 0x5c, 0xd6, 0x33, 0x55, 0x67, 0xac, 0x9f, 0x15, 0x24, 0xeb, 0x8c,
+	0xf7, 0xb7, 0x06, 0x38, 0x36, 0x95, 0xc9, 0x36, 0xac, 0x51, 0x2d, 0xb9, 0x92, 0xb3, 0xf3, 0x68,
+	0x23, 0x4f, 0xf5, 0x82, 0x56, 0xbe, 0x65, 0x92, 0x5d, 0x40, 0x40, 0x19, 0x8b, 0x90, 0x19, 0xc9,
+	0xed, 0x50, 0x96, 0x17, 0xc1, 0x68, 0x70, 0x24, 0xcb, 0x4b, 0x9c, 0xcd, 0x0e, 0x90, 0x19, 0x25,
+	0xfa, 0x16, 0xfe, 0x42, 0xa1, 0xd2, 0x3f, 0x29, 0x0d, 0x8e, 0xb4, 0x4d, 0xb5, 0x46, 0x8e, 0x06,
+	0x46, 0xea, 0x02, 0xa1, 0xaa, 0x51, 0xc9, 0xe2, 0x06, 0x19, 0x09, 0xd9, 0xc8, 0x84, 0x38, 0x8d,
+	0x8e, 0x91, 0x69, 0x06, 0xad, 0x5d, 0x27, 0xc7, 0x46, 0xc2, 0xfb, 0x75, 0x13, 0xc8, 0xae, 0x72,
+	0x91, 0x0a, 0x1e, 0x1b, 0xa4, 0x17, 0x3e, 0xf3, 0x16, 0x94, 0xc9, 0xe6, 0xc2, 0x32, 0xf9, 0x7d,
+	0xb8, 0x94, 0x33, 0x5a, 0x1b, 0xb6, 0xce, 0xb0, 0xe1, 0x3a, 0x2f, 0x03, 0x32, 0x54, 0x6c, 0x46,
+	0x9a, 0x24, 0xd1, 0xd6, 0xe8, 0x19, 0xf4, 0x85, 0x02, 0xc9, 0x27, 0x73, 0xb6, 0xa5, 0xc9, 0x6e,
+	0xa7, 0x98, 0x62, 0xf5, 0xa7, 0x06, 0x0c, 0x77, 0xe9, 0x74, 0x7a, 0x40, 0x83, 0xa3, 0x57, 0xf3,
+	0x72, 0x64, 0x6d, 0x71, 0xc1, 0xd2, 0x55, 0x90, 0xaf, 0x94, 0x4d, 0x76, 0x33, 0x93, 0x52, 0xc5,
+	0xf4, 0x6f, 0x95, 0xd3, 0xbf, 0x52, 0x61, 0x57, 0xce, 0xac, 0xb0, 0x77, 0x60, 0xfd, 0x19, 0x8a,
+	0x92, 0xd3, 0x2a, 0xb7, 0x0a, 0xef, 0x9b, 0xd0, 0x56, 0x74, 0x55, 0x26, 0xd5, 0x26, 0xb6, 0xcb,
+	0x84, 0x79, 0xa1, 0xf7, 0x0d, 0xc5, 0x9b, 0xc0, 0x15, 0xbb, 0xe0, 0xf3, 0x88, 0x17, 0xef, 0xa8,
+	0x5c, 0xc8, 0x4b, 0x6a, 0x28, 0x3b, 0x29, 0xd3, 0x6a, 0x2a, 0xe4, 0xa9, 0xec, 0xa5, 0xae, 0x83,
+	0x83, 0x71, 0xa8, 0x89, 0x26, 0xc4, 0x31, 0x0e, 0x15, 0x69, 0x49, 0xcd, 0xf6, 0x3e, 0x03, 0xb2,
+	0xab, 0x9a, 0x8a, 0xb3, 0x84, 0x5f, 0x76, 0x15, 0xf0, 0x18, 0x0c, 0xf5, 0x6c, 0x1f, 0x39, 0xb2,
+	0x63, 0x0c, 0x4b, 0xab, 0x9c, 0x51, 0x5c, 0xef, 0x40, 0xf7, 0x2d, 0x65, 0x78, 0x98, 0x64, 0x1c,
+	0xc7, 0xa6, 0x4f, 0x6b, 0xf9, 0x9d, 0x1c, 0x2b, 0x5d, 0x3f, 0x4a, 0xe5, 0xce, 0xfb, 0x6d, 0x03,
+	0x06, 0xaf, 0x53, 0xa9, 0xe3, 0x8f, 0xb2, 0xe9, 0x9b, 0x68, 0x3a, 0xbd, 0x78, 0x3d, 0x2f, 0x85,
+	0x82, 0x19, 0x15, 0xcb, 0x42, 0xeb, 0xdc, 0xb2, 0xb0, 0xb2, 0xa8, 0x2c, 0x78, 0x47, 0x00, 0xfa,
+	0xd8, 0x7f, 0xdf, 0x2b, 0xff, 0x45, 0xce, 0x96, 0x18, 0x88, 0xde, 0xec, 0xa2, 0xb6, 0xbe, 0x57,
+	0xbe, 0xcf, 0xae, 0x17, 0xda, 0x94, 0x62, 0x87, 0xbb, 0xcc, 0xde, 0xff, 0x68, 0x40, 0x5b, 0x73,
+	0x2f, 0x0a, 0x8b, 0x85, 0x26, 0xbd, 0x80, 0x1a, 0x85, 0xed, 0x56, 0x4a, 0xa7, 0x59, 0x2e, 0xed,
+	0xea, 0x99, 0xd2, 0xde, 0x04, 0x97, 0xa1, 0xba, 0x11, 0x62, 0xa8, 0x4a, 0xa9, 0xe3, 0xcf, 0x81,
+	0x73, 0xfa, 0x01, 0x59, 0xc6, 0x75, 0xdf, 0x25, 0xad, 0xa5, 0x5b, 0x6b, 0x47, 0x03, 0x7b, 0xa1,
+	0xf7, 0x4c, 0x3a, 0x53, 0x7d, 0xbf, 0x9f, 0x33, 0xbd, 0x04, 0xae, 0xe8, 0x62, 0xae, 0x97, 0xfb,
+	0xef, 0x3d, 0x65, 0x85, 0x39, 0xcf, 0x53, 0xbf, 0x84, 0x2b, 0x3e, 0x1e, 0x47, 0xf8, 0xb6, 0xbc,
+	0x61, 0x49, 0xdb, 0x46, 0x59, 0x5b, 0x19, 0xfd, 0x34, 0x4d, 0x59, 0x72, 0xac, 0x2b, 0x86, 0xe3,
+	0xdb, 0xa1, 0xba, 0xb7, 0x26, 0x62, 0x7e, 0x6f, 0x4d, 0x04, 0x7a, 0x3f, 0x85, 0x0d, 0x1f, 0x03,
+	0x8c, 0x8e, 0xf1, 0x1d, 0xb6, 0x38, 0x3f, 0xd7, 0xbd, 0xdf, 0x35, 0x65, 0x8c, 0x49, 0xfe, 0x5a,
+	0x8c, 0x15, 0xcd, 0xd5, 0x5c, 0x96, 0xd1, 0xe5, 0x3e, 0xf4, 0x1d, 0x43, 0xab, 0x6a, 0x5e, 0xab,
+	0x78, 0x7b, 0xae, 0x78, 0x4d, 0x87, 0xb5, 0x7a, 0xbd, 0x52, 0x36, 0x90, 0x61, 0x5a, 0x0a, 0x2a,
+	0x15, 0xb7, 0xd5, 0x80, 0x74, 0xab, 0x01, 0x79, 0x0b, 0x20, 0x4b, 0x43, 0x4b, 0xd6, 0x37, 0x64,
+	0xd7, 0x20, 0x23, 0xe1, 0xfd, 0xb1, 0x01, 0x57, 0x9f, 0x22, 0x0d, 0x9f, 0xa3, 0x10, 0xc8, 0x76,
+	0x93, 0x59, 0x8a, 0x31, 0xa7, 0xf2, 0xa8, 0x7b, 0x17, 0x73, 0x11, 0x58, 0xe1, 0x02, 0x53, 0xeb,
+	0x50, 0xf9, 0xad, 0xdc, 0x2f, 0x84, 0x7c, 0xfc, 0x55, 0xb6, 0x6a, 0xf9, 0x76, 0x28, 0x45, 0x9a,
+	0x52, 0x2e, 0xc6, 0xc8, 0x58, 0xc2, 0x6c, 0x37, 0x23, 0x91, 0xcf, 0x25, 0x50, 0x51, 0xa8, 0x5d,
+	0x6d, 0x2f, 0x7f, 0x01, 0xd7, 0x16, 0x0b, 0xcc, 0xc9, 0x08, 0x7a, 0x41, 0x11, 0x30, 0xa7, 0xe0,
+	0x0d, 0xe5, 0x8d, 0xc5, 0x93, 0xfc, 0xf2, 0x0c, 0xef, 0xaf, 0x2d, 0x70, 0x5f, 0xb2, 0x64, 0x96,
+	0x2c, 0x34, 0x81, 0x7d, 0x93, 0x6d, 0xce, 0xdf, 0x64, 0xe5, 0x6b, 0x63, 0x88, 0x3c, 0x60, 0x51,
+	0x2a, 0xa7, 0x18, 0x13, 0x14, 0x21, 0x39, 0x4b, 0x9c, 0xa6, 0xf6, 0x85, 0x4e, 0x7d, 0xcb, 0x4e,
+	0x97, 0x07, 0x49, 0x8a, 0x46, 0x7d, 0x3d, 0x90, 0x26, 0x56, 0x1f, 0xd2, 0xc4, 0x5a, 0xf1, 0x35,
+	0x35, 0xde, 0x0b, 0xc9, 0xff, 0x01, 0xa4, 0xc8, 0x02, 0x8c, 0x85, 0x7c, 0x6b, 0x32, 0x0f, 0x51,
+	0x73, 0xa4, 0x50, 0x18, 0x9d, 0xa5, 0x85, 0xf1, 0x0e, 0x74, 0x0f, 0xb2, 0xd3, 0x71, 0x5e, 0x56,
+	0x5c, 0x1d, 0x6a, 0x07, 0xd9, 0xe9, 0x57, 0x85, 0x37, 0xb1, 0x09, 0x8a, 0x39, 0x0b, 0x68, 0x96,
+	0x09, 0x8a, 0x9c, 0xe5, 0x36, 0x74, 0x32, 0xf9, 0xc2, 0x35, 0x9e, 0x46, 0xb3, 0x48, 0x3f, 0x84,
+	0xb6, 0x7c, 0x50, 0xd0, 0x73, 0x89, 0x90, 0x1d, 0xd8, 0x28, 0x30, 0xe8, 0x87, 0x28, 0x8e, 0x4c,
+	0x5d, 0xde, 0x5b, 0xfe, 0xe5, 0x39, 0xa7, 0x7c, 0x81, 0xe2, 0xba, 0xf7, 0x55, 0x9d, 0x06, 0x1f,
+	0x53, 0x7d, 0x65, 0x77, 0x7d, 0x47, 0x03, 0x23, 0x21, 0x5f, 0x57, 0x30, 0x0e, 0x15, 0x49, 0x5f,
+	0xcc, 0xdb, 0x72, 0xa8, 0x4b, 0x6d, 0xc4, 0xc7, 0xb2, 0x2f, 0x3b, 0x46, 0x75, 0x23, 0x77, 0x7c,
+	0x27, 0xe2, 0x23, 0x35, 0x7e, 0xf4, 0x77, 0xd7, 0x5c, 0x4c, 0xf7, 0x91, 0x1d, 0xcb, 0x87, 0xc0,
+	0x4f, 0xe1, 0xd2, 0x28, 0x0c, 0x5f, 0xea, 0xf2, 0xfa, 0x2a, 0x51, 0xcf, 0xb3, 0xfa, 0x3e, 0x5e,
+	0xff, 0x21, 0x31, 0xd4, 0xc6, 0xfb, 0x5c, 0xfe, 0xb8, 0xf0, 0x3e, 0x20, 0x1e, 0xac, 0x3d, 0xd3,
+	0xff, 0x0a, 0x48, 0x81, 0x30, 0x74, 0xf3, 0x27, 0x3c, 0xef, 0x03, 0xf2, 0x5d, 0xe8, 0x97, 0xff,
+	0x43, 0x90, 0xa1, 0xa9, 0x01, 0x0b, 0x7e, 0x4e, 0x54, 0xd6, 0x7f, 0x0a, 0x57, 0x16, 0xfc, 0x8b,
+	0x20, 0xb7, 0x75, 0xbf, 0xbc, 0xf4, 0x2f, 0x45, 0x65, 0x95, 0x7b, 0xe0, 0xee, 0x4e, 0x91, 0xb2,
+	0x9a, 0x9c, 0x65, 0xb6, 0x8f, 0xc1, 0xcd, 0xff, 0x4f, 0x90, 0x0f, 0x75, 0x90, 0x54, 0xfe, 0x57,
+	0x54, 0x66, 0x3c, 0x86, 0x4e, 0xe1, 0xf7, 0x84, 0x35, 0x5a, 0xed, 0x87, 0x45, 0xd9, 0x1e, 0x5b,
+	0xd0, 0x35, 0xaa, 0xeb, 0x59, 0xcb, 0x05, 0x7a, 0x02, 0x9d, 0xc2, 0xc5, 0xc4, 0x2c, 0x5f, 0xbf,
+	0xaa, 0x0c, 0x0b, 0xbd, 0xac, 0xb6, 0xd9, 0x82, 0x56, 0xde, 0xd8, 0x6c, 0x79, 0x93, 0x5f, 0xd9,
+	0x7b, 0x1b, 0x1c, 0xdb, 0x0b, 0x13, 0x7d, 0x3d, 0xa9, 0xf4, 0xda, 0x95, 0x5d, 0xbf, 0x03, 0xdd,
+	0x62, 0xef, 0x4c, 0x06, 0xa5, 0x39, 0x85, 0x76, 0x7a, 0xd8, 0x99, 0xcf, 0xe3, 0x46, 0xc9, 0x79,
+	0x2f, 0x6c, 0x95, 0xac, 0x75, 0xc7, 0x8b, 0x94, 0xac, 0xf5, 0xc0, 0xb9, 0x92, 0xcb, 0xba, 0xe3,
+	0xca, 0x2a, 0x9f, 0xc1, 0x7a, 0xe5, 0x89, 0x82, 0xdc, 0xb0, 0x81, 0xbf, 0xe0, 0xe1, 0xa2, 0x32,
+	0x7b, 0x0f, 0x6e, 0x48, 0xbd, 0x96, 0x95, 0xdc, 0xa2, 0x5f, 0x6f, 0x9e, 0x51, 0x67, 0xb9, 0x0a,
+	0xa4, 0x75, 0xed, 0xd7, 0x42, 0x81, 0x55, 0x53, 0xf2, 0xf1, 0xb0, 0x32, 0xf6, 0x3e, 0x20, 0x3f,
+	0x80, 0xcb, 0xb5, 0x96, 0x9c, 0xdc, 0x52, 0x6c, 0xcb, 0x5a, 0xf5, 0x8a, 0x06, 0x4f, 0xa0, 0x53,
+	0xe8, 0x6a, 0x8d, 0xed, 0xeb, 0x7d, 0x6e, 0xdd, 0xd5, 0xc5, 0x16, 0xcb, 0xb8, 0x7a, 0x41, 0xd7,
+	0x35, 0x2c, 0xbe, 0xba, 0xe9, 0x89, 0xc5, 0x56, 0xc9, 0x4c, 0x5c, 0xd0, 0x3d, 0x55, 0x27, 0x7e,
+	0x0a, 0xbd, 0x52, 0x07, 0x44, 0xae, 0x1b, 0x7a, 0xbd, 0x2b, 0xaa, 0x4c, 0xfd, 0xe1, 0x47, 0x3f,
+	0x7f, 0x30, 0x89, 0xc4, 0x61, 0x76, 0xb0, 0x1d, 0x24, 0xb3, 0x1d, 0x9c, 0xd2, 0x78, 0xc2, 0xf0,
+	0x57, 0x74, 0x07, 0x1f, 0x06, 0xc9, 0x6c, 0x26, 0x0f, 0x89, 0x1d, 0xf5, 0xff, 0x75, 0x67, 0x82,
+	0xf1, 0x41, 0x5b, 0x7d, 0x3e, 0xfe, 0xcf, 0x00, 0xd7, 0x20, 0x3f, 0x89, 0xb8, 0x1d, 0x00, 0x00,
 }
@@ -19,12 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_AddProductToCart_FullMethodName    = "/gen.OrderService/AddProductToCart"
-	OrderService_GetCart_FullMethodName             = "/gen.OrderService/GetCart"
-	OrderService_CreateOrder_FullMethodName         = "/gen.OrderService/CreateOrder"
-	OrderService_CallbackTransaction_FullMethodName = "/gen.OrderService/CallbackTransaction"
-	OrderService_GetOrder_FullMethodName            = "/gen.OrderService/GetOrder"
-	OrderService_GetOrderList_FullMethodName        = "/gen.OrderService/GetOrderList"
+	OrderService_AddProductToCart_FullMethodName            = "/gen.OrderService/AddProductToCart"
+	OrderService_GetCart_FullMethodName                     = "/gen.OrderService/GetCart"
+	OrderService_RemoveCartItem_FullMethodName              = "/gen.OrderService/RemoveCartItem"
+	OrderService_SetCartItemQuantity_FullMethodName         = "/gen.OrderService/SetCartItemQuantity"
+	OrderService_ClearCart_FullMethodName                   = "/gen.OrderService/ClearCart"
+	OrderService_MergeCart_FullMethodName                   = "/gen.OrderService/MergeCart"
+	OrderService_ApplyCoupon_FullMethodName                 = "/gen.OrderService/ApplyCoupon"
+	OrderService_RemoveCoupon_FullMethodName                = "/gen.OrderService/RemoveCoupon"
+	OrderService_CreateOrder_FullMethodName                 = "/gen.OrderService/CreateOrder"
+	OrderService_CallbackTransaction_FullMethodName         = "/gen.OrderService/CallbackTransaction"
+	OrderService_GetOrder_FullMethodName                    = "/gen.OrderService/GetOrder"
+	OrderService_GetOrderList_FullMethodName                = "/gen.OrderService/GetOrderList"
+	OrderService_CancelOrder_FullMethodName                 = "/gen.OrderService/CancelOrder"
+	OrderService_CancelReservedOrder_FullMethodName         = "/gen.OrderService/CancelReservedOrder"
+	OrderService_AddOrderPayment_FullMethodName             = "/gen.OrderService/AddOrderPayment"
+	OrderService_ListDeadLetterCompensations_FullMethodName = "/gen.OrderService/ListDeadLetterCompensations"
+	OrderService_CreatePromotion_FullMethodName             = "/gen.OrderService/CreatePromotion"
+	OrderService_UpdateFulfillment_FullMethodName           = "/gen.OrderService/UpdateFulfillment"
+	OrderService_RefundOrder_FullMethodName                 = "/gen.OrderService/RefundOrder"
+	OrderService_CreateReturn_FullMethodName                = "/gen.OrderService/CreateReturn"
+	OrderService_ReviewReturn_FullMethodName                = "/gen.OrderService/ReviewReturn"
+	OrderService_ReceiveReturn_FullMethodName               = "/gen.OrderService/ReceiveReturn"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -37,13 +53,49 @@ const (
 // and get user_id from context metadata or interceptor
 // the cart is unique for each user
 // the cart is created when the user first add product to cart
+// cart methods also accept cart_token from context metadata for guest carts
 type OrderServiceClient interface {
 	AddProductToCart(ctx context.Context, in *AddCartItemRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetCart(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Cart, error)
+	RemoveCartItem(ctx context.Context, in *RemoveCartItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	// quantity 0 removes the item from the cart
+	SetCartItemQuantity(ctx context.Context, in *SetCartItemQuantityRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearCart(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// folds the guest cart of cart_token into the cart of the user
+	MergeCart(ctx context.Context, in *MergeCartRequest, opts ...grpc.CallOption) (*Empty, error)
+	// validates the coupon against the cart and keeps it in the cart until the order is created
+	ApplyCoupon(ctx context.Context, in *ApplyCouponRequest, opts ...grpc.CallOption) (*Cart, error)
+	RemoveCoupon(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// refused with CartIssues detail when the cart has price changes, stock deficits or unavailable products.
+	// the changed prices are accepted into the cart so the next attempt uses them
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	CallbackTransaction(ctx context.Context, in *CallbackTransactionRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	GetOrderList(ctx context.Context, in *GetOrderListRequest, opts ...grpc.CallOption) (*Orders, error)
+	// only order with status STOCK_RESERVED can be cancelled
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// admin or warehouse service only, cancels the STOCK_RESERVED order whose reserved stock cannot be shipped,
+	// e.g. its warehouse is deactivated. the cancellation is recorded as done by the warehouse service
+	CancelReservedOrder(ctx context.Context, in *CancelReservedOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// pays a part or the rest of the STOCK_RESERVED order with another payment,
+	// e.g. after the previous payment is FAILED
+	AddOrderPayment(ctx context.Context, in *AddOrderPaymentRequest, opts ...grpc.CallOption) (*Order, error)
+	// admin only, list compensations that need manual handling
+	ListDeadLetterCompensations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DeadLetterCompensations, error)
+	// admin only, create a promotion that can be applied with its coupon code
+	CreatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error)
+	// called by the warehouse service, a paid order is PACKED then SHIPPED then DELIVERED
+	UpdateFulfillment(ctx context.Context, in *UpdateFulfillmentRequest, opts ...grpc.CallOption) (*Order, error)
+	// customer service only, refunds the paid order fully or per item and returns the items into the warehouse.
+	// the order becomes PARTIALLY_REFUNDED, or REFUNDED when everything is refunded
+	RefundOrder(ctx context.Context, in *RefundOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// the customer asks to return the items of the paid order, the return is REQUESTED
+	CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*Return, error)
+	// admin only, the requested return is APPROVED or REJECTED
+	ReviewReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*Return, error)
+	// called by the warehouse service when the items of the approved return arrive.
+	// the items are put into the warehouse, then the items are refunded and the return becomes REFUNDED
+	ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*Return, error)
 }
 
 type orderServiceClient struct {
@@ -74,6 +126,66 @@ func (c *orderServiceClient) GetCart(ctx context.Context, in *Empty, opts ...grp
 	return out, nil
 }
 
+func (c *orderServiceClient) RemoveCartItem(ctx context.Context, in *RemoveCartItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_RemoveCartItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetCartItemQuantity(ctx context.Context, in *SetCartItemQuantityRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_SetCartItemQuantity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ClearCart(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_ClearCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) MergeCart(ctx context.Context, in *MergeCartRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_MergeCart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ApplyCoupon(ctx context.Context, in *ApplyCouponRequest, opts ...grpc.CallOption) (*Cart, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, OrderService_ApplyCoupon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RemoveCoupon(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, OrderService_RemoveCoupon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Order)
@@ -114,6 +226,106 @@ func (c *orderServiceClient) GetOrderList(ctx context.Context, in *GetOrderListR
 	return out, nil
 }
 
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelReservedOrder(ctx context.Context, in *CancelReservedOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_CancelReservedOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AddOrderPayment(ctx context.Context, in *AddOrderPaymentRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_AddOrderPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListDeadLetterCompensations(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DeadLetterCompensations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetterCompensations)
+	err := c.cc.Invoke(ctx, OrderService_ListDeadLetterCompensations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreatePromotion(ctx context.Context, in *Promotion, opts ...grpc.CallOption) (*Promotion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Promotion)
+	err := c.cc.Invoke(ctx, OrderService_CreatePromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateFulfillment(ctx context.Context, in *UpdateFulfillmentRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_UpdateFulfillment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RefundOrder(ctx context.Context, in *RefundOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_RefundOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateReturn(ctx context.Context, in *CreateReturnRequest, opts ...grpc.CallOption) (*Return, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Return)
+	err := c.cc.Invoke(ctx, OrderService_CreateReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReviewReturn(ctx context.Context, in *ReviewReturnRequest, opts ...grpc.CallOption) (*Return, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Return)
+	err := c.cc.Invoke(ctx, OrderService_ReviewReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReceiveReturn(ctx context.Context, in *ReceiveReturnRequest, opts ...grpc.CallOption) (*Return, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Return)
+	err := c.cc.Invoke(ctx, OrderService_ReceiveReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -124,13 +336,49 @@ func (c *orderServiceClient) GetOrderList(ctx context.Context, in *GetOrderListR
 // and get user_id from context metadata or interceptor
 // the cart is unique for each user
 // the cart is created when the user first add product to cart
+// cart methods also accept cart_token from context metadata for guest carts
 type OrderServiceServer interface {
 	AddProductToCart(context.Context, *AddCartItemRequest) (*Empty, error)
 	GetCart(context.Context, *Empty) (*Cart, error)
+	RemoveCartItem(context.Context, *RemoveCartItemRequest) (*Empty, error)
+	// quantity 0 removes the item from the cart
+	SetCartItemQuantity(context.Context, *SetCartItemQuantityRequest) (*Empty, error)
+	ClearCart(context.Context, *Empty) (*Empty, error)
+	// folds the guest cart of cart_token into the cart of the user
+	MergeCart(context.Context, *MergeCartRequest) (*Empty, error)
+	// validates the coupon against the cart and keeps it in the cart until the order is created
+	ApplyCoupon(context.Context, *ApplyCouponRequest) (*Cart, error)
+	RemoveCoupon(context.Context, *Empty) (*Empty, error)
+	// refused with CartIssues detail when the cart has price changes, stock deficits or unavailable products.
+	// the changed prices are accepted into the cart so the next attempt uses them
 	CreateOrder(context.Context, *CreateOrderRequest) (*Order, error)
 	CallbackTransaction(context.Context, *CallbackTransactionRequest) (*Empty, error)
 	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
 	GetOrderList(context.Context, *GetOrderListRequest) (*Orders, error)
+	// only order with status STOCK_RESERVED can be cancelled
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
+	// admin or warehouse service only, cancels the STOCK_RESERVED order whose reserved stock cannot be shipped,
+	// e.g. its warehouse is deactivated. the cancellation is recorded as done by the warehouse service
+	CancelReservedOrder(context.Context, *CancelReservedOrderRequest) (*Order, error)
+	// pays a part or the rest of the STOCK_RESERVED order with another payment,
+	// e.g. after the previous payment is FAILED
+	AddOrderPayment(context.Context, *AddOrderPaymentRequest) (*Order, error)
+	// admin only, list compensations that need manual handling
+	ListDeadLetterCompensations(context.Context, *Empty) (*DeadLetterCompensations, error)
+	// admin only, create a promotion that can be applied with its coupon code
+	CreatePromotion(context.Context, *Promotion) (*Promotion, error)
+	// called by the warehouse service, a paid order is PACKED then SHIPPED then DELIVERED
+	UpdateFulfillment(context.Context, *UpdateFulfillmentRequest) (*Order, error)
+	// customer service only, refunds the paid order fully or per item and returns the items into the warehouse.
+	// the order becomes PARTIALLY_REFUNDED, or REFUNDED when everything is refunded
+	RefundOrder(context.Context, *RefundOrderRequest) (*Order, error)
+	// the customer asks to return the items of the paid order, the return is REQUESTED
+	CreateReturn(context.Context, *CreateReturnRequest) (*Return, error)
+	// admin only, the requested return is APPROVED or REJECTED
+	ReviewReturn(context.Context, *ReviewReturnRequest) (*Return, error)
+	// called by the warehouse service when the items of the approved return arrive.
+	// the items are put into the warehouse, then the items are refunded and the return becomes REFUNDED
+	ReceiveReturn(context.Context, *ReceiveReturnRequest) (*Return, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -147,6 +395,24 @@ func (UnimplementedOrderServiceServer) AddProductToCart(context.Context, *AddCar
 func (UnimplementedOrderServiceServer) GetCart(context.Context, *Empty) (*Cart, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCart not implemented")
 }
+func (UnimplementedOrderServiceServer) RemoveCartItem(context.Context, *RemoveCartItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCartItem not implemented")
+}
+func (UnimplementedOrderServiceServer) SetCartItemQuantity(context.Context, *SetCartItemQuantityRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCartItemQuantity not implemented")
+}
+func (UnimplementedOrderServiceServer) ClearCart(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCart not implemented")
+}
+func (UnimplementedOrderServiceServer) MergeCart(context.Context, *MergeCartRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCart not implemented")
+}
+func (UnimplementedOrderServiceServer) ApplyCoupon(context.Context, *ApplyCouponRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyCoupon not implemented")
+}
+func (UnimplementedOrderServiceServer) RemoveCoupon(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCoupon not implemented")
+}
 func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
 }
@@ -159,6 +425,36 @@ func (UnimplementedOrderServiceServer) GetOrder(context.Context, *GetOrderReques
 func (UnimplementedOrderServiceServer) GetOrderList(context.Context, *GetOrderListRequest) (*Orders, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderList not implemented")
 }
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelReservedOrder(context.Context, *CancelReservedOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservedOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) AddOrderPayment(context.Context, *AddOrderPaymentRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddOrderPayment not implemented")
+}
+func (UnimplementedOrderServiceServer) ListDeadLetterCompensations(context.Context, *Empty) (*DeadLetterCompensations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetterCompensations not implemented")
+}
+func (UnimplementedOrderServiceServer) CreatePromotion(context.Context, *Promotion) (*Promotion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateFulfillment(context.Context, *UpdateFulfillmentRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFulfillment not implemented")
+}
+func (UnimplementedOrderServiceServer) RefundOrder(context.Context, *RefundOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateReturn(context.Context, *CreateReturnRequest) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ReviewReturn(context.Context, *ReviewReturnRequest) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewReturn not implemented")
+}
+func (UnimplementedOrderServiceServer) ReceiveReturn(context.Context, *ReceiveReturnRequest) (*Return, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturn not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -216,6 +512,114 @@ func _OrderService_GetCart_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_RemoveCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RemoveCartItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveCartItem(ctx, req.(*RemoveCartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetCartItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCartItemQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetCartItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_SetCartItemQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetCartItemQuantity(ctx, req.(*SetCartItemQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ClearCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ClearCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ClearCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ClearCart(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_MergeCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).MergeCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_MergeCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).MergeCart(ctx, req.(*MergeCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ApplyCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyCouponRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ApplyCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ApplyCoupon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ApplyCoupon(ctx, req.(*ApplyCouponRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RemoveCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RemoveCoupon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveCoupon(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateOrderRequest)
 	if err := dec(in); err != nil {
@@ -288,6 +692,186 @@ func _OrderService_GetOrderList_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelReservedOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservedOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelReservedOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelReservedOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelReservedOrder(ctx, req.(*CancelReservedOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AddOrderPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOrderPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddOrderPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_AddOrderPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddOrderPayment(ctx, req.(*AddOrderPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListDeadLetterCompensations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListDeadLetterCompensations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ListDeadLetterCompensations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListDeadLetterCompensations(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Promotion)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreatePromotion(ctx, req.(*Promotion))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateFulfillment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFulfillmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateFulfillment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_UpdateFulfillment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateFulfillment(ctx, req.(*UpdateFulfillmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RefundOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RefundOrder(ctx, req.(*RefundOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CreateReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateReturn(ctx, req.(*CreateReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReviewReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReviewReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ReviewReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReviewReturn(ctx, req.(*ReviewReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReceiveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_ReceiveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReceiveReturn(ctx, req.(*ReceiveReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,6 +887,30 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCart",
 			Handler:    _OrderService_GetCart_Handler,
 		},
+		{
+			MethodName: "RemoveCartItem",
+			Handler:    _OrderService_RemoveCartItem_Handler,
+		},
+		{
+			MethodName: "SetCartItemQuantity",
+			Handler:    _OrderService_SetCartItemQuantity_Handler,
+		},
+		{
+			MethodName: "ClearCart",
+			Handler:    _OrderService_ClearCart_Handler,
+		},
+		{
+			MethodName: "MergeCart",
+			Handler:    _OrderService_MergeCart_Handler,
+		},
+		{
+			MethodName: "ApplyCoupon",
+			Handler:    _OrderService_ApplyCoupon_Handler,
+		},
+		{
+			MethodName: "RemoveCoupon",
+			Handler:    _OrderService_RemoveCoupon_Handler,
+		},
 		{
 			MethodName: "CreateOrder",
 			Handler:    _OrderService_CreateOrder_Handler,
@@ -319,6 +927,46 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderList",
 			Handler:    _OrderService_GetOrderList_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "CancelReservedOrder",
+			Handler:    _OrderService_CancelReservedOrder_Handler,
+		},
+		{
+			MethodName: "AddOrderPayment",
+			Handler:    _OrderService_AddOrderPayment_Handler,
+		},
+		{
+			MethodName: "ListDeadLetterCompensations",
+			Handler:    _OrderService_ListDeadLetterCompensations_Handler,
+		},
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _OrderService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "UpdateFulfillment",
+			Handler:    _OrderService_UpdateFulfillment_Handler,
+		},
+		{
+			MethodName: "RefundOrder",
+			Handler:    _OrderService_RefundOrder_Handler,
+		},
+		{
+			MethodName: "CreateReturn",
+			Handler:    _OrderService_CreateReturn_Handler,
+		},
+		{
+			MethodName: "ReviewReturn",
+			Handler:    _OrderService_ReviewReturn_Handler,
+		},
+		{
+			MethodName: "ReceiveReturn",
+			Handler:    _OrderService_ReceiveReturn_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order.proto",
@@ -21,8 +21,13 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type ProcessPaymentRequest struct {
-	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	TotalAmount          *Money   `protobuf:"bytes,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	OrderId     string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TotalAmount *Money `protobuf:"bytes,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// CARD, BANK_TRANSFER, E_WALLET or STORE_CREDIT, CARD when it is empty.
+	// an order can be paid by several payments, each payment is its own transaction
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// total amount of the order, the waiting and paid payments of the order cannot be more than it
+	OrderAmount          *Money   `protobuf:"bytes,4,opt,name=order_amount,json=orderAmount,proto3" json:"order_amount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -67,6 +72,20 @@ func (m *ProcessPaymentRequest) GetTotalAmount() *Money {
 	return nil
 }
 
+func (m *ProcessPaymentRequest) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ProcessPaymentRequest) GetOrderAmount() *Money {
+	if m != nil {
+		return m.OrderAmount
+	}
+	return nil
+}
+
 type ProcessPaymentResponse struct {
 	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -107,8 +126,11 @@ func (m *ProcessPaymentResponse) GetTransactionId() string {
 }
 
 type RollbackPaymentRequest struct {
-	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// when it is set instead of transaction_id, every payment of the order is rolled back.
+	// the waiting payment is cancelled and the paid payment is refunded
+	OrderId              string   `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -153,6 +175,13 @@ func (m *RollbackPaymentRequest) GetReason() string {
 	return ""
 }
 
+func (m *RollbackPaymentRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
 type UpdatePaymentRequest struct {
 	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	TotalAmount          *Money   `protobuf:"bytes,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
@@ -279,11 +308,17 @@ func (m *GetPaymentRequest) GetTransactionId() string {
 }
 
 type GetPaymentResponse struct {
-	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	TotalAmount          *Money   `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	CreatedAt            string   `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	ExpiredAt            string   `protobuf:"bytes,5,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TotalAmount   *Money `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	CreatedAt     string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiredAt     string `protobuf:"bytes,5,opt,name=expired_at,json=expiredAt,proto3" json:"expired_at,omitempty"`
+	// sum of every refund of the payment
+	RefundedAmount *Money `protobuf:"bytes,6,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	// the page of the provider where the customer pays, it is empty for the mock provider
+	PaymentUrl           string   `protobuf:"bytes,7,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	Method               string   `protobuf:"bytes,8,opt,name=method,proto3" json:"method,omitempty"`
+	OrderId              string   `protobuf:"bytes,9,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -349,6 +384,252 @@ func (m *GetPaymentResponse) GetExpiredAt() string {
 	return ""
 }
 
+func (m *GetPaymentResponse) GetRefundedAmount() *Money {
+	if m != nil {
+		return m.RefundedAmount
+	}
+	return nil
+}
+
+func (m *GetPaymentResponse) GetPaymentUrl() string {
+	if m != nil {
+		return m.PaymentUrl
+	}
+	return ""
+}
+
+func (m *GetPaymentResponse) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *GetPaymentResponse) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type RefundPaymentRequest struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// unique id of the refund from the caller, the same refund is only paid back once
+	RefundId string `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Amount   *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason   string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// when it is set instead of transaction_id, the amount is refunded from the paid payments of the order, oldest first
+	OrderId              string   `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundPaymentRequest) Reset()         { *m = RefundPaymentRequest{} }
+func (m *RefundPaymentRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundPaymentRequest) ProtoMessage()    {}
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6362648dfa63d410, []int{7}
+}
+
+func (m *RefundPaymentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundPaymentRequest.Unmarshal(m, b)
+}
+func (m *RefundPaymentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundPaymentRequest.Marshal(b, m, deterministic)
+}
+func (m *RefundPaymentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundPaymentRequest.Merge(m, src)
+}
+func (m *RefundPaymentRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundPaymentRequest.Size(m)
+}
+func (m *RefundPaymentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundPaymentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundPaymentRequest proto.InternalMessageInfo
+
+func (m *RefundPaymentRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *RefundPaymentRequest) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *RefundPaymentRequest) GetAmount() *Money {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *RefundPaymentRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RefundPaymentRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	RefundId string `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	// PARTIALLY_REFUNDED or REFUNDED
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// sum of every refund of the payment, or of the order when the order is refunded
+	RefundedAmount       *Money   `protobuf:"bytes,3,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundPaymentResponse) Reset()         { *m = RefundPaymentResponse{} }
+func (m *RefundPaymentResponse) String() string { return proto.CompactTextString(m) }
+func (*RefundPaymentResponse) ProtoMessage()    {}
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6362648dfa63d410, []int{8}
+}
+
+func (m *RefundPaymentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundPaymentResponse.Unmarshal(m, b)
+}
+func (m *RefundPaymentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundPaymentResponse.Marshal(b, m, deterministic)
+}
+func (m *RefundPaymentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundPaymentResponse.Merge(m, src)
+}
+func (m *RefundPaymentResponse) XXX_Size() int {
+	return xxx_messageInfo_RefundPaymentResponse.Size(m)
+}
+func (m *RefundPaymentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundPaymentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundPaymentResponse proto.InternalMessageInfo
+
+func (m *RefundPaymentResponse) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *RefundPaymentResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RefundPaymentResponse) GetRefundedAmount() *Money {
+	if m != nil {
+		return m.RefundedAmount
+	}
+	return nil
+}
+
+type ListPaymentsRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPaymentsRequest) Reset()         { *m = ListPaymentsRequest{} }
+func (m *ListPaymentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPaymentsRequest) ProtoMessage()    {}
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6362648dfa63d410, []int{9}
+}
+
+func (m *ListPaymentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPaymentsRequest.Unmarshal(m, b)
+}
+func (m *ListPaymentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPaymentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPaymentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPaymentsRequest.Merge(m, src)
+}
+func (m *ListPaymentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPaymentsRequest.Size(m)
+}
+func (m *ListPaymentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPaymentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPaymentsRequest proto.InternalMessageInfo
+
+func (m *ListPaymentsRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type ListPaymentsResponse struct {
+	// every payment of the order, oldest first
+	Payments []*GetPaymentResponse `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	// sum of the payments that are paid, including the refunded ones
+	PaidAmount           *Money   `protobuf:"bytes,2,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPaymentsResponse) Reset()         { *m = ListPaymentsResponse{} }
+func (m *ListPaymentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPaymentsResponse) ProtoMessage()    {}
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6362648dfa63d410, []int{10}
+}
+
+func (m *ListPaymentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPaymentsResponse.Unmarshal(m, b)
+}
+func (m *ListPaymentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPaymentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPaymentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPaymentsResponse.Merge(m, src)
+}
+func (m *ListPaymentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPaymentsResponse.Size(m)
+}
+func (m *ListPaymentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPaymentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPaymentsResponse proto.InternalMessageInfo
+
+func (m *ListPaymentsResponse) GetPayments() []*GetPaymentResponse {
+	if m != nil {
+		return m.Payments
+	}
+	return nil
+}
+
+func (m *ListPaymentsResponse) GetPaidAmount() *Money {
+	if m != nil {
+		return m.PaidAmount
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ProcessPaymentRequest)(nil), "gen.ProcessPaymentRequest")
 	proto.RegisterType((*ProcessPaymentResponse)(nil), "gen.ProcessPaymentResponse")
@@ -357,37 +638,55 @@ func init() {
 	proto.RegisterType((*UpdatePaymentResponse)(nil), "gen.UpdatePaymentResponse")
 	proto.RegisterType((*GetPaymentRequest)(nil), "gen.GetPaymentRequest")
 	proto.RegisterType((*GetPaymentResponse)(nil), "gen.GetPaymentResponse")
+	proto.RegisterType((*RefundPaymentRequest)(nil), "gen.RefundPaymentRequest")
+	proto.RegisterType((*RefundPaymentResponse)(nil), "gen.RefundPaymentResponse")
+	proto.RegisterType((*ListPaymentsRequest)(nil), "gen.ListPaymentsRequest")
+	proto.RegisterType((*ListPaymentsResponse)(nil), "gen.ListPaymentsResponse")
 }
 
 func init() { proto.RegisterFile("payment.proto", fileDescriptor_6362648dfa63d410) }
 
 var fileDescriptor_6362648dfa63d410 = []byte{
-	// 429 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x61, 0x6b, 0xd4, 0x40,
-	0x10, 0xed, 0x5d, 0xf5, 0xf4, 0xe6, 0xbc, 0x13, 0x17, 0x1b, 0xaf, 0x2b, 0x42, 0x59, 0x10, 0x2a,
-	0xd2, 0x0b, 0xd4, 0x6f, 0x22, 0x94, 0x13, 0x44, 0x8b, 0x08, 0x25, 0x22, 0x82, 0x5f, 0x8e, 0xbd,
-	0xcd, 0x10, 0x83, 0xc9, 0x6e, 0xdc, 0x9d, 0x88, 0xf5, 0x07, 0xf9, 0x3b, 0xfc, 0x69, 0xd2, 0xcd,
-	0x6a, 0xd3, 0x5c, 0x10, 0x7b, 0xdf, 0x32, 0xf3, 0x32, 0xf3, 0xde, 0xcc, 0xbc, 0x85, 0x69, 0x25,
-	0xcf, 0x4b, 0xd4, 0xb4, 0xa8, 0xac, 0x21, 0xc3, 0x76, 0x33, 0xd4, 0x7c, 0x82, 0x65, 0x45, 0xe7,
-	0x4d, 0x86, 0x4f, 0x4a, 0xa3, 0x31, 0x04, 0x42, 0xc2, 0xde, 0x99, 0x35, 0x0a, 0x9d, 0x3b, 0x6b,
-	0xca, 0x12, 0xfc, 0x5a, 0xa3, 0x23, 0xb6, 0x0f, 0xb7, 0x8d, 0x4d, 0xd1, 0xae, 0xf2, 0x74, 0x3e,
-	0x38, 0x18, 0x1c, 0x8e, 0x93, 0x5b, 0x3e, 0x3e, 0x4d, 0xd9, 0x11, 0xdc, 0x21, 0x43, 0xb2, 0x58,
-	0xc9, 0xd2, 0xd4, 0x9a, 0xe6, 0xc3, 0x83, 0xc1, 0xe1, 0xe4, 0x18, 0x16, 0x19, 0xea, 0xc5, 0xbb,
-	0x8b, 0xde, 0xc9, 0xc4, 0xe3, 0x4b, 0x0f, 0x8b, 0x13, 0x88, 0xba, 0x14, 0xae, 0x32, 0xda, 0x21,
-	0x7b, 0x0c, 0x33, 0xb2, 0x52, 0x3b, 0xa9, 0x28, 0x37, 0xfa, 0x92, 0x69, 0xda, 0xca, 0x9e, 0xa6,
-	0xe2, 0x23, 0x44, 0x89, 0x29, 0x8a, 0xb5, 0x54, 0x5f, 0x3a, 0x22, 0xff, 0xaf, 0x01, 0x8b, 0x60,
-	0x64, 0x51, 0x3a, 0xa3, 0xbd, 0xd4, 0x71, 0x12, 0x22, 0x51, 0xc0, 0xfd, 0x0f, 0x55, 0x2a, 0x09,
-	0xb7, 0x6b, 0x7b, 0xcd, 0x3d, 0xc4, 0xb0, 0xd7, 0x61, 0x0b, 0x6b, 0x88, 0x60, 0xe4, 0x48, 0x52,
-	0xed, 0x02, 0x4d, 0x88, 0xc4, 0x73, 0xb8, 0xf7, 0x1a, 0x69, 0x2b, 0x6d, 0xe2, 0xd7, 0x00, 0x58,
-	0xbb, 0xf8, 0x5a, 0x1b, 0x6f, 0x29, 0x1a, 0xb6, 0x15, 0x6d, 0x4c, 0xbc, 0xfb, 0xcf, 0x89, 0xd9,
-	0x23, 0x00, 0x65, 0x51, 0x12, 0xa6, 0x2b, 0x49, 0xf3, 0x1b, 0xbe, 0xd5, 0x38, 0x64, 0x96, 0x1e,
-	0xc6, 0xef, 0x55, 0x6e, 0x1b, 0xf8, 0x66, 0x03, 0x87, 0xcc, 0x92, 0x8e, 0x7f, 0x0e, 0x61, 0x16,
-	0xf4, 0xbf, 0x47, 0xfb, 0x2d, 0x57, 0xc8, 0xde, 0xc2, 0xec, 0xaa, 0x95, 0x18, 0xf7, 0xdc, 0xbd,
-	0x16, 0xe6, 0x0f, 0x7b, 0xb1, 0x66, 0x13, 0x62, 0x87, 0xbd, 0x80, 0xbb, 0x1d, 0x5b, 0xb1, 0xa6,
-	0xa2, 0xdf, 0x6c, 0xbc, 0x19, 0xf3, 0xd5, 0xc5, 0x4b, 0x12, 0x3b, 0xec, 0x0d, 0x4c, 0xaf, 0x5c,
-	0x93, 0xed, 0x7b, 0xb8, 0xcf, 0x4f, 0x9c, 0xf7, 0x41, 0x7f, 0x75, 0x9c, 0x00, 0x5c, 0x5e, 0x8a,
-	0x45, 0xfe, 0xdf, 0x8d, 0xbb, 0xf3, 0x07, 0x1b, 0xf9, 0x3f, 0x0d, 0x5e, 0x3e, 0xfd, 0xf4, 0x24,
-	0xcb, 0xe9, 0x73, 0xbd, 0x5e, 0x28, 0x53, 0xc6, 0x58, 0x48, 0x9d, 0x59, 0xfc, 0x21, 0x63, 0x3c,
-	0x52, 0xa6, 0x2c, 0xd1, 0x2a, 0x8c, 0xfd, 0x63, 0x8f, 0x33, 0xd4, 0xeb, 0x91, 0xff, 0x7c, 0xf6,
-	0x3b, 0x00, 0x00, 0xff, 0xff, 0xfd, 0xde, 0x3a, 0x09, 0x27, 0x04, 0x00, 0x00,
+	// 645 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x51, 0x4f, 0xd4, 0x4c,
+	0x14, 0xa5, 0x14, 0x96, 0xdd, 0xbb, 0xb0, 0xe4, 0x9b, 0x0f, 0xd6, 0xa5, 0xc4, 0x48, 0x26, 0x31,
+	0xc1, 0x10, 0x76, 0x0d, 0xbc, 0x19, 0x13, 0x82, 0x09, 0x51, 0xa2, 0x26, 0xa4, 0x86, 0x17, 0x5f,
+	0x36, 0x43, 0x7b, 0x5d, 0x1a, 0xdb, 0x99, 0x3a, 0x9d, 0x1a, 0xf0, 0xcd, 0x7f, 0xe3, 0xab, 0x4f,
+	0xfe, 0x06, 0xff, 0x95, 0xe9, 0xcc, 0x00, 0x6d, 0xb7, 0x2b, 0xb2, 0x6f, 0x9d, 0x7b, 0x66, 0xce,
+	0x3d, 0x73, 0xef, 0x99, 0x5b, 0x58, 0x4b, 0xd9, 0x75, 0x82, 0x5c, 0x0d, 0x53, 0x29, 0x94, 0x20,
+	0xee, 0x04, 0xb9, 0xd7, 0xc5, 0x24, 0x55, 0xd7, 0x26, 0xe2, 0x75, 0x13, 0xc1, 0xd1, 0x2e, 0xe8,
+	0x0f, 0x07, 0x36, 0xcf, 0xa4, 0x08, 0x30, 0xcb, 0xce, 0xcc, 0x39, 0x1f, 0xbf, 0xe4, 0x98, 0x29,
+	0xb2, 0x05, 0x6d, 0x21, 0x43, 0x94, 0xe3, 0x28, 0x1c, 0x38, 0x3b, 0xce, 0x6e, 0xc7, 0x5f, 0xd1,
+	0xeb, 0xd3, 0x90, 0xec, 0xc3, 0xaa, 0x12, 0x8a, 0xc5, 0x63, 0x96, 0x88, 0x9c, 0xab, 0xc1, 0xe2,
+	0x8e, 0xb3, 0xdb, 0x3d, 0x80, 0xe1, 0x04, 0xf9, 0xf0, 0x7d, 0x41, 0xee, 0x77, 0x35, 0x7e, 0xac,
+	0x61, 0xd2, 0x87, 0x56, 0x82, 0xea, 0x52, 0x84, 0x03, 0x57, 0xf3, 0xd8, 0x55, 0x41, 0x63, 0x32,
+	0x58, 0x9a, 0xa5, 0x69, 0x1a, 0x8d, 0x1b, 0x1a, 0x7a, 0x04, 0xfd, 0xba, 0xd2, 0x2c, 0x15, 0x3c,
+	0x43, 0xf2, 0x14, 0x7a, 0x4a, 0x32, 0x9e, 0xb1, 0x40, 0x45, 0x82, 0xdf, 0x09, 0x5e, 0x2b, 0x45,
+	0x4f, 0x43, 0x2a, 0xa1, 0xef, 0x8b, 0x38, 0xbe, 0x60, 0xc1, 0xe7, 0xda, 0x5d, 0xff, 0x8d, 0xa0,
+	0xb8, 0x88, 0x44, 0x96, 0x09, 0xae, 0x6f, 0xdc, 0xf1, 0xed, 0xaa, 0x52, 0x2a, 0xb7, 0x52, 0x2a,
+	0x1a, 0xc3, 0xc6, 0x79, 0x1a, 0x32, 0x85, 0xf3, 0x65, 0x7c, 0x58, 0xa5, 0xe9, 0x08, 0x36, 0x6b,
+	0xd9, 0x6c, 0x85, 0xfa, 0xd0, 0xca, 0x14, 0x53, 0x79, 0x66, 0xd3, 0xd8, 0x15, 0x7d, 0x01, 0xff,
+	0xbd, 0x46, 0x35, 0x97, 0x36, 0xfa, 0x7b, 0x11, 0x48, 0xf9, 0xf0, 0x83, 0x9a, 0x51, 0x52, 0xb4,
+	0x58, 0x56, 0x34, 0x75, 0x63, 0xf7, 0xef, 0xde, 0x7a, 0x0c, 0x10, 0x48, 0x64, 0x0a, 0xc3, 0x31,
+	0x33, 0x0e, 0xea, 0xf8, 0x1d, 0x1b, 0x39, 0xd6, 0x30, 0x5e, 0xa5, 0x91, 0x34, 0xf0, 0xb2, 0x81,
+	0x6d, 0xe4, 0x58, 0x91, 0x43, 0x58, 0x97, 0xf8, 0x29, 0xe7, 0x61, 0x81, 0x9b, 0x7c, 0xad, 0xa9,
+	0x7c, 0xbd, 0x9b, 0x2d, 0x36, 0xe5, 0x13, 0xe8, 0xda, 0x27, 0x36, 0xce, 0x65, 0x3c, 0x58, 0xd1,
+	0xa4, 0x60, 0x43, 0xe7, 0x32, 0x2e, 0xf9, 0xbd, 0x5d, 0xf1, 0x7b, 0xd9, 0x26, 0x9d, 0xaa, 0x4d,
+	0x7e, 0x3a, 0xb0, 0xe1, 0xeb, 0x34, 0xf3, 0xf9, 0x64, 0x1b, 0x3a, 0x46, 0x65, 0xb1, 0xc3, 0x14,
+	0xb4, 0x6d, 0x02, 0xa7, 0x21, 0xa1, 0xd0, 0x9a, 0x59, 0x4c, 0x8b, 0x94, 0xac, 0xbd, 0x34, 0xd3,
+	0xda, 0xcb, 0x55, 0xcd, 0xdf, 0x1d, 0xd8, 0xac, 0x69, 0xb6, 0x16, 0xa8, 0xa8, 0x71, 0x6a, 0x6a,
+	0x66, 0x35, 0xbe, 0xa1, 0x17, 0xee, 0x7d, 0xbd, 0xa0, 0xcf, 0xe1, 0xff, 0x77, 0x51, 0x76, 0xe3,
+	0xc1, 0xec, 0xfe, 0xd9, 0x45, 0xaf, 0x60, 0xa3, 0x7a, 0xc2, 0x6a, 0x3e, 0x84, 0xb6, 0x6d, 0x61,
+	0xf1, 0x46, 0xdc, 0xdd, 0xee, 0xc1, 0x23, 0x9d, 0x77, 0xda, 0xe1, 0xfe, 0xed, 0x46, 0xb2, 0x57,
+	0x58, 0x21, 0x0a, 0x67, 0xbf, 0x4e, 0x28, 0x60, 0xa3, 0xf5, 0xe0, 0x97, 0x0b, 0x3d, 0x4b, 0xf5,
+	0x01, 0xe5, 0xd7, 0x28, 0x40, 0xf2, 0x16, 0x7a, 0xd5, 0x91, 0x46, 0x3c, 0x7d, 0xb8, 0x71, 0x22,
+	0x7b, 0xdb, 0x8d, 0x98, 0x11, 0x45, 0x17, 0xc8, 0x4b, 0x58, 0xaf, 0x8d, 0x37, 0x62, 0x4e, 0x34,
+	0x0f, 0x3d, 0xcf, 0xe8, 0x3c, 0x29, 0xfe, 0x0c, 0x74, 0x81, 0xbc, 0x81, 0xb5, 0xca, 0xe8, 0x20,
+	0x5b, 0x1a, 0x6e, 0x1a, 0x5e, 0x9e, 0xd7, 0x04, 0xdd, 0xea, 0x38, 0x02, 0xb8, 0x2b, 0x1a, 0xe9,
+	0x4f, 0x55, 0xd1, 0x70, 0xcc, 0xaa, 0xae, 0x91, 0x52, 0xf1, 0x95, 0x95, 0xd2, 0xf4, 0x3e, 0x3c,
+	0xaf, 0x09, 0xba, 0x65, 0x3a, 0x81, 0xd5, 0x72, 0xb3, 0xc9, 0x40, 0xef, 0x6e, 0x70, 0x8c, 0xb7,
+	0xd5, 0x80, 0xdc, 0xd0, 0xbc, 0xda, 0xfb, 0xf8, 0x6c, 0x12, 0xa9, 0xcb, 0xfc, 0x62, 0x18, 0x88,
+	0x64, 0x84, 0x31, 0xe3, 0x13, 0x89, 0xdf, 0xd8, 0x08, 0xf7, 0x03, 0x91, 0x24, 0x28, 0x03, 0x1c,
+	0xe9, 0xbf, 0xe9, 0x68, 0x82, 0xfc, 0xa2, 0xa5, 0x3f, 0x0f, 0xff, 0x0c, 0x00, 0xd1, 0x8e, 0x84,
+	0x6b, 0x88, 0x07, 0x00, 0x00,
 }
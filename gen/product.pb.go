@@ -21,13 +21,15 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Product struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	ImageUrl             string   `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Price                *Money   `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
-	Stock                int64    `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
-	ShopId               int64    `protobuf:"varint,7,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl    string `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Price       *Money `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Stock       int64  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
+	ShopId      int64  `protobuf:"varint,7,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	// category of the product, e.g., electronics, used by the tax rules
+	Category             string   `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -107,6 +109,13 @@ func (m *Product) GetShopId() int64 {
 	return 0
 }
 
+func (m *Product) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
 type GetProductsRequest struct {
 	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 	WithStock            bool     `protobuf:"varint,2,opt,name=withStock,proto3" json:"withStock,omitempty"`
@@ -330,34 +339,34 @@ func init() {
 func init() { proto.RegisterFile("product.proto", fileDescriptor_f0fd8b59378f44a5) }
 
 var fileDescriptor_f0fd8b59378f44a5 = []byte{
-	// 451 bytes of a gzipped FileDescriptorProto
+	// 461 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x51, 0x8b, 0xd3, 0x40,
-	0x10, 0xbe, 0x34, 0xd7, 0xb4, 0x9d, 0xdc, 0x1d, 0x32, 0x1e, 0x5e, 0xac, 0x82, 0x21, 0x4f, 0x11,
-	0xb1, 0x85, 0x2a, 0xf8, 0x7e, 0x28, 0x22, 0x28, 0x1c, 0x7b, 0xf8, 0xe2, 0x4b, 0x49, 0x93, 0x21,
-	0x5d, 0x4c, 0xb2, 0x71, 0x77, 0xeb, 0x51, 0x7f, 0x84, 0xf8, 0x87, 0xfc, 0x6f, 0xb2, 0x93, 0x9c,
-	0xe6, 0xb4, 0x0f, 0xbe, 0xcd, 0xf7, 0xcd, 0x32, 0xf3, 0xcd, 0xf7, 0xb1, 0x70, 0xda, 0x6a, 0x55,
+	0x10, 0xbe, 0x34, 0xd7, 0x36, 0x9d, 0xdc, 0x1d, 0x32, 0x1e, 0xde, 0x5a, 0x05, 0x43, 0x9e, 0x22,
+	0x62, 0x0b, 0x55, 0xf0, 0xfd, 0x50, 0x44, 0x50, 0x38, 0x72, 0xf8, 0xe2, 0x4b, 0x49, 0x93, 0x21,
+	0x5d, 0x4c, 0xb2, 0x71, 0x77, 0xeb, 0x51, 0x7f, 0x84, 0xf8, 0xf7, 0xfc, 0x37, 0xb2, 0x93, 0x54,
+	0x73, 0x7a, 0x0f, 0xbe, 0xcd, 0xf7, 0xcd, 0x32, 0xdf, 0xcc, 0xf7, 0xb1, 0x70, 0xda, 0x6a, 0x55,
 	0xec, 0x72, 0xbb, 0x68, 0xb5, 0xb2, 0x0a, 0xfd, 0x92, 0x9a, 0x79, 0x58, 0xab, 0x86, 0xf6, 0x1d,
-	0x93, 0xfc, 0xf4, 0x60, 0x72, 0xd5, 0xbd, 0xc1, 0x33, 0x18, 0xc9, 0x22, 0xf2, 0x62, 0x2f, 0x9d,
-	0x89, 0x91, 0x2c, 0x10, 0xe1, 0xb8, 0xc9, 0x6a, 0x8a, 0x46, 0xcc, 0x70, 0x8d, 0x31, 0x84, 0x05,
-	0x99, 0x5c, 0xcb, 0xd6, 0x4a, 0xd5, 0x44, 0x3e, 0xb7, 0x86, 0x14, 0x3e, 0x82, 0x99, 0xac, 0xb3,
-	0x92, 0xd6, 0x3b, 0x5d, 0x45, 0xc7, 0xdc, 0x9f, 0x32, 0xf1, 0x51, 0x57, 0x18, 0xc3, 0xb8, 0xd5,
-	0x32, 0xa7, 0x68, 0x1c, 0x7b, 0x69, 0xb8, 0x82, 0x45, 0x49, 0xcd, 0xe2, 0x83, 0xd3, 0x23, 0xba,
-	0x06, 0x9e, 0xc3, 0xd8, 0x58, 0x95, 0x7f, 0x8e, 0x82, 0xd8, 0x4b, 0x7d, 0xd1, 0x01, 0xbc, 0x80,
-	0x89, 0xd9, 0xaa, 0x76, 0x2d, 0x8b, 0x68, 0xc2, 0x7c, 0xe0, 0xe0, 0xbb, 0x22, 0x79, 0x0d, 0xf8,
-	0x96, 0x6c, 0x7f, 0x81, 0x11, 0xf4, 0x65, 0x47, 0xc6, 0xe2, 0x3d, 0xf0, 0x65, 0x61, 0x22, 0x2f,
-	0xf6, 0xd3, 0x99, 0x70, 0x25, 0x3e, 0x86, 0xd9, 0x8d, 0xb4, 0xdb, 0x6b, 0x1e, 0xed, 0x0e, 0x9a,
-	0x8a, 0x3f, 0x44, 0xf2, 0x12, 0xa6, 0xb7, 0x23, 0x30, 0x85, 0x69, 0x6f, 0x5a, 0x37, 0x20, 0x5c,
-	0x9d, 0xb0, 0xca, 0xfe, 0x81, 0xf8, 0xdd, 0x4d, 0x6e, 0xe0, 0xfc, 0xbd, 0x34, 0x83, 0xe5, 0xa6,
-	0x55, 0x8d, 0xa1, 0xff, 0x9f, 0xe0, 0x8e, 0xb5, 0xca, 0x66, 0x15, 0x2b, 0xf2, 0x45, 0x07, 0xf0,
-	0x09, 0x84, 0x5c, 0xac, 0xdb, 0xac, 0x24, 0xc3, 0x1e, 0xfb, 0x02, 0x98, 0xba, 0x72, 0x4c, 0xf2,
-	0xdd, 0x83, 0xfb, 0x77, 0x37, 0x77, 0x67, 0x3f, 0x80, 0xc0, 0x50, 0xa6, 0xf3, 0x6d, 0x1f, 0x62,
-	0x8f, 0xdc, 0x9a, 0x4a, 0xd6, 0xd2, 0xde, 0xae, 0x61, 0xe0, 0xe2, 0x75, 0x0b, 0xfa, 0xf9, 0x5c,
-	0xb3, 0xcf, 0x4a, 0xdb, 0xf5, 0x66, 0xdf, 0x47, 0x17, 0x38, 0x78, 0xb9, 0xbf, 0xeb, 0xdf, 0xf8,
-	0x2f, 0xff, 0x56, 0x3f, 0x3c, 0x38, 0xeb, 0xc5, 0x5c, 0x93, 0xfe, 0xea, 0x72, 0x7c, 0x03, 0x27,
-	0x43, 0x89, 0x18, 0xb1, 0x05, 0x07, 0x54, 0xcf, 0x1f, 0x1e, 0xe8, 0x74, 0x4e, 0x26, 0x47, 0xf8,
-	0x0a, 0xc2, 0x41, 0xbe, 0x78, 0xc1, 0x6f, 0xff, 0x4d, 0x7c, 0x7e, 0x3a, 0x74, 0xd8, 0x24, 0x47,
-	0x97, 0xcf, 0x3e, 0x3d, 0x2d, 0xa5, 0xdd, 0xee, 0x36, 0x8b, 0x5c, 0xd5, 0x4b, 0xaa, 0xb2, 0xa6,
-	0xd4, 0xf4, 0x2d, 0x5b, 0xd2, 0xf3, 0x5c, 0xd5, 0x35, 0xe9, 0x9c, 0x96, 0xfc, 0x03, 0x96, 0x25,
-	0x35, 0x9b, 0x80, 0xcb, 0x17, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x3f, 0xe4, 0xf2, 0x7a, 0x2f,
-	0x03, 0x00, 0x00,
+	0x13, 0xff, 0xf4, 0x60, 0x7a, 0xd5, 0xbd, 0xc1, 0x33, 0x18, 0xc9, 0x42, 0x78, 0x91, 0x97, 0xcc,
+	0xd2, 0x91, 0x2c, 0x10, 0xe1, 0xb8, 0xc9, 0x6a, 0x12, 0x23, 0x66, 0xb8, 0xc6, 0x08, 0xc2, 0x82,
+	0x4c, 0xae, 0x65, 0x6b, 0xa5, 0x6a, 0x84, 0xcf, 0xad, 0x21, 0x85, 0x8f, 0x60, 0x26, 0xeb, 0xac,
+	0xa4, 0xf5, 0x4e, 0x57, 0xe2, 0x98, 0xfb, 0x01, 0x13, 0x1f, 0x75, 0x85, 0x11, 0x8c, 0x5b, 0x2d,
+	0x73, 0x12, 0xe3, 0xc8, 0x4b, 0xc2, 0x15, 0x2c, 0x4a, 0x6a, 0x16, 0x1f, 0xdc, 0x3e, 0x69, 0xd7,
+	0xc0, 0x73, 0x18, 0x1b, 0xab, 0xf2, 0xcf, 0x62, 0x12, 0x79, 0x89, 0x9f, 0x76, 0x00, 0x2f, 0x60,
+	0x6a, 0xb6, 0xaa, 0x5d, 0xcb, 0x42, 0x4c, 0x99, 0x9f, 0x38, 0xf8, 0xae, 0xc0, 0x39, 0x04, 0x79,
+	0x66, 0xa9, 0x54, 0x7a, 0x2f, 0x82, 0x4e, 0xec, 0x80, 0xe3, 0xd7, 0x80, 0x6f, 0xc9, 0xf6, 0xd7,
+	0x99, 0x94, 0xbe, 0xec, 0xc8, 0x58, 0xbc, 0x07, 0xbe, 0x2c, 0x8c, 0xf0, 0x22, 0x3f, 0x99, 0xa5,
+	0xae, 0xc4, 0xc7, 0x30, 0xbb, 0x91, 0x76, 0x7b, 0xcd, 0xb2, 0xee, 0xd8, 0x20, 0xfd, 0x43, 0xc4,
+	0x2f, 0x21, 0x38, 0x8c, 0xc0, 0x04, 0x82, 0xde, 0xd0, 0x6e, 0x40, 0xb8, 0x3a, 0xe1, 0x0b, 0xfa,
+	0x07, 0xe9, 0xef, 0x6e, 0x7c, 0x03, 0xe7, 0xef, 0xa5, 0x19, 0x88, 0x9b, 0x56, 0x35, 0x86, 0xfe,
+	0x7f, 0x82, 0x33, 0xc2, 0x2a, 0x9b, 0x55, 0xbc, 0x91, 0x9f, 0x76, 0x00, 0x9f, 0x40, 0xc8, 0xc5,
+	0xba, 0xcd, 0x4a, 0x32, 0xec, 0xbf, 0x9f, 0x02, 0x53, 0x57, 0x8e, 0x89, 0xbf, 0x7b, 0x70, 0xff,
+	0xb6, 0x72, 0x77, 0xf6, 0x03, 0x98, 0x18, 0xca, 0x74, 0xbe, 0xed, 0x03, 0xee, 0x91, 0x93, 0xa9,
+	0x64, 0x2d, 0xed, 0x41, 0x86, 0x81, 0x8b, 0xde, 0x09, 0xf4, 0xf3, 0xb9, 0xe6, 0x0c, 0x94, 0xb6,
+	0xeb, 0xcd, 0xbe, 0x8f, 0x75, 0xe2, 0xe0, 0xe5, 0xfe, 0xb6, 0x7f, 0xe3, 0xbf, 0xfc, 0x5b, 0xfd,
+	0xf0, 0xe0, 0xac, 0x5f, 0xe6, 0x9a, 0xf4, 0x57, 0x97, 0xf1, 0x1b, 0x38, 0x19, 0xae, 0x88, 0x82,
+	0x2d, 0xb8, 0x63, 0xeb, 0xf9, 0xc3, 0x3b, 0x3a, 0x9d, 0x93, 0xf1, 0x11, 0xbe, 0x82, 0x70, 0x90,
+	0x2f, 0x5e, 0xf0, 0xdb, 0x7f, 0x13, 0x9f, 0x9f, 0x0e, 0x1d, 0x36, 0xf1, 0xd1, 0xe5, 0xb3, 0x4f,
+	0x4f, 0x4b, 0x69, 0xb7, 0xbb, 0xcd, 0x22, 0x57, 0xf5, 0x92, 0xaa, 0xac, 0x29, 0x35, 0x7d, 0xcb,
+	0x96, 0xf4, 0x3c, 0x57, 0x75, 0x4d, 0x3a, 0xa7, 0x25, 0xff, 0x8e, 0x65, 0x49, 0xcd, 0x66, 0xc2,
+	0xe5, 0x8b, 0x5f, 0x03, 0x00, 0xdc, 0x73, 0xb2, 0x1d, 0x4b, 0x03, 0x00, 0x00,
 }
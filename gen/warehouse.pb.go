@@ -146,8 +146,11 @@ func (m *GetStockRequest) GetProductIds() []string {
 }
 
 type ReserveStockRequest struct {
-	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Stocks               []*Stock `protobuf:"bytes,2,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	OrderId string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Stocks  []*Stock `protobuf:"bytes,2,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	// RFC3339, the reservation is released by the warehouse service after this time,
+	// the default reservation ttl of the warehouse service is used when empty
+	ExpiresAt            string   `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -192,8 +195,17 @@ func (m *ReserveStockRequest) GetStocks() []*Stock {
 	return nil
 }
 
+func (m *ReserveStockRequest) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
 type ReserveStockResponse struct {
-	ReservedStockIds     []int64  `protobuf:"varint,1,rep,packed,name=reserved_stock_ids,json=reservedStockIds,proto3" json:"reserved_stock_ids,omitempty"`
+	ReservedStockIds []int64 `protobuf:"varint,1,rep,packed,name=reserved_stock_ids,json=reservedStockIds,proto3" json:"reserved_stock_ids,omitempty"`
+	// RFC3339
+	ExpiresAt            string   `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -231,302 +243,1569 @@ func (m *ReserveStockResponse) GetReservedStockIds() []int64 {
 	return nil
 }
 
-type ReleaseStockRequest struct {
-	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+func (m *ReserveStockResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type ExtendReservationRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// RFC3339, must be after the current expiry of the reservation
+	ExpiresAt            string   `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReleaseStockRequest) Reset()         { *m = ReleaseStockRequest{} }
-func (m *ReleaseStockRequest) String() string { return proto.CompactTextString(m) }
-func (*ReleaseStockRequest) ProtoMessage()    {}
-func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+func (m *ExtendReservationRequest) Reset()         { *m = ExtendReservationRequest{} }
+func (m *ExtendReservationRequest) String() string { return proto.CompactTextString(m) }
+func (*ExtendReservationRequest) ProtoMessage()    {}
+func (*ExtendReservationRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{5}
 }
 
-func (m *ReleaseStockRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReleaseStockRequest.Unmarshal(m, b)
+func (m *ExtendReservationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtendReservationRequest.Unmarshal(m, b)
 }
-func (m *ReleaseStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReleaseStockRequest.Marshal(b, m, deterministic)
+func (m *ExtendReservationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtendReservationRequest.Marshal(b, m, deterministic)
 }
-func (m *ReleaseStockRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseStockRequest.Merge(m, src)
+func (m *ExtendReservationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendReservationRequest.Merge(m, src)
 }
-func (m *ReleaseStockRequest) XXX_Size() int {
-	return xxx_messageInfo_ReleaseStockRequest.Size(m)
+func (m *ExtendReservationRequest) XXX_Size() int {
+	return xxx_messageInfo_ExtendReservationRequest.Size(m)
 }
-func (m *ReleaseStockRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseStockRequest.DiscardUnknown(m)
+func (m *ExtendReservationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendReservationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReleaseStockRequest proto.InternalMessageInfo
+var xxx_messageInfo_ExtendReservationRequest proto.InternalMessageInfo
 
-func (m *ReleaseStockRequest) GetOrderId() string {
+func (m *ExtendReservationRequest) GetOrderId() string {
 	if m != nil {
 		return m.OrderId
 	}
 	return ""
 }
 
-type ReleaseStockResponse struct {
-	ReleasedStockIds     []int64  `protobuf:"varint,1,rep,packed,name=released_stock_ids,json=releasedStockIds,proto3" json:"released_stock_ids,omitempty"`
+func (m *ExtendReservationRequest) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type ExtendReservationResponse struct {
+	ReservedStockIds []int64 `protobuf:"varint,1,rep,packed,name=reserved_stock_ids,json=reservedStockIds,proto3" json:"reserved_stock_ids,omitempty"`
+	// RFC3339
+	ExpiresAt            string   `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReleaseStockResponse) Reset()         { *m = ReleaseStockResponse{} }
-func (m *ReleaseStockResponse) String() string { return proto.CompactTextString(m) }
-func (*ReleaseStockResponse) ProtoMessage()    {}
-func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+func (m *ExtendReservationResponse) Reset()         { *m = ExtendReservationResponse{} }
+func (m *ExtendReservationResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtendReservationResponse) ProtoMessage()    {}
+func (*ExtendReservationResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{6}
 }
 
-func (m *ReleaseStockResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReleaseStockResponse.Unmarshal(m, b)
+func (m *ExtendReservationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtendReservationResponse.Unmarshal(m, b)
 }
-func (m *ReleaseStockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReleaseStockResponse.Marshal(b, m, deterministic)
+func (m *ExtendReservationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtendReservationResponse.Marshal(b, m, deterministic)
 }
-func (m *ReleaseStockResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseStockResponse.Merge(m, src)
+func (m *ExtendReservationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendReservationResponse.Merge(m, src)
 }
-func (m *ReleaseStockResponse) XXX_Size() int {
-	return xxx_messageInfo_ReleaseStockResponse.Size(m)
+func (m *ExtendReservationResponse) XXX_Size() int {
+	return xxx_messageInfo_ExtendReservationResponse.Size(m)
 }
-func (m *ReleaseStockResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseStockResponse.DiscardUnknown(m)
+func (m *ExtendReservationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendReservationResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReleaseStockResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExtendReservationResponse proto.InternalMessageInfo
 
-func (m *ReleaseStockResponse) GetReleasedStockIds() []int64 {
+func (m *ExtendReservationResponse) GetReservedStockIds() []int64 {
 	if m != nil {
-		return m.ReleasedStockIds
+		return m.ReservedStockIds
 	}
 	return nil
 }
 
-type SetWarehouseStatusRequest struct {
-	WarehouseId          int64    `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
-	IsActive             bool     `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+func (m *ExtendReservationResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type GetReservationRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetWarehouseStatusRequest) Reset()         { *m = SetWarehouseStatusRequest{} }
-func (m *SetWarehouseStatusRequest) String() string { return proto.CompactTextString(m) }
-func (*SetWarehouseStatusRequest) ProtoMessage()    {}
-func (*SetWarehouseStatusRequest) Descriptor() ([]byte, []int) {
+func (m *GetReservationRequest) Reset()         { *m = GetReservationRequest{} }
+func (m *GetReservationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReservationRequest) ProtoMessage()    {}
+func (*GetReservationRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{7}
 }
 
-func (m *SetWarehouseStatusRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetWarehouseStatusRequest.Unmarshal(m, b)
+func (m *GetReservationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReservationRequest.Unmarshal(m, b)
 }
-func (m *SetWarehouseStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetWarehouseStatusRequest.Marshal(b, m, deterministic)
+func (m *GetReservationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReservationRequest.Marshal(b, m, deterministic)
 }
-func (m *SetWarehouseStatusRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetWarehouseStatusRequest.Merge(m, src)
+func (m *GetReservationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReservationRequest.Merge(m, src)
 }
-func (m *SetWarehouseStatusRequest) XXX_Size() int {
-	return xxx_messageInfo_SetWarehouseStatusRequest.Size(m)
+func (m *GetReservationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReservationRequest.Size(m)
 }
-func (m *SetWarehouseStatusRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetWarehouseStatusRequest.DiscardUnknown(m)
+func (m *GetReservationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReservationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetWarehouseStatusRequest proto.InternalMessageInfo
-
-func (m *SetWarehouseStatusRequest) GetWarehouseId() int64 {
-	if m != nil {
-		return m.WarehouseId
-	}
-	return 0
-}
+var xxx_messageInfo_GetReservationRequest proto.InternalMessageInfo
 
-func (m *SetWarehouseStatusRequest) GetIsActive() bool {
+func (m *GetReservationRequest) GetOrderId() string {
 	if m != nil {
-		return m.IsActive
+		return m.OrderId
 	}
-	return false
+	return ""
 }
 
-type TransferStockBetweenWarehouseRequest struct {
-	FromWarehouseId      int64    `protobuf:"varint,1,opt,name=from_warehouse_id,json=fromWarehouseId,proto3" json:"from_warehouse_id,omitempty"`
-	ToWarehouseId        int64    `protobuf:"varint,2,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty"`
-	ProductId            string   `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity             int64    `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+type ReservedStock struct {
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StockId     int64  `protobuf:"varint,2,opt,name=stock_id,json=stockId,proto3" json:"stock_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId   string `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity    int64  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// reserved, released or confirmed
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC3339
+	ExpiresAt            string   `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransferStockBetweenWarehouseRequest) Reset()         { *m = TransferStockBetweenWarehouseRequest{} }
-func (m *TransferStockBetweenWarehouseRequest) String() string { return proto.CompactTextString(m) }
-func (*TransferStockBetweenWarehouseRequest) ProtoMessage()    {}
-func (*TransferStockBetweenWarehouseRequest) Descriptor() ([]byte, []int) {
+func (m *ReservedStock) Reset()         { *m = ReservedStock{} }
+func (m *ReservedStock) String() string { return proto.CompactTextString(m) }
+func (*ReservedStock) ProtoMessage()    {}
+func (*ReservedStock) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{8}
 }
 
-func (m *TransferStockBetweenWarehouseRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Unmarshal(m, b)
+func (m *ReservedStock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReservedStock.Unmarshal(m, b)
 }
-func (m *TransferStockBetweenWarehouseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Marshal(b, m, deterministic)
+func (m *ReservedStock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReservedStock.Marshal(b, m, deterministic)
 }
-func (m *TransferStockBetweenWarehouseRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransferStockBetweenWarehouseRequest.Merge(m, src)
+func (m *ReservedStock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReservedStock.Merge(m, src)
 }
-func (m *TransferStockBetweenWarehouseRequest) XXX_Size() int {
-	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Size(m)
+func (m *ReservedStock) XXX_Size() int {
+	return xxx_messageInfo_ReservedStock.Size(m)
 }
-func (m *TransferStockBetweenWarehouseRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransferStockBetweenWarehouseRequest.DiscardUnknown(m)
+func (m *ReservedStock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReservedStock.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransferStockBetweenWarehouseRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReservedStock proto.InternalMessageInfo
 
-func (m *TransferStockBetweenWarehouseRequest) GetFromWarehouseId() int64 {
+func (m *ReservedStock) GetId() int64 {
 	if m != nil {
-		return m.FromWarehouseId
+		return m.Id
 	}
 	return 0
 }
 
-func (m *TransferStockBetweenWarehouseRequest) GetToWarehouseId() int64 {
+func (m *ReservedStock) GetStockId() int64 {
 	if m != nil {
-		return m.ToWarehouseId
+		return m.StockId
 	}
 	return 0
 }
 
-func (m *TransferStockBetweenWarehouseRequest) GetProductId() string {
+func (m *ReservedStock) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *ReservedStock) GetProductId() string {
 	if m != nil {
 		return m.ProductId
 	}
 	return ""
 }
 
-func (m *TransferStockBetweenWarehouseRequest) GetQuantity() int64 {
+func (m *ReservedStock) GetQuantity() int64 {
 	if m != nil {
 		return m.Quantity
 	}
 	return 0
 }
 
-type Warehouse struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	IsActive             bool     `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+func (m *ReservedStock) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ReservedStock) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type Reservation struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// reserved, released or confirmed
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// the quantity of the products that is requested by the order
+	Stocks []*Stock `protobuf:"bytes,3,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	// the stock the quantity is taken from
+	ReservedStocks []*ReservedStock `protobuf:"bytes,4,rep,name=reserved_stocks,json=reservedStocks,proto3" json:"reserved_stocks,omitempty"`
+	// RFC3339
+	CreatedAt            string   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Warehouse) Reset()         { *m = Warehouse{} }
-func (m *Warehouse) String() string { return proto.CompactTextString(m) }
-func (*Warehouse) ProtoMessage()    {}
-func (*Warehouse) Descriptor() ([]byte, []int) {
+func (m *Reservation) Reset()         { *m = Reservation{} }
+func (m *Reservation) String() string { return proto.CompactTextString(m) }
+func (*Reservation) ProtoMessage()    {}
+func (*Reservation) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{9}
 }
 
-func (m *Warehouse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Warehouse.Unmarshal(m, b)
+func (m *Reservation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reservation.Unmarshal(m, b)
 }
-func (m *Warehouse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Warehouse.Marshal(b, m, deterministic)
+func (m *Reservation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reservation.Marshal(b, m, deterministic)
 }
-func (m *Warehouse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Warehouse.Merge(m, src)
+func (m *Reservation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reservation.Merge(m, src)
 }
-func (m *Warehouse) XXX_Size() int {
-	return xxx_messageInfo_Warehouse.Size(m)
+func (m *Reservation) XXX_Size() int {
+	return xxx_messageInfo_Reservation.Size(m)
 }
-func (m *Warehouse) XXX_DiscardUnknown() {
-	xxx_messageInfo_Warehouse.DiscardUnknown(m)
+func (m *Reservation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reservation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Warehouse proto.InternalMessageInfo
+var xxx_messageInfo_Reservation proto.InternalMessageInfo
 
-func (m *Warehouse) GetId() int64 {
+func (m *Reservation) GetOrderId() string {
 	if m != nil {
-		return m.Id
+		return m.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (m *Warehouse) GetName() string {
+func (m *Reservation) GetStatus() string {
 	if m != nil {
-		return m.Name
+		return m.Status
 	}
 	return ""
 }
 
-func (m *Warehouse) GetIsActive() bool {
+func (m *Reservation) GetStocks() []*Stock {
 	if m != nil {
-		return m.IsActive
+		return m.Stocks
 	}
-	return false
+	return nil
 }
 
-type GetWarehouseByShopIDRequest struct {
-	ShopId               int64    `protobuf:"varint,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+func (m *Reservation) GetReservedStocks() []*ReservedStock {
+	if m != nil {
+		return m.ReservedStocks
+	}
+	return nil
+}
+
+func (m *Reservation) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type ReleaseStockRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetWarehouseByShopIDRequest) Reset()         { *m = GetWarehouseByShopIDRequest{} }
-func (m *GetWarehouseByShopIDRequest) String() string { return proto.CompactTextString(m) }
-func (*GetWarehouseByShopIDRequest) ProtoMessage()    {}
-func (*GetWarehouseByShopIDRequest) Descriptor() ([]byte, []int) {
+func (m *ReleaseStockRequest) Reset()         { *m = ReleaseStockRequest{} }
+func (m *ReleaseStockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseStockRequest) ProtoMessage()    {}
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{10}
 }
 
-func (m *GetWarehouseByShopIDRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetWarehouseByShopIDRequest.Unmarshal(m, b)
+func (m *ReleaseStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseStockRequest.Unmarshal(m, b)
 }
-func (m *GetWarehouseByShopIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetWarehouseByShopIDRequest.Marshal(b, m, deterministic)
+func (m *ReleaseStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseStockRequest.Marshal(b, m, deterministic)
 }
-func (m *GetWarehouseByShopIDRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetWarehouseByShopIDRequest.Merge(m, src)
+func (m *ReleaseStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseStockRequest.Merge(m, src)
 }
-func (m *GetWarehouseByShopIDRequest) XXX_Size() int {
-	return xxx_messageInfo_GetWarehouseByShopIDRequest.Size(m)
+func (m *ReleaseStockRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseStockRequest.Size(m)
 }
-func (m *GetWarehouseByShopIDRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetWarehouseByShopIDRequest.DiscardUnknown(m)
+func (m *ReleaseStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseStockRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetWarehouseByShopIDRequest proto.InternalMessageInfo
+var xxx_messageInfo_ReleaseStockRequest proto.InternalMessageInfo
 
-func (m *GetWarehouseByShopIDRequest) GetShopId() int64 {
+func (m *ReleaseStockRequest) GetOrderId() string {
 	if m != nil {
-		return m.ShopId
+		return m.OrderId
 	}
-	return 0
+	return ""
 }
 
-type GetWarehouseByShopIDResponse struct {
-	Warehouses           []*Warehouse `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type ReleaseStockResponse struct {
+	ReleasedStockIds     []int64  `protobuf:"varint,1,rep,packed,name=released_stock_ids,json=releasedStockIds,proto3" json:"released_stock_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetWarehouseByShopIDResponse) Reset()         { *m = GetWarehouseByShopIDResponse{} }
-func (m *GetWarehouseByShopIDResponse) String() string { return proto.CompactTextString(m) }
-func (*GetWarehouseByShopIDResponse) ProtoMessage()    {}
-func (*GetWarehouseByShopIDResponse) Descriptor() ([]byte, []int) {
+func (m *ReleaseStockResponse) Reset()         { *m = ReleaseStockResponse{} }
+func (m *ReleaseStockResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseStockResponse) ProtoMessage()    {}
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_a49842460749824d, []int{11}
 }
 
+func (m *ReleaseStockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseStockResponse.Unmarshal(m, b)
+}
+func (m *ReleaseStockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseStockResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseStockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseStockResponse.Merge(m, src)
+}
+func (m *ReleaseStockResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseStockResponse.Size(m)
+}
+func (m *ReleaseStockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseStockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseStockResponse proto.InternalMessageInfo
+
+func (m *ReleaseStockResponse) GetReleasedStockIds() []int64 {
+	if m != nil {
+		return m.ReleasedStockIds
+	}
+	return nil
+}
+
+type ConfirmStockRequest struct {
+	OrderId              string   `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmStockRequest) Reset()         { *m = ConfirmStockRequest{} }
+func (m *ConfirmStockRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmStockRequest) ProtoMessage()    {}
+func (*ConfirmStockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{12}
+}
+
+func (m *ConfirmStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmStockRequest.Unmarshal(m, b)
+}
+func (m *ConfirmStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmStockRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmStockRequest.Merge(m, src)
+}
+func (m *ConfirmStockRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmStockRequest.Size(m)
+}
+func (m *ConfirmStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmStockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmStockRequest proto.InternalMessageInfo
+
+func (m *ConfirmStockRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type ConfirmStockResponse struct {
+	ConfirmedStockIds    []int64  `protobuf:"varint,1,rep,packed,name=confirmed_stock_ids,json=confirmedStockIds,proto3" json:"confirmed_stock_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfirmStockResponse) Reset()         { *m = ConfirmStockResponse{} }
+func (m *ConfirmStockResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmStockResponse) ProtoMessage()    {}
+func (*ConfirmStockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{13}
+}
+
+func (m *ConfirmStockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmStockResponse.Unmarshal(m, b)
+}
+func (m *ConfirmStockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmStockResponse.Marshal(b, m, deterministic)
+}
+func (m *ConfirmStockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmStockResponse.Merge(m, src)
+}
+func (m *ConfirmStockResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfirmStockResponse.Size(m)
+}
+func (m *ConfirmStockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmStockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmStockResponse proto.InternalMessageInfo
+
+func (m *ConfirmStockResponse) GetConfirmedStockIds() []int64 {
+	if m != nil {
+		return m.ConfirmedStockIds
+	}
+	return nil
+}
+
+type RestockStockRequest struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// unique id of the refund, the same refund is only restocked once
+	RefundId string   `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Stocks   []*Stock `protobuf:"bytes,3,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	// the stock is put into this warehouse instead of the warehouse it is taken from when set
+	WarehouseId          int64    `protobuf:"varint,4,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestockStockRequest) Reset()         { *m = RestockStockRequest{} }
+func (m *RestockStockRequest) String() string { return proto.CompactTextString(m) }
+func (*RestockStockRequest) ProtoMessage()    {}
+func (*RestockStockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{14}
+}
+
+func (m *RestockStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestockStockRequest.Unmarshal(m, b)
+}
+func (m *RestockStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestockStockRequest.Marshal(b, m, deterministic)
+}
+func (m *RestockStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestockStockRequest.Merge(m, src)
+}
+func (m *RestockStockRequest) XXX_Size() int {
+	return xxx_messageInfo_RestockStockRequest.Size(m)
+}
+func (m *RestockStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestockStockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestockStockRequest proto.InternalMessageInfo
+
+func (m *RestockStockRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *RestockStockRequest) GetRefundId() string {
+	if m != nil {
+		return m.RefundId
+	}
+	return ""
+}
+
+func (m *RestockStockRequest) GetStocks() []*Stock {
+	if m != nil {
+		return m.Stocks
+	}
+	return nil
+}
+
+func (m *RestockStockRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+type RestockStockResponse struct {
+	RestockedStockIds    []int64  `protobuf:"varint,1,rep,packed,name=restocked_stock_ids,json=restockedStockIds,proto3" json:"restocked_stock_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestockStockResponse) Reset()         { *m = RestockStockResponse{} }
+func (m *RestockStockResponse) String() string { return proto.CompactTextString(m) }
+func (*RestockStockResponse) ProtoMessage()    {}
+func (*RestockStockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{15}
+}
+
+func (m *RestockStockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestockStockResponse.Unmarshal(m, b)
+}
+func (m *RestockStockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestockStockResponse.Marshal(b, m, deterministic)
+}
+func (m *RestockStockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestockStockResponse.Merge(m, src)
+}
+func (m *RestockStockResponse) XXX_Size() int {
+	return xxx_messageInfo_RestockStockResponse.Size(m)
+}
+func (m *RestockStockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestockStockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestockStockResponse proto.InternalMessageInfo
+
+func (m *RestockStockResponse) GetRestockedStockIds() []int64 {
+	if m != nil {
+		return m.RestockedStockIds
+	}
+	return nil
+}
+
+type SetWarehouseStatusRequest struct {
+	WarehouseId          int64    `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	IsActive             bool     `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWarehouseStatusRequest) Reset()         { *m = SetWarehouseStatusRequest{} }
+func (m *SetWarehouseStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SetWarehouseStatusRequest) ProtoMessage()    {}
+func (*SetWarehouseStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{16}
+}
+
+func (m *SetWarehouseStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWarehouseStatusRequest.Unmarshal(m, b)
+}
+func (m *SetWarehouseStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWarehouseStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SetWarehouseStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWarehouseStatusRequest.Merge(m, src)
+}
+func (m *SetWarehouseStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SetWarehouseStatusRequest.Size(m)
+}
+func (m *SetWarehouseStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWarehouseStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWarehouseStatusRequest proto.InternalMessageInfo
+
+func (m *SetWarehouseStatusRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *SetWarehouseStatusRequest) GetIsActive() bool {
+	if m != nil {
+		return m.IsActive
+	}
+	return false
+}
+
+type TransferStockBetweenWarehouseRequest struct {
+	FromWarehouseId      int64    `protobuf:"varint,1,opt,name=from_warehouse_id,json=fromWarehouseId,proto3" json:"from_warehouse_id,omitempty"`
+	ToWarehouseId        int64    `protobuf:"varint,2,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty"`
+	ProductId            string   `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity             int64    `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferStockBetweenWarehouseRequest) Reset()         { *m = TransferStockBetweenWarehouseRequest{} }
+func (m *TransferStockBetweenWarehouseRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferStockBetweenWarehouseRequest) ProtoMessage()    {}
+func (*TransferStockBetweenWarehouseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{17}
+}
+
+func (m *TransferStockBetweenWarehouseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Unmarshal(m, b)
+}
+func (m *TransferStockBetweenWarehouseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferStockBetweenWarehouseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferStockBetweenWarehouseRequest.Merge(m, src)
+}
+func (m *TransferStockBetweenWarehouseRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferStockBetweenWarehouseRequest.Size(m)
+}
+func (m *TransferStockBetweenWarehouseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferStockBetweenWarehouseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferStockBetweenWarehouseRequest proto.InternalMessageInfo
+
+func (m *TransferStockBetweenWarehouseRequest) GetFromWarehouseId() int64 {
+	if m != nil {
+		return m.FromWarehouseId
+	}
+	return 0
+}
+
+func (m *TransferStockBetweenWarehouseRequest) GetToWarehouseId() int64 {
+	if m != nil {
+		return m.ToWarehouseId
+	}
+	return 0
+}
+
+func (m *TransferStockBetweenWarehouseRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *TransferStockBetweenWarehouseRequest) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+type Warehouse struct {
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsActive bool   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// region code of the warehouse location, e.g., JKT, used as the shipping origin
+	Region               string   `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Warehouse) Reset()         { *m = Warehouse{} }
+func (m *Warehouse) String() string { return proto.CompactTextString(m) }
+func (*Warehouse) ProtoMessage()    {}
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{18}
+}
+
+func (m *Warehouse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Warehouse.Unmarshal(m, b)
+}
+func (m *Warehouse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Warehouse.Marshal(b, m, deterministic)
+}
+func (m *Warehouse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Warehouse.Merge(m, src)
+}
+func (m *Warehouse) XXX_Size() int {
+	return xxx_messageInfo_Warehouse.Size(m)
+}
+func (m *Warehouse) XXX_DiscardUnknown() {
+	xxx_messageInfo_Warehouse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Warehouse proto.InternalMessageInfo
+
+func (m *Warehouse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Warehouse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Warehouse) GetIsActive() bool {
+	if m != nil {
+		return m.IsActive
+	}
+	return false
+}
+
+func (m *Warehouse) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+type FulfillOrderRequest struct {
+	WarehouseId int64  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	OrderId     string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// PACKED, SHIPPED or DELIVERED
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// required for SHIPPED
+	Carrier              string   `protobuf:"bytes,4,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber       string   `protobuf:"bytes,5,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FulfillOrderRequest) Reset()         { *m = FulfillOrderRequest{} }
+func (m *FulfillOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*FulfillOrderRequest) ProtoMessage()    {}
+func (*FulfillOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{19}
+}
+
+func (m *FulfillOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FulfillOrderRequest.Unmarshal(m, b)
+}
+func (m *FulfillOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FulfillOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *FulfillOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FulfillOrderRequest.Merge(m, src)
+}
+func (m *FulfillOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_FulfillOrderRequest.Size(m)
+}
+func (m *FulfillOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FulfillOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FulfillOrderRequest proto.InternalMessageInfo
+
+func (m *FulfillOrderRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *FulfillOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *FulfillOrderRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *FulfillOrderRequest) GetCarrier() string {
+	if m != nil {
+		return m.Carrier
+	}
+	return ""
+}
+
+func (m *FulfillOrderRequest) GetTrackingNumber() string {
+	if m != nil {
+		return m.TrackingNumber
+	}
+	return ""
+}
+
+type ReceiveReturnedStockRequest struct {
+	WarehouseId          int64    `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ReturnId             string   `protobuf:"bytes,2,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveReturnedStockRequest) Reset()         { *m = ReceiveReturnedStockRequest{} }
+func (m *ReceiveReturnedStockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveReturnedStockRequest) ProtoMessage()    {}
+func (*ReceiveReturnedStockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{20}
+}
+
+func (m *ReceiveReturnedStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveReturnedStockRequest.Unmarshal(m, b)
+}
+func (m *ReceiveReturnedStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveReturnedStockRequest.Marshal(b, m, deterministic)
+}
+func (m *ReceiveReturnedStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveReturnedStockRequest.Merge(m, src)
+}
+func (m *ReceiveReturnedStockRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveReturnedStockRequest.Size(m)
+}
+func (m *ReceiveReturnedStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveReturnedStockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveReturnedStockRequest proto.InternalMessageInfo
+
+func (m *ReceiveReturnedStockRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *ReceiveReturnedStockRequest) GetReturnId() string {
+	if m != nil {
+		return m.ReturnId
+	}
+	return ""
+}
+
+type ListStockMovementsRequest struct {
+	ProductId   string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// OPENING, RECEIVE, RESERVE, RELEASE, CONFIRM, TRANSFER_OUT, TRANSFER_IN, RESTOCK or ADJUSTMENT
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// the order, refund, transfer, receipt or adjustment id
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// RFC3339, the movements from this time
+	From string `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	// RFC3339, the movements before this time
+	To                   string   `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	Limit                int64    `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page                 int64    `protobuf:"varint,8,opt,name=page,proto3" json:"page,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStockMovementsRequest) Reset()         { *m = ListStockMovementsRequest{} }
+func (m *ListStockMovementsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListStockMovementsRequest) ProtoMessage()    {}
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{21}
+}
+
+func (m *ListStockMovementsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStockMovementsRequest.Unmarshal(m, b)
+}
+func (m *ListStockMovementsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStockMovementsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListStockMovementsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStockMovementsRequest.Merge(m, src)
+}
+func (m *ListStockMovementsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListStockMovementsRequest.Size(m)
+}
+func (m *ListStockMovementsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStockMovementsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStockMovementsRequest proto.InternalMessageInfo
+
+func (m *ListStockMovementsRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *ListStockMovementsRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *ListStockMovementsRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ListStockMovementsRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *ListStockMovementsRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ListStockMovementsRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ListStockMovementsRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListStockMovementsRequest) GetPage() int64 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+type StockMovement struct {
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StockId     int64  `protobuf:"varint,2,opt,name=stock_id,json=stockId,proto3" json:"stock_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId   string `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type        string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// the change of the quantity of the stock, negative when it is taken from the stock
+	Quantity int64 `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the quantity of the stock after the movement
+	Balance int64 `protobuf:"varint,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	// the user that requests the movement, or SYSTEM
+	Actor     string `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reference string `protobuf:"bytes,9,opt,name=reference,proto3" json:"reference,omitempty"`
+	// RFC3339
+	CreatedAt            string   `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StockMovement) Reset()         { *m = StockMovement{} }
+func (m *StockMovement) String() string { return proto.CompactTextString(m) }
+func (*StockMovement) ProtoMessage()    {}
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{22}
+}
+
+func (m *StockMovement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StockMovement.Unmarshal(m, b)
+}
+func (m *StockMovement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StockMovement.Marshal(b, m, deterministic)
+}
+func (m *StockMovement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StockMovement.Merge(m, src)
+}
+func (m *StockMovement) XXX_Size() int {
+	return xxx_messageInfo_StockMovement.Size(m)
+}
+func (m *StockMovement) XXX_DiscardUnknown() {
+	xxx_messageInfo_StockMovement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StockMovement proto.InternalMessageInfo
+
+func (m *StockMovement) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *StockMovement) GetStockId() int64 {
+	if m != nil {
+		return m.StockId
+	}
+	return 0
+}
+
+func (m *StockMovement) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *StockMovement) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *StockMovement) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *StockMovement) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *StockMovement) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *StockMovement) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *StockMovement) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *StockMovement) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+type ListStockMovementsResponse struct {
+	Movements  []*StockMovement `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	Total      int64            `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages int64            `protobuf:"varint,3,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// the quantity of the product (in the warehouse) before the to time, only set when the product_id is set
+	Balance              int64    `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListStockMovementsResponse) Reset()         { *m = ListStockMovementsResponse{} }
+func (m *ListStockMovementsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListStockMovementsResponse) ProtoMessage()    {}
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{23}
+}
+
+func (m *ListStockMovementsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListStockMovementsResponse.Unmarshal(m, b)
+}
+func (m *ListStockMovementsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListStockMovementsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListStockMovementsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListStockMovementsResponse.Merge(m, src)
+}
+func (m *ListStockMovementsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListStockMovementsResponse.Size(m)
+}
+func (m *ListStockMovementsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListStockMovementsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListStockMovementsResponse proto.InternalMessageInfo
+
+func (m *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if m != nil {
+		return m.Movements
+	}
+	return nil
+}
+
+func (m *ListStockMovementsResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ListStockMovementsResponse) GetTotalPages() int64 {
+	if m != nil {
+		return m.TotalPages
+	}
+	return 0
+}
+
+func (m *ListStockMovementsResponse) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type ReceiveStockRequest struct {
+	ProductId   string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ShopId      int64  `protobuf:"varint,3,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Quantity    int64  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the batch or lot of the received goods
+	LotNumber string `protobuf:"bytes,5,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	// RFC3339, the time the goods arrive in the warehouse, default is now
+	ReceivedAt string `protobuf:"bytes,6,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	// RFC3339, empty when the goods do not expire
+	ExpiresAt            string   `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveStockRequest) Reset()         { *m = ReceiveStockRequest{} }
+func (m *ReceiveStockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveStockRequest) ProtoMessage()    {}
+func (*ReceiveStockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{24}
+}
+
+func (m *ReceiveStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveStockRequest.Unmarshal(m, b)
+}
+func (m *ReceiveStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveStockRequest.Marshal(b, m, deterministic)
+}
+func (m *ReceiveStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveStockRequest.Merge(m, src)
+}
+func (m *ReceiveStockRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveStockRequest.Size(m)
+}
+func (m *ReceiveStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveStockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveStockRequest proto.InternalMessageInfo
+
+func (m *ReceiveStockRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *ReceiveStockRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *ReceiveStockRequest) GetShopId() int64 {
+	if m != nil {
+		return m.ShopId
+	}
+	return 0
+}
+
+func (m *ReceiveStockRequest) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *ReceiveStockRequest) GetLotNumber() string {
+	if m != nil {
+		return m.LotNumber
+	}
+	return ""
+}
+
+func (m *ReceiveStockRequest) GetReceivedAt() string {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return ""
+}
+
+func (m *ReceiveStockRequest) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type AdjustStockRequest struct {
+	ProductId   string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ShopId      int64  `protobuf:"varint,3,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	// the change of the quantity of the stock, negative when it is taken from the stock
+	Quantity int64 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// DAMAGE, LOSS or CYCLE_COUNT, the damaged or lost stock can only be taken from the stock
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Note   string `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	// the lot of the stock, empty for the stock that is not tracked by lot
+	LotNumber            string   `protobuf:"bytes,7,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdjustStockRequest) Reset()         { *m = AdjustStockRequest{} }
+func (m *AdjustStockRequest) String() string { return proto.CompactTextString(m) }
+func (*AdjustStockRequest) ProtoMessage()    {}
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{25}
+}
+
+func (m *AdjustStockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdjustStockRequest.Unmarshal(m, b)
+}
+func (m *AdjustStockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdjustStockRequest.Marshal(b, m, deterministic)
+}
+func (m *AdjustStockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdjustStockRequest.Merge(m, src)
+}
+func (m *AdjustStockRequest) XXX_Size() int {
+	return xxx_messageInfo_AdjustStockRequest.Size(m)
+}
+func (m *AdjustStockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdjustStockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdjustStockRequest proto.InternalMessageInfo
+
+func (m *AdjustStockRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *AdjustStockRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *AdjustStockRequest) GetShopId() int64 {
+	if m != nil {
+		return m.ShopId
+	}
+	return 0
+}
+
+func (m *AdjustStockRequest) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *AdjustStockRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *AdjustStockRequest) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *AdjustStockRequest) GetLotNumber() string {
+	if m != nil {
+		return m.LotNumber
+	}
+	return ""
+}
+
+type ListExpiringLotsRequest struct {
+	// the lots that expire within the days, 0 only returns the expired lots
+	Days                 int64    `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	WarehouseId          int64    `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId            string   `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListExpiringLotsRequest) Reset()         { *m = ListExpiringLotsRequest{} }
+func (m *ListExpiringLotsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListExpiringLotsRequest) ProtoMessage()    {}
+func (*ListExpiringLotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{26}
+}
+
+func (m *ListExpiringLotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExpiringLotsRequest.Unmarshal(m, b)
+}
+func (m *ListExpiringLotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExpiringLotsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListExpiringLotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExpiringLotsRequest.Merge(m, src)
+}
+func (m *ListExpiringLotsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListExpiringLotsRequest.Size(m)
+}
+func (m *ListExpiringLotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExpiringLotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExpiringLotsRequest proto.InternalMessageInfo
+
+func (m *ListExpiringLotsRequest) GetDays() int64 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *ListExpiringLotsRequest) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *ListExpiringLotsRequest) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+type StockLot struct {
+	StockId     int64  `protobuf:"varint,1,opt,name=stock_id,json=stockId,proto3" json:"stock_id,omitempty"`
+	WarehouseId int64  `protobuf:"varint,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	ProductId   string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ShopId      int64  `protobuf:"varint,4,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	LotNumber   string `protobuf:"bytes,5,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	Quantity    int64  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// RFC3339
+	ExpiresAt string `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// the expired lot cannot be reserved anymore
+	Expired              bool     `protobuf:"varint,8,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StockLot) Reset()         { *m = StockLot{} }
+func (m *StockLot) String() string { return proto.CompactTextString(m) }
+func (*StockLot) ProtoMessage()    {}
+func (*StockLot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{27}
+}
+
+func (m *StockLot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StockLot.Unmarshal(m, b)
+}
+func (m *StockLot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StockLot.Marshal(b, m, deterministic)
+}
+func (m *StockLot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StockLot.Merge(m, src)
+}
+func (m *StockLot) XXX_Size() int {
+	return xxx_messageInfo_StockLot.Size(m)
+}
+func (m *StockLot) XXX_DiscardUnknown() {
+	xxx_messageInfo_StockLot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StockLot proto.InternalMessageInfo
+
+func (m *StockLot) GetStockId() int64 {
+	if m != nil {
+		return m.StockId
+	}
+	return 0
+}
+
+func (m *StockLot) GetWarehouseId() int64 {
+	if m != nil {
+		return m.WarehouseId
+	}
+	return 0
+}
+
+func (m *StockLot) GetProductId() string {
+	if m != nil {
+		return m.ProductId
+	}
+	return ""
+}
+
+func (m *StockLot) GetShopId() int64 {
+	if m != nil {
+		return m.ShopId
+	}
+	return 0
+}
+
+func (m *StockLot) GetLotNumber() string {
+	if m != nil {
+		return m.LotNumber
+	}
+	return ""
+}
+
+func (m *StockLot) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *StockLot) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *StockLot) GetExpired() bool {
+	if m != nil {
+		return m.Expired
+	}
+	return false
+}
+
+type ListExpiringLotsResponse struct {
+	Lots                 []*StockLot `protobuf:"bytes,1,rep,name=lots,proto3" json:"lots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListExpiringLotsResponse) Reset()         { *m = ListExpiringLotsResponse{} }
+func (m *ListExpiringLotsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListExpiringLotsResponse) ProtoMessage()    {}
+func (*ListExpiringLotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{28}
+}
+
+func (m *ListExpiringLotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExpiringLotsResponse.Unmarshal(m, b)
+}
+func (m *ListExpiringLotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExpiringLotsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListExpiringLotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExpiringLotsResponse.Merge(m, src)
+}
+func (m *ListExpiringLotsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListExpiringLotsResponse.Size(m)
+}
+func (m *ListExpiringLotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExpiringLotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExpiringLotsResponse proto.InternalMessageInfo
+
+func (m *ListExpiringLotsResponse) GetLots() []*StockLot {
+	if m != nil {
+		return m.Lots
+	}
+	return nil
+}
+
+type GetWarehouseByShopIDRequest struct {
+	ShopId               int64    `protobuf:"varint,1,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWarehouseByShopIDRequest) Reset()         { *m = GetWarehouseByShopIDRequest{} }
+func (m *GetWarehouseByShopIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWarehouseByShopIDRequest) ProtoMessage()    {}
+func (*GetWarehouseByShopIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{29}
+}
+
+func (m *GetWarehouseByShopIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWarehouseByShopIDRequest.Unmarshal(m, b)
+}
+func (m *GetWarehouseByShopIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWarehouseByShopIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWarehouseByShopIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWarehouseByShopIDRequest.Merge(m, src)
+}
+func (m *GetWarehouseByShopIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWarehouseByShopIDRequest.Size(m)
+}
+func (m *GetWarehouseByShopIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWarehouseByShopIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWarehouseByShopIDRequest proto.InternalMessageInfo
+
+func (m *GetWarehouseByShopIDRequest) GetShopId() int64 {
+	if m != nil {
+		return m.ShopId
+	}
+	return 0
+}
+
+type GetWarehouseByShopIDResponse struct {
+	Warehouses           []*Warehouse `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetWarehouseByShopIDResponse) Reset()         { *m = GetWarehouseByShopIDResponse{} }
+func (m *GetWarehouseByShopIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWarehouseByShopIDResponse) ProtoMessage()    {}
+func (*GetWarehouseByShopIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a49842460749824d, []int{30}
+}
+
 func (m *GetWarehouseByShopIDResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetWarehouseByShopIDResponse.Unmarshal(m, b)
 }
@@ -558,11 +1837,30 @@ func init() {
 	proto.RegisterType((*GetStockRequest)(nil), "gen.GetStockRequest")
 	proto.RegisterType((*ReserveStockRequest)(nil), "gen.ReserveStockRequest")
 	proto.RegisterType((*ReserveStockResponse)(nil), "gen.ReserveStockResponse")
+	proto.RegisterType((*ExtendReservationRequest)(nil), "gen.ExtendReservationRequest")
+	proto.RegisterType((*ExtendReservationResponse)(nil), "gen.ExtendReservationResponse")
+	proto.RegisterType((*GetReservationRequest)(nil), "gen.GetReservationRequest")
+	proto.RegisterType((*ReservedStock)(nil), "gen.ReservedStock")
+	proto.RegisterType((*Reservation)(nil), "gen.Reservation")
 	proto.RegisterType((*ReleaseStockRequest)(nil), "gen.ReleaseStockRequest")
 	proto.RegisterType((*ReleaseStockResponse)(nil), "gen.ReleaseStockResponse")
+	proto.RegisterType((*ConfirmStockRequest)(nil), "gen.ConfirmStockRequest")
+	proto.RegisterType((*ConfirmStockResponse)(nil), "gen.ConfirmStockResponse")
+	proto.RegisterType((*RestockStockRequest)(nil), "gen.RestockStockRequest")
+	proto.RegisterType((*RestockStockResponse)(nil), "gen.RestockStockResponse")
 	proto.RegisterType((*SetWarehouseStatusRequest)(nil), "gen.SetWarehouseStatusRequest")
 	proto.RegisterType((*TransferStockBetweenWarehouseRequest)(nil), "gen.TransferStockBetweenWarehouseRequest")
 	proto.RegisterType((*Warehouse)(nil), "gen.Warehouse")
+	proto.RegisterType((*FulfillOrderRequest)(nil), "gen.FulfillOrderRequest")
+	proto.RegisterType((*ReceiveReturnedStockRequest)(nil), "gen.ReceiveReturnedStockRequest")
+	proto.RegisterType((*ListStockMovementsRequest)(nil), "gen.ListStockMovementsRequest")
+	proto.RegisterType((*StockMovement)(nil), "gen.StockMovement")
+	proto.RegisterType((*ListStockMovementsResponse)(nil), "gen.ListStockMovementsResponse")
+	proto.RegisterType((*ReceiveStockRequest)(nil), "gen.ReceiveStockRequest")
+	proto.RegisterType((*AdjustStockRequest)(nil), "gen.AdjustStockRequest")
+	proto.RegisterType((*ListExpiringLotsRequest)(nil), "gen.ListExpiringLotsRequest")
+	proto.RegisterType((*StockLot)(nil), "gen.StockLot")
+	proto.RegisterType((*ListExpiringLotsResponse)(nil), "gen.ListExpiringLotsResponse")
 	proto.RegisterType((*GetWarehouseByShopIDRequest)(nil), "gen.GetWarehouseByShopIDRequest")
 	proto.RegisterType((*GetWarehouseByShopIDResponse)(nil), "gen.GetWarehouseByShopIDResponse")
 }
@@ -570,43 +1868,97 @@ func init() {
 func init() { proto.RegisterFile("warehouse.proto", fileDescriptor_a49842460749824d) }
 
 var fileDescriptor_a49842460749824d = []byte{
-	// 603 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x6e, 0xda, 0x4c,
-	0x10, 0x0d, 0x38, 0x5f, 0x82, 0x87, 0x7c, 0x90, 0x6e, 0x91, 0x0a, 0x4e, 0xd3, 0x12, 0xab, 0xaa,
-	0x48, 0x7f, 0xa0, 0x22, 0x52, 0xef, 0x8b, 0x12, 0x45, 0x48, 0x51, 0x2f, 0x4c, 0xa4, 0x54, 0xad,
-	0x2a, 0xe4, 0xd8, 0x13, 0xb0, 0x1a, 0x7b, 0x9d, 0xdd, 0x35, 0x11, 0x7d, 0xaf, 0xbe, 0x48, 0x9f,
-	0xa8, 0xf2, 0x1a, 0xaf, 0x0d, 0x18, 0x94, 0x3b, 0xcf, 0xef, 0x39, 0x3b, 0x73, 0xc6, 0x50, 0x7f,
-	0xb4, 0x19, 0x4e, 0x69, 0xc4, 0xb1, 0x1b, 0x32, 0x2a, 0x28, 0xd1, 0x26, 0x18, 0x18, 0x55, 0xf4,
-	0x43, 0x31, 0x4f, 0x3c, 0xe6, 0x00, 0xfe, 0x1b, 0x09, 0xea, 0xfc, 0x22, 0xc7, 0x00, 0x21, 0xa3,
-	0x6e, 0xe4, 0x88, 0xb1, 0xe7, 0x36, 0xcb, 0xed, 0x52, 0x47, 0xb7, 0xf4, 0x85, 0x67, 0xe8, 0x12,
-	0x03, 0x2a, 0x0f, 0x91, 0x1d, 0x08, 0x4f, 0xcc, 0x9b, 0x5a, 0xbb, 0xd4, 0xd1, 0x2c, 0x65, 0x9b,
-	0x3d, 0xd0, 0x65, 0x8f, 0x2b, 0x8f, 0x0b, 0x62, 0xc2, 0x1e, 0x8f, 0x0d, 0xde, 0x2c, 0xb5, 0xb5,
-	0x4e, 0xb5, 0x0f, 0xdd, 0x09, 0x06, 0x5d, 0x19, 0xb7, 0x16, 0x11, 0xb3, 0x0f, 0xf5, 0x4b, 0x14,
-	0x89, 0x0f, 0x1f, 0x22, 0xe4, 0x82, 0xbc, 0x86, 0x6a, 0x06, 0x9f, 0xd4, 0xea, 0x16, 0x28, 0x7c,
-	0x6e, 0x5e, 0xc3, 0x73, 0x0b, 0x39, 0xb2, 0x19, 0x2e, 0xd5, 0xb5, 0xa0, 0x42, 0x99, 0x8b, 0x2c,
-	0x26, 0x5d, 0x92, 0xa4, 0xf7, 0xa5, 0x3d, 0x74, 0x73, 0x4c, 0xca, 0x1b, 0x99, 0x9c, 0x43, 0x63,
-	0xb9, 0x2b, 0x0f, 0x69, 0xc0, 0x91, 0x7c, 0x00, 0xc2, 0x12, 0xbf, 0x3b, 0x96, 0xa9, 0x8a, 0x95,
-	0x66, 0x1d, 0xa6, 0x11, 0x59, 0x12, 0x73, 0xfb, 0x14, 0x73, 0xbb, 0x47, 0x9b, 0x3f, 0x95, 0x5b,
-	0x82, 0x9b, 0xaf, 0xc8, 0xe3, 0x4a, 0x7f, 0x21, 0x6e, 0x12, 0x51, 0xb8, 0x3f, 0xa0, 0x35, 0x42,
-	0x71, 0x93, 0x2e, 0x79, 0x24, 0x6c, 0x11, 0xf1, 0x14, 0xfd, 0x04, 0x0e, 0xd4, 0xfa, 0x53, 0x06,
-	0x9a, 0x55, 0x55, 0xbe, 0xa1, 0x4b, 0x8e, 0x40, 0xf7, 0xf8, 0xd8, 0x76, 0x84, 0x37, 0x43, 0xb9,
-	0xf2, 0x8a, 0x55, 0xf1, 0xf8, 0x17, 0x69, 0x9b, 0x7f, 0x4a, 0xf0, 0xe6, 0x9a, 0xd9, 0x01, 0xbf,
-	0x43, 0x26, 0x11, 0x07, 0x28, 0x1e, 0x11, 0x03, 0x05, 0x97, 0x02, 0xbd, 0x83, 0x67, 0x77, 0x8c,
-	0xfa, 0xe3, 0x02, 0xb4, 0x7a, 0x1c, 0xb8, 0xc9, 0x21, 0xbe, 0x85, 0xba, 0xa0, 0xcb, 0x99, 0x65,
-	0x99, 0xf9, 0xbf, 0xa0, 0xf9, 0xbc, 0x65, 0x35, 0x6a, 0xdb, 0xd4, 0xb8, 0xbb, 0xa2, 0xc6, 0x2b,
-	0xd0, 0x55, 0x27, 0x52, 0x83, 0xb2, 0x22, 0x53, 0xf6, 0x5c, 0x42, 0x60, 0x37, 0xb0, 0x7d, 0x5c,
-	0xe8, 0x5b, 0x7e, 0x2f, 0x4f, 0x41, 0x5b, 0x99, 0xc2, 0x67, 0x38, 0xba, 0xcc, 0x8d, 0x78, 0x30,
-	0x1f, 0x4d, 0x69, 0x38, 0x3c, 0x4f, 0xdf, 0xfe, 0x02, 0xf6, 0xf9, 0x94, 0x86, 0xd9, 0x8b, 0xf7,
-	0x62, 0x73, 0xe8, 0x9a, 0x5f, 0xe1, 0x65, 0x71, 0xdd, 0x62, 0xd1, 0x5d, 0x00, 0x35, 0x85, 0xf4,
-	0x54, 0x6a, 0x52, 0xa0, 0xd9, 0x7c, 0x73, 0x19, 0xfd, 0xbf, 0x1a, 0x1c, 0x66, 0x8b, 0x46, 0x36,
-	0xf3, 0x1c, 0x24, 0x67, 0xa0, 0xa7, 0x77, 0xc4, 0x49, 0x43, 0x56, 0xaf, 0xdc, 0x95, 0x51, 0xcb,
-	0x44, 0x1f, 0x9f, 0xa7, 0xb9, 0x43, 0x2e, 0xe0, 0x20, 0x2f, 0x79, 0xd2, 0x94, 0x19, 0x05, 0xb7,
-	0x65, 0xb4, 0x0a, 0x22, 0x09, 0xfd, 0xb4, 0x4d, 0xa6, 0x60, 0xd5, 0x66, 0xed, 0x0c, 0x54, 0x9b,
-	0x75, 0xb9, 0x9b, 0x3b, 0x64, 0x00, 0x64, 0x5d, 0xc2, 0xe4, 0x55, 0xc2, 0x7a, 0x93, 0xb6, 0x8d,
-	0xe4, 0x94, 0x2f, 0xe2, 0xff, 0x98, 0xb9, 0x43, 0xbe, 0xc1, 0xf1, 0x56, 0xa1, 0x92, 0x53, 0x99,
-	0xfe, 0x14, 0x31, 0xaf, 0x74, 0xfe, 0x09, 0x8d, 0xa2, 0x2d, 0x92, 0x76, 0x3a, 0xeb, 0x4d, 0xc2,
-	0x30, 0x4e, 0xb6, 0x64, 0xa4, 0x8f, 0x1f, 0xbc, 0xff, 0x7e, 0x3a, 0xf1, 0xc4, 0x34, 0xba, 0xed,
-	0x3a, 0xd4, 0xef, 0xe1, 0xbd, 0x1d, 0x4c, 0x18, 0xfe, 0xb6, 0x7b, 0xf8, 0xd1, 0xa1, 0xbe, 0x8f,
-	0xcc, 0xc1, 0x9e, 0xfc, 0x4b, 0xf7, 0x26, 0x18, 0xdc, 0xee, 0xc9, 0xcf, 0xb3, 0x7f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x0e, 0xf2, 0x31, 0x8b, 0xd5, 0x05, 0x00, 0x00,
+	// 1465 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0x1c, 0x45,
+	0x10, 0xf6, 0xec, 0xae, 0xbd, 0x3b, 0xe5, 0xf8, 0x91, 0x8e, 0x21, 0xbb, 0xe3, 0x98, 0x38, 0x23,
+	0x04, 0x09, 0x0f, 0x3b, 0x72, 0xa4, 0x5c, 0x78, 0x08, 0x9b, 0x38, 0x91, 0xa5, 0x10, 0x60, 0x1c,
+	0xc9, 0x08, 0x14, 0xad, 0xc6, 0x33, 0xed, 0xf5, 0x90, 0xdd, 0xe9, 0x4d, 0x77, 0xaf, 0x13, 0xf3,
+	0x3b, 0xb8, 0x70, 0x42, 0xe2, 0x07, 0x70, 0xe3, 0xce, 0x3f, 0xe0, 0xc6, 0x8d, 0x5f, 0xc0, 0x95,
+	0x1f, 0x80, 0xfa, 0x31, 0x8f, 0x9e, 0xc7, 0x7a, 0x2d, 0x04, 0xe2, 0x36, 0x55, 0xd5, 0xdd, 0x55,
+	0x5f, 0xbd, 0xba, 0x7a, 0x60, 0xe5, 0xa5, 0x4f, 0xf1, 0x29, 0x99, 0x30, 0xbc, 0x35, 0xa6, 0x84,
+	0x13, 0xd4, 0x1c, 0xe0, 0xd8, 0x59, 0xc4, 0xa3, 0x31, 0x3f, 0x57, 0x1c, 0x77, 0x0f, 0xe6, 0x0f,
+	0x39, 0x09, 0x9e, 0xa3, 0x0d, 0x80, 0x31, 0x25, 0xe1, 0x24, 0xe0, 0xfd, 0x28, 0xec, 0x36, 0x36,
+	0xad, 0xdb, 0xb6, 0x67, 0x6b, 0xce, 0x41, 0x88, 0x1c, 0xe8, 0xbc, 0x98, 0xf8, 0x31, 0x8f, 0xf8,
+	0x79, 0xb7, 0xb9, 0x69, 0xdd, 0x6e, 0x7a, 0x29, 0xed, 0x6e, 0x83, 0x2d, 0xcf, 0x78, 0x1c, 0x31,
+	0x8e, 0x5c, 0x58, 0x60, 0x82, 0x60, 0x5d, 0x6b, 0xb3, 0x79, 0x7b, 0x71, 0x07, 0xb6, 0x06, 0x38,
+	0xde, 0x92, 0x72, 0x4f, 0x4b, 0xdc, 0x1d, 0x58, 0x79, 0x84, 0xb9, 0xe2, 0xe1, 0x17, 0x13, 0xcc,
+	0x38, 0xba, 0x09, 0x8b, 0x99, 0x7a, 0xb5, 0xd7, 0xf6, 0x20, 0xd5, 0xcf, 0x5c, 0x06, 0xd7, 0x3c,
+	0xcc, 0x30, 0x3d, 0xc3, 0xc6, 0xbe, 0x1e, 0x74, 0x08, 0x0d, 0x31, 0x15, 0x46, 0x5b, 0xd2, 0xe8,
+	0xb6, 0xa4, 0x0f, 0xc2, 0x9c, 0x25, 0x8d, 0x3a, 0x4b, 0x04, 0x6a, 0xfc, 0x6a, 0x1c, 0x51, 0xcc,
+	0xfa, 0x3e, 0x97, 0xc0, 0x6c, 0xcf, 0xd6, 0x9c, 0x5d, 0xee, 0x06, 0xb0, 0x66, 0x2a, 0x65, 0x63,
+	0x12, 0x33, 0x8c, 0xde, 0x03, 0x44, 0x15, 0x3f, 0xec, 0xcb, 0x93, 0x52, 0xa3, 0x9b, 0xde, 0x6a,
+	0x22, 0x91, 0x5b, 0x0e, 0xc2, 0xa2, 0x92, 0x46, 0x51, 0xc9, 0x53, 0xe8, 0xee, 0xbf, 0xe2, 0x38,
+	0x0e, 0x95, 0x2a, 0x9f, 0x47, 0x24, 0x9e, 0x01, 0xde, 0x05, 0xa7, 0x9e, 0x42, 0xaf, 0xe2, 0xd4,
+	0x7f, 0xc3, 0xfe, 0x1d, 0x78, 0xed, 0x11, 0xe6, 0x97, 0x32, 0xde, 0xfd, 0xcd, 0x82, 0x25, 0x2f,
+	0xaf, 0x07, 0x2d, 0x43, 0x43, 0x2f, 0x6b, 0x7a, 0x8d, 0x28, 0x14, 0x9b, 0x13, 0xcb, 0xa4, 0xca,
+	0xa6, 0xd7, 0x66, 0xca, 0x20, 0x74, 0x0b, 0xae, 0xa4, 0x89, 0x2d, 0xc4, 0x2a, 0x1f, 0x17, 0x53,
+	0x9e, 0x72, 0x4e, 0x2e, 0x9b, 0x5b, 0xd3, 0xb2, 0x79, 0xde, 0xcc, 0x66, 0xf4, 0xba, 0x48, 0x1b,
+	0x9f, 0x4f, 0x58, 0x77, 0x41, 0x6e, 0xd3, 0x54, 0xc1, 0x0b, 0xed, 0xa2, 0x17, 0x7e, 0xb5, 0x60,
+	0x31, 0xe7, 0x83, 0x69, 0x91, 0xcb, 0x34, 0x34, 0x0c, 0x0d, 0x59, 0xc2, 0x36, 0x6b, 0x13, 0xf6,
+	0x03, 0x58, 0x31, 0x23, 0xc7, 0xba, 0x2d, 0xb9, 0x18, 0xc9, 0xc5, 0x86, 0x4f, 0xbd, 0x65, 0x23,
+	0x94, 0x12, 0x42, 0x40, 0xb1, 0xcf, 0x71, 0x28, 0x20, 0xcc, 0x2b, 0x08, 0x9a, 0xb3, 0xcb, 0xdd,
+	0xbb, 0xa2, 0xc4, 0x86, 0xd8, 0x67, 0xb3, 0x96, 0x98, 0xfb, 0x00, 0xd6, 0xcc, 0x1d, 0xf9, 0xfc,
+	0x92, 0xfc, 0xca, 0xfc, 0x52, 0x92, 0x24, 0xbf, 0x84, 0xde, 0x4f, 0x49, 0x7c, 0x12, 0xd1, 0xd1,
+	0xac, 0x7a, 0x1f, 0xc2, 0x9a, 0xb9, 0x43, 0xeb, 0xdd, 0x82, 0x6b, 0x81, 0xe2, 0x57, 0x28, 0xbe,
+	0x9a, 0x8a, 0x52, 0xcd, 0xdf, 0x5b, 0xb2, 0xab, 0x08, 0x72, 0xd6, 0xae, 0xb2, 0x0e, 0x36, 0xc5,
+	0x27, 0x93, 0x38, 0xcc, 0xda, 0x64, 0x47, 0x31, 0x0e, 0xc2, 0x99, 0x22, 0x58, 0xcc, 0xde, 0x56,
+	0x29, 0x7b, 0x05, 0x3c, 0xd3, 0xaa, 0x0c, 0x1e, 0x55, 0xfc, 0x2a, 0x78, 0xa9, 0x28, 0x85, 0xf7,
+	0x0d, 0xf4, 0x0e, 0x31, 0x3f, 0x4a, 0x4e, 0x3e, 0x94, 0x69, 0x96, 0x60, 0x2c, 0xda, 0x61, 0x95,
+	0xab, 0x68, 0x1d, 0xec, 0x88, 0xf5, 0xfd, 0x80, 0x47, 0x67, 0x58, 0x62, 0xed, 0x78, 0x9d, 0x88,
+	0xed, 0x4a, 0xda, 0xfd, 0xc5, 0x82, 0x37, 0x9f, 0x52, 0x3f, 0x66, 0x27, 0x98, 0x4a, 0x8d, 0x7b,
+	0x98, 0xbf, 0xc4, 0x38, 0x4e, 0xd5, 0x25, 0x8a, 0xde, 0x81, 0xab, 0x27, 0x94, 0x8c, 0xfa, 0x15,
+	0xda, 0x56, 0x84, 0xe0, 0x28, 0xa7, 0xf1, 0x2d, 0x58, 0xe1, 0xc4, 0x5c, 0xa9, 0x8a, 0x7f, 0x89,
+	0x93, 0xa3, 0xda, 0xfa, 0x6e, 0x4e, 0xab, 0xef, 0x56, 0xe1, 0xb6, 0x0a, 0xc1, 0x4e, 0x4f, 0x2a,
+	0x75, 0x1d, 0x04, 0xad, 0xd8, 0x1f, 0x61, 0x1d, 0x58, 0xf9, 0x6d, 0x7a, 0xa1, 0x69, 0x7a, 0x41,
+	0xd4, 0x32, 0xc5, 0x83, 0x88, 0xc4, 0xba, 0xc9, 0x68, 0xca, 0xfd, 0xd9, 0x82, 0x6b, 0x0f, 0x27,
+	0xc3, 0x93, 0x68, 0x38, 0xfc, 0x5c, 0x64, 0xce, 0x25, 0xbc, 0x9e, 0x4f, 0xbe, 0x46, 0x5d, 0xe7,
+	0x68, 0x1a, 0x9d, 0xa3, 0x0b, 0xed, 0xc0, 0xa7, 0x34, 0xc2, 0x54, 0x9b, 0x91, 0x90, 0xe8, 0x6d,
+	0x58, 0xe1, 0xd4, 0x0f, 0x9e, 0x47, 0xf1, 0xa0, 0x1f, 0x4f, 0x46, 0xc7, 0x98, 0xea, 0xba, 0x5f,
+	0x4e, 0xd8, 0x4f, 0x24, 0xd7, 0x7d, 0x06, 0xeb, 0x1e, 0x0e, 0x70, 0x74, 0x86, 0x3d, 0xcc, 0x27,
+	0x34, 0x4e, 0x7a, 0xc8, 0xa5, 0xb2, 0x85, 0xca, 0xad, 0x46, 0x65, 0x08, 0xc6, 0x41, 0xe8, 0xfe,
+	0x61, 0x41, 0x4f, 0xcc, 0x07, 0xf2, 0xd0, 0xcf, 0xc8, 0x19, 0x1e, 0xe1, 0x98, 0xa7, 0xb9, 0x68,
+	0x86, 0xd3, 0x2a, 0x86, 0xb3, 0xa8, 0xbc, 0x51, 0x56, 0x8e, 0xa0, 0xc5, 0xcf, 0xc7, 0x58, 0xfb,
+	0x45, 0x7e, 0xa3, 0x1b, 0xb2, 0x54, 0x31, 0xc5, 0x71, 0x80, 0x93, 0x3b, 0x20, 0x65, 0x88, 0x1d,
+	0x22, 0xfb, 0xb4, 0x3b, 0xe4, 0xb7, 0x48, 0x07, 0x4e, 0x74, 0xdf, 0x6f, 0x70, 0x82, 0xd6, 0x60,
+	0x7e, 0x18, 0x8d, 0x22, 0xd5, 0xee, 0x9b, 0x9e, 0x22, 0xc4, 0xce, 0xb1, 0x3f, 0xc0, 0xdd, 0x8e,
+	0x64, 0xca, 0x6f, 0xf7, 0x87, 0x06, 0x2c, 0x19, 0xd8, 0xfe, 0xdb, 0x0b, 0x2d, 0x81, 0x3f, 0x9f,
+	0x83, 0x9f, 0x2f, 0x82, 0x85, 0xc2, 0x25, 0xd7, 0x85, 0xf6, 0xb1, 0x3f, 0xf4, 0x85, 0x63, 0x14,
+	0xb4, 0x84, 0x14, 0x90, 0xfd, 0x80, 0x13, 0x2a, 0xd1, 0xd9, 0x9e, 0x22, 0x4c, 0x57, 0xda, 0x45,
+	0x57, 0x9a, 0xf7, 0x0a, 0x14, 0xef, 0x95, 0x1f, 0x2d, 0x70, 0xaa, 0x62, 0xaf, 0xbb, 0xda, 0x5d,
+	0xb0, 0x47, 0x09, 0xb3, 0x6b, 0xe5, 0x2e, 0x33, 0x63, 0xbd, 0x97, 0x2d, 0x12, 0x36, 0x72, 0xc2,
+	0xfd, 0xa1, 0xf6, 0xa3, 0x22, 0xc4, 0x08, 0x29, 0x3f, 0xfa, 0x22, 0x20, 0x4c, 0x3b, 0x11, 0x24,
+	0xeb, 0x0b, 0xc1, 0xc9, 0x83, 0x6e, 0x19, 0xa0, 0xdd, 0x3f, 0xe5, 0x3d, 0x20, 0xb3, 0xdf, 0xc8,
+	0xfa, 0x7f, 0x9e, 0x97, 0xd7, 0xa1, 0xcd, 0x4e, 0xc9, 0x38, 0x8b, 0xea, 0x82, 0x20, 0xa7, 0xb7,
+	0x28, 0xa1, 0x76, 0x48, 0xb8, 0x59, 0xaf, 0xf6, 0x90, 0x70, 0x55, 0xaa, 0x02, 0x28, 0x55, 0xc6,
+	0x4a, 0x7f, 0xab, 0x74, 0x85, 0x84, 0xb5, 0xcb, 0x2f, 0x1a, 0x55, 0x7e, 0xb7, 0x00, 0xed, 0x86,
+	0xdf, 0x4e, 0x18, 0xff, 0x3f, 0x80, 0x95, 0x1d, 0xd4, 0x67, 0x24, 0xd6, 0x40, 0x35, 0x25, 0x5b,
+	0x31, 0xe1, 0x58, 0xc3, 0x93, 0xdf, 0x05, 0xc7, 0xb4, 0x0b, 0x8e, 0x71, 0x09, 0x5c, 0x17, 0x79,
+	0xb6, 0x2f, 0x90, 0x46, 0xf1, 0xe0, 0x31, 0xc9, 0x3a, 0x0c, 0x82, 0x56, 0xe8, 0x9f, 0x33, 0x5d,
+	0x8f, 0xf2, 0x7b, 0x16, 0x44, 0xd3, 0xef, 0x19, 0xf7, 0x2f, 0x0b, 0x3a, 0xea, 0xe9, 0x43, 0xb8,
+	0x51, 0xe0, 0xd6, 0xf4, 0x02, 0xbf, 0xb4, 0xa6, 0xbc, 0x6b, 0x5b, 0x86, 0x6b, 0x2f, 0xc8, 0x95,
+	0x69, 0x4d, 0x60, 0x7a, 0x9a, 0x88, 0x72, 0x51, 0x44, 0x28, 0x7b, 0x41, 0xc7, 0x4b, 0x48, 0xf7,
+	0x23, 0xe8, 0x96, 0xfd, 0xac, 0xab, 0xf9, 0x16, 0xb4, 0x86, 0x24, 0x2d, 0xe4, 0xa5, 0xac, 0x90,
+	0x1f, 0x13, 0xee, 0x49, 0x91, 0x7b, 0x1f, 0xd6, 0x1f, 0xe5, 0xc6, 0x92, 0xbd, 0xf3, 0x43, 0x01,
+	0xe5, 0x41, 0x12, 0xaa, 0x1c, 0x54, 0x2b, 0x0f, 0xd5, 0x7d, 0x02, 0x37, 0xaa, 0xf7, 0xa5, 0xe3,
+	0x11, 0xa4, 0x1e, 0x4d, 0x0c, 0x58, 0x96, 0x06, 0xa4, 0x7b, 0xbc, 0xdc, 0x8a, 0x9d, 0x9f, 0x6c,
+	0x58, 0x4d, 0x25, 0x87, 0x98, 0x9e, 0x45, 0x01, 0x46, 0xf7, 0xc0, 0x4e, 0xde, 0xa6, 0x0c, 0xad,
+	0xc9, 0xdd, 0x85, 0xb7, 0xaa, 0xb3, 0x9c, 0x03, 0x15, 0x31, 0xee, 0xce, 0xa1, 0x7d, 0xb8, 0x92,
+	0x7f, 0x27, 0xa2, 0x6e, 0x7e, 0x18, 0x37, 0xf6, 0xf6, 0x2a, 0x24, 0xca, 0xfc, 0xe4, 0x98, 0x6c,
+	0x9c, 0x4e, 0x8f, 0x29, 0xcd, 0xe4, 0x4e, 0xaf, 0x42, 0x92, 0x1e, 0xf3, 0x09, 0x2c, 0x9b, 0x0f,
+	0x32, 0xe4, 0x24, 0x38, 0xca, 0xaf, 0x34, 0x67, 0x35, 0x67, 0x91, 0x14, 0xb8, 0x73, 0xe8, 0x29,
+	0x5c, 0x2d, 0x3d, 0x1e, 0xd1, 0x86, 0x5c, 0x58, 0xf7, 0x54, 0x75, 0xde, 0xa8, 0x13, 0xe7, 0xe1,
+	0xe5, 0xa7, 0x76, 0x0d, 0xaf, 0x62, 0xf4, 0x77, 0x7a, 0x15, 0x12, 0xd3, 0x4b, 0xd9, 0x74, 0x9c,
+	0x39, 0xbb, 0x38, 0xc6, 0x67, 0xce, 0x2e, 0x8d, 0xd2, 0xee, 0x1c, 0xda, 0x03, 0x54, 0x1e, 0x8e,
+	0x91, 0x42, 0x51, 0x3b, 0x35, 0x3b, 0x6a, 0xa2, 0xdf, 0x17, 0x7f, 0x50, 0xdc, 0x39, 0xf4, 0x15,
+	0x6c, 0x4c, 0x1d, 0x81, 0xd1, 0x1d, 0xb9, 0x7c, 0x96, 0x31, 0xb9, 0x70, 0xf2, 0x33, 0x58, 0xab,
+	0xca, 0x75, 0xb4, 0x99, 0x44, 0xb2, 0xae, 0x7c, 0x9c, 0x5b, 0x53, 0x56, 0xa4, 0xe0, 0x3f, 0x16,
+	0x3e, 0xcc, 0xee, 0xbb, 0xd4, 0x87, 0xa5, 0x2b, 0xd0, 0xa9, 0xb8, 0x8a, 0xdd, 0x39, 0xf4, 0x21,
+	0x2c, 0xe6, 0x6e, 0x10, 0x74, 0x5d, 0x2e, 0x2a, 0xdf, 0x29, 0x35, 0xbb, 0xbf, 0x84, 0xd5, 0x62,
+	0xff, 0x40, 0x37, 0xe4, 0xca, 0x9a, 0xf6, 0xed, 0x6c, 0xd4, 0x48, 0x53, 0x40, 0x47, 0x80, 0xca,
+	0x23, 0x86, 0x8e, 0x66, 0xed, 0xdc, 0xe9, 0xdc, 0xac, 0x95, 0xa7, 0x07, 0xdf, 0x87, 0x2b, 0xf9,
+	0x39, 0x5e, 0x7b, 0xaa, 0x62, 0xb4, 0x2f, 0x04, 0x50, 0xbe, 0xe1, 0xca, 0xf3, 0xb4, 0x0e, 0xe0,
+	0x94, 0x51, 0xdb, 0x3c, 0x67, 0xef, 0xdd, 0xaf, 0xef, 0x0c, 0x22, 0x7e, 0x3a, 0x39, 0xde, 0x0a,
+	0xc8, 0x68, 0x1b, 0x0f, 0xfd, 0x78, 0x40, 0xf1, 0x77, 0xfe, 0x36, 0x7e, 0x3f, 0x20, 0xa3, 0x11,
+	0xa6, 0x01, 0xde, 0x96, 0x7f, 0xf2, 0xb6, 0x07, 0x38, 0x3e, 0x5e, 0x90, 0x9f, 0xf7, 0xfe, 0x1e,
+	0x00, 0xf5, 0xa6, 0xda, 0x57, 0xf9, 0x13, 0x00, 0x00,
 }
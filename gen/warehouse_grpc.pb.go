@@ -22,9 +22,19 @@ const (
 	WarehouseService_GetStocks_FullMethodName                     = "/gen.WarehouseService/GetStocks"
 	WarehouseService_ReserveStock_FullMethodName                  = "/gen.WarehouseService/ReserveStock"
 	WarehouseService_ReleaseStock_FullMethodName                  = "/gen.WarehouseService/ReleaseStock"
+	WarehouseService_GetReservation_FullMethodName                = "/gen.WarehouseService/GetReservation"
+	WarehouseService_ExtendReservation_FullMethodName             = "/gen.WarehouseService/ExtendReservation"
+	WarehouseService_ConfirmStock_FullMethodName                  = "/gen.WarehouseService/ConfirmStock"
+	WarehouseService_RestockStock_FullMethodName                  = "/gen.WarehouseService/RestockStock"
 	WarehouseService_SetWarehouseStatus_FullMethodName            = "/gen.WarehouseService/SetWarehouseStatus"
 	WarehouseService_TransferStockBetweenWarehouse_FullMethodName = "/gen.WarehouseService/TransferStockBetweenWarehouse"
 	WarehouseService_GetWarehouseByShopID_FullMethodName          = "/gen.WarehouseService/GetWarehouseByShopID"
+	WarehouseService_ReceiveStock_FullMethodName                  = "/gen.WarehouseService/ReceiveStock"
+	WarehouseService_AdjustStock_FullMethodName                   = "/gen.WarehouseService/AdjustStock"
+	WarehouseService_ListExpiringLots_FullMethodName              = "/gen.WarehouseService/ListExpiringLots"
+	WarehouseService_ListStockMovements_FullMethodName            = "/gen.WarehouseService/ListStockMovements"
+	WarehouseService_FulfillOrder_FullMethodName                  = "/gen.WarehouseService/FulfillOrder"
+	WarehouseService_ReceiveReturnedStock_FullMethodName          = "/gen.WarehouseService/ReceiveReturnedStock"
 )
 
 // WarehouseServiceClient is the client API for WarehouseService service.
@@ -32,11 +42,32 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WarehouseServiceClient interface {
 	GetStocks(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*StockList, error)
+	// reserves the stock once per order, the same order returns its reservation again and is refused when the quantities are different
 	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	// releases the reserved stock of the order, the released order returns its released stock again
 	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error)
+	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// moves the expiry of the reserved stock of the order later, for the checkout that takes longer than the reservation ttl
+	ExtendReservation(ctx context.Context, in *ExtendReservationRequest, opts ...grpc.CallOption) (*ExtendReservationResponse, error)
+	// confirm the reserved stock of paid order, confirmed stock cannot be released
+	ConfirmStock(ctx context.Context, in *ConfirmStockRequest, opts ...grpc.CallOption) (*ConfirmStockResponse, error)
+	// returns the confirmed stock of the refunded order into the warehouse it is taken from, or into warehouse_id when set
+	RestockStock(ctx context.Context, in *RestockStockRequest, opts ...grpc.CallOption) (*RestockStockResponse, error)
 	SetWarehouseStatus(ctx context.Context, in *SetWarehouseStatusRequest, opts ...grpc.CallOption) (*Empty, error)
 	TransferStockBetweenWarehouse(ctx context.Context, in *TransferStockBetweenWarehouseRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetWarehouseByShopID(ctx context.Context, in *GetWarehouseByShopIDRequest, opts ...grpc.CallOption) (*GetWarehouseByShopIDResponse, error)
+	// ReceiveStock puts the received goods into the stock, it returns the RECEIVE movement
+	ReceiveStock(ctx context.Context, in *ReceiveStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// AdjustStock corrects the quantity of the stock, it returns the ADJUSTMENT movement
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockMovement, error)
+	// ListExpiringLots returns the lots in stock that expire within the days, the earliest expiry first
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error)
+	// ListStockMovements returns the ledger of the stock, oldest first
+	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
+	// moves the paid order that is shipped from the warehouse to the next fulfillment status
+	FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*Empty, error)
+	// receives the items of the approved return into the active warehouse, the order service refunds them
+	ReceiveReturnedStock(ctx context.Context, in *ReceiveReturnedStockRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type warehouseServiceClient struct {
@@ -77,6 +108,46 @@ func (c *warehouseServiceClient) ReleaseStock(ctx context.Context, in *ReleaseSt
 	return out, nil
 }
 
+func (c *warehouseServiceClient) GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, WarehouseService_GetReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ExtendReservation(ctx context.Context, in *ExtendReservationRequest, opts ...grpc.CallOption) (*ExtendReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendReservationResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_ExtendReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ConfirmStock(ctx context.Context, in *ConfirmStockRequest, opts ...grpc.CallOption) (*ConfirmStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmStockResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_ConfirmStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) RestockStock(ctx context.Context, in *RestockStockRequest, opts ...grpc.CallOption) (*RestockStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestockStockResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_RestockStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warehouseServiceClient) SetWarehouseStatus(ctx context.Context, in *SetWarehouseStatusRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -107,16 +178,97 @@ func (c *warehouseServiceClient) GetWarehouseByShopID(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *warehouseServiceClient) ReceiveStock(ctx context.Context, in *ReceiveStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, WarehouseService_ReceiveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockMovement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockMovement)
+	err := c.cc.Invoke(ctx, WarehouseService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpiringLotsResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_ListExpiringLots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockMovementsResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_ListStockMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, WarehouseService_FulfillOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ReceiveReturnedStock(ctx context.Context, in *ReceiveReturnedStockRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, WarehouseService_ReceiveReturnedStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WarehouseServiceServer is the server API for WarehouseService service.
 // All implementations must embed UnimplementedWarehouseServiceServer
 // for forward compatibility.
 type WarehouseServiceServer interface {
 	GetStocks(context.Context, *GetStockRequest) (*StockList, error)
+	// reserves the stock once per order, the same order returns its reservation again and is refused when the quantities are different
 	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	// releases the reserved stock of the order, the released order returns its released stock again
 	ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error)
+	GetReservation(context.Context, *GetReservationRequest) (*Reservation, error)
+	// moves the expiry of the reserved stock of the order later, for the checkout that takes longer than the reservation ttl
+	ExtendReservation(context.Context, *ExtendReservationRequest) (*ExtendReservationResponse, error)
+	// confirm the reserved stock of paid order, confirmed stock cannot be released
+	ConfirmStock(context.Context, *ConfirmStockRequest) (*ConfirmStockResponse, error)
+	// returns the confirmed stock of the refunded order into the warehouse it is taken from, or into warehouse_id when set
+	RestockStock(context.Context, *RestockStockRequest) (*RestockStockResponse, error)
 	SetWarehouseStatus(context.Context, *SetWarehouseStatusRequest) (*Empty, error)
 	TransferStockBetweenWarehouse(context.Context, *TransferStockBetweenWarehouseRequest) (*Empty, error)
 	GetWarehouseByShopID(context.Context, *GetWarehouseByShopIDRequest) (*GetWarehouseByShopIDResponse, error)
+	// ReceiveStock puts the received goods into the stock, it returns the RECEIVE movement
+	ReceiveStock(context.Context, *ReceiveStockRequest) (*StockMovement, error)
+	// AdjustStock corrects the quantity of the stock, it returns the ADJUSTMENT movement
+	AdjustStock(context.Context, *AdjustStockRequest) (*StockMovement, error)
+	// ListExpiringLots returns the lots in stock that expire within the days, the earliest expiry first
+	ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error)
+	// ListStockMovements returns the ledger of the stock, oldest first
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
+	// moves the paid order that is shipped from the warehouse to the next fulfillment status
+	FulfillOrder(context.Context, *FulfillOrderRequest) (*Empty, error)
+	// receives the items of the approved return into the active warehouse, the order service refunds them
+	ReceiveReturnedStock(context.Context, *ReceiveReturnedStockRequest) (*Empty, error)
 	mustEmbedUnimplementedWarehouseServiceServer()
 }
 
@@ -136,6 +288,18 @@ func (UnimplementedWarehouseServiceServer) ReserveStock(context.Context, *Reserv
 func (UnimplementedWarehouseServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
 }
+func (UnimplementedWarehouseServiceServer) GetReservation(context.Context, *GetReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ExtendReservation(context.Context, *ExtendReservationRequest) (*ExtendReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendReservation not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ConfirmStock(context.Context, *ConfirmStockRequest) (*ConfirmStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmStock not implemented")
+}
+func (UnimplementedWarehouseServiceServer) RestockStock(context.Context, *RestockStockRequest) (*RestockStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockStock not implemented")
+}
 func (UnimplementedWarehouseServiceServer) SetWarehouseStatus(context.Context, *SetWarehouseStatusRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetWarehouseStatus not implemented")
 }
@@ -145,6 +309,24 @@ func (UnimplementedWarehouseServiceServer) TransferStockBetweenWarehouse(context
 func (UnimplementedWarehouseServiceServer) GetWarehouseByShopID(context.Context, *GetWarehouseByShopIDRequest) (*GetWarehouseByShopIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWarehouseByShopID not implemented")
 }
+func (UnimplementedWarehouseServiceServer) ReceiveStock(context.Context, *ReceiveStockRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveStock not implemented")
+}
+func (UnimplementedWarehouseServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*StockMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockMovements not implemented")
+}
+func (UnimplementedWarehouseServiceServer) FulfillOrder(context.Context, *FulfillOrderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfillOrder not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ReceiveReturnedStock(context.Context, *ReceiveReturnedStockRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturnedStock not implemented")
+}
 func (UnimplementedWarehouseServiceServer) mustEmbedUnimplementedWarehouseServiceServer() {}
 func (UnimplementedWarehouseServiceServer) testEmbeddedByValue()                          {}
 
@@ -220,6 +402,78 @@ func _WarehouseService_ReleaseStock_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarehouseService_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_GetReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).GetReservation(ctx, req.(*GetReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ExtendReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ExtendReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ExtendReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ExtendReservation(ctx, req.(*ExtendReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ConfirmStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ConfirmStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ConfirmStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ConfirmStock(ctx, req.(*ConfirmStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_RestockStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).RestockStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_RestockStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).RestockStock(ctx, req.(*RestockStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WarehouseService_SetWarehouseStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetWarehouseStatusRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +528,114 @@ func _WarehouseService_GetWarehouseByShopID_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WarehouseService_ReceiveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ReceiveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ReceiveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ReceiveStock(ctx, req.(*ReceiveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ListExpiringLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ListExpiringLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ListExpiringLots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ListExpiringLots(ctx, req.(*ListExpiringLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ListStockMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_FulfillOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).FulfillOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_FulfillOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).FulfillOrder(ctx, req.(*FulfillOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ReceiveReturnedStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveReturnedStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ReceiveReturnedStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ReceiveReturnedStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ReceiveReturnedStock(ctx, req.(*ReceiveReturnedStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WarehouseService_ServiceDesc is the grpc.ServiceDesc for WarehouseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -293,6 +655,22 @@ var WarehouseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseStock",
 			Handler:    _WarehouseService_ReleaseStock_Handler,
 		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _WarehouseService_GetReservation_Handler,
+		},
+		{
+			MethodName: "ExtendReservation",
+			Handler:    _WarehouseService_ExtendReservation_Handler,
+		},
+		{
+			MethodName: "ConfirmStock",
+			Handler:    _WarehouseService_ConfirmStock_Handler,
+		},
+		{
+			MethodName: "RestockStock",
+			Handler:    _WarehouseService_RestockStock_Handler,
+		},
 		{
 			MethodName: "SetWarehouseStatus",
 			Handler:    _WarehouseService_SetWarehouseStatus_Handler,
@@ -305,6 +683,30 @@ var WarehouseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWarehouseByShopID",
 			Handler:    _WarehouseService_GetWarehouseByShopID_Handler,
 		},
+		{
+			MethodName: "ReceiveStock",
+			Handler:    _WarehouseService_ReceiveStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _WarehouseService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ListExpiringLots",
+			Handler:    _WarehouseService_ListExpiringLots_Handler,
+		},
+		{
+			MethodName: "ListStockMovements",
+			Handler:    _WarehouseService_ListStockMovements_Handler,
+		},
+		{
+			MethodName: "FulfillOrder",
+			Handler:    _WarehouseService_FulfillOrder_Handler,
+		},
+		{
+			MethodName: "ReceiveReturnedStock",
+			Handler:    _WarehouseService_ReceiveReturnedStock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "warehouse.proto",
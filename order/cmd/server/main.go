@@ -9,6 +9,7 @@ import (
 	"github.com/elangreza/e-commerce/pkg/config"
 	"github.com/elangreza/e-commerce/pkg/dbsql"
 	"github.com/elangreza/e-commerce/pkg/gracefulshutdown"
+	"github.com/elangreza/e-commerce/pkg/money"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -30,6 +31,18 @@ type Config struct {
 	ShopServiceAddr      string        `koanf:"SHOP_SERVICE_ADDR"`
 	PaymentServiceAddr   string        `koanf:"PAYMENT_SERVICE_ADDR"`
 	MaxTimeToBeExpired   time.Duration `koanf:"MAX_TIME_TO_BE_EXPIRED"`
+	// ReservationTTL is how long the warehouse service keeps the stock of the order reserved,
+	// it must be longer than the expiry of the order so the stock is released by the order first
+	ReservationTTL time.Duration `koanf:"RESERVATION_TTL"`
+
+	CompensationMaxAttempts int64         `koanf:"COMPENSATION_MAX_ATTEMPTS"`
+	CompensationBaseDelay   time.Duration `koanf:"COMPENSATION_BASE_DELAY"`
+	CompensationMaxDelay    time.Duration `koanf:"COMPENSATION_MAX_DELAY"`
+
+	// DefaultCurrency is the settlement currency of guests and users without preference
+	DefaultCurrency string `koanf:"DEFAULT_CURRENCY"`
+	// ExchangeRateFile loads static rates from a json file, the exchange_rates table is used when empty
+	ExchangeRateFile string `koanf:"EXCHANGE_RATE_FILE"`
 }
 
 func main() {
@@ -53,8 +66,43 @@ func main() {
 	)
 	errChecker(err)
 
+	retryPolicy := service.CompensationRetryPolicy{
+		MaxAttempts: cfg.CompensationMaxAttempts,
+		BaseDelay:   cfg.CompensationBaseDelay,
+		MaxDelay:    cfg.CompensationMaxDelay,
+	}
+	if retryPolicy.MaxAttempts <= 0 {
+		retryPolicy.MaxAttempts = 5
+	}
+	if retryPolicy.BaseDelay <= 0 {
+		retryPolicy.BaseDelay = 10 * time.Second
+	}
+	if retryPolicy.MaxDelay <= 0 {
+		retryPolicy.MaxDelay = 10 * time.Minute
+	}
+
+	defaultCurrency := cfg.DefaultCurrency
+	if defaultCurrency == "" {
+		defaultCurrency = "IDR"
+	}
+
+	reservationTTL := cfg.ReservationTTL
+	if reservationTTL <= 0 {
+		reservationTTL = 15 * time.Minute
+	}
+
+	var rateProvider money.ExchangeRateProvider = money.NewDBRateProvider(db)
+	if cfg.ExchangeRateFile != "" {
+		rateProvider, err = money.NewStaticRateProviderFromFile(cfg.ExchangeRateFile)
+		errChecker(err)
+	}
+
 	cartRepo := sqlitedb.NewCartRepository(db)
 	orderRepo := sqlitedb.NewOrderRepository(db)
+	sagaRepo := sqlitedb.NewSagaRepository(db)
+	promotionRepo := sqlitedb.NewPromotionRepository(db)
+	pricingRepo := sqlitedb.NewPricingRepository(db)
+	returnRepo := sqlitedb.NewReturnRepository(db)
 
 	// grpc clients
 	grpcClientProduct, err := grpc.NewClient(cfg.ProductServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -67,9 +115,26 @@ func main() {
 	orderService := service.NewOrderService(
 		orderRepo,
 		cartRepo,
+		sagaRepo,
+		promotionRepo,
+		pricingRepo,
+		returnRepo,
 		gen.NewWarehouseServiceClient(grpcClientWarehouse),
 		gen.NewProductServiceClient(grpcClientProduct),
-		gen.NewPaymentServiceClient(grpcClientPayment))
+		gen.NewPaymentServiceClient(grpcClientPayment),
+		retryPolicy,
+		rateProvider,
+		defaultCurrency,
+		reservationTTL)
+
+	// continue or compensate the orders that were interrupted by the previous shutdown
+	resumedSagas, err := orderService.ResumeSagas(context.Background())
+	if err != nil {
+		fmt.Println("getting error from ResumeSagas", err)
+	}
+	if resumedSagas > 0 {
+		fmt.Printf("resuming %d saga(s)\n", resumedSagas)
+	}
 
 	srv := server.New(orderService)
 	addr := fmt.Sprintf(":%s", cfg.ServicePort)
@@ -0,0 +1,30 @@
+package constanta
+
+type CartIssueReason string
+
+const (
+	// the price in the catalog is different from the price when the product was added
+	CartIssueReasonPriceChanged CartIssueReason = "PRICE_CHANGED"
+	// the stock is lower than the quantity in the cart
+	CartIssueReasonInsufficientStock CartIssueReason = "INSUFFICIENT_STOCK"
+	// the product is removed from the catalog
+	CartIssueReasonUnavailable CartIssueReason = "UNAVAILABLE"
+	// the coupon in the cart cannot be used anymore, e.g., expired or the usage limit is reached
+	CartIssueReasonCouponNotApplicable CartIssueReason = "COUPON_NOT_APPLICABLE"
+)
+
+// return string
+func (cir CartIssueReason) String() string {
+	switch cir {
+	case CartIssueReasonPriceChanged:
+		return "PRICE_CHANGED"
+	case CartIssueReasonInsufficientStock:
+		return "INSUFFICIENT_STOCK"
+	case CartIssueReasonUnavailable:
+		return "UNAVAILABLE"
+	case CartIssueReasonCouponNotApplicable:
+		return "COUPON_NOT_APPLICABLE"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -11,8 +11,15 @@ const (
 	OrderStatusPending       OrderStatus = "PENDING"
 	OrderStatusStockReserved OrderStatus = "STOCK_RESERVED"
 	OrderStatusCompleted     OrderStatus = "COMPLETED" // when customer pays the bills
-	OrderStatusCancelled     OrderStatus = "CANCELLED" // when the warehouse is inactive, cancelled all order
+	OrderStatusCancelled     OrderStatus = "CANCELLED" // cancelled by the customer or when the warehouse is inactive
 	OrderStatusFailed        OrderStatus = "FAILED"    // when customer exceeded the expiry order
+	// fulfillment of the paid order, driven by the warehouse service
+	OrderStatusPacked    OrderStatus = "PACKED"
+	OrderStatusShipped   OrderStatus = "SHIPPED"
+	OrderStatusDelivered OrderStatus = "DELIVERED"
+	// refund of the paid order by the customer service
+	OrderStatusPartiallyRefunded OrderStatus = "PARTIALLY_REFUNDED"
+	OrderStatusRefunded          OrderStatus = "REFUNDED"
 )
 
 // return string
@@ -28,11 +35,44 @@ func (ps OrderStatus) String() string {
 		return "CANCELLED"
 	case OrderStatusFailed:
 		return "FAILED"
+	case OrderStatusPacked:
+		return "PACKED"
+	case OrderStatusShipped:
+		return "SHIPPED"
+	case OrderStatusDelivered:
+		return "DELIVERED"
+	case OrderStatusPartiallyRefunded:
+		return "PARTIALLY_REFUNDED"
+	case OrderStatusRefunded:
+		return "REFUNDED"
 	default:
 		return "UNKNOWN"
 	}
 }
 
+// IsFulfillment reports whether the status is set by the warehouse service after the order is paid
+func (ps OrderStatus) IsFulfillment() bool {
+	switch ps {
+	case OrderStatusPacked, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderActor is who changed the status of the order, it is recorded in the status history
+type OrderActor string
+
+const (
+	OrderActorCustomer  OrderActor = "CUSTOMER"
+	OrderActorPayment   OrderActor = "PAYMENT_SERVICE"
+	OrderActorWarehouse OrderActor = "WAREHOUSE_SERVICE"
+	// refunds of the paid order
+	OrderActorCustomerService OrderActor = "CUSTOMER_SERVICE"
+	// the expiry worker and the saga recovery
+	OrderActorSystem OrderActor = "SYSTEM"
+)
+
 // Implement driver.Valuer interface for writing to database
 func (ps OrderStatus) Value() (driver.Value, error) {
 	return string(ps), nil
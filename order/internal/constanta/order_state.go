@@ -0,0 +1,65 @@
+package constanta
+
+// OrderTransition is a legal status change of the order with its side effects.
+// Before are the saga steps that must succeed before the status is changed,
+// After are the compensations that are run once the status is changed
+type OrderTransition struct {
+	From   OrderStatus
+	To     OrderStatus
+	Before []SagaStep
+	After  []SagaStep
+}
+
+// orderStateMachine is every legal transition of the order, the order is created as PENDING
+var orderStateMachine = []OrderTransition{
+	{From: "", To: OrderStatusPending},
+	// the create order saga reserved the stock and created the payment
+	{From: OrderStatusPending, To: OrderStatusStockReserved},
+	// the create order saga failed or the order expired, the compensations depend on the progress of the saga
+	{From: OrderStatusPending, To: OrderStatusFailed},
+	// the stock is sold, it cannot be released anymore
+	{From: OrderStatusStockReserved, To: OrderStatusCompleted, Before: []SagaStep{SagaStepConfirmStock}},
+	// the order expired before it is fully paid, the waiting payments are cancelled and the paid ones are refunded.
+	// a failed payment does not fail the order, the customer can pay it with another payment
+	{From: OrderStatusStockReserved, To: OrderStatusFailed, After: []SagaStep{SagaStepRollbackPayment, SagaStepReleaseStock}},
+	// the payments are cancelled first, the paid part of the order is refunded
+	{From: OrderStatusStockReserved, To: OrderStatusCancelled, Before: []SagaStep{SagaStepCancelPayment}, After: []SagaStep{SagaStepReleaseStock}},
+	// fulfillment by the warehouse service
+	{From: OrderStatusCompleted, To: OrderStatusPacked},
+	{From: OrderStatusPacked, To: OrderStatusShipped},
+	{From: OrderStatusShipped, To: OrderStatusDelivered},
+	// refund by the customer service or of the received return.
+	// only the items that are not shipped yet or are returned are put back into the warehouse
+	{From: OrderStatusCompleted, To: OrderStatusPartiallyRefunded},
+	{From: OrderStatusCompleted, To: OrderStatusRefunded},
+	{From: OrderStatusPacked, To: OrderStatusPartiallyRefunded},
+	{From: OrderStatusPacked, To: OrderStatusRefunded},
+	{From: OrderStatusShipped, To: OrderStatusPartiallyRefunded},
+	{From: OrderStatusShipped, To: OrderStatusRefunded},
+	{From: OrderStatusDelivered, To: OrderStatusPartiallyRefunded},
+	{From: OrderStatusDelivered, To: OrderStatusRefunded},
+	{From: OrderStatusPartiallyRefunded, To: OrderStatusPartiallyRefunded},
+	{From: OrderStatusPartiallyRefunded, To: OrderStatusRefunded},
+	// the remaining items of the partially refunded order are still fulfilled, the progress is kept by the shipment
+	{From: OrderStatusPartiallyRefunded, To: OrderStatusPacked},
+	{From: OrderStatusPartiallyRefunded, To: OrderStatusShipped},
+	{From: OrderStatusPartiallyRefunded, To: OrderStatusDelivered},
+}
+
+// FindOrderTransition returns the transition of the order from the status to the next status,
+// false when the order cannot be moved
+func FindOrderTransition(from, to OrderStatus) (OrderTransition, bool) {
+	for _, transition := range orderStateMachine {
+		if transition.From == from && transition.To == to {
+			return transition, true
+		}
+	}
+
+	return OrderTransition{}, false
+}
+
+// CanTransitionTo reports whether the order with the status can be moved to the next status
+func (ps OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	_, ok := FindOrderTransition(ps, next)
+	return ok
+}
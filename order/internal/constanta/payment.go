@@ -12,6 +12,11 @@ const (
 	PAID PaymentStatus = "PAID"
 	// if payment amount is less or more the actual amount or payment expired
 	FAILED PaymentStatus = "FAILED"
+	// the statuses below are only known from the payments of the order, they are never sent with the callback
+	WAITING            PaymentStatus = "WAITING"
+	CANCELLED          PaymentStatus = "CANCELLED"
+	PARTIALLY_REFUNDED PaymentStatus = "PARTIALLY_REFUNDED"
+	REFUNDED           PaymentStatus = "REFUNDED"
 )
 
 // Implement driver.Valuer interface for writing to database
@@ -46,6 +51,14 @@ func (ps PaymentStatus) String() string {
 		return "PAID"
 	case FAILED:
 		return "FAILED"
+	case WAITING:
+		return "WAITING"
+	case CANCELLED:
+		return "CANCELLED"
+	case PARTIALLY_REFUNDED:
+		return "PARTIALLY_REFUNDED"
+	case REFUNDED:
+		return "REFUNDED"
 	default:
 		return "UNKNOWN"
 	}
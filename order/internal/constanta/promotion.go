@@ -0,0 +1,51 @@
+package constanta
+
+type PromotionType string
+
+const (
+	// percentage off the eligible items
+	PromotionTypePercentage PromotionType = "PERCENTAGE"
+	// fixed amount off the eligible items, split by the total of each item
+	PromotionTypeFixedAmount PromotionType = "FIXED_AMOUNT"
+	// buy X units of a product and get Y units of the same product free
+	PromotionTypeBuyXGetY PromotionType = "BUY_X_GET_Y"
+)
+
+// return string
+func (pt PromotionType) String() string {
+	switch pt {
+	case PromotionTypePercentage:
+		return "PERCENTAGE"
+	case PromotionTypeFixedAmount:
+		return "FIXED_AMOUNT"
+	case PromotionTypeBuyXGetY:
+		return "BUY_X_GET_Y"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+type PromotionScope string
+
+const (
+	// every item in the cart
+	PromotionScopeOrder PromotionScope = "ORDER"
+	// items sold by the shop of scope_id
+	PromotionScopeShop PromotionScope = "SHOP"
+	// the product of scope_id
+	PromotionScopeProduct PromotionScope = "PRODUCT"
+)
+
+// return string
+func (ps PromotionScope) String() string {
+	switch ps {
+	case PromotionScopeOrder:
+		return "ORDER"
+	case PromotionScopeShop:
+		return "SHOP"
+	case PromotionScopeProduct:
+		return "PRODUCT"
+	default:
+		return "UNKNOWN"
+	}
+}
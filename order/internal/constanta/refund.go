@@ -0,0 +1,55 @@
+package constanta
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type RefundStatus string
+
+const (
+	// the refund is recorded, the payment is not refunded yet
+	RefundStatusPending RefundStatus = "PENDING"
+	// the payment is refunded
+	RefundStatusCompleted RefundStatus = "COMPLETED"
+	// the payment service refused the refund
+	RefundStatusFailed RefundStatus = "FAILED"
+)
+
+// return string
+func (rs RefundStatus) String() string {
+	switch rs {
+	case RefundStatusPending:
+		return "PENDING"
+	case RefundStatusCompleted:
+		return "COMPLETED"
+	case RefundStatusFailed:
+		return "FAILED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Implement driver.Valuer interface for writing to database
+func (rs RefundStatus) Value() (driver.Value, error) {
+	return string(rs), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (rs *RefundStatus) Scan(value interface{}) error {
+	if value == nil {
+		*rs = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*rs = RefundStatus(v)
+	case []byte:
+		*rs = RefundStatus(v)
+	default:
+		return fmt.Errorf("cannot scan %T into RefundStatus", value)
+	}
+
+	return nil
+}
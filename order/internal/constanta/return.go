@@ -0,0 +1,68 @@
+package constanta
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type ReturnStatus string
+
+const (
+	// the customer asks to return the items
+	ReturnStatusRequested ReturnStatus = "REQUESTED"
+	// the admin accepts the return, the customer can send the items back
+	ReturnStatusApproved ReturnStatus = "APPROVED"
+	// the admin refuses the return
+	ReturnStatusRejected ReturnStatus = "REJECTED"
+	// the items are put into the warehouse, the refund is not completed yet
+	ReturnStatusReceived ReturnStatus = "RECEIVED"
+	// the items are refunded
+	ReturnStatusRefunded ReturnStatus = "REFUNDED"
+)
+
+// return string
+func (rs ReturnStatus) String() string {
+	switch rs {
+	case ReturnStatusRequested:
+		return "REQUESTED"
+	case ReturnStatusApproved:
+		return "APPROVED"
+	case ReturnStatusRejected:
+		return "REJECTED"
+	case ReturnStatusReceived:
+		return "RECEIVED"
+	case ReturnStatusRefunded:
+		return "REFUNDED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IsOpen reports whether the items of the return are not refunded yet and cannot be returned again
+func (rs ReturnStatus) IsOpen() bool {
+	return rs == ReturnStatusRequested || rs == ReturnStatusApproved || rs == ReturnStatusReceived
+}
+
+// Implement driver.Valuer interface for writing to database
+func (rs ReturnStatus) Value() (driver.Value, error) {
+	return string(rs), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (rs *ReturnStatus) Scan(value interface{}) error {
+	if value == nil {
+		*rs = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*rs = ReturnStatus(v)
+	case []byte:
+		*rs = ReturnStatus(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ReturnStatus", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package constanta
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type SagaStep string
+
+const (
+	// forward steps of create order saga
+	SagaStepReserveStock   SagaStep = "RESERVE_STOCK"
+	SagaStepProcessPayment SagaStep = "PROCESS_PAYMENT"
+	// compensation steps of create order saga
+	SagaStepReleaseStock    SagaStep = "RELEASE_STOCK"
+	SagaStepRollbackPayment SagaStep = "ROLLBACK_PAYMENT"
+	// compensation of the completed order that is paid more than its total amount
+	SagaStepRefundOverpayment SagaStep = "REFUND_OVERPAYMENT"
+	// forward steps of cancel order saga
+	SagaStepCancelPayment SagaStep = "CANCEL_PAYMENT"
+	// forward steps of paid order
+	SagaStepConfirmStock SagaStep = "CONFIRM_STOCK"
+	// steps of refund saga, they are recorded per refund and retried until they succeed
+	SagaStepRestockRefund  SagaStep = "RESTOCK_REFUND"
+	SagaStepRefundPayment  SagaStep = "REFUND_PAYMENT"
+	SagaStepCompleteRefund SagaStep = "COMPLETE_REFUND"
+)
+
+// return string
+func (ss SagaStep) String() string {
+	switch ss {
+	case SagaStepReserveStock:
+		return "RESERVE_STOCK"
+	case SagaStepProcessPayment:
+		return "PROCESS_PAYMENT"
+	case SagaStepReleaseStock:
+		return "RELEASE_STOCK"
+	case SagaStepRollbackPayment:
+		return "ROLLBACK_PAYMENT"
+	case SagaStepRefundOverpayment:
+		return "REFUND_OVERPAYMENT"
+	case SagaStepCancelPayment:
+		return "CANCEL_PAYMENT"
+	case SagaStepConfirmStock:
+		return "CONFIRM_STOCK"
+	case SagaStepRestockRefund:
+		return "RESTOCK_REFUND"
+	case SagaStepRefundPayment:
+		return "REFUND_PAYMENT"
+	case SagaStepCompleteRefund:
+		return "COMPLETE_REFUND"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IsCompensation reports whether the step undoes a previous forward step or the overpayment of the order
+func (ss SagaStep) IsCompensation() bool {
+	return ss == SagaStepReleaseStock || ss == SagaStepRollbackPayment || ss == SagaStepRefundOverpayment
+}
+
+// IsRefund reports whether the step belongs to the refund saga
+func (ss SagaStep) IsRefund() bool {
+	return ss == SagaStepRestockRefund || ss == SagaStepRefundPayment || ss == SagaStepCompleteRefund
+}
+
+// IsRetried reports whether the failed step is retried with backoff, which are the compensations and the steps of the refund saga
+func (ss SagaStep) IsRetried() bool {
+	return ss.IsCompensation() || ss.IsRefund()
+}
+
+// Implement driver.Valuer interface for writing to database
+func (ss SagaStep) Value() (driver.Value, error) {
+	return string(ss), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (ss *SagaStep) Scan(value interface{}) error {
+	if value == nil {
+		*ss = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*ss = SagaStep(v)
+	case []byte:
+		*ss = SagaStep(v)
+	default:
+		return fmt.Errorf("cannot scan %T into SagaStep", value)
+	}
+
+	return nil
+}
+
+type SagaStepStatus string
+
+const (
+	SagaStepStatusStarted    SagaStepStatus = "STARTED"     // step is running, or the service died while running it
+	SagaStepStatusSucceeded  SagaStepStatus = "SUCCEEDED"   // step is done
+	SagaStepStatusFailed     SagaStepStatus = "FAILED"      // step returned an error
+	SagaStepStatusDeadLetter SagaStepStatus = "DEAD_LETTER" // compensation exceeded the retry attempts, must be handled manually
+)
+
+// return string
+func (ss SagaStepStatus) String() string {
+	switch ss {
+	case SagaStepStatusStarted:
+		return "STARTED"
+	case SagaStepStatusSucceeded:
+		return "SUCCEEDED"
+	case SagaStepStatusFailed:
+		return "FAILED"
+	case SagaStepStatusDeadLetter:
+		return "DEAD_LETTER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Implement driver.Valuer interface for writing to database
+func (ss SagaStepStatus) Value() (driver.Value, error) {
+	return string(ss), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (ss *SagaStepStatus) Scan(value interface{}) error {
+	if value == nil {
+		*ss = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*ss = SagaStepStatus(v)
+	case []byte:
+		*ss = SagaStepStatus(v)
+	default:
+		return fmt.Errorf("cannot scan %T into SagaStepStatus", value)
+	}
+
+	return nil
+}
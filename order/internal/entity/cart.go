@@ -1,14 +1,39 @@
 package entity
 
 import (
+	"github.com/elangreza/e-commerce/pkg/money"
+
 	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type Cart struct {
 	ID     uuid.UUID
 	UserID uuid.UUID
-	Items  []CartItem
+	// CartToken is set for guest carts, the token is issued by the api gateway
+	// and the cart is merged into the user cart after login
+	CartToken string
+	Items     []CartItem
+	// Subtotal is the sum of the current price of the available items in the currency of the user
+	Subtotal *gen.Money
+	// CouponCode is kept in the cart until the order is created
+	CouponCode string
+	// Discount and Total (Subtotal - Discount) are in the currency of the user
+	Discount *gen.Money
+	Total    *gen.Money
+	// CouponIssue is the reason the coupon in the cart cannot be applied anymore
+	CouponIssue string
+	// Tax, Shipping and GrandTotal are only calculated when the order is created,
+	// GrandTotal = Total + exclusive tax + Shipping
+	Tax        *gen.Money
+	Shipping   *gen.Money
+	GrandTotal *gen.Money
+}
+
+func (c *Cart) IsGuest() bool {
+	return c.UserID == uuid.Nil
 }
 
 type CartItem struct {
@@ -25,6 +50,30 @@ type CartItem struct {
 	// so the result is deficit 1 qty
 	// must be appeared warning message in FE
 	ActualStock int64
+	// CurrentPrice is the price in the catalog, Price is the snapshot when the product was added
+	CurrentPrice *gen.Money
+	// Unavailable is true when the product is no longer in the catalog
+	Unavailable bool
+	// SettlementTotal is CurrentPrice * Quantity converted with ExchangeRate into the currency of the user
+	SettlementTotal *gen.Money
+	ExchangeRate    decimal.Decimal
+	// ShopID is the shop of the product, used by the promotion scope and the tax rules
+	ShopID   int64
+	Category string
+	// Discount is the part of the coupon discount for the item in the currency of the user
+	Discount *gen.Money
+	// Tax is calculated after the discount with TaxRate in percent
+	Tax          *gen.Money
+	TaxRate      decimal.Decimal
+	TaxInclusive bool
+}
+
+func (ci *CartItem) IsPriceChanged() bool {
+	return !ci.Unavailable && !money.Equals(ci.Price, ci.CurrentPrice)
+}
+
+func (ci *CartItem) IsStockInsufficient() bool {
+	return !ci.Unavailable && ci.ActualStock < ci.Quantity
 }
 
 func (c *Cart) GetProductIDs() []string {
@@ -42,8 +91,13 @@ func (c *Cart) GetProductIDs() []string {
 
 func (c *Cart) GetGenCart() *gen.Cart {
 	res := &gen.Cart{
-		Id:    c.ID.String(),
-		Items: []*gen.CartItem{},
+		Id:          c.ID.String(),
+		Items:       []*gen.CartItem{},
+		Subtotal:    c.Subtotal,
+		CouponCode:  c.CouponCode,
+		Discount:    c.Discount,
+		Total:       c.Total,
+		CouponIssue: c.CouponIssue,
 	}
 
 	if len(c.Items) == 0 {
@@ -52,13 +106,64 @@ func (c *Cart) GetGenCart() *gen.Cart {
 
 	for _, items := range c.Items {
 		res.Items = append(res.Items, &gen.CartItem{
-			ProductId:   items.ProductID,
-			Quantity:    items.Quantity,
-			Name:        items.Name,
-			Price:       items.Price,
-			ActualStock: items.ActualStock,
+			ProductId:         items.ProductID,
+			Quantity:          items.Quantity,
+			Name:              items.Name,
+			Price:             items.Price,
+			ActualStock:       items.ActualStock,
+			CurrentPrice:      items.CurrentPrice,
+			PriceChanged:      items.IsPriceChanged(),
+			InsufficientStock: items.IsStockInsufficient(),
+			Unavailable:       items.Unavailable,
+			SettlementTotal:   items.SettlementTotal,
+			Discount:          items.Discount,
 		})
 	}
 
 	return res
 }
+
+// GetIssues lists the items that must be reviewed before the cart can be ordered
+func (c *Cart) GetIssues() []*gen.CartIssue {
+	issues := []*gen.CartIssue{}
+	for _, item := range c.Items {
+		if item.Unavailable {
+			issues = append(issues, &gen.CartIssue{
+				ProductId: item.ProductID,
+				Reason:    constanta.CartIssueReasonUnavailable.String(),
+				OldPrice:  item.Price,
+				Quantity:  item.Quantity,
+			})
+			continue
+		}
+
+		if item.IsPriceChanged() {
+			issues = append(issues, &gen.CartIssue{
+				ProductId: item.ProductID,
+				Reason:    constanta.CartIssueReasonPriceChanged.String(),
+				OldPrice:  item.Price,
+				NewPrice:  item.CurrentPrice,
+				Quantity:  item.Quantity,
+			})
+		}
+
+		if item.IsStockInsufficient() {
+			issues = append(issues, &gen.CartIssue{
+				ProductId:   item.ProductID,
+				Reason:      constanta.CartIssueReasonInsufficientStock.String(),
+				Quantity:    item.Quantity,
+				ActualStock: item.ActualStock,
+			})
+		}
+	}
+
+	if c.CouponIssue != "" {
+		issues = append(issues, &gen.CartIssue{
+			Reason:     constanta.CartIssueReasonCouponNotApplicable.String(),
+			CouponCode: c.CouponCode,
+			Message:    c.CouponIssue,
+		})
+	}
+
+	return issues
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/order/internal/constanta"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type Order struct {
@@ -14,10 +15,34 @@ type Order struct {
 	UserID         uuid.UUID             `json:"user_id" db:"user_id"` // can be uuid
 	Status         constanta.OrderStatus `json:"status" db:"status"`
 	TotalAmount    *gen.Money            `json:"total_amount" db:"total_amount"`
+	// SubtotalAmount is the amount before the coupon discount,
+	// TotalAmount = SubtotalAmount - DiscountAmount + exclusive tax + ShippingAmount
+	SubtotalAmount *gen.Money `json:"subtotal_amount" db:"subtotal_amount"`
+	DiscountAmount *gen.Money `json:"discount_amount" db:"discount_amount"`
+	CouponCode     string     `json:"coupon_code" db:"coupon_code"`
+	// TaxAmount is the tax of the items, both inclusive and exclusive
+	TaxAmount      *gen.Money `json:"tax_amount" db:"tax_amount"`
+	ShippingAmount *gen.Money `json:"shipping_amount" db:"shipping_amount"`
+	ShippingRegion string     `json:"shipping_region" db:"shipping_region"`
+	// Shipment is nil for the order without shipping address
+	Shipment *Shipment `json:"shipment" db:"-"`
+	// StatusHistory is only loaded for the detail of the order
+	StatusHistory []OrderStatusHistory `json:"status_history" db:"-"`
+	// Refunds is only loaded for the detail of the order
+	Refunds []Refund `json:"refunds" db:"-"`
+	// Returns is only loaded for the detail of the order
+	Returns []Return `json:"returns" db:"-"`
+	// Payments and PaidAmount are only loaded from the payment service for the detail of the order
+	Payments   []OrderPayment `json:"payments" db:"-"`
+	PaidAmount *gen.Money     `json:"paid_amount" db:"-"`
+	// PromotionID is only set when the order is created, the usage of the promotion is recorded with it
+	PromotionID uuid.UUID `json:"-" db:"-"`
 	// TransactionID is available after payment is processed, and successfully created
-	TransactionID string     `json:"transaction_id" db:"transaction_id"`
-	CreatedAt     *time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     *time.Time `json:"updated_at" db:"updated_at"`
+	TransactionID string `json:"transaction_id" db:"transaction_id"`
+	// CancelReason is only available when the order is cancelled
+	CancelReason string     `json:"cancel_reason" db:"cancel_reason"`
+	CreatedAt    *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at" db:"updated_at"`
 
 	Items []OrderItem
 }
@@ -30,18 +55,54 @@ type OrderItem struct {
 	PricePerUnit      *gen.Money `json:"price_per_unit" db:"price_per_unit"`
 	Quantity          int64      `json:"quantity" db:"quantity"`
 	TotalPricePerUnit *gen.Money `json:"total_price" db:"total_price"`
+	// SettlementTotal is TotalPricePerUnit converted into the currency of the order total amount
+	SettlementTotal *gen.Money      `json:"settlement_total" db:"settlement_total_units"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate" db:"exchange_rate"`
+	// Discount is the part of the coupon discount in the currency of the order total amount
+	Discount *gen.Money `json:"discount" db:"discount_units"`
+	// Tax is in the currency of the order total amount, it is only added to the total when not inclusive
+	Tax          *gen.Money      `json:"tax" db:"tax_units"`
+	TaxRate      decimal.Decimal `json:"tax_rate" db:"tax_rate"`
+	TaxInclusive bool            `json:"tax_inclusive" db:"tax_inclusive"`
 }
 
 func (ord *Order) GetGenOrder() *gen.Order {
 	orderItem := []*gen.OrderItem{}
 	for _, oi := range ord.Items {
 		orderItem = append(orderItem, &gen.OrderItem{
-			ProductId:    oi.ProductID,
-			Name:         oi.Name,
-			PricePerUnit: oi.PricePerUnit,
-			Quantity:     oi.Quantity,
+			ProductId:       oi.ProductID,
+			Name:            oi.Name,
+			PricePerUnit:    oi.PricePerUnit,
+			Quantity:        oi.Quantity,
+			SettlementTotal: oi.SettlementTotal,
+			ExchangeRate:    oi.ExchangeRate.String(),
+			Discount:        oi.Discount,
+			Tax:             oi.Tax,
+			TaxRate:         oi.TaxRate.String(),
+			TaxInclusive:    oi.TaxInclusive,
 		})
 	}
+	var shipment *gen.Shipment
+	if ord.Shipment != nil {
+		shipment = ord.Shipment.GetGenShipment()
+	}
+	statusHistory := []*gen.OrderStatusHistory{}
+	for _, h := range ord.StatusHistory {
+		statusHistory = append(statusHistory, h.GetGenOrderStatusHistory())
+	}
+	refunds := []*gen.Refund{}
+	for _, r := range ord.Refunds {
+		refunds = append(refunds, r.GetGenRefund())
+	}
+	returns := []*gen.Return{}
+	for _, r := range ord.Returns {
+		returns = append(returns, r.GetGenReturn())
+	}
+	payments := []*gen.OrderPayment{}
+	for _, p := range ord.Payments {
+		payments = append(payments, p.GetGenOrderPayment())
+	}
+
 	return &gen.Order{
 		Id:             ord.ID.String(),
 		UserId:         ord.UserID.String(),
@@ -50,6 +111,19 @@ func (ord *Order) GetGenOrder() *gen.Order {
 		Status:         ord.Status.String(),
 		IdempotencyKey: ord.IdempotencyKey.String(),
 		TransactionId:  ord.TransactionID,
+		CancelReason:   ord.CancelReason,
+		SubtotalAmount: ord.SubtotalAmount,
+		DiscountAmount: ord.DiscountAmount,
+		CouponCode:     ord.CouponCode,
+		TaxAmount:      ord.TaxAmount,
+		ShippingAmount: ord.ShippingAmount,
+		ShippingRegion: ord.ShippingRegion,
+		Shipment:       shipment,
+		StatusHistory:  statusHistory,
+		Refunds:        refunds,
+		Returns:        returns,
+		Payments:       payments,
+		PaidAmount:     ord.PaidAmount,
 	}
 }
 
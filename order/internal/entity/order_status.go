@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidStatusTransition is returned when the current status of the order cannot be moved to the next status
+var ErrInvalidStatusTransition = errors.New("invalid order status transition")
+
+// StatusChange moves the order to the next status, it is recorded in the status history.
+// The status is only changed when the order still has the From status
+type StatusChange struct {
+	From   constanta.OrderStatus
+	To     constanta.OrderStatus
+	Reason string
+	Actor  constanta.OrderActor
+	// TransactionID and CancelReason are written together with the status when they are not empty
+	TransactionID string
+	CancelReason  string
+}
+
+type OrderStatusHistory struct {
+	ID         int64                 `json:"id" db:"id"`
+	OrderID    uuid.UUID             `json:"order_id" db:"order_id"`
+	FromStatus constanta.OrderStatus `json:"from_status" db:"from_status"`
+	ToStatus   constanta.OrderStatus `json:"to_status" db:"to_status"`
+	Reason     string                `json:"reason" db:"reason"`
+	Actor      constanta.OrderActor  `json:"actor" db:"actor"`
+	CreatedAt  time.Time             `json:"created_at" db:"created_at"`
+}
+
+func (h *OrderStatusHistory) GetGenOrderStatusHistory() *gen.OrderStatusHistory {
+	return &gen.OrderStatusHistory{
+		FromStatus: string(h.FromStatus),
+		ToStatus:   string(h.ToStatus),
+		Reason:     h.Reason,
+		Actor:      string(h.Actor),
+		CreatedAt:  h.CreatedAt.Format(time.RFC3339),
+	}
+}
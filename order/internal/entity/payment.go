@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/pkg/money"
+)
+
+// OrderPayment is one payment of the order, the payments are owned by the payment service
+type OrderPayment struct {
+	TransactionID  string                  `json:"transaction_id"`
+	Method         string                  `json:"method"`
+	Status         constanta.PaymentStatus `json:"status"`
+	Amount         *gen.Money              `json:"amount"`
+	RefundedAmount *gen.Money              `json:"refunded_amount"`
+	PaymentURL     string                  `json:"payment_url"`
+	CreatedAt      string                  `json:"created_at"`
+}
+
+func NewOrderPayments(res *gen.ListPaymentsResponse) []OrderPayment {
+	payments := []OrderPayment{}
+	for _, payment := range res.GetPayments() {
+		payments = append(payments, OrderPayment{
+			TransactionID:  payment.TransactionId,
+			Method:         payment.Method,
+			Status:         constanta.PaymentStatus(payment.Status),
+			Amount:         payment.TotalAmount,
+			RefundedAmount: payment.RefundedAmount,
+			PaymentURL:     payment.PaymentUrl,
+			CreatedAt:      payment.CreatedAt,
+		})
+	}
+
+	return payments
+}
+
+func (p OrderPayment) GetGenOrderPayment() *gen.OrderPayment {
+	return &gen.OrderPayment{
+		TransactionId:  p.TransactionID,
+		Method:         p.Method,
+		Status:         p.Status.String(),
+		Amount:         p.Amount,
+		RefundedAmount: p.RefundedAmount,
+		PaymentUrl:     p.PaymentURL,
+		CreatedAt:      p.CreatedAt,
+	}
+}
+
+// UnpaidAmount is the part of the total amount that is neither paid nor waiting to be paid by the payments
+func (ord *Order) UnpaidAmount(payments []OrderPayment) (*gen.Money, error) {
+	unpaid := ord.TotalAmount.GetUnits()
+	for _, payment := range payments {
+		switch payment.Status {
+		case constanta.WAITING, constanta.PAID, constanta.PARTIALLY_REFUNDED, constanta.REFUNDED:
+			unpaid -= payment.Amount.GetUnits()
+		}
+	}
+
+	return money.New(max(unpaid, 0), ord.TotalAmount.GetCurrencyCode())
+}
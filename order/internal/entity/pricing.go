@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/shopspring/decimal"
+)
+
+// AnyRegion matches every region of the shipping rates
+const AnyRegion = "*"
+
+// TaxRule is the tax of the items in a shop and/or a category,
+// the rule with the most specific scope is used for the item
+type TaxRule struct {
+	ID   int64  `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	// ShopID 0 is every shop
+	ShopID int64 `json:"shop_id" db:"shop_id"`
+	// Category empty is every category
+	Category string `json:"category" db:"category"`
+	// Rate is in percent, e.g., 11 is 11%
+	Rate decimal.Decimal `json:"rate" db:"rate"`
+	// Inclusive means the price already contains the tax, so the tax is not added to the total
+	Inclusive bool `json:"is_inclusive" db:"is_inclusive"`
+}
+
+func (r *TaxRule) matches(shopID int64, category string) bool {
+	return (r.ShopID == 0 || r.ShopID == shopID) && (r.Category == "" || r.Category == category)
+}
+
+// specificity ranks the shop over the category, so the rule of the shop wins over the rule of the category
+func (r *TaxRule) specificity() int {
+	rank := 0
+	if r.ShopID != 0 {
+		rank += 2
+	}
+	if r.Category != "" {
+		rank++
+	}
+	return rank
+}
+
+// Calculate returns the tax of the amount, the amount contains the tax when the rule is inclusive
+func (r *TaxRule) Calculate(amount *gen.Money) (*gen.Money, error) {
+	hundred := decimal.NewFromInt(100)
+	factor := r.Rate.Div(hundred)
+	if r.Inclusive {
+		factor = r.Rate.Div(hundred.Add(r.Rate))
+	}
+
+	return money.MultiplyByDecimal(amount, factor)
+}
+
+// MatchTaxRule returns the most specific rule of the item, nil when the item is not taxed
+func MatchTaxRule(rules []TaxRule, shopID int64, category string) *TaxRule {
+	var match *TaxRule
+	for i := range rules {
+		if !rules[i].matches(shopID, category) {
+			continue
+		}
+		if match == nil || rules[i].specificity() > match.specificity() {
+			match = &rules[i]
+		}
+	}
+
+	return match
+}
+
+// ShippingRate is the fee of a shipment from the region of the warehouse to the region of the order
+type ShippingRate struct {
+	ID                int64      `json:"id" db:"id"`
+	OriginRegion      string     `json:"origin_region" db:"origin_region"`
+	DestinationRegion string     `json:"destination_region" db:"destination_region"`
+	BaseFee           *gen.Money `json:"base_fee" db:"base_fee_units"`
+	PerItemFee        *gen.Money `json:"per_item_fee" db:"per_item_fee_units"`
+}
+
+func (r *ShippingRate) matches(origin, destination string) bool {
+	return (r.OriginRegion == AnyRegion || r.OriginRegion == origin) &&
+		(r.DestinationRegion == AnyRegion || r.DestinationRegion == destination)
+}
+
+// specificity ranks the origin over the destination
+func (r *ShippingRate) specificity() int {
+	rank := 0
+	if r.OriginRegion != AnyRegion {
+		rank += 2
+	}
+	if r.DestinationRegion != AnyRegion {
+		rank++
+	}
+	return rank
+}
+
+// Fee returns the base fee and the fee of every item of the shipment
+func (r *ShippingRate) Fee(quantity int64) (*gen.Money, error) {
+	itemFee, err := money.MultiplyByInt(r.PerItemFee, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return money.Add(r.BaseFee, itemFee)
+}
+
+// MatchShippingRate returns the most specific rate of the route, nil when the route is not served
+func MatchShippingRate(rates []ShippingRate, origin, destination string) *ShippingRate {
+	var match *ShippingRate
+	for i := range rates {
+		if !rates[i].matches(origin, destination) {
+			continue
+		}
+		if match == nil || rates[i].specificity() > match.specificity() {
+			match = &rates[i]
+		}
+	}
+
+	return match
+}
+
+// ApplyTax calculates the tax of every available item after the discount and the tax of the cart.
+// The discount must be applied before
+func (c *Cart) ApplyTax(rules []TaxRule) error {
+	var err error
+	c.Tax, err = money.New(0, c.Subtotal.GetCurrencyCode())
+	if err != nil {
+		return err
+	}
+
+	for i, item := range c.Items {
+		if item.Unavailable || item.SettlementTotal == nil {
+			continue
+		}
+
+		rule := MatchTaxRule(rules, item.ShopID, item.Category)
+		if rule == nil {
+			continue
+		}
+
+		taxable := item.SettlementTotal
+		if item.Discount != nil {
+			taxable, err = money.Subtract(taxable, item.Discount)
+			if err != nil {
+				return err
+			}
+		}
+
+		tax, err := rule.Calculate(taxable)
+		if err != nil {
+			return fmt.Errorf("failed to calculate tax %s of product %s: %w", rule.Name, item.ProductID, err)
+		}
+
+		c.Items[i].Tax = tax
+		c.Items[i].TaxRate = rule.Rate
+		c.Items[i].TaxInclusive = rule.Inclusive
+
+		c.Tax, err = money.Add(c.Tax, tax)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CalculateGrandTotal adds the exclusive tax of the items and the shipping fee to the total
+func (c *Cart) CalculateGrandTotal() error {
+	grandTotal := c.Total
+
+	var err error
+	for _, item := range c.Items {
+		if item.Tax == nil || item.TaxInclusive {
+			continue
+		}
+
+		grandTotal, err = money.Add(grandTotal, item.Tax)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Shipping != nil {
+		grandTotal, err = money.Add(grandTotal, c.Shipping)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.GrandTotal = grandTotal
+	return nil
+}
@@ -0,0 +1,254 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrCouponNotApplicable is wrapped by every reason the coupon cannot be used for the cart
+var ErrCouponNotApplicable = errors.New("coupon is not applicable")
+
+type Promotion struct {
+	ID          uuid.UUID                `json:"id" db:"id"`
+	Code        string                   `json:"code" db:"code"`
+	Description string                   `json:"description" db:"description"`
+	Type        constanta.PromotionType  `json:"type" db:"type"`
+	Scope       constanta.PromotionScope `json:"scope" db:"scope"`
+	// ScopeID is the shop id or the product id of the scope, empty for ORDER
+	ScopeID string `json:"scope_id" db:"scope_id"`
+	// Percentage is only used for PERCENTAGE, e.g., 12.5 is 12.5% off
+	Percentage decimal.Decimal `json:"percentage" db:"percentage"`
+	// Amount is only used for FIXED_AMOUNT, it must be converted into the currency of the cart before applied
+	Amount *gen.Money `json:"amount" db:"amount_units"`
+	// BuyQuantity and GetQuantity are only used for BUY_X_GET_Y
+	BuyQuantity int64 `json:"buy_quantity" db:"buy_quantity"`
+	GetQuantity int64 `json:"get_quantity" db:"get_quantity"`
+	// UsageLimit and UsageLimitPerUser are unlimited when 0
+	UsageLimit        int64     `json:"usage_limit" db:"usage_limit"`
+	UsageLimitPerUser int64     `json:"usage_limit_per_user" db:"usage_limit_per_user"`
+	StartsAt          time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt            time.Time `json:"ends_at" db:"ends_at"`
+	IsActive          bool      `json:"is_active" db:"is_active"`
+}
+
+// Validate checks the promotion before it is created
+func (p *Promotion) Validate() error {
+	if p.Code == "" {
+		return errors.New("code is required")
+	}
+
+	if !p.EndsAt.After(p.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	switch p.Scope {
+	case constanta.PromotionScopeOrder:
+	case constanta.PromotionScopeShop, constanta.PromotionScopeProduct:
+		if p.ScopeID == "" {
+			return fmt.Errorf("scope_id is required for scope %s", p.Scope)
+		}
+	default:
+		return fmt.Errorf("unknown scope %s", p.Scope)
+	}
+
+	switch p.Type {
+	case constanta.PromotionTypePercentage:
+		if !p.Percentage.IsPositive() || p.Percentage.GreaterThan(decimal.NewFromInt(100)) {
+			return errors.New("percentage must be greater than 0 and at most 100")
+		}
+	case constanta.PromotionTypeFixedAmount:
+		if _, err := money.FromProto(p.Amount); err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+		if p.Amount.Units == 0 {
+			return errors.New("amount must be greater than 0")
+		}
+	case constanta.PromotionTypeBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return errors.New("buy_quantity and get_quantity must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("unknown type %s", p.Type)
+	}
+
+	if p.UsageLimit < 0 || p.UsageLimitPerUser < 0 {
+		return errors.New("usage limit cannot be negative")
+	}
+
+	return nil
+}
+
+// CheckAvailability checks the validity window and the usage limits,
+// usage and userUsage are the orders that already used the promotion
+func (p *Promotion) CheckAvailability(now time.Time, usage, userUsage int64) error {
+	if !p.IsActive {
+		return fmt.Errorf("%w: coupon %s is not active", ErrCouponNotApplicable, p.Code)
+	}
+
+	if now.Before(p.StartsAt) {
+		return fmt.Errorf("%w: coupon %s is not started yet", ErrCouponNotApplicable, p.Code)
+	}
+
+	if !now.Before(p.EndsAt) {
+		return fmt.Errorf("%w: coupon %s is expired", ErrCouponNotApplicable, p.Code)
+	}
+
+	if p.UsageLimit > 0 && usage >= p.UsageLimit {
+		return fmt.Errorf("%w: coupon %s has reached its usage limit", ErrCouponNotApplicable, p.Code)
+	}
+
+	if p.UsageLimitPerUser > 0 && userUsage >= p.UsageLimitPerUser {
+		return fmt.Errorf("%w: coupon %s has been used the maximum number of times", ErrCouponNotApplicable, p.Code)
+	}
+
+	return nil
+}
+
+// IsEligible reports whether the item is in the scope of the promotion
+func (p *Promotion) IsEligible(item CartItem) bool {
+	if item.Unavailable || item.SettlementTotal == nil {
+		return false
+	}
+
+	switch p.Scope {
+	case constanta.PromotionScopeOrder:
+		return true
+	case constanta.PromotionScopeShop:
+		return strconv.FormatInt(item.ShopID, 10) == p.ScopeID
+	case constanta.PromotionScopeProduct:
+		return item.ProductID == p.ScopeID
+	default:
+		return false
+	}
+}
+
+// Apply calculates the discount of every eligible item and the cart total.
+// The settlement total of the items must be calculated before,
+// and the amount of FIXED_AMOUNT must already be in the currency of the cart
+func (p *Promotion) Apply(cart *Cart) error {
+	eligible := []int{}
+	for i, item := range cart.Items {
+		if p.IsEligible(item) {
+			eligible = append(eligible, i)
+		}
+	}
+	if len(eligible) == 0 {
+		return fmt.Errorf("%w: no item in the cart is eligible for coupon %s", ErrCouponNotApplicable, p.Code)
+	}
+
+	discounts := make(map[int]*gen.Money)
+	switch p.Type {
+	case constanta.PromotionTypePercentage:
+		factor := p.Percentage.Div(decimal.NewFromInt(100))
+		for _, i := range eligible {
+			discount, err := money.MultiplyByDecimal(cart.Items[i].SettlementTotal, factor)
+			if err != nil {
+				return err
+			}
+			discounts[i] = discount
+		}
+
+	case constanta.PromotionTypeFixedAmount:
+		if p.Amount.GetCurrencyCode() != cart.Subtotal.GetCurrencyCode() {
+			return fmt.Errorf("amount of coupon %s must be in %s", p.Code, cart.Subtotal.GetCurrencyCode())
+		}
+
+		// the amount is split by the total of each item, the items cannot be discounted below zero
+		weights := []int64{}
+		var eligibleTotal int64
+		for _, i := range eligible {
+			weights = append(weights, cart.Items[i].SettlementTotal.Units)
+			eligibleTotal += cart.Items[i].SettlementTotal.Units
+		}
+		if eligibleTotal == 0 {
+			return fmt.Errorf("%w: eligible items of coupon %s are free", ErrCouponNotApplicable, p.Code)
+		}
+
+		amount, err := money.New(min(p.Amount.Units, eligibleTotal), cart.Subtotal.CurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		parts, err := money.Allocate(amount, weights)
+		if err != nil {
+			return err
+		}
+		for k, i := range eligible {
+			discounts[i] = parts[k]
+		}
+
+	case constanta.PromotionTypeBuyXGetY:
+		for _, i := range eligible {
+			item := cart.Items[i]
+			free := item.Quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+			if free == 0 {
+				continue
+			}
+
+			// the line total is split by units, so the rounding of the converted total is kept
+			parts, err := money.Allocate(item.SettlementTotal, []int64{free, item.Quantity - free})
+			if err != nil {
+				return err
+			}
+			discounts[i] = parts[0]
+		}
+		if len(discounts) == 0 {
+			return fmt.Errorf("%w: buy %d to get %d free with coupon %s", ErrCouponNotApplicable, p.BuyQuantity+p.GetQuantity, p.GetQuantity, p.Code)
+		}
+
+	default:
+		return fmt.Errorf("unknown promotion type %s", p.Type)
+	}
+
+	totalDiscount, err := money.New(0, cart.Subtotal.GetCurrencyCode())
+	if err != nil {
+		return err
+	}
+
+	for i, discount := range discounts {
+		cart.Items[i].Discount = discount
+		totalDiscount, err = money.Add(totalDiscount, discount)
+		if err != nil {
+			return err
+		}
+	}
+
+	total, err := money.Subtract(cart.Subtotal, totalDiscount)
+	if err != nil {
+		return err
+	}
+
+	cart.Discount = totalDiscount
+	cart.Total = total
+
+	return nil
+}
+
+func (p *Promotion) GetGenPromotion() *gen.Promotion {
+	return &gen.Promotion{
+		Id:                p.ID.String(),
+		Code:              p.Code,
+		Description:       p.Description,
+		Type:              p.Type.String(),
+		Scope:             p.Scope.String(),
+		ScopeId:           p.ScopeID,
+		Percentage:        p.Percentage.String(),
+		Amount:            p.Amount,
+		BuyQuantity:       p.BuyQuantity,
+		GetQuantity:       p.GetQuantity,
+		UsageLimit:        p.UsageLimit,
+		UsageLimitPerUser: p.UsageLimitPerUser,
+		StartsAt:          p.StartsAt.Format(time.RFC3339),
+		EndsAt:            p.EndsAt.Format(time.RFC3339),
+		IsActive:          p.IsActive,
+	}
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRefund is returned when the refund does not match the items of the order that are not refunded yet
+var ErrInvalidRefund = errors.New("invalid refund")
+
+// Refund is the money paid back to the customer for the returned items of the order,
+// the amount is in the currency of the order total amount
+type Refund struct {
+	ID      uuid.UUID              `json:"id" db:"id"`
+	OrderID uuid.UUID              `json:"order_id" db:"order_id"`
+	Status  constanta.RefundStatus `json:"status" db:"status"`
+	Amount  *gen.Money             `json:"amount" db:"amount"`
+	Reason  string                 `json:"reason" db:"reason"`
+	// Restock is set when the items are put back into the warehouse, they are not shipped yet or they are returned
+	Restock   bool `json:"restock" db:"restock"`
+	Restocked bool `json:"restocked" db:"restocked"`
+	// WarehouseID is the warehouse that receives the returned items, 0 is the warehouse the items are sold from
+	WarehouseID int64        `json:"warehouse_id" db:"warehouse_id"`
+	Items       []RefundItem `json:"items" db:"-"`
+	// ReturnID is the return the refund is for, empty when it is refunded by the customer service directly
+	ReturnID string `json:"return_id" db:"return_id"`
+	// Full is set when the refund completes the refund of the whole order, it is not stored
+	Full      bool      `json:"-" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type RefundItem struct {
+	ProductID string     `json:"product_id" db:"product_id"`
+	Quantity  int64      `json:"quantity" db:"quantity"`
+	Amount    *gen.Money `json:"amount" db:"amount"`
+}
+
+func (r *Refund) GetGenRefund() *gen.Refund {
+	items := []*gen.RefundItem{}
+	for _, item := range r.Items {
+		items = append(items, &gen.RefundItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+			Amount:    item.Amount,
+		})
+	}
+
+	return &gen.Refund{
+		Id:        r.ID.String(),
+		Status:    r.Status.String(),
+		Amount:    r.Amount,
+		Reason:    r.Reason,
+		Items:     items,
+		Restocked: r.Restocked,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		ReturnId:  r.ReturnID,
+	}
+}
+
+// NewRefund calculates the refund of the items from the paid amount of the order,
+// every item that is not refunded yet is refunded when items is empty.
+// The amount of the item is its share of the paid amount after the discount and the exclusive tax,
+// the refund that completes the order also pays back the shipping and the rounding of the previous refunds
+func (ord *Order) NewRefund(items []RefundItem, refunds []Refund, reason string) (*Refund, error) {
+	refundedQuantity := map[string]int64{}
+	var refundedAmount int64
+	for _, refund := range refunds {
+		if refund.Status == constanta.RefundStatusFailed {
+			continue
+		}
+
+		refundedAmount += refund.Amount.GetUnits()
+		for _, item := range refund.Items {
+			refundedQuantity[item.ProductID] += item.Quantity
+		}
+	}
+
+	orderedProducts := map[string]bool{}
+	for _, item := range ord.Items {
+		orderedProducts[item.ProductID] = true
+	}
+
+	requested := map[string]int64{}
+	for _, item := range items {
+		if !orderedProducts[item.ProductID] {
+			return nil, fmt.Errorf("%w: product %s is not in the order", ErrInvalidRefund, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of product %s must be greater than 0", ErrInvalidRefund, item.ProductID)
+		}
+
+		requested[item.ProductID] += item.Quantity
+	}
+
+	if len(items) == 0 {
+		for _, item := range ord.Items {
+			if remaining := item.Quantity - refundedQuantity[item.ProductID]; remaining > 0 {
+				requested[item.ProductID] = remaining
+			}
+		}
+
+		if len(requested) == 0 {
+			return nil, fmt.Errorf("%w: every item of the order is already refunded", ErrInvalidRefund)
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ord.TotalAmount.GetCurrencyCode()
+	refund := &Refund{
+		ID:      id,
+		OrderID: ord.ID,
+		Status:  constanta.RefundStatusPending,
+		Reason:  reason,
+		Full:    true,
+	}
+
+	var amount int64
+	for _, item := range ord.Items {
+		refunded := refundedQuantity[item.ProductID]
+		quantity := requested[item.ProductID]
+		if refunded+quantity > item.Quantity {
+			return nil, fmt.Errorf("%w: only %d of product %s can be refunded", ErrInvalidRefund, item.Quantity-refunded, item.ProductID)
+		}
+
+		if refunded+quantity < item.Quantity {
+			refund.Full = false
+		}
+
+		if quantity == 0 {
+			continue
+		}
+
+		// the share of the refunded units is taken from the cumulative quantity,
+		// so the refunds of the item never sum up more than its paid amount
+		paid := item.paidAmount()
+		itemAmount := paid*(refunded+quantity)/item.Quantity - paid*refunded/item.Quantity
+		amount += itemAmount
+
+		refund.Items = append(refund.Items, RefundItem{
+			ProductID: item.ProductID,
+			Quantity:  quantity,
+			Amount:    &gen.Money{Units: itemAmount, CurrencyCode: currency},
+		})
+	}
+
+	if refund.Full {
+		amount = ord.TotalAmount.GetUnits() - refundedAmount
+	}
+
+	refund.Amount = &gen.Money{Units: amount, CurrencyCode: currency}
+
+	return refund, nil
+}
+
+// IsFullyRefunded reports whether every item of the order is refunded by the refunds that are not failed
+func (ord *Order) IsFullyRefunded(refunds []Refund) bool {
+	refundedQuantity := map[string]int64{}
+	for _, refund := range refunds {
+		if refund.Status == constanta.RefundStatusFailed {
+			continue
+		}
+
+		for _, item := range refund.Items {
+			refundedQuantity[item.ProductID] += item.Quantity
+		}
+	}
+
+	for _, item := range ord.Items {
+		if refundedQuantity[item.ProductID] < item.Quantity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// paidAmount is the settlement total of the item after the discount with the exclusive tax
+func (oi OrderItem) paidAmount() int64 {
+	paid := oi.SettlementTotal.GetUnits() - oi.Discount.GetUnits()
+	if !oi.TaxInclusive {
+		paid += oi.Tax.GetUnits()
+	}
+
+	return paid
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidReturn is returned when the return does not match the items of the order that can still be returned
+var ErrInvalidReturn = errors.New("invalid return")
+
+// Return is the request of the customer to send back the items of the paid order.
+// It is reviewed by the admin, then the items are received by the warehouse and refunded
+type Return struct {
+	ID      uuid.UUID              `json:"id" db:"id"`
+	OrderID uuid.UUID              `json:"order_id" db:"order_id"`
+	UserID  uuid.UUID              `json:"user_id" db:"user_id"`
+	Status  constanta.ReturnStatus `json:"status" db:"status"`
+	Reason  string                 `json:"reason" db:"reason"`
+	// Note is written by the admin when the return is reviewed
+	Note        string       `json:"note" db:"note"`
+	WarehouseID int64        `json:"warehouse_id" db:"warehouse_id"`
+	RefundID    string       `json:"refund_id" db:"refund_id"`
+	Items       []ReturnItem `json:"items" db:"-"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+type ReturnItem struct {
+	ProductID string `json:"product_id" db:"product_id"`
+	Quantity  int64  `json:"quantity" db:"quantity"`
+}
+
+func (r *Return) GetGenReturn() *gen.Return {
+	items := []*gen.ReturnItem{}
+	for _, item := range r.Items {
+		items = append(items, &gen.ReturnItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &gen.Return{
+		Id:          r.ID.String(),
+		OrderId:     r.OrderID.String(),
+		Status:      r.Status.String(),
+		Reason:      r.Reason,
+		Items:       items,
+		Note:        r.Note,
+		WarehouseId: r.WarehouseID,
+		RefundId:    r.RefundID,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetRefundItems returns the items of the return to be refunded
+func (r *Return) GetRefundItems() []RefundItem {
+	items := []RefundItem{}
+	for _, item := range r.Items {
+		items = append(items, RefundItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return items
+}
+
+// NewReturn validates the items against the items of the order that are not refunded yet
+// and are not held by another open return
+func (ord *Order) NewReturn(items []ReturnItem, returns []Return, refunds []Refund, reason string) (*Return, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: items are required", ErrInvalidReturn)
+	}
+
+	heldQuantity := map[string]int64{}
+	for _, refund := range refunds {
+		if refund.Status == constanta.RefundStatusFailed {
+			continue
+		}
+
+		for _, item := range refund.Items {
+			heldQuantity[item.ProductID] += item.Quantity
+		}
+	}
+
+	// the items of a refunded return are already counted by its refund
+	for _, ret := range returns {
+		if !ret.Status.IsOpen() {
+			continue
+		}
+
+		for _, item := range ret.Items {
+			heldQuantity[item.ProductID] += item.Quantity
+		}
+	}
+
+	orderedQuantity := map[string]int64{}
+	for _, item := range ord.Items {
+		orderedQuantity[item.ProductID] += item.Quantity
+	}
+
+	requested := map[string]int64{}
+	for _, item := range items {
+		if _, ok := orderedQuantity[item.ProductID]; !ok {
+			return nil, fmt.Errorf("%w: product %s is not in the order", ErrInvalidReturn, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of product %s must be greater than 0", ErrInvalidReturn, item.ProductID)
+		}
+
+		requested[item.ProductID] += item.Quantity
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Return{
+		ID:      id,
+		OrderID: ord.ID,
+		UserID:  ord.UserID,
+		Status:  constanta.ReturnStatusRequested,
+		Reason:  reason,
+	}
+
+	// the items follow the order of the order items
+	for _, item := range ord.Items {
+		quantity, ok := requested[item.ProductID]
+		if !ok {
+			continue
+		}
+		delete(requested, item.ProductID)
+
+		if remaining := orderedQuantity[item.ProductID] - heldQuantity[item.ProductID]; quantity > remaining {
+			return nil, fmt.Errorf("%w: only %d of product %s can be returned", ErrInvalidReturn, max(remaining, 0), item.ProductID)
+		}
+
+		ret.Items = append(ret.Items, ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  quantity,
+		})
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/google/uuid"
+)
+
+type SagaStep struct {
+	ID      uuid.UUID          `json:"id" db:"id"`
+	OrderID uuid.UUID          `json:"order_id" db:"order_id"`
+	Step    constanta.SagaStep `json:"step" db:"step"`
+	// Reference is the id of the refund for the steps of the refund saga, empty for the steps of the order
+	Reference string                   `json:"reference" db:"reference"`
+	Status    constanta.SagaStepStatus `json:"status" db:"status"`
+	Attempt   int64                    `json:"attempt" db:"attempt"`
+	// Result holds the output needed to resume the saga, e.g. transaction_id after payment is processed
+	Result string `json:"result" db:"result"`
+	Error  string `json:"error" db:"error"`
+	// NextRetryAt is only set for the failed step that is retried
+	NextRetryAt *time.Time `json:"next_retry_at" db:"next_retry_at"`
+	CreatedAt   *time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsRetryDue reports whether the failed step can be retried at the given time,
+// the step without the retry time is failed for good, e.g. the refund is refused by the payment service
+func (ss *SagaStep) IsRetryDue(now time.Time) bool {
+	if !ss.Step.IsRetried() || ss.Status != constanta.SagaStepStatusFailed {
+		return false
+	}
+
+	return ss.NextRetryAt != nil && !ss.NextRetryAt.After(now)
+}
+
+// Saga is the persisted state of create order saga for a single order
+type Saga struct {
+	OrderID     uuid.UUID
+	UserID      uuid.UUID
+	OrderStatus constanta.OrderStatus
+	// PendingStatus is the next status claimed by the transition that was interrupted, empty when none
+	PendingStatus constanta.OrderStatus
+	Steps         []SagaStep
+}
+
+// GetStep returns the recorded step of the order, or nil when the step was never started
+func (s *Saga) GetStep(step constanta.SagaStep) *SagaStep {
+	return s.GetReferenceStep(step, "")
+}
+
+// GetReferenceStep returns the recorded step of the reference, e.g. the step of the refund saga
+func (s *Saga) GetReferenceStep(step constanta.SagaStep, reference string) *SagaStep {
+	for i := range s.Steps {
+		if s.Steps[i].Step == step && s.Steps[i].Reference == reference {
+			return &s.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// GetTransactionID returns the transaction id created by process payment step
+func (s *Saga) GetTransactionID() string {
+	if payment := s.GetStep(constanta.SagaStepProcessPayment); payment != nil {
+		return payment.Result
+	}
+
+	return ""
+}
+
+// IsStepSucceeded reports whether the step is recorded as succeeded
+func (s *Saga) IsStepSucceeded(step constanta.SagaStep) bool {
+	ss := s.GetStep(step)
+	return ss != nil && ss.Status == constanta.SagaStepStatusSucceeded
+}
+
+type DeadLetterCompensation struct {
+	ID         uuid.UUID          `json:"id" db:"id"`
+	SagaStepID uuid.UUID          `json:"saga_step_id" db:"saga_step_id"`
+	OrderID    uuid.UUID          `json:"order_id" db:"order_id"`
+	Step       constanta.SagaStep `json:"step" db:"step"`
+	Attempt    int64              `json:"attempt" db:"attempt"`
+	LastError  string             `json:"last_error" db:"last_error"`
+	CreatedAt  *time.Time         `json:"created_at" db:"created_at"`
+}
+
+func (dlc *DeadLetterCompensation) GetGenDeadLetterCompensation() *gen.DeadLetterCompensation {
+	res := &gen.DeadLetterCompensation{
+		Id:        dlc.ID.String(),
+		OrderId:   dlc.OrderID.String(),
+		Step:      dlc.Step.String(),
+		Attempt:   dlc.Attempt,
+		LastError: dlc.LastError,
+	}
+
+	if dlc.CreatedAt != nil {
+		res.CreatedAt = dlc.CreatedAt.Format(time.DateTime)
+	}
+
+	return res
+}
@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+)
+
+// ShippingAddress is the snapshot of the address book entry of the user when the order is created
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name" db:"recipient_name"`
+	Phone         string `json:"phone" db:"phone"`
+	Street        string `json:"street" db:"street"`
+	City          string `json:"city" db:"city"`
+	// Region is the region code used for the shipping fee, e.g., JKT
+	Region     string `json:"region" db:"region"`
+	PostalCode string `json:"postal_code" db:"postal_code"`
+}
+
+func NewShippingAddress(address *gen.ShippingAddress) (*ShippingAddress, error) {
+	if address.GetRecipientName() == "" {
+		return nil, errors.New("recipient_name of shipping_address is required")
+	}
+	if address.GetStreet() == "" || address.GetCity() == "" {
+		return nil, errors.New("street and city of shipping_address are required")
+	}
+	if address.GetRegion() == "" {
+		return nil, errors.New("region of shipping_address is required")
+	}
+
+	return &ShippingAddress{
+		RecipientName: address.GetRecipientName(),
+		Phone:         address.GetPhone(),
+		Street:        address.GetStreet(),
+		City:          address.GetCity(),
+		Region:        address.GetRegion(),
+		PostalCode:    address.GetPostalCode(),
+	}, nil
+}
+
+// Shipment is the delivery information of the order, the time of every fulfillment step is kept as its history
+type Shipment struct {
+	Address        ShippingAddress `json:"address"`
+	Carrier        string          `json:"carrier" db:"carrier"`
+	TrackingNumber string          `json:"tracking_number" db:"tracking_number"`
+	PackedAt       *time.Time      `json:"packed_at" db:"packed_at"`
+	ShippedAt      *time.Time      `json:"shipped_at" db:"shipped_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at" db:"delivered_at"`
+}
+
+// Advance records the fulfillment step, the carrier and the tracking number are required to ship the order.
+// The steps are kept in order by the shipment itself, since a partially refunded order does not show its fulfillment status
+func (s *Shipment) Advance(status constanta.OrderStatus, carrier, trackingNumber string, now time.Time) error {
+	switch status {
+	case constanta.OrderStatusPacked:
+		if s.PackedAt != nil {
+			return fmt.Errorf("%w: order is already packed", ErrInvalidStatusTransition)
+		}
+		s.PackedAt = &now
+	case constanta.OrderStatusShipped:
+		if s.PackedAt == nil || s.ShippedAt != nil {
+			return fmt.Errorf("%w: only the packed order that is not shipped yet can be shipped", ErrInvalidStatusTransition)
+		}
+		if carrier == "" || trackingNumber == "" {
+			return errors.New("carrier and tracking_number are required to ship the order")
+		}
+		s.Carrier = carrier
+		s.TrackingNumber = trackingNumber
+		s.ShippedAt = &now
+	case constanta.OrderStatusDelivered:
+		if s.ShippedAt == nil || s.DeliveredAt != nil {
+			return fmt.Errorf("%w: only the shipped order that is not delivered yet can be delivered", ErrInvalidStatusTransition)
+		}
+		s.DeliveredAt = &now
+	default:
+		return fmt.Errorf("%s is not a fulfillment status", status)
+	}
+
+	return nil
+}
+
+// IsShipped reports whether the items of the order left the warehouse
+func (s *Shipment) IsShipped() bool {
+	return s != nil && s.ShippedAt != nil
+}
+
+func (s *Shipment) GetGenShipment() *gen.Shipment {
+	return &gen.Shipment{
+		Address: &gen.ShippingAddress{
+			RecipientName: s.Address.RecipientName,
+			Phone:         s.Address.Phone,
+			Street:        s.Address.Street,
+			City:          s.Address.City,
+			Region:        s.Address.Region,
+			PostalCode:    s.Address.PostalCode,
+		},
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		PackedAt:       formatTime(s.PackedAt),
+		ShippedAt:      formatTime(s.ShippedAt),
+		DeliveredAt:    formatTime(s.DeliveredAt),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateFulfillment moves the paid order to the next fulfillment status, it is called by the warehouse service on behalf of the admin.
+// The same status is accepted again without changing the order, so the warehouse service can retry it
+func (s *OrderService) UpdateFulfillment(ctx context.Context, req *gen.UpdateFulfillmentRequest) (*gen.Order, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	nextStatus := constanta.OrderStatus(strings.ToUpper(req.GetStatus()))
+	if !nextStatus.IsFulfillment() {
+		return nil, status.Errorf(codes.InvalidArgument, "status must be one of %s, %s or %s",
+			constanta.OrderStatusPacked, constanta.OrderStatusShipped, constanta.OrderStatusDelivered)
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if order.Status == nextStatus {
+		return order.GetGenOrder(), nil
+	}
+
+	if !order.Status.CanTransitionTo(nextStatus) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be %s", order.Status, nextStatus)
+	}
+
+	shipment := order.Shipment
+	if shipment == nil {
+		shipment = &entity.Shipment{}
+	}
+
+	err = shipment.Advance(nextStatus, strings.TrimSpace(req.GetCarrier()), strings.TrimSpace(req.GetTrackingNumber()), time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.orderRepo.UpdateFulfillment(ctx, order.ID, entity.StatusChange{
+		From:   order.Status,
+		To:     nextStatus,
+		Reason: fulfillmentReason(nextStatus, shipment),
+		Actor:  constanta.OrderActorWarehouse,
+	}, *shipment)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+	}
+
+	order.Status = nextStatus
+	order.Shipment = shipment
+
+	return order.GetGenOrder(), nil
+}
+
+func fulfillmentReason(nextStatus constanta.OrderStatus, shipment *entity.Shipment) string {
+	if nextStatus == constanta.OrderStatusShipped {
+		return fmt.Sprintf("shipped with %s, tracking number %s", shipment.Carrier, shipment.TrackingNumber)
+	}
+
+	return fmt.Sprintf("order %s", strings.ToLower(nextStatus.String()))
+}
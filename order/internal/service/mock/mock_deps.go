@@ -42,6 +42,106 @@ func (m *MockWarehouseServiceClient) EXPECT() *MockWarehouseServiceClientMockRec
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockWarehouseServiceClient) AdjustStock(ctx context.Context, in *gen.AdjustStockRequest, opts ...grpc.CallOption) (*gen.StockMovement, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AdjustStock", varargs...)
+	ret0, _ := ret[0].(*gen.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockWarehouseServiceClientMockRecorder) AdjustStock(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).AdjustStock), varargs...)
+}
+
+// ConfirmStock mocks base method.
+func (m *MockWarehouseServiceClient) ConfirmStock(ctx context.Context, in *gen.ConfirmStockRequest, opts ...grpc.CallOption) (*gen.ConfirmStockResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmStock", varargs...)
+	ret0, _ := ret[0].(*gen.ConfirmStockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmStock indicates an expected call of ConfirmStock.
+func (mr *MockWarehouseServiceClientMockRecorder) ConfirmStock(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ConfirmStock), varargs...)
+}
+
+// ExtendReservation mocks base method.
+func (m *MockWarehouseServiceClient) ExtendReservation(ctx context.Context, in *gen.ExtendReservationRequest, opts ...grpc.CallOption) (*gen.ExtendReservationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExtendReservation", varargs...)
+	ret0, _ := ret[0].(*gen.ExtendReservationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendReservation indicates an expected call of ExtendReservation.
+func (mr *MockWarehouseServiceClientMockRecorder) ExtendReservation(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendReservation", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ExtendReservation), varargs...)
+}
+
+// FulfillOrder mocks base method.
+func (m *MockWarehouseServiceClient) FulfillOrder(ctx context.Context, in *gen.FulfillOrderRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FulfillOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FulfillOrder indicates an expected call of FulfillOrder.
+func (mr *MockWarehouseServiceClientMockRecorder) FulfillOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FulfillOrder", reflect.TypeOf((*MockWarehouseServiceClient)(nil).FulfillOrder), varargs...)
+}
+
+// GetReservation mocks base method.
+func (m *MockWarehouseServiceClient) GetReservation(ctx context.Context, in *gen.GetReservationRequest, opts ...grpc.CallOption) (*gen.Reservation, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReservation", varargs...)
+	ret0, _ := ret[0].(*gen.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockWarehouseServiceClientMockRecorder) GetReservation(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockWarehouseServiceClient)(nil).GetReservation), varargs...)
+}
+
 // GetStocks mocks base method.
 func (m *MockWarehouseServiceClient) GetStocks(ctx context.Context, in *gen.GetStockRequest, opts ...grpc.CallOption) (*gen.StockList, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +182,86 @@ func (mr *MockWarehouseServiceClientMockRecorder) GetWarehouseByShopID(ctx, in a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseByShopID", reflect.TypeOf((*MockWarehouseServiceClient)(nil).GetWarehouseByShopID), varargs...)
 }
 
+// ListExpiringLots mocks base method.
+func (m *MockWarehouseServiceClient) ListExpiringLots(ctx context.Context, in *gen.ListExpiringLotsRequest, opts ...grpc.CallOption) (*gen.ListExpiringLotsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListExpiringLots", varargs...)
+	ret0, _ := ret[0].(*gen.ListExpiringLotsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringLots indicates an expected call of ListExpiringLots.
+func (mr *MockWarehouseServiceClientMockRecorder) ListExpiringLots(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringLots", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ListExpiringLots), varargs...)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockWarehouseServiceClient) ListStockMovements(ctx context.Context, in *gen.ListStockMovementsRequest, opts ...grpc.CallOption) (*gen.ListStockMovementsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListStockMovements", varargs...)
+	ret0, _ := ret[0].(*gen.ListStockMovementsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockWarehouseServiceClientMockRecorder) ListStockMovements(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ListStockMovements), varargs...)
+}
+
+// ReceiveReturnedStock mocks base method.
+func (m *MockWarehouseServiceClient) ReceiveReturnedStock(ctx context.Context, in *gen.ReceiveReturnedStockRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReceiveReturnedStock", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveReturnedStock indicates an expected call of ReceiveReturnedStock.
+func (mr *MockWarehouseServiceClientMockRecorder) ReceiveReturnedStock(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturnedStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ReceiveReturnedStock), varargs...)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockWarehouseServiceClient) ReceiveStock(ctx context.Context, in *gen.ReceiveStockRequest, opts ...grpc.CallOption) (*gen.StockMovement, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReceiveStock", varargs...)
+	ret0, _ := ret[0].(*gen.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockWarehouseServiceClientMockRecorder) ReceiveStock(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ReceiveStock), varargs...)
+}
+
 // ReleaseStock mocks base method.
 func (m *MockWarehouseServiceClient) ReleaseStock(ctx context.Context, in *gen.ReleaseStockRequest, opts ...grpc.CallOption) (*gen.ReleaseStockResponse, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +302,26 @@ func (mr *MockWarehouseServiceClientMockRecorder) ReserveStock(ctx, in any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).ReserveStock), varargs...)
 }
 
+// RestockStock mocks base method.
+func (m *MockWarehouseServiceClient) RestockStock(ctx context.Context, in *gen.RestockStockRequest, opts ...grpc.CallOption) (*gen.RestockStockResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestockStock", varargs...)
+	ret0, _ := ret[0].(*gen.RestockStockResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestockStock indicates an expected call of RestockStock.
+func (mr *MockWarehouseServiceClientMockRecorder) RestockStock(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestockStock", reflect.TypeOf((*MockWarehouseServiceClient)(nil).RestockStock), varargs...)
+}
+
 // SetWarehouseStatus mocks base method.
 func (m *MockWarehouseServiceClient) SetWarehouseStatus(ctx context.Context, in *gen.SetWarehouseStatusRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +406,26 @@ func (mr *MockPaymentServiceClientMockRecorder) GetPayment(ctx, in any, opts ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockPaymentServiceClient)(nil).GetPayment), varargs...)
 }
 
+// ListPayments mocks base method.
+func (m *MockPaymentServiceClient) ListPayments(ctx context.Context, in *gen.ListPaymentsRequest, opts ...grpc.CallOption) (*gen.ListPaymentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPayments", varargs...)
+	ret0, _ := ret[0].(*gen.ListPaymentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockPaymentServiceClientMockRecorder) ListPayments(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockPaymentServiceClient)(nil).ListPayments), varargs...)
+}
+
 // ProcessPayment mocks base method.
 func (m *MockPaymentServiceClient) ProcessPayment(ctx context.Context, in *gen.ProcessPaymentRequest, opts ...grpc.CallOption) (*gen.ProcessPaymentResponse, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +446,26 @@ func (mr *MockPaymentServiceClientMockRecorder) ProcessPayment(ctx, in any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPayment", reflect.TypeOf((*MockPaymentServiceClient)(nil).ProcessPayment), varargs...)
 }
 
+// RefundPayment mocks base method.
+func (m *MockPaymentServiceClient) RefundPayment(ctx context.Context, in *gen.RefundPaymentRequest, opts ...grpc.CallOption) (*gen.RefundPaymentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefundPayment", varargs...)
+	ret0, _ := ret[0].(*gen.RefundPaymentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPaymentServiceClientMockRecorder) RefundPayment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPaymentServiceClient)(nil).RefundPayment), varargs...)
+}
+
 // RollbackPayment mocks base method.
 func (m *MockPaymentServiceClient) RollbackPayment(ctx context.Context, in *gen.RollbackPaymentRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
 	m.ctrl.T.Helper()
@@ -44,6 +44,20 @@ func (m *MockcartRepo) EXPECT() *MockcartRepoMockRecorder {
 	return m.recorder
 }
 
+// ClearCart mocks base method.
+func (m *MockcartRepo) ClearCart(ctx context.Context, cartID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx, cartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockcartRepoMockRecorder) ClearCart(ctx, cartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockcartRepo)(nil).ClearCart), ctx, cartID)
+}
+
 // CreateCart mocks base method.
 func (m *MockcartRepo) CreateCart(ctx context.Context, cart entity.Cart) error {
 	m.ctrl.T.Helper()
@@ -58,6 +72,21 @@ func (mr *MockcartRepoMockRecorder) CreateCart(ctx, cart any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCart", reflect.TypeOf((*MockcartRepo)(nil).CreateCart), ctx, cart)
 }
 
+// GetCartByToken mocks base method.
+func (m *MockcartRepo) GetCartByToken(ctx context.Context, cartToken string) (*entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartByToken", ctx, cartToken)
+	ret0, _ := ret[0].(*entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartByToken indicates an expected call of GetCartByToken.
+func (mr *MockcartRepoMockRecorder) GetCartByToken(ctx, cartToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByToken", reflect.TypeOf((*MockcartRepo)(nil).GetCartByToken), ctx, cartToken)
+}
+
 // GetCartByUserID mocks base method.
 func (m *MockcartRepo) GetCartByUserID(ctx context.Context, userID uuid.UUID) (*entity.Cart, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +102,62 @@ func (mr *MockcartRepoMockRecorder) GetCartByUserID(ctx, userID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserID", reflect.TypeOf((*MockcartRepo)(nil).GetCartByUserID), ctx, userID)
 }
 
+// MergeCart mocks base method.
+func (m *MockcartRepo) MergeCart(ctx context.Context, guestCartID uuid.UUID, cart entity.Cart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCart", ctx, guestCartID, cart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCart indicates an expected call of MergeCart.
+func (mr *MockcartRepoMockRecorder) MergeCart(ctx, guestCartID, cart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCart", reflect.TypeOf((*MockcartRepo)(nil).MergeCart), ctx, guestCartID, cart)
+}
+
+// RemoveCartItem mocks base method.
+func (m *MockcartRepo) RemoveCartItem(ctx context.Context, cartID uuid.UUID, productID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCartItem", ctx, cartID, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCartItem indicates an expected call of RemoveCartItem.
+func (mr *MockcartRepoMockRecorder) RemoveCartItem(ctx, cartID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockcartRepo)(nil).RemoveCartItem), ctx, cartID, productID)
+}
+
+// SetCartCoupon mocks base method.
+func (m *MockcartRepo) SetCartCoupon(ctx context.Context, cartID uuid.UUID, couponCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartCoupon", ctx, cartID, couponCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartCoupon indicates an expected call of SetCartCoupon.
+func (mr *MockcartRepoMockRecorder) SetCartCoupon(ctx, cartID, couponCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartCoupon", reflect.TypeOf((*MockcartRepo)(nil).SetCartCoupon), ctx, cartID, couponCode)
+}
+
+// SetCartItemQuantity mocks base method.
+func (m *MockcartRepo) SetCartItemQuantity(ctx context.Context, cartID uuid.UUID, productID string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartItemQuantity", ctx, cartID, productID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartItemQuantity indicates an expected call of SetCartItemQuantity.
+func (mr *MockcartRepoMockRecorder) SetCartItemQuantity(ctx, cartID, productID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartItemQuantity", reflect.TypeOf((*MockcartRepo)(nil).SetCartItemQuantity), ctx, cartID, productID, quantity)
+}
+
 // UpdateCartItem mocks base method.
 func (m *MockcartRepo) UpdateCartItem(ctx context.Context, item entity.CartItem) error {
 	m.ctrl.T.Helper()
@@ -111,6 +196,34 @@ func (m *MockorderRepo) EXPECT() *MockorderRepoMockRecorder {
 	return m.recorder
 }
 
+// ClaimOrderTransition mocks base method.
+func (m *MockorderRepo) ClaimOrderTransition(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOrderTransition", ctx, orderID, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimOrderTransition indicates an expected call of ClaimOrderTransition.
+func (mr *MockorderRepoMockRecorder) ClaimOrderTransition(ctx, orderID, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOrderTransition", reflect.TypeOf((*MockorderRepo)(nil).ClaimOrderTransition), ctx, orderID, change)
+}
+
+// CompleteRefund mocks base method.
+func (m *MockorderRepo) CompleteRefund(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", ctx, refund, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund.
+func (mr *MockorderRepoMockRecorder) CompleteRefund(ctx, refund, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockorderRepo)(nil).CompleteRefund), ctx, refund, change)
+}
+
 // CreateOrder mocks base method.
 func (m *MockorderRepo) CreateOrder(ctx context.Context, order entity.Order) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +239,34 @@ func (mr *MockorderRepoMockRecorder) CreateOrder(ctx, order any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockorderRepo)(nil).CreateOrder), ctx, order)
 }
 
+// CreateRefund mocks base method.
+func (m *MockorderRepo) CreateRefund(ctx context.Context, refund entity.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", ctx, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockorderRepoMockRecorder) CreateRefund(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockorderRepo)(nil).CreateRefund), ctx, refund)
+}
+
+// FailRefund mocks base method.
+func (m *MockorderRepo) FailRefund(ctx context.Context, refundID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRefund", ctx, refundID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRefund indicates an expected call of FailRefund.
+func (mr *MockorderRepoMockRecorder) FailRefund(ctx, refundID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRefund", reflect.TypeOf((*MockorderRepo)(nil).FailRefund), ctx, refundID)
+}
+
 // GetExpiryOrders mocks base method.
 func (m *MockorderRepo) GetExpiryOrders(ctx context.Context, duration time.Duration) ([]entity.Order, error) {
 	m.ctrl.T.Helper()
@@ -201,30 +342,436 @@ func (mr *MockorderRepoMockRecorder) GetOrderList(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderList", reflect.TypeOf((*MockorderRepo)(nil).GetOrderList), ctx, req)
 }
 
-// UpdateOrder mocks base method.
-func (m *MockorderRepo) UpdateOrder(ctx context.Context, payloads map[string]any, orderID uuid.UUID) error {
+// GetOrderRefunds mocks base method.
+func (m *MockorderRepo) GetOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]entity.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefunds", ctx, orderID)
+	ret0, _ := ret[0].([]entity.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefunds indicates an expected call of GetOrderRefunds.
+func (mr *MockorderRepoMockRecorder) GetOrderRefunds(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockorderRepo)(nil).GetOrderRefunds), ctx, orderID)
+}
+
+// GetOrderStatusHistory mocks base method.
+func (m *MockorderRepo) GetOrderStatusHistory(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStatusHistory", ctx, orderID)
+	ret0, _ := ret[0].([]entity.OrderStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderStatusHistory indicates an expected call of GetOrderStatusHistory.
+func (mr *MockorderRepoMockRecorder) GetOrderStatusHistory(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatusHistory", reflect.TypeOf((*MockorderRepo)(nil).GetOrderStatusHistory), ctx, orderID)
+}
+
+// GetPendingRefunds mocks base method.
+func (m *MockorderRepo) GetPendingRefunds(ctx context.Context) ([]entity.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingRefunds", ctx)
+	ret0, _ := ret[0].([]entity.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingRefunds indicates an expected call of GetPendingRefunds.
+func (mr *MockorderRepoMockRecorder) GetPendingRefunds(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRefunds", reflect.TypeOf((*MockorderRepo)(nil).GetPendingRefunds), ctx)
+}
+
+// ReleaseOrderTransition mocks base method.
+func (m *MockorderRepo) ReleaseOrderTransition(ctx context.Context, orderID uuid.UUID, to constanta.OrderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseOrderTransition", ctx, orderID, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseOrderTransition indicates an expected call of ReleaseOrderTransition.
+func (mr *MockorderRepoMockRecorder) ReleaseOrderTransition(ctx, orderID, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseOrderTransition", reflect.TypeOf((*MockorderRepo)(nil).ReleaseOrderTransition), ctx, orderID, to)
+}
+
+// UpdateFulfillment mocks base method.
+func (m *MockorderRepo) UpdateFulfillment(ctx context.Context, orderID uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFulfillment", ctx, orderID, change, shipment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFulfillment indicates an expected call of UpdateFulfillment.
+func (mr *MockorderRepoMockRecorder) UpdateFulfillment(ctx, orderID, change, shipment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFulfillment", reflect.TypeOf((*MockorderRepo)(nil).UpdateFulfillment), ctx, orderID, change, shipment)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockorderRepo) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, orderID, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockorderRepoMockRecorder) UpdateOrderStatus(ctx, orderID, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockorderRepo)(nil).UpdateOrderStatus), ctx, orderID, change)
+}
+
+// MocksagaRepo is a mock of sagaRepo interface.
+type MocksagaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocksagaRepoMockRecorder
+	isgomock struct{}
+}
+
+// MocksagaRepoMockRecorder is the mock recorder for MocksagaRepo.
+type MocksagaRepoMockRecorder struct {
+	mock *MocksagaRepo
+}
+
+// NewMocksagaRepo creates a new mock instance.
+func NewMocksagaRepo(ctrl *gomock.Controller) *MocksagaRepo {
+	mock := &MocksagaRepo{ctrl: ctrl}
+	mock.recorder = &MocksagaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksagaRepo) EXPECT() *MocksagaRepoMockRecorder {
+	return m.recorder
+}
+
+// CompleteSagaStep mocks base method.
+func (m *MocksagaRepo) CompleteSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, result string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSagaStep", ctx, orderID, step, reference, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteSagaStep indicates an expected call of CompleteSagaStep.
+func (mr *MocksagaRepoMockRecorder) CompleteSagaStep(ctx, orderID, step, reference, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSagaStep", reflect.TypeOf((*MocksagaRepo)(nil).CompleteSagaStep), ctx, orderID, step, reference, result)
+}
+
+// DeadLetterSagaStep mocks base method.
+func (m *MocksagaRepo) DeadLetterSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrder", ctx, payloads, orderID)
+	ret := m.ctrl.Call(m, "DeadLetterSagaStep", ctx, orderID, step, reference, errMessage)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateOrder indicates an expected call of UpdateOrder.
-func (mr *MockorderRepoMockRecorder) UpdateOrder(ctx, payloads, orderID any) *gomock.Call {
+// DeadLetterSagaStep indicates an expected call of DeadLetterSagaStep.
+func (mr *MocksagaRepoMockRecorder) DeadLetterSagaStep(ctx, orderID, step, reference, errMessage any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockorderRepo)(nil).UpdateOrder), ctx, payloads, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetterSagaStep", reflect.TypeOf((*MocksagaRepo)(nil).DeadLetterSagaStep), ctx, orderID, step, reference, errMessage)
 }
 
-// UpdateOrderStatusWithCallback mocks base method.
-func (m *MockorderRepo) UpdateOrderStatusWithCallback(ctx context.Context, status constanta.OrderStatus, orderID uuid.UUID, callback func() error) error {
+// FailSagaStep mocks base method.
+func (m *MocksagaRepo) FailSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string, retryAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatusWithCallback", ctx, status, orderID, callback)
+	ret := m.ctrl.Call(m, "FailSagaStep", ctx, orderID, step, reference, errMessage, retryAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateOrderStatusWithCallback indicates an expected call of UpdateOrderStatusWithCallback.
-func (mr *MockorderRepoMockRecorder) UpdateOrderStatusWithCallback(ctx, status, orderID, callback any) *gomock.Call {
+// FailSagaStep indicates an expected call of FailSagaStep.
+func (mr *MocksagaRepoMockRecorder) FailSagaStep(ctx, orderID, step, reference, errMessage, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailSagaStep", reflect.TypeOf((*MocksagaRepo)(nil).FailSagaStep), ctx, orderID, step, reference, errMessage, retryAt)
+}
+
+// GetDeadLetterCompensations mocks base method.
+func (m *MocksagaRepo) GetDeadLetterCompensations(ctx context.Context) ([]entity.DeadLetterCompensation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetterCompensations", ctx)
+	ret0, _ := ret[0].([]entity.DeadLetterCompensation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetterCompensations indicates an expected call of GetDeadLetterCompensations.
+func (mr *MocksagaRepoMockRecorder) GetDeadLetterCompensations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetterCompensations", reflect.TypeOf((*MocksagaRepo)(nil).GetDeadLetterCompensations), ctx)
+}
+
+// GetInFlightSagas mocks base method.
+func (m *MocksagaRepo) GetInFlightSagas(ctx context.Context) ([]entity.Saga, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInFlightSagas", ctx)
+	ret0, _ := ret[0].([]entity.Saga)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInFlightSagas indicates an expected call of GetInFlightSagas.
+func (mr *MocksagaRepoMockRecorder) GetInFlightSagas(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInFlightSagas", reflect.TypeOf((*MocksagaRepo)(nil).GetInFlightSagas), ctx)
+}
+
+// GetSagaSteps mocks base method.
+func (m *MocksagaRepo) GetSagaSteps(ctx context.Context, orderID uuid.UUID) ([]entity.SagaStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagaSteps", ctx, orderID)
+	ret0, _ := ret[0].([]entity.SagaStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagaSteps indicates an expected call of GetSagaSteps.
+func (mr *MocksagaRepoMockRecorder) GetSagaSteps(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagaSteps", reflect.TypeOf((*MocksagaRepo)(nil).GetSagaSteps), ctx, orderID)
+}
+
+// GetSagasWithDueCompensations mocks base method.
+func (m *MocksagaRepo) GetSagasWithDueCompensations(ctx context.Context, now time.Time) ([]entity.Saga, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSagasWithDueCompensations", ctx, now)
+	ret0, _ := ret[0].([]entity.Saga)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSagasWithDueCompensations indicates an expected call of GetSagasWithDueCompensations.
+func (mr *MocksagaRepoMockRecorder) GetSagasWithDueCompensations(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSagasWithDueCompensations", reflect.TypeOf((*MocksagaRepo)(nil).GetSagasWithDueCompensations), ctx, now)
+}
+
+// StartSagaStep mocks base method.
+func (m *MocksagaRepo) StartSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSagaStep", ctx, orderID, step, reference)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSagaStep indicates an expected call of StartSagaStep.
+func (mr *MocksagaRepoMockRecorder) StartSagaStep(ctx, orderID, step, reference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSagaStep", reflect.TypeOf((*MocksagaRepo)(nil).StartSagaStep), ctx, orderID, step, reference)
+}
+
+// MockpromotionRepo is a mock of promotionRepo interface.
+type MockpromotionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromotionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockpromotionRepoMockRecorder is the mock recorder for MockpromotionRepo.
+type MockpromotionRepoMockRecorder struct {
+	mock *MockpromotionRepo
+}
+
+// NewMockpromotionRepo creates a new mock instance.
+func NewMockpromotionRepo(ctrl *gomock.Controller) *MockpromotionRepo {
+	mock := &MockpromotionRepo{ctrl: ctrl}
+	mock.recorder = &MockpromotionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpromotionRepo) EXPECT() *MockpromotionRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockpromotionRepo) CreatePromotion(ctx context.Context, promotion entity.Promotion) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, promotion)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockpromotionRepoMockRecorder) CreatePromotion(ctx, promotion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockpromotionRepo)(nil).CreatePromotion), ctx, promotion)
+}
+
+// GetPromotionByCode mocks base method.
+func (m *MockpromotionRepo) GetPromotionByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionByCode", ctx, code)
+	ret0, _ := ret[0].(*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionByCode indicates an expected call of GetPromotionByCode.
+func (mr *MockpromotionRepoMockRecorder) GetPromotionByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionByCode", reflect.TypeOf((*MockpromotionRepo)(nil).GetPromotionByCode), ctx, code)
+}
+
+// GetPromotionUsage mocks base method.
+func (m *MockpromotionRepo) GetPromotionUsage(ctx context.Context, promotionID, userID uuid.UUID) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionUsage", ctx, promotionID, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPromotionUsage indicates an expected call of GetPromotionUsage.
+func (mr *MockpromotionRepoMockRecorder) GetPromotionUsage(ctx, promotionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionUsage", reflect.TypeOf((*MockpromotionRepo)(nil).GetPromotionUsage), ctx, promotionID, userID)
+}
+
+// MockpricingRepo is a mock of pricingRepo interface.
+type MockpricingRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricingRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockpricingRepoMockRecorder is the mock recorder for MockpricingRepo.
+type MockpricingRepoMockRecorder struct {
+	mock *MockpricingRepo
+}
+
+// NewMockpricingRepo creates a new mock instance.
+func NewMockpricingRepo(ctrl *gomock.Controller) *MockpricingRepo {
+	mock := &MockpricingRepo{ctrl: ctrl}
+	mock.recorder = &MockpricingRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpricingRepo) EXPECT() *MockpricingRepoMockRecorder {
+	return m.recorder
+}
+
+// GetShippingRates mocks base method.
+func (m *MockpricingRepo) GetShippingRates(ctx context.Context) ([]entity.ShippingRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingRates", ctx)
+	ret0, _ := ret[0].([]entity.ShippingRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingRates indicates an expected call of GetShippingRates.
+func (mr *MockpricingRepoMockRecorder) GetShippingRates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingRates", reflect.TypeOf((*MockpricingRepo)(nil).GetShippingRates), ctx)
+}
+
+// GetTaxRules mocks base method.
+func (m *MockpricingRepo) GetTaxRules(ctx context.Context) ([]entity.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRules", ctx)
+	ret0, _ := ret[0].([]entity.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRules indicates an expected call of GetTaxRules.
+func (mr *MockpricingRepoMockRecorder) GetTaxRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRules", reflect.TypeOf((*MockpricingRepo)(nil).GetTaxRules), ctx)
+}
+
+// MockreturnRepo is a mock of returnRepo interface.
+type MockreturnRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockreturnRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockreturnRepoMockRecorder is the mock recorder for MockreturnRepo.
+type MockreturnRepoMockRecorder struct {
+	mock *MockreturnRepo
+}
+
+// NewMockreturnRepo creates a new mock instance.
+func NewMockreturnRepo(ctrl *gomock.Controller) *MockreturnRepo {
+	mock := &MockreturnRepo{ctrl: ctrl}
+	mock.recorder = &MockreturnRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreturnRepo) EXPECT() *MockreturnRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateReturn mocks base method.
+func (m *MockreturnRepo) CreateReturn(ctx context.Context, ret entity.Return) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "CreateReturn", ctx, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockreturnRepoMockRecorder) CreateReturn(ctx, ret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockreturnRepo)(nil).CreateReturn), ctx, ret)
+}
+
+// GetOrderReturns mocks base method.
+func (m *MockreturnRepo) GetOrderReturns(ctx context.Context, orderID uuid.UUID) ([]entity.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, orderID)
+	ret0, _ := ret[0].([]entity.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockreturnRepoMockRecorder) GetOrderReturns(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockreturnRepo)(nil).GetOrderReturns), ctx, orderID)
+}
+
+// GetReturnByID mocks base method.
+func (m *MockreturnRepo) GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnByID", ctx, returnID)
+	ret0, _ := ret[0].(*entity.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnByID indicates an expected call of GetReturnByID.
+func (mr *MockreturnRepoMockRecorder) GetReturnByID(ctx, returnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnByID", reflect.TypeOf((*MockreturnRepo)(nil).GetReturnByID), ctx, returnID)
+}
+
+// UpdateReturnStatus mocks base method.
+func (m *MockreturnRepo) UpdateReturnStatus(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "UpdateReturnStatus", ctx, ret, from)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// UpdateReturnStatus indicates an expected call of UpdateReturnStatus.
+func (mr *MockreturnRepoMockRecorder) UpdateReturnStatus(ctx, ret, from any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatusWithCallback", reflect.TypeOf((*MockorderRepo)(nil).UpdateOrderStatusWithCallback), ctx, status, orderID, callback)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnStatus", reflect.TypeOf((*MockreturnRepo)(nil).UpdateReturnStatus), ctx, ret, from)
 }
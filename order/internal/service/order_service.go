@@ -25,54 +25,124 @@ import (
 type (
 	cartRepo interface {
 		GetCartByUserID(ctx context.Context, userID uuid.UUID) (*entity.Cart, error)
+		GetCartByToken(ctx context.Context, cartToken string) (*entity.Cart, error)
 		CreateCart(ctx context.Context, cart entity.Cart) error
 		UpdateCartItem(ctx context.Context, item entity.CartItem) error
+		RemoveCartItem(ctx context.Context, cartID uuid.UUID, productID string) error
+		SetCartItemQuantity(ctx context.Context, cartID uuid.UUID, productID string, quantity int64) error
+		ClearCart(ctx context.Context, cartID uuid.UUID) error
+		MergeCart(ctx context.Context, guestCartID uuid.UUID, cart entity.Cart) error
+		SetCartCoupon(ctx context.Context, cartID uuid.UUID, couponCode string) error
 	}
 
 	orderRepo interface {
 		CreateOrder(ctx context.Context, order entity.Order) (uuid.UUID, error)
 		GetOrderByIdempotencyKey(ctx context.Context, idempotencyKey uuid.UUID) (*entity.Order, error)
-		UpdateOrder(ctx context.Context, payloads map[string]any, orderID uuid.UUID) error
+		UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error
+		ClaimOrderTransition(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error
+		ReleaseOrderTransition(ctx context.Context, orderID uuid.UUID, to constanta.OrderStatus) error
+		GetOrderStatusHistory(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error)
 		GetExpiryOrders(ctx context.Context, duration time.Duration) ([]entity.Order, error)
-		UpdateOrderStatusWithCallback(ctx context.Context, status constanta.OrderStatus, orderID uuid.UUID, callback func() error) error
 		GetOrderByTransactionID(ctx context.Context, transactionID string) (*entity.Order, error)
 		GetOrderByID(ctx context.Context, orderID uuid.UUID) (*entity.Order, error)
 		GetOrderList(ctx context.Context, req entity.GetOrderListRequest) ([]entity.Order, error)
+		UpdateFulfillment(ctx context.Context, orderID uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error
+		CreateRefund(ctx context.Context, refund entity.Refund) error
+		GetOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]entity.Refund, error)
+		GetPendingRefunds(ctx context.Context) ([]entity.Refund, error)
+		FailRefund(ctx context.Context, refundID uuid.UUID) error
+		CompleteRefund(ctx context.Context, refund entity.Refund, change entity.StatusChange) error
+	}
+
+	sagaRepo interface {
+		StartSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference string) (int64, error)
+		CompleteSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, result string) error
+		FailSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string, retryAt time.Time) error
+		DeadLetterSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string) error
+		GetSagaSteps(ctx context.Context, orderID uuid.UUID) ([]entity.SagaStep, error)
+		GetInFlightSagas(ctx context.Context) ([]entity.Saga, error)
+		GetSagasWithDueCompensations(ctx context.Context, now time.Time) ([]entity.Saga, error)
+		GetDeadLetterCompensations(ctx context.Context) ([]entity.DeadLetterCompensation, error)
+	}
+
+	promotionRepo interface {
+		CreatePromotion(ctx context.Context, promotion entity.Promotion) (uuid.UUID, error)
+		GetPromotionByCode(ctx context.Context, code string) (*entity.Promotion, error)
+		GetPromotionUsage(ctx context.Context, promotionID, userID uuid.UUID) (int64, int64, error)
+	}
+
+	pricingRepo interface {
+		GetTaxRules(ctx context.Context) ([]entity.TaxRule, error)
+		GetShippingRates(ctx context.Context) ([]entity.ShippingRate, error)
+	}
+
+	returnRepo interface {
+		CreateReturn(ctx context.Context, ret entity.Return) error
+		GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.Return, error)
+		GetOrderReturns(ctx context.Context, orderID uuid.UUID) ([]entity.Return, error)
+		UpdateReturnStatus(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error
 	}
 )
 
 type OrderService struct {
 	orderRepo              orderRepo
 	cartRepo               cartRepo
+	sagaRepo               sagaRepo
+	promotionRepo          promotionRepo
+	pricingRepo            pricingRepo
+	returnRepo             returnRepo
 	warehouseServiceClient gen.WarehouseServiceClient
 	productServiceClient   gen.ProductServiceClient
 	paymentServiceClient   gen.PaymentServiceClient
+	retryPolicy            CompensationRetryPolicy
+	rateProvider           money.ExchangeRateProvider
+	// defaultCurrency is used for guests and users without a currency preference
+	defaultCurrency string
+	// reservationTTL is how long the warehouse service keeps the stock of the order reserved,
+	// the default of the warehouse service is used when it is zero
+	reservationTTL time.Duration
 	gen.UnimplementedOrderServiceServer
 }
 
 func NewOrderService(
 	orderRepo orderRepo,
 	cartRepo cartRepo,
+	sagaRepo sagaRepo,
+	promotionRepo promotionRepo,
+	pricingRepo pricingRepo,
+	returnRepo returnRepo,
 	warehouseServiceClient gen.WarehouseServiceClient,
 	productServiceClient gen.ProductServiceClient,
 	paymentServiceClient gen.PaymentServiceClient,
+	retryPolicy CompensationRetryPolicy,
+	rateProvider money.ExchangeRateProvider,
+	defaultCurrency string,
+	reservationTTL time.Duration,
 ) *OrderService {
 	return &OrderService{
 		orderRepo:              orderRepo,
 		cartRepo:               cartRepo,
+		sagaRepo:               sagaRepo,
+		promotionRepo:          promotionRepo,
+		pricingRepo:            pricingRepo,
+		returnRepo:             returnRepo,
 		warehouseServiceClient: warehouseServiceClient,
 		productServiceClient:   productServiceClient,
 		paymentServiceClient:   paymentServiceClient,
+		retryPolicy:            retryPolicy,
+		rateProvider:           rateProvider,
+		defaultCurrency:        defaultCurrency,
+		reservationTTL:         reservationTTL,
 	}
 }
 
 func (s *OrderService) AddProductToCart(ctx context.Context, req *gen.AddCartItemRequest) (*gen.Empty, error) {
-	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	owner, err := cartOwnerFromMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	cart, err := s.cartRepo.GetCartByUserID(ctx, userID)
+	cart, err := s.getCart(ctx, owner)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
@@ -97,7 +167,8 @@ func (s *OrderService) AddProductToCart(ctx context.Context, req *gen.AddCartIte
 
 	if cart == nil {
 		cart = &entity.Cart{
-			UserID: userID,
+			UserID:    owner.UserID,
+			CartToken: owner.CartToken,
 			Items: []entity.CartItem{
 				{
 					ProductID: req.ProductId,
@@ -132,12 +203,12 @@ func (s *OrderService) AddProductToCart(ctx context.Context, req *gen.AddCartIte
 }
 
 func (s *OrderService) GetCart(ctx context.Context, req *gen.Empty) (*gen.Cart, error) {
-	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	owner, err := cartOwnerFromMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	cart, err := s.cartRepo.GetCartByUserID(ctx, userID)
+	cart, err := s.getCart(ctx, owner)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "cart not found")
@@ -145,32 +216,206 @@ func (s *OrderService) GetCart(ctx context.Context, req *gen.Empty) (*gen.Cart,
 		return nil, err
 	}
 
-	productIDs := make([]string, 0, len(cart.Items))
-	for _, item := range cart.Items {
-		productIDs = append(productIDs, item.ProductID)
+	_, err = s.calculateCart(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return cart.GetGenCart(), nil
+}
+
+func (s *OrderService) RemoveCartItem(ctx context.Context, req *gen.RemoveCartItemRequest) (*gen.Empty, error) {
+	owner, err := cartOwnerFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id cannot be empty")
 	}
 
-	stocks, err := s.warehouseServiceClient.GetStocks(ctx, &gen.GetStockRequest{
-		ProductIds: productIDs,
+	cart, err := s.getCart(ctx, owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "cart not found")
+		}
+		return nil, err
+	}
+
+	err = s.cartRepo.RemoveCartItem(ctx, cart.ID, req.ProductId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "product not found in cart")
+		}
+		return nil, err
+	}
+
+	return &gen.Empty{}, nil
+}
+
+func (s *OrderService) SetCartItemQuantity(ctx context.Context, req *gen.SetCartItemQuantityRequest) (*gen.Empty, error) {
+	if req.Quantity == 0 {
+		return s.RemoveCartItem(ctx, &gen.RemoveCartItemRequest{
+			ProductId: req.ProductId,
+		})
+	}
+
+	owner, err := cartOwnerFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id cannot be empty")
+	}
+
+	if req.Quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity cannot be negative")
+	}
+
+	cart, err := s.getCart(ctx, owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "cart not found")
+		}
+		return nil, err
+	}
+
+	products, err := s.productServiceClient.GetProducts(ctx, &gen.GetProductsRequest{
+		Ids:       []string{req.ProductId},
+		WithStock: true,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if products == nil || len(products.Products) == 0 {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
 
-	stockMap := make(map[string]int64)
-	for _, stock := range stocks.Stocks {
-		stockMap[stock.ProductId] = stock.Quantity
+	product := products.Products[0]
+
+	if req.Quantity > product.Stock {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity cannot exceed the maximum stock, current stock is %d", product.Stock)
 	}
 
-	for i, item := range cart.Items {
-		if stock, ok := stockMap[item.ProductID]; ok {
-			cart.Items[i].ActualStock = stock
-		} else {
-			cart.Items[i].ActualStock = 0
+	err = s.cartRepo.SetCartItemQuantity(ctx, cart.ID, req.ProductId, req.Quantity)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "product not found in cart")
 		}
+		return nil, err
 	}
 
-	return cart.GetGenCart(), nil
+	return &gen.Empty{}, nil
+}
+
+func (s *OrderService) ClearCart(ctx context.Context, req *gen.Empty) (*gen.Empty, error) {
+	owner, err := cartOwnerFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.getCart(ctx, owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// nothing to clear
+			return &gen.Empty{}, nil
+		}
+		return nil, err
+	}
+
+	err = s.cartRepo.ClearCart(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.Empty{}, nil
+}
+
+// MergeCart folds the guest cart into the user cart after login.
+// The quantity of the same product is summed and capped by the current stock,
+// products that are no longer available are dropped
+func (s *OrderService) MergeCart(ctx context.Context, req *gen.MergeCartRequest) (*gen.Empty, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CartToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "cart_token cannot be empty")
+	}
+
+	guestCart, err := s.cartRepo.GetCartByToken(ctx, req.CartToken)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// nothing to merge
+			return &gen.Empty{}, nil
+		}
+		return nil, err
+	}
+
+	userCart, err := s.cartRepo.GetCartByUserID(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		userCart = &entity.Cart{UserID: userID}
+	}
+
+	mergedCart := entity.Cart{
+		ID:         userCart.ID,
+		UserID:     userID,
+		CouponCode: userCart.CouponCode,
+	}
+	if mergedCart.CouponCode == "" {
+		// the coupon of the guest is kept, it is validated again for the user when ordering
+		mergedCart.CouponCode = guestCart.CouponCode
+	}
+
+	productIDs := guestCart.GetProductIDs()
+	if len(productIDs) > 0 {
+		products, err := s.productServiceClient.GetProducts(ctx, &gen.GetProductsRequest{
+			Ids:       productIDs,
+			WithStock: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		productMap := make(map[string]*gen.Product)
+		for _, product := range products.GetProducts() {
+			productMap[product.Id] = product
+		}
+
+		userQuantities := make(map[string]int64)
+		for _, item := range userCart.Items {
+			userQuantities[item.ProductID] = item.Quantity
+		}
+
+		for _, item := range guestCart.Items {
+			product, ok := productMap[item.ProductID]
+			if !ok || product.Stock <= 0 {
+				fmt.Printf("product %s is not available, skipped from merged cart\n", item.ProductID)
+				continue
+			}
+
+			quantity := min(userQuantities[item.ProductID]+item.Quantity, product.Stock)
+
+			mergedCart.Items = append(mergedCart.Items, entity.CartItem{
+				ProductID: item.ProductID,
+				Quantity:  quantity,
+				Name:      product.GetName(),
+				Price:     product.GetPrice(),
+			})
+		}
+	}
+
+	err = s.cartRepo.MergeCart(ctx, guestCart.ID, mergedCart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.Empty{}, nil
 }
 
 func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequest) (*gen.Order, error) {
@@ -179,6 +424,20 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 		return nil, errors.New("invalid idempotency_key format")
 	}
 
+	// the region of the shipping address wins over the shipping region of the request
+	shippingRegion := normalizeRegion(req.GetShippingRegion())
+	var shipment *entity.Shipment
+	if req.GetShippingAddress() != nil {
+		address, err := entity.NewShippingAddress(req.GetShippingAddress())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		address.Region = normalizeRegion(address.Region)
+		shippingRegion = address.Region
+		shipment = &entity.Shipment{Address: *address}
+	}
+
 	ord, err := s.orderRepo.GetOrderByIdempotencyKey(ctx, idempotencyKey)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
@@ -206,59 +465,51 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 		}
 	}
 
-	// Enforce single-currency cart (required to safely sum totalAmount)
-	var cartCurrency string
-	orderItems := make([]entity.OrderItem, 0, len(cart.Items))
-	totalAmount, _ := money.New(0, "IDR")
-
-	var withStock = false
-	products, err := s.productServiceClient.GetProducts(ctx, &gen.GetProductsRequest{
-		Ids:       cart.GetProductIDs(),
-		WithStock: withStock,
-	})
+	promotion, err := s.calculateCart(ctx, cart)
 	if err != nil {
-		return nil, errors.New("failed to fetch products")
+		return nil, err
 	}
 
-	productsMap := make(map[string]*gen.Product)
-	for _, product := range products.Products {
-		productsMap[product.Id] = product
+	issues := cart.GetIssues()
+	if len(issues) > 0 {
+		return nil, s.refuseCartIssues(ctx, cart, issues)
 	}
 
-	for _, item := range cart.Items {
-		product, ok := productsMap[item.ProductID]
-		if !ok {
-			return nil, status.Errorf(codes.NotFound, "product not found")
-		}
-
-		price := product.GetPrice()
-		if price == nil {
-			return nil, fmt.Errorf("product %s has no price", item.ProductID)
-		}
-
-		// Validate currency consistency
-		if cartCurrency == "" {
-			cartCurrency = price.GetCurrencyCode()
-		} else if cartCurrency != price.GetCurrencyCode() {
-			return nil, status.Errorf(codes.InvalidArgument, "mixed currencies in cart are not supported")
-		}
+	err = s.priceOrder(ctx, cart, shippingRegion)
+	if err != nil {
+		return nil, err
+	}
 
-		totalPricePerUnit, err := money.MultiplyByInt(price, item.Quantity)
+	orderItems := make([]entity.OrderItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		totalPricePerUnit, err := money.MultiplyByInt(item.CurrentPrice, item.Quantity)
 		if err != nil {
 			return nil, fmt.Errorf("failed to calculate total price for product %s: %w", item.ProductID, err)
 		}
 
 		orderItems = append(orderItems, entity.OrderItem{
 			ProductID:         item.ProductID,
-			Name:              product.GetName(),
-			PricePerUnit:      price,
+			Name:              item.Name,
+			PricePerUnit:      item.CurrentPrice,
 			Quantity:          item.Quantity,
 			TotalPricePerUnit: totalPricePerUnit,
+			SettlementTotal:   item.SettlementTotal,
+			ExchangeRate:      item.ExchangeRate,
+			Discount:          item.Discount,
+			Tax:               item.Tax,
+			TaxRate:           item.TaxRate,
+			TaxInclusive:      item.TaxInclusive,
 		})
+	}
+	// the order is paid in the settlement currency of the user after the coupon discount, the tax and the shipping
+	totalAmount := cart.GrandTotal
 
-		totalAmount, err = money.Add(totalAmount, totalPricePerUnit)
+	// the first payment may only pay a part of the order, the rest is paid by AddOrderPayment
+	paymentAmount := totalAmount
+	if req.GetPaymentAmount() != nil {
+		paymentAmount, err = validatePaymentAmount(req.PaymentAmount, totalAmount)
 		if err != nil {
-			return nil, fmt.Errorf("failed to accumulate total amount: %w", err)
+			return nil, err
 		}
 	}
 
@@ -268,20 +519,28 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 		Status:         constanta.OrderStatusPending, // New initial status
 		Items:          orderItems,
 		TotalAmount:    totalAmount,
+		SubtotalAmount: cart.Subtotal,
+		DiscountAmount: cart.Discount,
+		TaxAmount:      cart.Tax,
+		ShippingAmount: cart.Shipping,
+		ShippingRegion: shippingRegion,
+		Shipment:       shipment,
+	}
+	if promotion != nil {
+		order.CouponCode = promotion.Code
+		order.PromotionID = promotion.ID
 	}
 
 	orderID, err := s.orderRepo.CreateOrder(ctx, order)
 	if err != nil {
+		// another order has used the last usage of the coupon since the cart is checked
+		if errors.Is(err, entity.ErrCouponNotApplicable) {
+			cart.CouponIssue = err.Error()
+			return nil, s.refuseCartIssues(ctx, cart, cart.GetIssues())
+		}
 		return nil, fmt.Errorf("failed to persist order: %w", err)
 	}
 
-	// Create a rollback function for cleanup
-	rollback := func() error {
-		return s.orderRepo.UpdateOrder(ctx, map[string]any{
-			"status": constanta.OrderStatusFailed,
-		}, orderID)
-	}
-
 	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, userID)
 
 	stocks := []*gen.Stock{}
@@ -293,12 +552,20 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 	}
 
 	// Reserve stock
-	_, err = s.warehouseServiceClient.ReserveStock(ctx, &gen.ReserveStockRequest{
-		OrderId: orderID.String(),
-		Stocks:  stocks,
+	_, err = s.runSagaStep(ctx, orderID, constanta.SagaStepReserveStock, "", func(ctx context.Context) (string, error) {
+		reserveStockReq := &gen.ReserveStockRequest{
+			OrderId: orderID.String(),
+			Stocks:  stocks,
+		}
+		if s.reservationTTL > 0 {
+			reserveStockReq.ExpiresAt = time.Now().Add(s.reservationTTL).UTC().Format(time.RFC3339)
+		}
+		_, err := s.warehouseServiceClient.ReserveStock(ctx, reserveStockReq)
+		return "", err
 	})
 	if err != nil {
-		rollbackErr := rollback()
+		// nothing is reserved, only the order must be failed
+		rollbackErr := s.failOrder(ctx, orderID, "", fmt.Sprintf("failed to reserve stock: %v", err))
 		if rollbackErr != nil {
 			// Log this error - partial failure state
 			fmt.Printf("Error during rollback: %v", rollbackErr)
@@ -307,22 +574,25 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 	}
 
 	// Process payment
-	paymentTransaction, err := s.paymentServiceClient.ProcessPayment(ctx, &gen.ProcessPaymentRequest{
-		OrderId:     orderID.String(),
-		TotalAmount: totalAmount,
-	})
-	if err != nil {
-		// Release reserved stock
-		_, releaseErr := s.warehouseServiceClient.ReleaseStock(ctx, &gen.ReleaseStockRequest{
-			OrderId: orderID.String(),
+	transactionID, err := s.runSagaStep(ctx, orderID, constanta.SagaStepProcessPayment, "", func(ctx context.Context) (string, error) {
+		paymentTransaction, err := s.paymentServiceClient.ProcessPayment(ctx, &gen.ProcessPaymentRequest{
+			OrderId:     orderID.String(),
+			TotalAmount: paymentAmount,
+			Method:      req.GetPaymentMethod(),
+			OrderAmount: totalAmount,
 		})
-		if releaseErr != nil {
-			// Log - stock might be stuck in reserved state
-			fmt.Printf("Error releasing stock during payment failure: %v", releaseErr)
+		if err != nil {
+			return "", err
 		}
-
-		rollbackErr := rollback()
+		return paymentTransaction.TransactionId, nil
+	})
+	if err != nil {
+		// the payment might be created even though the call failed, e.g. it timed out,
+		// so every payment of the order is rolled back before the reserved stock is released
+		rollbackErr := s.failOrder(ctx, orderID, "", fmt.Sprintf("failed to process payment: %v", err),
+			constanta.SagaStepRollbackPayment, constanta.SagaStepReleaseStock)
 		if rollbackErr != nil {
+			// Log - stock might be stuck in reserved state
 			fmt.Printf("Error during rollback: %v", rollbackErr)
 		}
 
@@ -330,22 +600,25 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *gen.CreateOrderRequ
 	}
 
 	// Update order status to indicate stock is reserved and include transaction ID
-	err = s.orderRepo.UpdateOrder(ctx, map[string]any{
-		"status":         constanta.OrderStatusStockReserved,
-		"transaction_id": paymentTransaction.TransactionId,
-	}, orderID)
-	if err != nil {
-		// Payment succeeded but status update failed - log this inconsistency
-		fmt.Printf("Payment succeeded but status update failed: %v", err)
-		// Consider whether to return an error or continue with partial success
-		// For now, we'll return the error to indicate the operation didn't complete successfully
+	order.ID = orderID
+	err = s.transitionOrder(ctx, &order, entity.StatusChange{
+		To:            constanta.OrderStatusStockReserved,
+		Reason:        "stock reserved and payment created",
+		Actor:         constanta.OrderActorSystem,
+		TransactionID: transactionID,
+	})
+	if err != nil {
+		// Payment succeeded but status update failed, undo the payment and the reservation
+		// so the customer cannot pay an order that is not tracked
+		rollbackErr := s.failOrder(ctx, orderID, transactionID, fmt.Sprintf("failed to update order with transaction ID: %v", err),
+			constanta.SagaStepRollbackPayment, constanta.SagaStepReleaseStock)
+		if rollbackErr != nil {
+			fmt.Printf("Error during rollback: %v", rollbackErr)
+		}
+
 		return nil, fmt.Errorf("failed to update order with transaction ID: %w", err)
 	}
 
-	order.ID = orderID
-	order.Status = constanta.OrderStatusStockReserved
-	order.TransactionID = paymentTransaction.TransactionId
-
 	return order.GetGenOrder(), nil
 }
 
@@ -356,45 +629,35 @@ func (s *OrderService) RemoveExpiryOrder(ctx context.Context, duration time.Dura
 	}
 
 	for _, order := range orders {
-
-		if order.Status == constanta.OrderStatusPending {
-			// TODO must be retry flow, process the cause of pending order
-			// if the cause is stock reservation failed, then release stock
-			// if the cause is payment failed, then update status to failed
-			// if the cause is repository is failed, then update status to failed
-			// if the cause is unknown, then update status to failed
-			err = s.orderRepo.UpdateOrder(ctx, map[string]any{
-				"status": constanta.OrderStatusFailed,
-			}, order.ID)
-			if err != nil {
-				fmt.Println("err when Update status", err)
-			}
-		}
-
+		// TODO must be retry flow, process the cause of pending order
+		// if the cause is stock reservation failed, then release stock
+		// if the cause is payment failed, then update status to failed
+		// if the cause is repository is failed, then update status to failed
+		// if the cause is unknown, then update status to failed
+		reason := "order expired before the stock is reserved"
 		if order.Status == constanta.OrderStatusStockReserved {
-			var releaseStock = func() error {
-				ctx := contextrequest.AppendUserIDintoContextGrpcClient(context.Background(), order.UserID)
-				_, err := s.warehouseServiceClient.ReleaseStock(ctx, &gen.ReleaseStockRequest{
-					OrderId: order.ID.String(),
-				})
-				if err != nil {
-					return err
-				}
-
-				return nil
-			}
-
-			err := s.orderRepo.UpdateOrderStatusWithCallback(ctx, constanta.OrderStatusFailed, order.ID, releaseStock)
-			if err != nil {
-				fmt.Println("err when Release Stock", err)
-			}
+			reason = "order expired before the payment"
+		}
 
+		// the reserved stock is released by the state machine once the order is failed.
+		// a payment callback that comes in the meantime wins the guarded update, the order is not failed then
+		ctx := contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+		err = s.transitionOrder(ctx, &order, entity.StatusChange{
+			To:     constanta.OrderStatusFailed,
+			Reason: reason,
+			Actor:  constanta.OrderActorSystem,
+		})
+		if err != nil {
+			fmt.Println("err when Update status", err)
 		}
 	}
 
 	return len(orders), nil
 }
 
+// CallbackTransaction applies the payment of the order that is settled by payment service.
+// The order is completed once the paid payments cover its total amount,
+// a failed payment does not fail the order, the customer can pay it with another payment until the order expires
 func (s *OrderService) CallbackTransaction(ctx context.Context, req *gen.CallbackTransactionRequest) (*gen.Empty, error) {
 	if req.PaymentStatus == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "payment_status cannot be empty")
@@ -406,41 +669,136 @@ func (s *OrderService) CallbackTransaction(ctx context.Context, req *gen.Callbac
 		return nil, err
 	}
 
-	if paymentStatus.String() == "UNKNOWN" {
+	if paymentStatus != constanta.PAID && paymentStatus != constanta.FAILED {
 		return nil, status.Errorf(codes.InvalidArgument, "payment_status is %s, must be one of %s or %s", paymentStatus.String(), constanta.PAID, constanta.FAILED)
 	}
 
-	order, err := s.orderRepo.GetOrderByTransactionID(ctx, req.TransactionId)
+	order, err := s.getCallbackOrder(ctx, req)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "order not found")
 		}
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
-	if order.Status != constanta.OrderStatusStockReserved {
-		return nil, status.Errorf(codes.FailedPrecondition, "order status must be status reserved")
+	if paymentStatus == constanta.FAILED {
+		return &gen.Empty{}, nil
+	}
+
+	// payment service delivers the callback at least once,
+	// the same callback is accepted again without changing the order
+	if order.Status == constanta.OrderStatusCompleted {
+		s.refundOverpaidOrder(ctx, order, req.GetPaidAmount())
+		return &gen.Empty{}, nil
+	}
+
+	if !order.Status.CanTransitionTo(constanta.OrderStatusCompleted) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be %s", order.Status, constanta.OrderStatusCompleted)
+	}
+
+	// the callback without paid amount is sent for the order that is paid by a single payment
+	if req.GetPaidAmount() != nil {
+		if req.PaidAmount.GetCurrencyCode() != order.TotalAmount.GetCurrencyCode() {
+			return nil, status.Errorf(codes.InvalidArgument, "currency code not match")
+		}
+
+		if req.PaidAmount.GetUnits() < order.TotalAmount.GetUnits() {
+			fmt.Printf("Order %s is paid %d of %d, waiting for the rest\n", order.ID, req.PaidAmount.GetUnits(), order.TotalAmount.GetUnits())
+			return &gen.Empty{}, nil
+		}
+	}
+
+	// the paid order confirms the stock so it cannot be released anymore,
+	// when the confirmation fails the callback is retried by payment service
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+	err = s.transitionOrder(ctx, order, entity.StatusChange{
+		To:     constanta.OrderStatusCompleted,
+		Reason: fmt.Sprintf("payment %s", paymentStatus),
+		Actor:  constanta.OrderActorPayment,
+	})
+	if err != nil {
+		var stepErr *transitionStepError
+		if errors.As(err, &stepErr) {
+			return nil, status.Errorf(codes.Internal, "failed to confirm stock: %v", stepErr.err)
+		}
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
 	}
 
-	if paymentStatus == constanta.PAID {
-		err = s.orderRepo.UpdateOrder(ctx, map[string]any{
-			"status": constanta.OrderStatusCompleted,
-		}, order.ID)
+	s.refundOverpaidOrder(ctx, order, req.GetPaidAmount())
+
+	return &gen.Empty{}, nil
+}
+
+// refuseCartIssues refuses the order with the issues of the cart,
+// the cart is updated so the next attempt is ordered with what the customer has seen
+func (s *OrderService) refuseCartIssues(ctx context.Context, cart *entity.Cart, issues []*gen.CartIssue) error {
+	// the customer has seen the new prices in the refusal, so the next attempt uses them
+	for _, item := range cart.Items {
+		if !item.IsPriceChanged() {
+			continue
+		}
+
+		err := s.cartRepo.UpdateCartItem(ctx, entity.CartItem{
+			CartID:    cart.ID,
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Name:      item.Name,
+			Price:     item.CurrentPrice,
+		})
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+			return err
 		}
 	}
 
-	if paymentStatus == constanta.FAILED {
-		err = s.orderRepo.UpdateOrder(ctx, map[string]any{
-			"status": constanta.OrderStatusFailed,
-		}, order.ID)
+	// the coupon that cannot be used is removed, so the next attempt is ordered without it
+	if cart.CouponIssue != "" {
+		err := s.cartRepo.SetCartCoupon(ctx, cart.ID, "")
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+			return err
 		}
 	}
 
-	return &gen.Empty{}, nil
+	st, err := status.New(codes.FailedPrecondition, "cart has items that must be reviewed").
+		WithDetails(&gen.CartIssues{Issues: issues})
+	if err != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// refundOverpaidOrder refunds the completed order that is paid more than its total amount,
+// the refund that fails is retried in the background like the compensations
+func (s *OrderService) refundOverpaidOrder(ctx context.Context, order *entity.Order, paidAmount *gen.Money) {
+	if paidAmount.GetUnits() <= order.TotalAmount.GetUnits() {
+		return
+	}
+
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+	err := s.compensate(ctx, order.ID, order.TransactionID, constanta.SagaStepRefundOverpayment)
+	if err != nil {
+		fmt.Printf("Error when refunding overpaid order %s: %v\n", order.ID, err)
+	}
+}
+
+// getCallbackOrder finds the order of the callback, the callback that is sent before an order could be paid
+// by several payments only has the transaction id of the first payment
+func (s *OrderService) getCallbackOrder(ctx context.Context, req *gen.CallbackTransactionRequest) (*entity.Order, error) {
+	if req.GetOrderId() == "" {
+		return s.orderRepo.GetOrderByTransactionID(ctx, req.TransactionId)
+	}
+
+	id, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	return s.orderRepo.GetOrderByID(ctx, id)
 }
 
 func (s *OrderService) GetOrder(ctx context.Context, req *gen.GetOrderRequest) (*gen.Order, error) {
@@ -466,9 +824,137 @@ func (s *OrderService) GetOrder(ctx context.Context, req *gen.GetOrderRequest) (
 		return nil, status.Errorf(codes.PermissionDenied, "you are not authorized to access this order")
 	}
 
+	order.StatusHistory, err = s.orderRepo.GetOrderStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order status history: %v", err)
+	}
+
+	order.Refunds, err = s.orderRepo.GetOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order refunds: %v", err)
+	}
+
+	order.Returns, err = s.returnRepo.GetOrderReturns(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order returns: %v", err)
+	}
+
+	err = s.loadOrderPayments(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
 	return order.GetGenOrder(), nil
 }
 
+// loadOrderPayments fills the payments of the order from the payment service
+func (s *OrderService) loadOrderPayments(ctx context.Context, order *entity.Order) error {
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+	payments, err := s.paymentServiceClient.ListPayments(ctx, &gen.ListPaymentsRequest{
+		OrderId: order.ID.String(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get order payments: %v", err)
+	}
+
+	order.Payments = entity.NewOrderPayments(payments)
+	order.PaidAmount = payments.GetPaidAmount()
+
+	return nil
+}
+
+// AddOrderPayment pays the STOCK_RESERVED order with another payment, e.g. after the previous payment is FAILED.
+// The amount cannot be more than the part of the order that is neither paid nor waiting to be paid,
+// it is the whole unpaid amount when it is empty
+func (s *OrderService) AddOrderPayment(ctx context.Context, req *gen.AddOrderPaymentRequest) (*gen.Order, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "you are not authorized to access this order")
+	}
+
+	if order.Status != constanta.OrderStatusStockReserved {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be paid", order.Status)
+	}
+
+	err = s.loadOrderPayments(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	unpaidAmount, err := order.UnpaidAmount(order.Payments)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to calculate unpaid amount: %v", err)
+	}
+
+	if unpaidAmount.GetUnits() == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "order is already paid or waiting for its payments")
+	}
+
+	paymentAmount := unpaidAmount
+	if req.GetAmount() != nil {
+		paymentAmount, err = validatePaymentAmount(req.Amount, unpaidAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, userID)
+	_, err = s.paymentServiceClient.ProcessPayment(ctx, &gen.ProcessPaymentRequest{
+		OrderId:     order.ID.String(),
+		TotalAmount: paymentAmount,
+		Method:      req.GetMethod(),
+		OrderAmount: order.TotalAmount,
+	})
+	if err != nil {
+		// another payment of the order is created at the same time
+		if status.Code(err) == codes.InvalidArgument || status.Code(err) == codes.FailedPrecondition {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to process payment: %v", err)
+	}
+
+	err = s.loadOrderPayments(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.GetGenOrder(), nil
+}
+
+// validatePaymentAmount checks the amount of the payment that pays a part of the order
+func validatePaymentAmount(amount, maxAmount *gen.Money) (*gen.Money, error) {
+	if amount.GetCurrencyCode() != maxAmount.GetCurrencyCode() {
+		return nil, status.Errorf(codes.InvalidArgument, "currency code not match")
+	}
+
+	if amount.GetUnits() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "payment amount must be greater than 0")
+	}
+
+	if amount.GetUnits() > maxAmount.GetUnits() {
+		return nil, status.Errorf(codes.InvalidArgument, "payment amount cannot be more than %d", maxAmount.GetUnits())
+	}
+
+	return money.New(amount.GetUnits(), amount.GetCurrencyCode())
+}
+
 func (s *OrderService) GetOrderList(ctx context.Context, req *gen.GetOrderListRequest) (*gen.Orders, error) {
 	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
 	if err != nil {
@@ -532,3 +1018,199 @@ func (s *OrderService) GetOrderList(ctx context.Context, req *gen.GetOrderListRe
 		Orders: orders,
 	}, nil
 }
+
+// CancelOrder cancels the reserved order of the user.
+// The payments are cancelled first, the paid part of the order is refunded.
+// Then the stock is released, a failed release is retried by the compensation retry worker.
+func (s *OrderService) CancelOrder(ctx context.Context, req *gen.CancelOrderRequest) (*gen.Order, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "you are not authorized to access this order")
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "cancelled by customer"
+	}
+
+	return s.cancelOrder(ctx, order, reason, constanta.OrderActorCustomer)
+}
+
+// CancelReservedOrder cancels the order whose reserved stock cannot be shipped anymore, e.g. its warehouse is deactivated.
+// It is called by the admin or by the warehouse service, the cancellation is recorded as done by the warehouse service
+func (s *OrderService) CancelReservedOrder(ctx context.Context, req *gen.CancelReservedOrderRequest) (*gen.Order, error) {
+	_, err := extractor.ExtractBackOfficeRoleFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	reason := req.GetReason()
+	if reason == "" {
+		reason = fmt.Sprintf("stock of warehouse %d cannot be shipped", req.GetWarehouseId())
+	}
+
+	return s.cancelOrder(ctx, order, reason, constanta.OrderActorWarehouse)
+}
+
+// cancelOrder cancels the STOCK_RESERVED order, the cancelled order is returned again
+func (s *OrderService) cancelOrder(ctx context.Context, order *entity.Order, reason string, actor constanta.OrderActor) (*gen.Order, error) {
+	if order.Status == constanta.OrderStatusCancelled {
+		return order.GetGenOrder(), nil
+	}
+
+	if !order.Status.CanTransitionTo(constanta.OrderStatusCancelled) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be cancelled", order.Status)
+	}
+
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+
+	// the payments are cancelled first, the order is cancelled even if the stock is not released yet
+	err := s.transitionOrder(ctx, order, entity.StatusChange{
+		To:           constanta.OrderStatusCancelled,
+		Reason:       reason,
+		Actor:        actor,
+		CancelReason: reason,
+	})
+	if err != nil {
+		var stepErr *transitionStepError
+		if errors.As(err, &stepErr) {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to cancel payment: %v", stepErr.err)
+		}
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
+	}
+
+	return order.GetGenOrder(), nil
+}
+
+// checkCartItems compares the cart with the catalog,
+// it fills the current price, the stock and the availability of each item
+// and sums the subtotal in the settlement currency of the user
+func (s *OrderService) checkCartItems(ctx context.Context, cart *entity.Cart) error {
+	if len(cart.Items) == 0 {
+		return nil
+	}
+
+	products, err := s.productServiceClient.GetProducts(ctx, &gen.GetProductsRequest{
+		Ids:       cart.GetProductIDs(),
+		WithStock: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	productsMap := make(map[string]*gen.Product)
+	for _, product := range products.GetProducts() {
+		productsMap[product.Id] = product
+	}
+
+	currency := s.settlementCurrency(ctx)
+	cart.Subtotal, err = money.New(0, currency)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid currency %s", currency)
+	}
+
+	for i, item := range cart.Items {
+		product, ok := productsMap[item.ProductID]
+		if !ok || product.GetPrice() == nil {
+			cart.Items[i].Unavailable = true
+			cart.Items[i].ActualStock = 0
+			continue
+		}
+
+		cart.Items[i].Name = product.GetName()
+		cart.Items[i].CurrentPrice = product.GetPrice()
+		cart.Items[i].ActualStock = product.GetStock()
+		cart.Items[i].ShopID = product.GetShopId()
+		cart.Items[i].Category = product.GetCategory()
+
+		totalPrice, err := money.MultiplyByInt(product.GetPrice(), item.Quantity)
+		if err != nil {
+			return err
+		}
+
+		// the line total is converted instead of the unit price to keep the rounding error in one place
+		settlementTotal, rate, err := money.Convert(ctx, s.rateProvider, totalPrice, currency)
+		if err != nil {
+			if errors.Is(err, money.ErrRateNotFound) {
+				return status.Errorf(codes.FailedPrecondition, "exchange rate from %s to %s is not available", totalPrice.GetCurrencyCode(), currency)
+			}
+			return err
+		}
+
+		cart.Items[i].SettlementTotal = settlementTotal
+		cart.Items[i].ExchangeRate = rate
+
+		cart.Subtotal, err = money.Add(cart.Subtotal, settlementTotal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// settlementCurrency is the currency the user sees and pays with
+func (s *OrderService) settlementCurrency(ctx context.Context) string {
+	if currency := extractor.ExtractCurrencyFromMetadata(ctx); currency != "" {
+		return currency
+	}
+
+	return s.defaultCurrency
+}
+
+// cartOwnerFromMetadata resolves the owner of the cart,
+// the user when user_id is in the metadata otherwise the guest holding the cart_token
+func cartOwnerFromMetadata(ctx context.Context) (entity.Cart, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err == nil {
+		return entity.Cart{UserID: userID}, nil
+	}
+
+	cartToken, tokenErr := extractor.ExtractCartTokenFromMetadata(ctx)
+	if tokenErr != nil {
+		return entity.Cart{}, err
+	}
+
+	return entity.Cart{CartToken: cartToken}, nil
+}
+
+func (s *OrderService) getCart(ctx context.Context, owner entity.Cart) (*entity.Cart, error) {
+	if owner.IsGuest() {
+		return s.cartRepo.GetCartByToken(ctx, owner.CartToken)
+	}
+
+	return s.cartRepo.GetCartByUserID(ctx, owner.UserID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,10 +14,15 @@ import (
 	"github.com/elangreza/e-commerce/order/internal/service"
 	"github.com/elangreza/e-commerce/order/internal/service/mock"
 	globalcontanta "github.com/elangreza/e-commerce/pkg/globalcontanta"
+	"github.com/elangreza/e-commerce/pkg/money"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type OrderServiceTestSuite struct {
@@ -24,6 +30,10 @@ type OrderServiceTestSuite struct {
 	ctrl                *gomock.Controller
 	mockOrderRepo       *mock.MockorderRepo
 	mockCartRepo        *mock.MockcartRepo
+	mockSagaRepo        *mock.MocksagaRepo
+	mockPromotionRepo   *mock.MockpromotionRepo
+	mockPricingRepo     *mock.MockpricingRepo
+	mockReturnRepo      *mock.MockreturnRepo
 	mockWarehouseClient *mock.MockWarehouseServiceClient
 	mockProductClient   *mock.MockProductServiceClient
 	mockPaymentClient   *mock.MockPaymentServiceClient
@@ -34,16 +44,37 @@ func (s *OrderServiceTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockOrderRepo = mock.NewMockorderRepo(s.ctrl)
 	s.mockCartRepo = mock.NewMockcartRepo(s.ctrl)
+	s.mockSagaRepo = mock.NewMocksagaRepo(s.ctrl)
+	s.mockPromotionRepo = mock.NewMockpromotionRepo(s.ctrl)
+	s.mockPricingRepo = mock.NewMockpricingRepo(s.ctrl)
+	s.mockReturnRepo = mock.NewMockreturnRepo(s.ctrl)
 	s.mockWarehouseClient = mock.NewMockWarehouseServiceClient(s.ctrl)
 	s.mockProductClient = mock.NewMockProductServiceClient(s.ctrl)
 	s.mockPaymentClient = mock.NewMockPaymentServiceClient(s.ctrl)
 
+	rateProvider, err := money.NewStaticRateProvider([]money.ExchangeRate{
+		{From: "USD", To: "IDR", Rate: decimal.NewFromInt(16000)},
+	})
+	s.Require().NoError(err)
+
 	s.svc = service.NewOrderService(
 		s.mockOrderRepo,
 		s.mockCartRepo,
+		s.mockSagaRepo,
+		s.mockPromotionRepo,
+		s.mockPricingRepo,
+		s.mockReturnRepo,
 		s.mockWarehouseClient,
 		s.mockProductClient,
 		s.mockPaymentClient,
+		service.CompensationRetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Second,
+			MaxDelay:    time.Minute,
+		},
+		rateProvider,
+		"IDR",
+		15*time.Minute,
 	)
 }
 
@@ -55,6 +86,25 @@ func TestOrderServiceSuite(t *testing.T) {
 	suite.Run(t, new(OrderServiceTestSuite))
 }
 
+// expectUntaxedFreeShipping prices the order without tax rules and with the free shipping rate of every region
+func (s *OrderServiceTestSuite) expectUntaxedFreeShipping() {
+	s.mockPricingRepo.EXPECT().GetTaxRules(gomock.Any()).Return([]entity.TaxRule{}, nil)
+	s.mockPricingRepo.EXPECT().GetShippingRates(gomock.Any()).Return([]entity.ShippingRate{
+		{
+			OriginRegion:      entity.AnyRegion,
+			DestinationRegion: entity.AnyRegion,
+			BaseFee:           &gen.Money{Units: 0, CurrencyCode: "IDR"},
+			PerItemFee:        &gen.Money{Units: 0, CurrencyCode: "IDR"},
+		},
+	}, nil)
+	s.mockWarehouseClient.EXPECT().
+		GetWarehouseByShopID(gomock.Any(), gomock.Any()).
+		Return(&gen.GetWarehouseByShopIDResponse{
+			Warehouses: []*gen.Warehouse{{Id: 1, Name: "w-JKT", IsActive: true, Region: "JKT"}},
+		}, nil).
+		AnyTimes()
+}
+
 func (s *OrderServiceTestSuite) TestAddProductToCart() {
 	userID := uuid.New()
 	cartID := uuid.New()
@@ -268,15 +318,18 @@ func (s *OrderServiceTestSuite) TestGetCart() {
 						},
 					}, nil)
 
-				s.mockWarehouseClient.EXPECT().
-					GetStocks(gomock.Any(), &gen.GetStockRequest{
-						ProductIds: []string{productID},
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), &gen.GetProductsRequest{
+						Ids:       []string{productID},
+						WithStock: true,
 					}).
-					Return(&gen.StockList{
-						Stocks: []*gen.Stock{
+					Return(&gen.Products{
+						Products: []*gen.Product{
 							{
-								ProductId: productID,
-								Quantity:  2,
+								Id:    productID,
+								Name:  "a",
+								Stock: 2,
+								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
 							},
 						},
 					}, nil)
@@ -294,258 +347,186 @@ func (s *OrderServiceTestSuite) TestGetCart() {
 							CurrencyCode: "IDR",
 						},
 						ActualStock: 2,
+						CurrentPrice: &gen.Money{
+							Units:        10000,
+							CurrencyCode: "IDR",
+						},
+						SettlementTotal: &gen.Money{
+							Units:        10000,
+							CurrencyCode: "IDR",
+						},
 					},
 				},
+				Subtotal: &gen.Money{
+					Units:        10000,
+					CurrencyCode: "IDR",
+				},
+				Discount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+				Total:    &gen.Money{Units: 10000, CurrencyCode: "IDR"},
 			},
 		},
 		{
-			name: "Error cart not found",
-			setupMock: func() {
-				s.mockCartRepo.EXPECT().
-					GetCartByUserID(gomock.Any(), userID).
-					Return(nil, sql.ErrNoRows)
-			},
-			expectedError: "cart not found",
-			expectedResp:  nil,
-		},
-	}
-
-	for _, tt := range tests {
-		s.Run(tt.name, func() {
-			tt.setupMock()
-
-			resp, err := s.svc.GetCart(ctx, &gen.Empty{})
-			if tt.expectedError != "" {
-				s.Error(err)
-				s.Contains(err.Error(), tt.expectedError)
-				s.Nil(resp)
-			} else {
-				s.NoError(err)
-				s.NotNil(resp)
-				s.Equal(tt.expectedResp, resp)
-			}
-		})
-	}
-}
-
-func (s *OrderServiceTestSuite) TestCreateOrder() {
-	userID := uuid.New()
-	cartID := uuid.New()
-	productID := "prod-123"
-	idempotencyKey := uuid.New()
-	transactionID := "txn-abc-123"
-
-	md := metadata.New(map[string]string{
-		string(globalcontanta.UserIDKey): userID.String(),
-	})
-	ctx := metadata.NewIncomingContext(context.Background(), md)
-
-	tests := []struct {
-		name          string
-		req           *gen.CreateOrderRequest
-		setupMock     func()
-		expectedError string
-		expectedResp  *gen.Order
-	}{
-		{
-			name: "Success",
-			req: &gen.CreateOrderRequest{
-				IdempotencyKey: idempotencyKey.String(),
-			},
+			name: "Success with price drift, stock deficit and unavailable product",
 			setupMock: func() {
-				// 1. Check Idempotency
-				s.mockOrderRepo.EXPECT().
-					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
-					Return(nil, sql.ErrNoRows)
-
-				// 2. Get Cart
 				s.mockCartRepo.EXPECT().
 					GetCartByUserID(gomock.Any(), userID).
 					Return(&entity.Cart{
 						ID:     cartID,
 						UserID: userID,
 						Items: []entity.CartItem{
-							{ProductID: productID, Quantity: 2},
+							{
+								ProductID: productID,
+								Quantity:  3,
+								Price:     &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							},
+							{
+								ProductID: "prod-removed",
+								Quantity:  1,
+								Price:     &gen.Money{Units: 5000, CurrencyCode: "IDR"},
+							},
 						},
 					}, nil)
 
-				// 3. Get Products
 				s.mockProductClient.EXPECT().
 					GetProducts(gomock.Any(), &gen.GetProductsRequest{
-						Ids:       []string{productID},
-						WithStock: false,
+						Ids:       []string{productID, "prod-removed"},
+						WithStock: true,
 					}).
 					Return(&gen.Products{
 						Products: []*gen.Product{
 							{
 								Id:    productID,
-								Name:  "Test Product",
-								Stock: 100,
-								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+								Name:  "a",
+								Stock: 2,
+								Price: &gen.Money{Units: 12000, CurrencyCode: "IDR"},
 							},
 						},
 					}, nil)
-
-				// 4. Create Order
-				orderID := uuid.New()
-				s.mockOrderRepo.EXPECT().
-					CreateOrder(gomock.Any(), gomock.AssignableToTypeOf(entity.Order{})).
-					Return(orderID, nil)
-
-				// 5. Reserve Stock
-				s.mockWarehouseClient.EXPECT().
-					ReserveStock(gomock.Any(), gomock.AssignableToTypeOf(&gen.ReserveStockRequest{})).
-					Return(&gen.ReserveStockResponse{ReservedStockIds: []int64{1}}, nil)
-
-				// 6. Process Payment
-				s.mockPaymentClient.EXPECT().
-					ProcessPayment(gomock.Any(), gomock.AssignableToTypeOf(&gen.ProcessPaymentRequest{})).
-					Return(&gen.ProcessPaymentResponse{
-						TransactionId: transactionID,
-					}, nil)
-
-				// 7. Update Order Status
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(2), orderID).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusStockReserved, payloads["status"])
-						s.Equal(transactionID, payloads["transaction_id"])
-						return nil
-					})
 			},
-			expectedResp: &gen.Order{
-				Status:        constanta.OrderStatusStockReserved.String(),
-				TransactionId: transactionID,
+			expectedError: "",
+			expectedResp: &gen.Cart{
+				Id: cartID.String(),
+				Items: []*gen.CartItem{
+					{
+						ProductId:         productID,
+						Quantity:          3,
+						Name:              "a",
+						Price:             &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+						ActualStock:       2,
+						CurrentPrice:      &gen.Money{Units: 12000, CurrencyCode: "IDR"},
+						PriceChanged:      true,
+						InsufficientStock: true,
+						SettlementTotal:   &gen.Money{Units: 36000, CurrencyCode: "IDR"},
+					},
+					{
+						ProductId:   "prod-removed",
+						Quantity:    1,
+						Price:       &gen.Money{Units: 5000, CurrencyCode: "IDR"},
+						Unavailable: true,
+					},
+				},
+				Subtotal: &gen.Money{Units: 36000, CurrencyCode: "IDR"},
+				Discount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+				Total:    &gen.Money{Units: 36000, CurrencyCode: "IDR"},
 			},
 		},
 		{
-			name: "Failed_StockReservation",
-			req: &gen.CreateOrderRequest{
-				IdempotencyKey: idempotencyKey.String(),
-			},
+			name: "Success with mixed currencies converted into the settlement currency",
 			setupMock: func() {
-				// 1. Check Idempotency
-				s.mockOrderRepo.EXPECT().
-					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
-					Return(nil, sql.ErrNoRows)
-
-				// 2. Get Cart
 				s.mockCartRepo.EXPECT().
 					GetCartByUserID(gomock.Any(), userID).
 					Return(&entity.Cart{
 						ID:     cartID,
 						UserID: userID,
 						Items: []entity.CartItem{
-							{ProductID: productID, Quantity: 2},
+							{
+								ProductID: productID,
+								Quantity:  1,
+								Price:     &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							},
+							{
+								ProductID: "prod-usd",
+								Quantity:  2,
+								Price:     &gen.Money{Units: 1050, CurrencyCode: "USD"},
+							},
 						},
 					}, nil)
 
-				// 3. Get Products
 				s.mockProductClient.EXPECT().
 					GetProducts(gomock.Any(), gomock.Any()).
 					Return(&gen.Products{
 						Products: []*gen.Product{
-							{
-								Id:    productID,
-								Name:  "Test Product",
-								Stock: 100,
-								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
-							},
+							{Id: productID, Name: "a", Stock: 5, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+							{Id: "prod-usd", Name: "b", Stock: 5, Price: &gen.Money{Units: 1050, CurrencyCode: "USD"}},
 						},
 					}, nil)
-
-				// 4. Create Order
-				orderID := uuid.New()
-				s.mockOrderRepo.EXPECT().
-					CreateOrder(gomock.Any(), gomock.Any()).
-					Return(orderID, nil)
-
-				// 5. Reserve Stock FAILS
-				s.mockWarehouseClient.EXPECT().
-					ReserveStock(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("insufficient stock"))
-
-				// 6. Rollback (Update Order to Failed)
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), orderID).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusFailed, payloads["status"])
-						return nil
-					})
 			},
-			expectedError: "failed to reserve stock",
+			expectedError: "",
+			expectedResp: &gen.Cart{
+				Id: cartID.String(),
+				Items: []*gen.CartItem{
+					{
+						ProductId:       productID,
+						Quantity:        1,
+						Name:            "a",
+						Price:           &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+						ActualStock:     5,
+						CurrentPrice:    &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+						SettlementTotal: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+					},
+					{
+						ProductId:    "prod-usd",
+						Quantity:     2,
+						Name:         "b",
+						Price:        &gen.Money{Units: 1050, CurrencyCode: "USD"},
+						ActualStock:  5,
+						CurrentPrice: &gen.Money{Units: 1050, CurrencyCode: "USD"},
+						// $21.00 * 16000
+						SettlementTotal: &gen.Money{Units: 336000, CurrencyCode: "IDR"},
+					},
+				},
+				Subtotal: &gen.Money{Units: 346000, CurrencyCode: "IDR"},
+				Discount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+				Total:    &gen.Money{Units: 346000, CurrencyCode: "IDR"},
+			},
 		},
 		{
-			name: "Failed_PaymentProcessing",
-			req: &gen.CreateOrderRequest{
-				IdempotencyKey: idempotencyKey.String(),
-			},
+			name: "Error exchange rate not available",
 			setupMock: func() {
-				// 1. Check Idempotency
-				s.mockOrderRepo.EXPECT().
-					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
-					Return(nil, sql.ErrNoRows)
-
-				// 2. Get Cart
 				s.mockCartRepo.EXPECT().
 					GetCartByUserID(gomock.Any(), userID).
 					Return(&entity.Cart{
 						ID:     cartID,
 						UserID: userID,
 						Items: []entity.CartItem{
-							{ProductID: productID, Quantity: 2},
+							{
+								ProductID: productID,
+								Quantity:  1,
+								Price:     &gen.Money{Units: 1000, CurrencyCode: "EUR"},
+							},
 						},
 					}, nil)
 
-				// 3. Get Products
 				s.mockProductClient.EXPECT().
 					GetProducts(gomock.Any(), gomock.Any()).
 					Return(&gen.Products{
 						Products: []*gen.Product{
-							{
-								Id:    productID,
-								Name:  "Test Product",
-								Stock: 100,
-								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
-							},
+							{Id: productID, Name: "a", Stock: 5, Price: &gen.Money{Units: 1000, CurrencyCode: "EUR"}},
 						},
 					}, nil)
-
-				// 4. Create Order
-				orderID := uuid.New()
-				s.mockOrderRepo.EXPECT().
-					CreateOrder(gomock.Any(), gomock.Any()).
-					Return(orderID, nil)
-
-				// 5. Reserve Stock SUCCESS
-				s.mockWarehouseClient.EXPECT().
-					ReserveStock(gomock.Any(), gomock.Any()).
-					Return(&gen.ReserveStockResponse{
-						ReservedStockIds: []int64{1},
-					}, nil)
-
-				// 6. Process Payment FAILS
-				s.mockPaymentClient.EXPECT().
-					ProcessPayment(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("failed to process payment"))
-
-				// 7. Release Stock
-				s.mockWarehouseClient.EXPECT().
-					ReleaseStock(gomock.Any(), gomock.Any()).
-					Return(&gen.ReleaseStockResponse{
-						ReleasedStockIds: []int64{1},
-					}, nil)
-
-				// 8. Update Order to Failed
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), orderID).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusFailed, payloads["status"])
-						return nil
-					})
 			},
-			expectedError: "failed to process payment",
-			expectedResp:  &gen.Order{},
+			expectedError: "exchange rate from EUR to IDR is not available",
+			expectedResp:  nil,
+		},
+		{
+			name: "Error cart not found",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "cart not found",
+			expectedResp:  nil,
 		},
 	}
 
@@ -553,8 +534,7 @@ func (s *OrderServiceTestSuite) TestCreateOrder() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.CreateOrder(ctx, tt.req)
-
+			resp, err := s.svc.GetCart(ctx, &gen.Empty{})
 			if tt.expectedError != "" {
 				s.Error(err)
 				s.Contains(err.Error(), tt.expectedError)
@@ -562,15 +542,16 @@ func (s *OrderServiceTestSuite) TestCreateOrder() {
 			} else {
 				s.NoError(err)
 				s.NotNil(resp)
-				s.Equal(tt.expectedResp.Status, resp.Status)
-				s.Equal(tt.expectedResp.TransactionId, resp.TransactionId)
+				s.Equal(tt.expectedResp, resp)
 			}
 		})
 	}
 }
 
-func (s *OrderServiceTestSuite) TestRemoveExpiryOrder() {
+func (s *OrderServiceTestSuite) TestRemoveCartItem() {
 	userID := uuid.New()
+	cartID := uuid.New()
+	productID := "prod-123"
 
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
@@ -579,90 +560,51 @@ func (s *OrderServiceTestSuite) TestRemoveExpiryOrder() {
 
 	tests := []struct {
 		name          string
-		req           time.Duration
+		req           *gen.RemoveCartItemRequest
 		setupMock     func()
 		expectedError string
-		expectedResp  int
 	}{
 		{
 			name: "Success",
+			req: &gen.RemoveCartItemRequest{
+				ProductId: productID,
+			},
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetExpiryOrders(gomock.Any(), 1*time.Minute).
-					Return([]entity.Order{
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Status: constanta.OrderStatusPending,
-						},
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Status: constanta.OrderStatusStockReserved,
-						},
-					}, nil)
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusFailed, payloads["status"])
-						return nil
-					})
-
-				s.mockWarehouseClient.EXPECT().
-					ReleaseStock(gomock.Any(), gomock.Any()).
-					Return(&gen.ReleaseStockResponse{
-						ReleasedStockIds: []int64{1},
-					}, nil)
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrderStatusWithCallback(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, status constanta.OrderStatus, id uuid.UUID, callback func() error) error {
-					return callback()
-				})
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockCartRepo.EXPECT().
+					RemoveCartItem(gomock.Any(), cartID, productID).
+					Return(nil)
 			},
 			expectedError: "",
-			expectedResp:  2,
-			req:           1 * time.Minute,
 		},
 		{
-			name: "Error when releasing stock",
+			name: "Product is not in cart",
+			req: &gen.RemoveCartItemRequest{
+				ProductId: productID,
+			},
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetExpiryOrders(gomock.Any(), 1*time.Minute).
-					Return([]entity.Order{
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Status: constanta.OrderStatusPending,
-						},
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Status: constanta.OrderStatusStockReserved,
-						},
-					}, nil)
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusFailed, payloads["status"])
-						return nil
-					})
-
-				s.mockWarehouseClient.EXPECT().
-					ReleaseStock(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("failed to release stock"))
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrderStatusWithCallback(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, status constanta.OrderStatus, id uuid.UUID, callback func() error) error {
-					return callback()
-				})
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockCartRepo.EXPECT().
+					RemoveCartItem(gomock.Any(), cartID, productID).
+					Return(sql.ErrNoRows)
 			},
-			expectedError: "",
-			expectedResp:  2,
-			req:           1 * time.Minute,
+			expectedError: "product not found in cart",
+		},
+		{
+			name: "Cart not found",
+			req: &gen.RemoveCartItemRequest{
+				ProductId: productID,
+			},
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "cart not found",
 		},
 	}
 
@@ -670,24 +612,24 @@ func (s *OrderServiceTestSuite) TestRemoveExpiryOrder() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.RemoveExpiryOrder(ctx, tt.req)
+			resp, err := s.svc.RemoveCartItem(ctx, tt.req)
+
 			if tt.expectedError != "" {
 				s.Error(err)
 				s.Contains(err.Error(), tt.expectedError)
-				s.Zero(resp)
+				s.Nil(resp)
 			} else {
 				s.NoError(err)
-				s.NotZero(resp)
-				s.Equal(tt.expectedResp, resp)
+				s.NotNil(resp)
 			}
 		})
 	}
 }
 
-func (s *OrderServiceTestSuite) TestCallbackTransaction() {
+func (s *OrderServiceTestSuite) TestSetCartItemQuantity() {
 	userID := uuid.New()
-	orderID := uuid.New()
-	transactionID := "txn-abc-123"
+	cartID := uuid.New()
+	productID := "prod-123"
 
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
@@ -696,66 +638,106 @@ func (s *OrderServiceTestSuite) TestCallbackTransaction() {
 
 	tests := []struct {
 		name          string
-		req           *gen.CallbackTransactionRequest
+		req           *gen.SetCartItemQuantityRequest
 		setupMock     func()
 		expectedError string
 	}{
 		{
-			name: "Unknown status",
-			req: &gen.CallbackTransactionRequest{
-				TransactionId: transactionID,
-				PaymentStatus: "UNKNOWN",
+			name: "Success",
+			req: &gen.SetCartItemQuantityRequest{
+				ProductId: productID,
+				Quantity:  3,
 			},
-			setupMock:     func() {},
-			expectedError: "payment_status is UNKNOWN, must be one of PAID or FAILED",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockProductClient.EXPECT().GetProducts(ctx, &gen.GetProductsRequest{
+					Ids:       []string{productID},
+					WithStock: true,
+				}).Return(&gen.Products{
+					Products: []*gen.Product{
+						{
+							Id:    productID,
+							Stock: 5,
+						},
+					},
+				}, nil)
+				s.mockCartRepo.EXPECT().
+					SetCartItemQuantity(gomock.Any(), cartID, productID, int64(3)).
+					Return(nil)
+			},
+			expectedError: "",
 		},
 		{
-			name: "Success with status Paid",
-			req: &gen.CallbackTransactionRequest{
-				TransactionId: transactionID,
-				PaymentStatus: "PAID",
+			name: "Zero quantity removes the item",
+			req: &gen.SetCartItemQuantityRequest{
+				ProductId: productID,
+				Quantity:  0,
 			},
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
-					Return(&entity.Order{
-						ID:     orderID,
-						Status: constanta.OrderStatusStockReserved,
-					}, nil)
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), orderID).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusCompleted, payloads["status"])
-						return nil
-					})
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockCartRepo.EXPECT().
+					RemoveCartItem(gomock.Any(), cartID, productID).
+					Return(nil)
 			},
 			expectedError: "",
 		},
 		{
-			name: "Success with status Failed",
-			req: &gen.CallbackTransactionRequest{
-				TransactionId: transactionID,
-				PaymentStatus: "FAILED",
+			name: "Negative quantity",
+			req: &gen.SetCartItemQuantityRequest{
+				ProductId: productID,
+				Quantity:  -1,
+			},
+			setupMock:     func() {},
+			expectedError: "quantity cannot be negative",
+		},
+		{
+			name: "Failed because stock is not enough",
+			req: &gen.SetCartItemQuantityRequest{
+				ProductId: productID,
+				Quantity:  10,
 			},
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
-					Return(&entity.Order{
-						ID:     orderID,
-						Status: constanta.OrderStatusStockReserved,
-					}, nil)
-
-				s.mockOrderRepo.EXPECT().
-					UpdateOrder(gomock.Any(), gomock.Len(1), orderID).
-					DoAndReturn(func(ctx context.Context, payloads map[string]any, id uuid.UUID) error {
-						s.Equal(constanta.OrderStatusFailed, payloads["status"])
-						return nil
-					})
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockProductClient.EXPECT().GetProducts(ctx, gomock.Any()).Return(&gen.Products{
+					Products: []*gen.Product{
+						{
+							Id:    productID,
+							Stock: 5,
+						},
+					},
+				}, nil)
 			},
-			expectedError: "",
+			expectedError: "quantity cannot exceed the maximum stock, current stock is 5",
+		},
+		{
+			name: "Product is not in cart",
+			req: &gen.SetCartItemQuantityRequest{
+				ProductId: productID,
+				Quantity:  1,
+			},
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockProductClient.EXPECT().GetProducts(ctx, gomock.Any()).Return(&gen.Products{
+					Products: []*gen.Product{
+						{
+							Id:    productID,
+							Stock: 5,
+						},
+					},
+				}, nil)
+				s.mockCartRepo.EXPECT().
+					SetCartItemQuantity(gomock.Any(), cartID, productID, int64(1)).
+					Return(sql.ErrNoRows)
+			},
+			expectedError: "product not found in cart",
 		},
 	}
 
@@ -763,7 +745,7 @@ func (s *OrderServiceTestSuite) TestCallbackTransaction() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.CallbackTransaction(ctx, tt.req)
+			resp, err := s.svc.SetCartItemQuantity(ctx, tt.req)
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -777,9 +759,9 @@ func (s *OrderServiceTestSuite) TestCallbackTransaction() {
 	}
 }
 
-func (s *OrderServiceTestSuite) TestGetOrder() {
+func (s *OrderServiceTestSuite) TestClearCart() {
 	userID := uuid.New()
-	orderID := uuid.New()
+	cartID := uuid.New()
 
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
@@ -788,42 +770,29 @@ func (s *OrderServiceTestSuite) TestGetOrder() {
 
 	tests := []struct {
 		name          string
-		req           *gen.GetOrderRequest
 		setupMock     func()
 		expectedError string
 	}{
-
 		{
 			name: "Success",
-			req: &gen.GetOrderRequest{
-				Id: orderID.String(),
-			},
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetOrderByID(gomock.Any(), orderID).
-					Return(&entity.Order{
-						ID:     orderID,
-						UserID: userID,
-					}, nil)
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{ID: cartID, UserID: userID}, nil)
+				s.mockCartRepo.EXPECT().
+					ClearCart(gomock.Any(), cartID).
+					Return(nil)
 			},
 			expectedError: "",
 		},
 		{
-			name: "Permission denied",
-			req: &gen.GetOrderRequest{
-				Id: orderID.String(),
-			},
+			name: "No cart to clear",
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetOrderByID(gomock.Any(), orderID).
-					Return(&entity.Order{
-						ID:     orderID,
-						UserID: uuid.New(),
-					}, nil)
-
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(nil, sql.ErrNoRows)
 			},
-			expectedError: "you are not authorized to access this order",
+			expectedError: "",
 		},
 	}
 
@@ -831,7 +800,7 @@ func (s *OrderServiceTestSuite) TestGetOrder() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.GetOrder(ctx, tt.req)
+			resp, err := s.svc.ClearCart(ctx, &gen.Empty{})
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -845,56 +814,212 @@ func (s *OrderServiceTestSuite) TestGetOrder() {
 	}
 }
 
-func (s *OrderServiceTestSuite) TestGetOrderList() {
-	userID := uuid.New()
+func (s *OrderServiceTestSuite) TestAddProductToCartAsGuest() {
+	cartToken := uuid.NewString()
+	productID := "prod-123"
 
 	md := metadata.New(map[string]string{
-		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.CartTokenKey): cartToken,
 	})
-	ctx := metadata.NewIncomingContext(context.Background(), md)
+	guestCtx := metadata.NewIncomingContext(context.Background(), md)
 
 	tests := []struct {
 		name          string
-		req           *gen.GetOrderListRequest
+		ctx           context.Context
 		setupMock     func()
 		expectedError string
-		expectedResp  *gen.Orders
 	}{
-
 		{
-			name: "Success",
-			req: &gen.GetOrderListRequest{
-				StartDate: "2006-01-02",
-				EndDate:   "2006-01-02",
-				Status:    "PENDING",
-			},
+			name:          "Failed without user or cart token",
+			ctx:           metadata.NewIncomingContext(context.Background(), metadata.New(nil)),
+			setupMock:     func() {},
+			expectedError: "not valid userID",
+		},
+		{
+			name: "Success create guest cart",
+			ctx:  guestCtx,
 			setupMock: func() {
-				s.mockOrderRepo.EXPECT().
-					GetOrderList(gomock.Any(), gomock.Any()).
-					Return([]entity.Order{
-						{
-							IdempotencyKey: uuid.UUID{},
-							ID:             userID,
-							UserID:         userID,
-							Status:         "",
-							TotalAmount:    &gen.Money{},
-							TransactionID:  "",
-							CreatedAt:      &time.Time{},
-							UpdatedAt:      &time.Time{},
-							Items:          []entity.OrderItem{},
-						},
-					}, nil)
+				s.mockCartRepo.EXPECT().
+					GetCartByToken(gomock.Any(), cartToken).
+					Return(nil, sql.ErrNoRows)
 
-			},
-			expectedError: "",
-		},
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), &gen.GetProductsRequest{
+					Ids:       []string{productID},
+					WithStock: true,
+				}).Return(&gen.Products{
+					Products: []*gen.Product{
+						{
+							Id:    productID,
+							Name:  "a",
+							Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							Stock: 2,
+						},
+					},
+				}, nil)
+
+				s.mockCartRepo.EXPECT().
+					CreateCart(gomock.Any(),
+						entity.Cart{
+							CartToken: cartToken,
+							Items: []entity.CartItem{
+								{
+									ProductID: productID,
+									Quantity:  2,
+									Name:      "a",
+									Price:     &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+								},
+							},
+						},
+					).
+					Return(nil)
+			},
+			expectedError: "",
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			tt.setupMock()
+			_, err := s.svc.AddProductToCart(tt.ctx, &gen.AddCartItemRequest{
+				ProductId: productID,
+				Quantity:  2,
+			})
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
 
-			resp, err := s.svc.GetOrderList(ctx, tt.req)
+func (s *OrderServiceTestSuite) TestMergeCart() {
+	userID := uuid.New()
+	userCartID := uuid.New()
+	guestCartID := uuid.New()
+	cartToken := uuid.NewString()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	price := &gen.Money{Units: 10000, CurrencyCode: "IDR"}
+	newPrice := &gen.Money{Units: 12000, CurrencyCode: "IDR"}
+
+	guestCart := &entity.Cart{
+		ID:        guestCartID,
+		CartToken: cartToken,
+		Items: []entity.CartItem{
+			{ProductID: "prod-1", Quantity: 2, Price: price},
+			{ProductID: "prod-2", Quantity: 1, Price: price},
+			{ProductID: "prod-3", Quantity: 1, Price: price},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.MergeCartRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:          "Failed because cart token is empty",
+			req:           &gen.MergeCartRequest{},
+			setupMock:     func() {},
+			expectedError: "cart_token cannot be empty",
+		},
+		{
+			name: "No guest cart to merge",
+			req:  &gen.MergeCartRequest{CartToken: cartToken},
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByToken(gomock.Any(), cartToken).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success merge into existing user cart with stock re-validation",
+			req:  &gen.MergeCartRequest{CartToken: cartToken},
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByToken(gomock.Any(), cartToken).
+					Return(guestCart, nil)
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     userCartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: "prod-1", Quantity: 2, Price: price},
+						},
+					}, nil)
+
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), &gen.GetProductsRequest{
+					Ids:       []string{"prod-1", "prod-2", "prod-3"},
+					WithStock: true,
+				}).Return(&gen.Products{
+					Products: []*gen.Product{
+						{Id: "prod-1", Name: "a", Price: newPrice, Stock: 3},
+						{Id: "prod-2", Name: "b", Price: price, Stock: 0},
+					},
+				}, nil)
+
+				// prod-1 is capped by the stock, prod-2 is out of stock and prod-3 no longer exists
+				s.mockCartRepo.EXPECT().
+					MergeCart(gomock.Any(), guestCartID, entity.Cart{
+						ID:     userCartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: "prod-1", Quantity: 3, Name: "a", Price: newPrice},
+						},
+					}).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success merge creates user cart",
+			req:  &gen.MergeCartRequest{CartToken: cartToken},
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().
+					GetCartByToken(gomock.Any(), cartToken).
+					Return(guestCart, nil)
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(nil, sql.ErrNoRows)
+
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{Id: "prod-1", Name: "a", Price: price, Stock: 5},
+							{Id: "prod-2", Name: "b", Price: price, Stock: 5},
+							{Id: "prod-3", Name: "c", Price: price, Stock: 5},
+						},
+					}, nil)
+
+				s.mockCartRepo.EXPECT().
+					MergeCart(gomock.Any(), guestCartID, entity.Cart{
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: "prod-1", Quantity: 2, Name: "a", Price: price},
+							{ProductID: "prod-2", Quantity: 1, Name: "b", Price: price},
+							{ProductID: "prod-3", Quantity: 1, Name: "c", Price: price},
+						},
+					}).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.MergeCart(ctx, tt.req)
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -907,3 +1032,4358 @@ func (s *OrderServiceTestSuite) TestGetOrderList() {
 		})
 	}
 }
+
+func (s *OrderServiceTestSuite) TestApplyCoupon() {
+	userID := uuid.New()
+	cartID := uuid.New()
+	promotionID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	cart := func() *entity.Cart {
+		return &entity.Cart{
+			ID:     cartID,
+			UserID: userID,
+			Items: []entity.CartItem{
+				{ProductID: "prod-a", Quantity: 3, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+				{ProductID: "prod-b", Quantity: 1, Price: &gen.Money{Units: 5000, CurrencyCode: "IDR"}},
+			},
+		}
+	}
+
+	products := &gen.Products{
+		Products: []*gen.Product{
+			{Id: "prod-a", Name: "a", Stock: 10, ShopId: 1, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+			{Id: "prod-b", Name: "b", Stock: 10, ShopId: 2, Price: &gen.Money{Units: 5000, CurrencyCode: "IDR"}},
+		},
+	}
+
+	activePromotion := func(promotion entity.Promotion) *entity.Promotion {
+		promotion.ID = promotionID
+		promotion.Code = "PROMO"
+		promotion.StartsAt = time.Now().Add(-time.Hour)
+		promotion.EndsAt = time.Now().Add(time.Hour)
+		promotion.IsActive = true
+		return &promotion
+	}
+
+	tests := []struct {
+		name             string
+		code             string
+		setupMock        func()
+		expectedError    string
+		expectedDiscount []*gen.Money
+		expectedTotal    *gen.Money
+	}{
+		{
+			name: "Success percentage of the order",
+			code: " promo ",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:       constanta.PromotionTypePercentage,
+						Scope:      constanta.PromotionScopeOrder,
+						Percentage: decimal.RequireFromString("12.5"),
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "PROMO").Return(nil)
+			},
+			// 30000 * 12.5% = 3750, 5000 * 12.5% = 625
+			expectedDiscount: []*gen.Money{{Units: 3750, CurrencyCode: "IDR"}, {Units: 625, CurrencyCode: "IDR"}},
+			expectedTotal:    &gen.Money{Units: 30625, CurrencyCode: "IDR"},
+		},
+		{
+			name: "Success fixed amount split by the item total",
+			code: "PROMO",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:   constanta.PromotionTypeFixedAmount,
+						Scope:  constanta.PromotionScopeOrder,
+						Amount: &gen.Money{Units: 1001, CurrencyCode: "IDR"},
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "PROMO").Return(nil)
+			},
+			// 1001 * 30000 / 35000 = 858.0, 1001 * 5000 / 35000 = 143.0
+			expectedDiscount: []*gen.Money{{Units: 858, CurrencyCode: "IDR"}, {Units: 143, CurrencyCode: "IDR"}},
+			expectedTotal:    &gen.Money{Units: 33999, CurrencyCode: "IDR"},
+		},
+		{
+			name: "Success fixed amount in another currency of a shop",
+			code: "PROMO",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:    constanta.PromotionTypeFixedAmount,
+						Scope:   constanta.PromotionScopeShop,
+						ScopeID: "2",
+						// $1.00 is Rp16000, capped by the total of the shop items
+						Amount: &gen.Money{Units: 100, CurrencyCode: "USD"},
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "PROMO").Return(nil)
+			},
+			expectedDiscount: []*gen.Money{nil, {Units: 5000, CurrencyCode: "IDR"}},
+			expectedTotal:    &gen.Money{Units: 30000, CurrencyCode: "IDR"},
+		},
+		{
+			name: "Success buy 2 get 1 of a product",
+			code: "PROMO",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:        constanta.PromotionTypeBuyXGetY,
+						Scope:       constanta.PromotionScopeProduct,
+						ScopeID:     "prod-a",
+						BuyQuantity: 2,
+						GetQuantity: 1,
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "PROMO").Return(nil)
+			},
+			expectedDiscount: []*gen.Money{{Units: 10000, CurrencyCode: "IDR"}, nil},
+			expectedTotal:    &gen.Money{Units: 25000, CurrencyCode: "IDR"},
+		},
+		{
+			name: "Error no eligible item",
+			code: "PROMO",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:       constanta.PromotionTypePercentage,
+						Scope:      constanta.PromotionScopeProduct,
+						ScopeID:    "prod-c",
+						Percentage: decimal.NewFromInt(10),
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+			},
+			expectedError: "no item in the cart is eligible for coupon PROMO",
+		},
+		{
+			name: "Error expired",
+			code: "PROMO",
+			setupMock: func() {
+				promotion := activePromotion(entity.Promotion{
+					Type:       constanta.PromotionTypePercentage,
+					Scope:      constanta.PromotionScopeOrder,
+					Percentage: decimal.NewFromInt(10),
+				})
+				promotion.EndsAt = time.Now().Add(-time.Minute)
+
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionByCode(gomock.Any(), "PROMO").Return(promotion, nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+			},
+			expectedError: "coupon PROMO is expired",
+		},
+		{
+			name: "Error usage limit per user is reached",
+			code: "PROMO",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().
+					GetPromotionByCode(gomock.Any(), "PROMO").
+					Return(activePromotion(entity.Promotion{
+						Type:              constanta.PromotionTypePercentage,
+						Scope:             constanta.PromotionScopeOrder,
+						Percentage:        decimal.NewFromInt(10),
+						UsageLimit:        100,
+						UsageLimitPerUser: 1,
+					}), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(10), int64(1), nil)
+			},
+			expectedError: "coupon PROMO has been used the maximum number of times",
+		},
+		{
+			name: "Error coupon not found",
+			code: "UNKNOWN",
+			setupMock: func() {
+				s.mockCartRepo.EXPECT().GetCartByUserID(gomock.Any(), userID).Return(cart(), nil)
+				s.mockProductClient.EXPECT().GetProducts(gomock.Any(), gomock.Any()).Return(products, nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionByCode(gomock.Any(), "UNKNOWN").Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "coupon UNKNOWN is not found",
+		},
+		{
+			name:          "Error empty code",
+			code:          " ",
+			setupMock:     func() {},
+			expectedError: "code cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ApplyCoupon(ctx, &gen.ApplyCouponRequest{Code: tt.code})
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal("PROMO", resp.CouponCode)
+				s.Equal(tt.expectedTotal, resp.Total)
+				for i, discount := range tt.expectedDiscount {
+					s.Equal(discount, resp.Items[i].Discount)
+				}
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder() {
+	userID := uuid.New()
+	cartID := uuid.New()
+	productID := "prod-123"
+	idempotencyKey := uuid.New()
+	transactionID := "txn-abc-123"
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.CreateOrderRequest
+		setupMock     func()
+		expectedError string
+		expectedResp  *gen.Order
+	}{
+		{
+			name: "Success",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+			},
+			setupMock: func() {
+				// 1. Check Idempotency
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+
+				// 2. Get Cart
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+
+				// 3. Get Products
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), &gen.GetProductsRequest{
+						Ids:       []string{productID},
+						WithStock: true,
+					}).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{
+								Id:    productID,
+								Name:  "Test Product",
+								Stock: 100,
+								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							},
+						},
+					}, nil)
+
+				s.expectUntaxedFreeShipping()
+
+				// 4. Create Order
+				orderID := uuid.New()
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.AssignableToTypeOf(entity.Order{})).
+					Return(orderID, nil)
+
+				// 5. Reserve Stock
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "").
+					Return(int64(1), nil)
+				// the stock is reserved for the reservation ttl
+				s.mockWarehouseClient.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Cond(func(req *gen.ReserveStockRequest) bool {
+						expiresAt, err := time.Parse(time.RFC3339, req.GetExpiresAt())
+						return err == nil && expiresAt.After(time.Now().Add(14*time.Minute))
+					})).
+					Return(&gen.ReserveStockResponse{ReservedStockIds: []int64{1}}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "", "").
+					Return(nil)
+
+				// 6. Process Payment
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), gomock.AssignableToTypeOf(&gen.ProcessPaymentRequest{})).
+					Return(&gen.ProcessPaymentResponse{
+						TransactionId: transactionID,
+					}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "", transactionID).
+					Return(nil)
+
+				// 7. Update Order Status
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.To)
+						s.Equal(transactionID, change.TransactionID)
+						return nil
+					})
+			},
+			expectedResp: &gen.Order{
+				Status:        constanta.OrderStatusStockReserved.String(),
+				TransactionId: transactionID,
+			},
+		},
+		{
+			name: "Success with partial first payment",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+				PaymentMethod:  "E_WALLET",
+				PaymentAmount:  &gen.Money{Units: 5000, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{Id: productID, Name: "Test Product", Stock: 100, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+				s.expectUntaxedFreeShipping()
+
+				orderID := uuid.New()
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(orderID, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(&gen.ReserveStockResponse{ReservedStockIds: []int64{1}}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "", "").
+					Return(nil)
+
+				// the rest of the order is paid later by another payment
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), &gen.ProcessPaymentRequest{
+						OrderId:     orderID.String(),
+						TotalAmount: &gen.Money{Units: 5000, CurrencyCode: "IDR"},
+						Method:      "E_WALLET",
+						OrderAmount: &gen.Money{Units: 20000, CurrencyCode: "IDR"},
+					}).
+					Return(&gen.ProcessPaymentResponse{TransactionId: transactionID}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "", transactionID).
+					Return(nil)
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					Return(nil)
+			},
+			expectedResp: &gen.Order{
+				Status:        constanta.OrderStatusStockReserved.String(),
+				TransactionId: transactionID,
+			},
+		},
+		{
+			name: "Failed_PaymentAmountExceedsTotal",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+				PaymentAmount:  &gen.Money{Units: 25000, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{Id: productID, Name: "Test Product", Stock: 100, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+				s.expectUntaxedFreeShipping()
+			},
+			expectedError: "payment amount cannot be more than 20000",
+		},
+		{
+			name: "Failed_CartIssues",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{
+								Id:    productID,
+								Name:  "Test Product",
+								Stock: 1,
+								Price: &gen.Money{Units: 12000, CurrencyCode: "IDR"},
+							},
+						},
+					}, nil)
+
+				// the new price is accepted into the cart, no order is created
+				s.mockCartRepo.EXPECT().
+					UpdateCartItem(gomock.Any(), entity.CartItem{
+						CartID:    cartID,
+						ProductID: productID,
+						Quantity:  2,
+						Name:      "Test Product",
+						Price:     &gen.Money{Units: 12000, CurrencyCode: "IDR"},
+					}).
+					Return(nil)
+			},
+			expectedError: "cart has items that must be reviewed",
+		},
+		{
+			name: "Failed_StockReservation",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+			},
+			setupMock: func() {
+				// 1. Check Idempotency
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+
+				// 2. Get Cart
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+
+				// 3. Get Products
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{
+								Id:    productID,
+								Name:  "Test Product",
+								Stock: 100,
+								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							},
+						},
+					}, nil)
+
+				s.expectUntaxedFreeShipping()
+
+				// 4. Create Order
+				orderID := uuid.New()
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(orderID, nil)
+
+				// 5. Reserve Stock FAILS
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("insufficient stock"))
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "", "insufficient stock", gomock.Any()).
+					Return(nil)
+
+				// 6. Rollback (Update Order to Failed)
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					})
+			},
+			expectedError: "failed to reserve stock",
+		},
+		{
+			name: "Failed_PaymentProcessing",
+			req: &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+			},
+			setupMock: func() {
+				// 1. Check Idempotency
+				s.mockOrderRepo.EXPECT().
+					GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+					Return(nil, sql.ErrNoRows)
+
+				// 2. Get Cart
+				s.mockCartRepo.EXPECT().
+					GetCartByUserID(gomock.Any(), userID).
+					Return(&entity.Cart{
+						ID:     cartID,
+						UserID: userID,
+						Items: []entity.CartItem{
+							{ProductID: productID, Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+
+				// 3. Get Products
+				s.mockProductClient.EXPECT().
+					GetProducts(gomock.Any(), gomock.Any()).
+					Return(&gen.Products{
+						Products: []*gen.Product{
+							{
+								Id:    productID,
+								Name:  "Test Product",
+								Stock: 100,
+								Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+							},
+						},
+					}, nil)
+
+				s.expectUntaxedFreeShipping()
+
+				// 4. Create Order
+				orderID := uuid.New()
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(orderID, nil)
+
+				// 5. Reserve Stock SUCCESS
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(&gen.ReserveStockResponse{
+						ReservedStockIds: []int64{1},
+					}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReserveStock, "", "").
+					Return(nil)
+
+				// 6. Process Payment FAILS
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("failed to process payment"))
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepProcessPayment, "", "failed to process payment", gomock.Any()).
+					Return(nil)
+
+				// 7. Rollback the payment that might be created, e.g. the call timed out
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+						OrderId: orderID.String(),
+						Reason:  "order failed",
+					}).
+					Return(&gen.Empty{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "", "").
+					Return(nil)
+
+				// 8. Release Stock
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(&gen.ReleaseStockResponse{
+						ReleasedStockIds: []int64{1},
+					}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+				// 9. Update Order to Failed
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					})
+			},
+			expectedError: "failed to process payment",
+			expectedResp:  &gen.Order{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.CreateOrder(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+				if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+					s.Require().Len(st.Details(), 1)
+					issues, ok := st.Details()[0].(*gen.CartIssues)
+					s.Require().True(ok)
+					s.Len(issues.Issues, 2)
+					s.Equal(constanta.CartIssueReasonPriceChanged.String(), issues.Issues[0].Reason)
+					s.Equal(constanta.CartIssueReasonInsufficientStock.String(), issues.Issues[1].Reason)
+				}
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+				s.Equal(tt.expectedResp.Status, resp.Status)
+				s.Equal(tt.expectedResp.TransactionId, resp.TransactionId)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrderWithSettlementCurrency() {
+	userID := uuid.New()
+	cartID := uuid.New()
+	idempotencyKey := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey):   userID.String(),
+		string(globalcontanta.CurrencyKey): "usd",
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	s.mockOrderRepo.EXPECT().
+		GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+		Return(nil, sql.ErrNoRows)
+
+	s.mockCartRepo.EXPECT().
+		GetCartByUserID(gomock.Any(), userID).
+		Return(&entity.Cart{
+			ID:     cartID,
+			UserID: userID,
+			Items: []entity.CartItem{
+				{ProductID: "prod-idr", Quantity: 1, Price: &gen.Money{Units: 100000, CurrencyCode: "IDR"}},
+				{ProductID: "prod-usd", Quantity: 2, Price: &gen.Money{Units: 1050, CurrencyCode: "USD"}},
+			},
+		}, nil)
+
+	s.mockProductClient.EXPECT().
+		GetProducts(gomock.Any(), gomock.Any()).
+		Return(&gen.Products{
+			Products: []*gen.Product{
+				{Id: "prod-idr", Name: "a", Stock: 5, Price: &gen.Money{Units: 100000, CurrencyCode: "IDR"}},
+				{Id: "prod-usd", Name: "b", Stock: 5, Price: &gen.Money{Units: 1050, CurrencyCode: "USD"}},
+			},
+		}, nil)
+
+	s.expectUntaxedFreeShipping()
+
+	// stop after the order is built, the saga is covered by TestCreateOrder
+	s.mockOrderRepo.EXPECT().
+		CreateOrder(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order entity.Order) (uuid.UUID, error) {
+			// Rp100000 / 16000 = $6.25, the original price is kept on the item
+			s.Equal(&gen.Money{Units: 100000, CurrencyCode: "IDR"}, order.Items[0].TotalPricePerUnit)
+			s.Equal(&gen.Money{Units: 625, CurrencyCode: "USD"}, order.Items[0].SettlementTotal)
+			s.Equal("0.0000625", order.Items[0].ExchangeRate.String())
+
+			s.Equal(&gen.Money{Units: 2100, CurrencyCode: "USD"}, order.Items[1].SettlementTotal)
+			s.Equal("1", order.Items[1].ExchangeRate.String())
+
+			s.Equal(&gen.Money{Units: 2725, CurrencyCode: "USD"}, order.TotalAmount)
+			return uuid.Nil, errors.New("db is closed")
+		})
+
+	resp, err := s.svc.CreateOrder(ctx, &gen.CreateOrderRequest{
+		IdempotencyKey: idempotencyKey.String(),
+	})
+	s.Error(err)
+	s.Contains(err.Error(), "failed to persist order")
+	s.Nil(resp)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrderWithCoupon() {
+	userID := uuid.New()
+	cartID := uuid.New()
+	promotionID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	promotion := func(endsAt time.Time) *entity.Promotion {
+		return &entity.Promotion{
+			ID:         promotionID,
+			Code:       "PROMO",
+			Type:       constanta.PromotionTypePercentage,
+			Scope:      constanta.PromotionScopeOrder,
+			Percentage: decimal.NewFromInt(10),
+			StartsAt:   time.Now().Add(-time.Hour),
+			EndsAt:     endsAt,
+			IsActive:   true,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(idempotencyKey uuid.UUID)
+		expectedError string
+		expectedIssue string
+	}{
+		{
+			name: "Success discount is recorded on the order",
+			setupMock: func(idempotencyKey uuid.UUID) {
+				s.mockPromotionRepo.EXPECT().GetPromotionByCode(gomock.Any(), "PROMO").Return(promotion(time.Now().Add(time.Hour)), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.expectUntaxedFreeShipping()
+
+				// stop after the order is built, the saga is covered by TestCreateOrder
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order entity.Order) (uuid.UUID, error) {
+						s.Equal(&gen.Money{Units: 25000, CurrencyCode: "IDR"}, order.SubtotalAmount)
+						s.Equal(&gen.Money{Units: 2500, CurrencyCode: "IDR"}, order.DiscountAmount)
+						s.Equal(&gen.Money{Units: 22500, CurrencyCode: "IDR"}, order.TotalAmount)
+						s.Equal(&gen.Money{Units: 2000, CurrencyCode: "IDR"}, order.Items[0].Discount)
+						s.Equal(&gen.Money{Units: 500, CurrencyCode: "IDR"}, order.Items[1].Discount)
+						s.Equal("PROMO", order.CouponCode)
+						s.Equal(promotionID, order.PromotionID)
+						return uuid.Nil, errors.New("db is closed")
+					})
+			},
+			expectedError: "failed to persist order",
+		},
+		{
+			name: "Failed coupon expired is removed from the cart",
+			setupMock: func(idempotencyKey uuid.UUID) {
+				s.mockPromotionRepo.EXPECT().GetPromotionByCode(gomock.Any(), "PROMO").Return(promotion(time.Now().Add(-time.Minute)), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "").Return(nil)
+			},
+			expectedError: "cart has items that must be reviewed",
+			expectedIssue: "coupon PROMO is expired",
+		},
+		{
+			name: "Failed coupon is used up by another order",
+			setupMock: func(idempotencyKey uuid.UUID) {
+				s.mockPromotionRepo.EXPECT().GetPromotionByCode(gomock.Any(), "PROMO").Return(promotion(time.Now().Add(time.Hour)), nil)
+				s.mockPromotionRepo.EXPECT().GetPromotionUsage(gomock.Any(), promotionID, userID).Return(int64(0), int64(0), nil)
+				s.expectUntaxedFreeShipping()
+
+				// the usage limit is checked again when the order is persisted
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(uuid.Nil, fmt.Errorf("%w: coupon PROMO has reached its usage limit", entity.ErrCouponNotApplicable))
+				s.mockCartRepo.EXPECT().SetCartCoupon(gomock.Any(), cartID, "").Return(nil)
+			},
+			expectedError: "cart has items that must be reviewed",
+			expectedIssue: "coupon PROMO has reached its usage limit",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			idempotencyKey := uuid.New()
+
+			s.mockOrderRepo.EXPECT().
+				GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+				Return(nil, sql.ErrNoRows)
+
+			s.mockCartRepo.EXPECT().
+				GetCartByUserID(gomock.Any(), userID).
+				Return(&entity.Cart{
+					ID:         cartID,
+					UserID:     userID,
+					CouponCode: "PROMO",
+					Items: []entity.CartItem{
+						{ProductID: "prod-a", Quantity: 2, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						{ProductID: "prod-b", Quantity: 1, Price: &gen.Money{Units: 5000, CurrencyCode: "IDR"}},
+					},
+				}, nil)
+
+			s.mockProductClient.EXPECT().
+				GetProducts(gomock.Any(), gomock.Any()).
+				Return(&gen.Products{
+					Products: []*gen.Product{
+						{Id: "prod-a", Name: "a", Stock: 5, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+						{Id: "prod-b", Name: "b", Stock: 5, Price: &gen.Money{Units: 5000, CurrencyCode: "IDR"}},
+					},
+				}, nil)
+
+			tt.setupMock(idempotencyKey)
+
+			resp, err := s.svc.CreateOrder(ctx, &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+			})
+			s.Error(err)
+			s.Contains(err.Error(), tt.expectedError)
+			s.Nil(resp)
+
+			if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+				s.Require().Len(st.Details(), 1)
+				issues := st.Details()[0].(*gen.CartIssues)
+				s.Equal(constanta.CartIssueReasonCouponNotApplicable.String(), issues.Issues[0].Reason)
+				s.Equal("PROMO", issues.Issues[0].CouponCode)
+				s.Contains(issues.Issues[0].Message, tt.expectedIssue)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrderWithTaxAndShipping() {
+	userID := uuid.New()
+	cartID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	taxRules := []entity.TaxRule{
+		{Name: "VAT", Rate: decimal.NewFromInt(11)},
+		{Name: "Luxury", Category: "electronics", Rate: decimal.NewFromInt(20)},
+		{Name: "VAT shop 2", ShopID: 2, Rate: decimal.NewFromInt(11), Inclusive: true},
+	}
+
+	shippingRate := func(origin, destination string, baseFee, perItemFee int64) entity.ShippingRate {
+		return entity.ShippingRate{
+			OriginRegion:      origin,
+			DestinationRegion: destination,
+			BaseFee:           &gen.Money{Units: baseFee, CurrencyCode: "IDR"},
+			PerItemFee:        &gen.Money{Units: perItemFee, CurrencyCode: "IDR"},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		shippingRegion string
+		setupMock      func()
+		expectedError  string
+	}{
+		{
+			name:           "Success tax and shipping are added to the total",
+			shippingRegion: " bdg ",
+			setupMock: func() {
+				s.mockPricingRepo.EXPECT().GetShippingRates(gomock.Any()).Return([]entity.ShippingRate{
+					shippingRate(entity.AnyRegion, entity.AnyRegion, 20000, 1000),
+					shippingRate("JKT", "BDG", 8000, 1000),
+					shippingRate("BDG", entity.AnyRegion, 15000, 0),
+				}, nil)
+
+				// the inactive warehouse cannot ship
+				s.mockWarehouseClient.EXPECT().
+					GetWarehouseByShopID(gomock.Any(), &gen.GetWarehouseByShopIDRequest{ShopId: 1}).
+					Return(&gen.GetWarehouseByShopIDResponse{
+						Warehouses: []*gen.Warehouse{
+							{Id: 1, Name: "w-JKT", IsActive: true, Region: "JKT"},
+							{Id: 2, Name: "w-BDG", IsActive: false, Region: "BDG"},
+						},
+					}, nil)
+				// the cheapest warehouse is used
+				s.mockWarehouseClient.EXPECT().
+					GetWarehouseByShopID(gomock.Any(), &gen.GetWarehouseByShopIDRequest{ShopId: 2}).
+					Return(&gen.GetWarehouseByShopIDResponse{
+						Warehouses: []*gen.Warehouse{
+							{Id: 1, Name: "w-JKT", IsActive: true, Region: "JKT"},
+							{Id: 2, Name: "w-BDG", IsActive: true, Region: "BDG"},
+						},
+					}, nil)
+
+				// stop after the order is built, the saga is covered by TestCreateOrder
+				s.mockOrderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order entity.Order) (uuid.UUID, error) {
+						// the rule of the category wins over the default rule
+						s.Equal(&gen.Money{Units: 40000, CurrencyCode: "IDR"}, order.Items[0].Tax)
+						s.Equal("20", order.Items[0].TaxRate.String())
+						s.False(order.Items[0].TaxInclusive)
+
+						// the rule of the shop wins over the default rule, 55500 * 11 / 111
+						s.Equal(&gen.Money{Units: 5500, CurrencyCode: "IDR"}, order.Items[1].Tax)
+						s.True(order.Items[1].TaxInclusive)
+
+						// shop 1 from JKT 8000 + 2 * 1000, shop 2 from JKT 8000 + 1000
+						s.Equal(&gen.Money{Units: 19000, CurrencyCode: "IDR"}, order.ShippingAmount)
+						s.Equal("BDG", order.ShippingRegion)
+
+						s.Equal(&gen.Money{Units: 255500, CurrencyCode: "IDR"}, order.SubtotalAmount)
+						s.Equal(&gen.Money{Units: 45500, CurrencyCode: "IDR"}, order.TaxAmount)
+						// the inclusive tax is not added again
+						s.Equal(&gen.Money{Units: 314500, CurrencyCode: "IDR"}, order.TotalAmount)
+						return uuid.Nil, errors.New("db is closed")
+					})
+			},
+			expectedError: "failed to persist order",
+		},
+		{
+			name:           "Failed region is not served by the shop",
+			shippingRegion: "SBY",
+			setupMock: func() {
+				s.mockPricingRepo.EXPECT().GetShippingRates(gomock.Any()).Return([]entity.ShippingRate{
+					shippingRate("JKT", "BDG", 8000, 1000),
+				}, nil)
+
+				s.mockWarehouseClient.EXPECT().
+					GetWarehouseByShopID(gomock.Any(), &gen.GetWarehouseByShopIDRequest{ShopId: 1}).
+					Return(&gen.GetWarehouseByShopIDResponse{
+						Warehouses: []*gen.Warehouse{{Id: 1, Name: "w-JKT", IsActive: true, Region: "JKT"}},
+					}, nil)
+			},
+			expectedError: `shop 1 cannot ship to region "SBY"`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			idempotencyKey := uuid.New()
+
+			s.mockOrderRepo.EXPECT().
+				GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+				Return(nil, sql.ErrNoRows)
+
+			s.mockCartRepo.EXPECT().
+				GetCartByUserID(gomock.Any(), userID).
+				Return(&entity.Cart{
+					ID:     cartID,
+					UserID: userID,
+					Items: []entity.CartItem{
+						{ProductID: "prod-a", Quantity: 2, Price: &gen.Money{Units: 100000, CurrencyCode: "IDR"}},
+						{ProductID: "prod-b", Quantity: 1, Price: &gen.Money{Units: 55500, CurrencyCode: "IDR"}},
+					},
+				}, nil)
+
+			s.mockProductClient.EXPECT().
+				GetProducts(gomock.Any(), gomock.Any()).
+				Return(&gen.Products{
+					Products: []*gen.Product{
+						{Id: "prod-a", Name: "a", Stock: 5, ShopId: 1, Category: "electronics", Price: &gen.Money{Units: 100000, CurrencyCode: "IDR"}},
+						{Id: "prod-b", Name: "b", Stock: 5, ShopId: 2, Category: "clothing", Price: &gen.Money{Units: 55500, CurrencyCode: "IDR"}},
+					},
+				}, nil)
+
+			s.mockPricingRepo.EXPECT().GetTaxRules(gomock.Any()).Return(taxRules, nil)
+
+			tt.setupMock()
+
+			resp, err := s.svc.CreateOrder(ctx, &gen.CreateOrderRequest{
+				IdempotencyKey: idempotencyKey.String(),
+				ShippingRegion: tt.shippingRegion,
+			})
+			s.Error(err)
+			s.Contains(err.Error(), tt.expectedError)
+			s.Nil(resp)
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestResumeSagas() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	transactionID := "txn-abc-123"
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError string
+		expectedResp  int
+	}{
+		{
+			name: "Pending order with processed payment is moved forward",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusPending,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusSucceeded, Result: transactionID},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.To)
+						s.Equal(transactionID, change.TransactionID)
+						return nil
+					})
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Claimed cancellation with done side effect is finished",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:       orderID,
+							UserID:        userID,
+							OrderStatus:   constanta.OrderStatusStockReserved,
+							PendingStatus: constanta.OrderStatusCancelled,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusSucceeded, Result: transactionID},
+								{Step: constanta.SagaStepCancelPayment, Status: constanta.SagaStepStatusSucceeded},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						return nil
+					})
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), &gen.ReleaseStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ReleaseStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Claimed completion interrupted before its side effect is released",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:       orderID,
+							UserID:        userID,
+							OrderStatus:   constanta.OrderStatusStockReserved,
+							PendingStatus: constanta.OrderStatusCompleted,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusSucceeded, Result: transactionID},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				// the order can be moved again by the next callback or the expiry task
+				s.mockOrderRepo.EXPECT().
+					ReleaseOrderTransition(gomock.Any(), orderID, constanta.OrderStatusCompleted).
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Pending order interrupted while processing payment rolls back its payments",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusPending,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusStarted},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				// the transaction id is unknown, the payment that might be created is rolled back by the order id
+				gomock.InOrder(
+					s.mockSagaRepo.EXPECT().
+						StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "").
+						Return(int64(1), nil),
+					s.mockPaymentClient.EXPECT().
+						RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+							OrderId: orderID.String(),
+							Reason:  "order failed",
+						}).
+						Return(&gen.Empty{}, nil),
+					s.mockSagaRepo.EXPECT().
+						CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "", "").
+						Return(nil),
+					s.mockSagaRepo.EXPECT().
+						StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+						Return(int64(1), nil),
+				)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), &gen.ReleaseStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ReleaseStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					})
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Pending order interrupted while reserving stock is compensated",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusPending,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusStarted},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), &gen.ReleaseStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ReleaseStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					})
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Failed order retries the unfinished compensation",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusFailed,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusSucceeded, Result: transactionID},
+								{Step: constanta.SagaStepRollbackPayment, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepReleaseStock, Status: constanta.SagaStepStatusFailed},
+							},
+						},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{}, nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("warehouse unavailable"))
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "warehouse unavailable", gomock.Any()).
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Pending refund is continued",
+			setupMock: func() {
+				refundID := uuid.New()
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return([]entity.Saga{}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetPendingRefunds(gomock.Any()).
+					Return([]entity.Refund{
+						{ID: refundID, OrderID: orderID, Status: constanta.RefundStatusPending},
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{ID: orderID, UserID: userID, Status: constanta.OrderStatusCompleted}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{
+						{
+							ID:      refundID,
+							OrderID: orderID,
+							Status:  constanta.RefundStatusPending,
+							Amount:  &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+						},
+					}, nil)
+				// the refund that is moved into dead letter is left for the manual handling
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{
+						{Step: constanta.SagaStepRefundPayment, Reference: refundID.String(), Status: constanta.SagaStepStatusDeadLetter},
+					}, nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Error when getting in flight sagas",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetInFlightSagas(gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ResumeSagas(context.Background())
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Zero(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestRetryCompensations() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	refundID := uuid.New()
+	productA := uuid.NewString()
+	transactionID := "transaction-1"
+	dueAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError string
+		expectedResp  int
+	}{
+		{
+			name: "Success retrying due compensations",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetSagasWithDueCompensations(gomock.Any(), gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusFailed,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepProcessPayment, Status: constanta.SagaStepStatusSucceeded, Result: transactionID},
+								{Step: constanta.SagaStepRollbackPayment, Status: constanta.SagaStepStatusFailed, Attempt: 1, NextRetryAt: &dueAt},
+								{Step: constanta.SagaStepReleaseStock, Status: constanta.SagaStepStatusSucceeded},
+							},
+						},
+					}, nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "").
+					Return(int64(2), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+						TransactionId: transactionID,
+						OrderId:       orderID.String(),
+						Reason:        "order failed",
+					}).
+					Return(&gen.Empty{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepRollbackPayment, "", "").
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Compensation is moved into dead letter after max attempts",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetSagasWithDueCompensations(gomock.Any(), gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusFailed,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReserveStock, Status: constanta.SagaStepStatusSucceeded},
+								{Step: constanta.SagaStepReleaseStock, Status: constanta.SagaStepStatusFailed, Attempt: 2, NextRetryAt: &dueAt},
+							},
+						},
+					}, nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(3), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("warehouse unavailable"))
+				s.mockSagaRepo.EXPECT().
+					DeadLetterSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "warehouse unavailable").
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Refund is continued from its failed step",
+			setupMock: func() {
+				steps := []entity.SagaStep{
+					{Step: constanta.SagaStepRefundPayment, Reference: refundID.String(), Status: constanta.SagaStepStatusSucceeded},
+					{Step: constanta.SagaStepRestockRefund, Reference: refundID.String(), Status: constanta.SagaStepStatusFailed, Attempt: 1, NextRetryAt: &dueAt},
+				}
+				refund := entity.Refund{
+					ID:      refundID,
+					OrderID: orderID,
+					Status:  constanta.RefundStatusPending,
+					Amount:  &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+					Reason:  "damaged item",
+					Restock: true,
+					Items:   []entity.RefundItem{{ProductID: productA, Quantity: 1}},
+				}
+
+				s.mockSagaRepo.EXPECT().
+					GetSagasWithDueCompensations(gomock.Any(), gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusCompleted,
+							Steps:       steps,
+						},
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:            orderID,
+						UserID:        userID,
+						Status:        constanta.OrderStatusCompleted,
+						TransactionID: transactionID,
+						Items:         []entity.OrderItem{{ProductID: productA, Quantity: 2}},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{refund}, nil).
+					Times(2)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return(steps, nil)
+
+				// the payment is already refunded, only the restock and the completion are left
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, refundID.String()).
+					Return(int64(2), nil)
+				s.mockWarehouseClient.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RestockStockRequest, opts ...grpc.CallOption) (*gen.RestockStockResponse, error) {
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						s.Equal(refundID.String(), req.RefundId)
+						return &gen.RestockStockResponse{RestockedStockIds: []int64{1}}, nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, refundID.String(), "").
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepCompleteRefund, refundID.String()).
+					Return(int64(1), nil)
+				s.mockOrderRepo.EXPECT().
+					CompleteRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+						s.Equal(refundID, refund.ID)
+						s.True(refund.Restocked)
+						s.Equal(constanta.OrderStatusPartiallyRefunded, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepCompleteRefund, refundID.String(), "").
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Compensation is not retried before it is due",
+			setupMock: func() {
+				nextRetryAt := time.Now().Add(time.Hour)
+				s.mockSagaRepo.EXPECT().
+					GetSagasWithDueCompensations(gomock.Any(), gomock.Any()).
+					Return([]entity.Saga{
+						{
+							OrderID:     orderID,
+							UserID:      userID,
+							OrderStatus: constanta.OrderStatusFailed,
+							Steps: []entity.SagaStep{
+								{Step: constanta.SagaStepReleaseStock, Status: constanta.SagaStepStatusFailed, Attempt: 1, NextRetryAt: &nextRetryAt},
+							},
+						},
+					}, nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Error when getting due compensations",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetSagasWithDueCompensations(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.RetryCompensations(context.Background())
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Zero(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestListDeadLetterCompensations() {
+	compensationID := uuid.New()
+	orderID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError string
+		expectedResp  *gen.DeadLetterCompensations
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetDeadLetterCompensations(gomock.Any()).
+					Return([]entity.DeadLetterCompensation{
+						{
+							ID:        compensationID,
+							OrderID:   orderID,
+							Step:      constanta.SagaStepReleaseStock,
+							Attempt:   3,
+							LastError: "warehouse unavailable",
+						},
+					}, nil)
+			},
+			expectedResp: &gen.DeadLetterCompensations{
+				Compensations: []*gen.DeadLetterCompensation{
+					{
+						Id:        compensationID.String(),
+						OrderId:   orderID.String(),
+						Step:      constanta.SagaStepReleaseStock.String(),
+						Attempt:   3,
+						LastError: "warehouse unavailable",
+					},
+				},
+			},
+		},
+		{
+			name: "Error when getting dead letter compensations",
+			setupMock: func() {
+				s.mockSagaRepo.EXPECT().
+					GetDeadLetterCompensations(gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "failed to get dead letter compensations",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ListDeadLetterCompensations(ctx, &gen.Empty{})
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestListDeadLetterCompensationsRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+
+	// the compensations are not loaded
+	resp, err := s.svc.ListDeadLetterCompensations(metadata.NewIncomingContext(context.Background(), md), &gen.Empty{})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	// the caller without metadata is not authenticated
+	resp, err = s.svc.ListDeadLetterCompensations(context.Background(), &gen.Empty{})
+
+	s.Nil(resp)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *OrderServiceTestSuite) TestRemoveExpiryOrder() {
+	userID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           time.Duration
+		setupMock     func()
+		expectedError string
+		expectedResp  int
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetExpiryOrders(gomock.Any(), 1*time.Minute).
+					Return([]entity.Order{
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Status: constanta.OrderStatusPending,
+						},
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Status: constanta.OrderStatusStockReserved,
+						},
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					}).Times(2)
+
+				// the paid part of the reserved order is refunded before the stock is released
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RollbackPaymentRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+						s.NotEmpty(req.OrderId)
+						return &gen.Empty{}, nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "", "").
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(&gen.ReleaseStockResponse{
+						ReleasedStockIds: []int64{1},
+					}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+			},
+			expectedError: "",
+			expectedResp:  2,
+			req:           1 * time.Minute,
+		},
+		{
+			name: "Error when releasing stock",
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetExpiryOrders(gomock.Any(), 1*time.Minute).
+					Return([]entity.Order{
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Status: constanta.OrderStatusPending,
+						},
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Status: constanta.OrderStatusStockReserved,
+						},
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return nil
+					}).Times(2)
+
+				// the paid part of the reserved order is refunded before the stock is released
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RollbackPaymentRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+						s.NotEmpty(req.OrderId)
+						return &gen.Empty{}, nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "", "").
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("failed to release stock"))
+				// the release is scheduled to be retried
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "", "failed to release stock", gomock.Not(time.Time{})).
+					Return(nil)
+
+			},
+			expectedError: "",
+			expectedResp:  2,
+			req:           1 * time.Minute,
+		},
+		{
+			name: "Success stock is never reserved",
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetExpiryOrders(gomock.Any(), 1*time.Minute).
+					Return([]entity.Order{
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Status: constanta.OrderStatusStockReserved,
+						},
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), gomock.Any()).
+					Return(&gen.Empty{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepRollbackPayment, "", "").
+					Return(nil)
+
+				// nothing to be released, the release is done
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "order is not reserved"))
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), gomock.Any(), constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+			},
+			expectedError: "",
+			expectedResp:  1,
+			req:           1 * time.Minute,
+		},
+		{
+			name: "Order is paid while the expiry is processed",
+			setupMock: func() {
+				orderID := uuid.New()
+				s.mockOrderRepo.EXPECT().
+					GetExpiryOrders(gomock.Any(), 1*time.Minute).
+					Return([]entity.Order{
+						{
+							ID:     orderID,
+							UserID: userID,
+							Status: constanta.OrderStatusStockReserved,
+						},
+					}, nil)
+
+				// the payment callback won the guarded update, the sold stock is not released
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusFailed, change.To)
+						return errors.Join(entity.ErrInvalidStatusTransition, errors.New("order is COMPLETED, not STOCK_RESERVED anymore"))
+					})
+			},
+			expectedError: "",
+			expectedResp:  1,
+			req:           1 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.RemoveExpiryOrder(ctx, tt.req)
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Zero(resp)
+			} else {
+				s.NoError(err)
+				s.NotZero(resp)
+				s.Equal(tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCallbackTransaction() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	transactionID := "txn-abc-123"
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.CallbackTransactionRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Unknown status",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "UNKNOWN",
+			},
+			setupMock:     func() {},
+			expectedError: "payment_status is UNKNOWN, must be one of PAID or FAILED",
+		},
+		{
+			name: "Success with status Paid",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCompleted, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ConfirmStock(gomock.Any(), &gen.ConfirmStockRequest{OrderId: orderID.String()}).
+					DoAndReturn(func(ctx context.Context, req *gen.ConfirmStockRequest, opts ...grpc.CallOption) (*gen.ConfirmStockResponse, error) {
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						return &gen.ConfirmStockResponse{ConfirmedStockIds: []int64{1}}, nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "", "").
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCompleted, change.To)
+						s.Equal(constanta.OrderActorPayment, change.Actor)
+						s.Equal("payment PAID", change.Reason)
+						return nil
+					})
+
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error when confirming stock",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCompleted, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ConfirmStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("warehouse unavailable"))
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "", "warehouse unavailable", time.Time{}).
+					Return(nil)
+				// the claim is released, the order can be moved again
+				s.mockOrderRepo.EXPECT().
+					ReleaseOrderTransition(gomock.Any(), orderID, constanta.OrderStatusCompleted).
+					Return(nil)
+			},
+			expectedError: "failed to confirm stock",
+		},
+		{
+			name: "Failed payment keeps the order reserved",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "FAILED",
+			},
+			setupMock: func() {
+				// the customer can pay the order with another payment until it expires
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Partially paid order waits for the rest",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: "txn-second",
+				PaymentStatus: "PAID",
+				OrderId:       orderID.String(),
+				PaidAmount:    &gen.Money{Units: 600, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:          orderID,
+						UserID:      userID,
+						Status:      constanta.OrderStatusStockReserved,
+						TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}, nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success order is paid by several payments",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: "txn-second",
+				PaymentStatus: "PAID",
+				OrderId:       orderID.String(),
+				PaidAmount:    &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:            orderID,
+						UserID:        userID,
+						Status:        constanta.OrderStatusStockReserved,
+						TotalAmount:   &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+						TransactionID: transactionID,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCompleted, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ConfirmStock(gomock.Any(), &gen.ConfirmStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ConfirmStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepConfirmStock, "", "").
+					Return(nil)
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusCompleted, change.To)
+						return nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error paid amount with another currency",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+				OrderId:       orderID.String(),
+				PaidAmount:    &gen.Money{Units: 1000, CurrencyCode: "USD"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:          orderID,
+						UserID:      userID,
+						Status:      constanta.OrderStatusStockReserved,
+						TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}, nil)
+			},
+			expectedError: "currency code not match",
+		},
+		{
+			name: "Error invalid order id",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+				OrderId:       "not-an-id",
+			},
+			setupMock:     func() {},
+			expectedError: "invalid order id",
+		},
+		{
+			name: "Order is expired while the late callback is processed",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+
+				// the expiry task won the claim, the stock is not confirmed
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					Return(errors.Join(entity.ErrInvalidStatusTransition, errors.New("order is FAILED, not STOCK_RESERVED anymore")))
+			},
+			expectedError: "order is FAILED, not STOCK_RESERVED anymore",
+		},
+		{
+			name: "Duplicate callback with status Paid",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						Status: constanta.OrderStatusCompleted,
+					}, nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Overpaid order refunds the overpayment",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: "txn-second",
+				PaymentStatus: "PAID",
+				OrderId:       orderID.String(),
+				PaidAmount:    &gen.Money{Units: 1300, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				completedOrder := &entity.Order{
+					ID:            orderID,
+					UserID:        userID,
+					Status:        constanta.OrderStatusCompleted,
+					TotalAmount:   &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					TransactionID: transactionID,
+				}
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(completedOrder, nil).
+					Times(2)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundOverpayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), &gen.ListPaymentsRequest{OrderId: orderID.String()}).
+					Return(&gen.ListPaymentsResponse{
+						PaidAmount: &gen.Money{Units: 1300, CurrencyCode: "IDR"},
+					}, nil)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), &gen.RefundPaymentRequest{
+						OrderId:  orderID.String(),
+						RefundId: "overpaid:" + orderID.String(),
+						Amount:   &gen.Money{Units: 300, CurrencyCode: "IDR"},
+						Reason:   "order is paid more than its total amount",
+					}).
+					Return(&gen.RefundPaymentResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundOverpayment, "", "").
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Duplicate callback with status Failed",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "FAILED",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						Status: constanta.OrderStatusFailed,
+					}, nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Paid callback for failed order",
+			req: &gen.CallbackTransactionRequest{
+				TransactionId: transactionID,
+				PaymentStatus: "PAID",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Order{
+						ID:     orderID,
+						Status: constanta.OrderStatusFailed,
+					}, nil)
+			},
+			expectedError: "order with status FAILED cannot be COMPLETED",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.CallbackTransaction(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestGetOrder() {
+	userID := uuid.New()
+	orderID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.GetOrderRequest
+		setupMock     func()
+		expectedError string
+	}{
+
+		{
+			name: "Success",
+			req: &gen.GetOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderStatusHistory(gomock.Any(), orderID).
+					Return([]entity.OrderStatusHistory{
+						{OrderID: orderID, ToStatus: constanta.OrderStatusPending, Reason: "order created", Actor: constanta.OrderActorCustomer},
+						{OrderID: orderID, FromStatus: constanta.OrderStatusPending, ToStatus: constanta.OrderStatusStockReserved, Actor: constanta.OrderActorSystem},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockReturnRepo.EXPECT().
+					GetOrderReturns(gomock.Any(), orderID).
+					Return([]entity.Return{}, nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), &gen.ListPaymentsRequest{OrderId: orderID.String()}).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							{TransactionId: "txn-1", Status: "FAILED", Method: "CARD", TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+							{TransactionId: "txn-2", Status: "WAITING", Method: "E_WALLET", TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+						},
+						PaidAmount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+					}, nil)
+
+			},
+			expectedError: "",
+		},
+		{
+			name: "Permission denied",
+			req: &gen.GetOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: uuid.New(),
+					}, nil)
+
+			},
+			expectedError: "you are not authorized to access this order",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.GetOrder(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Require().Len(resp.StatusHistory, 2)
+				s.Equal("", resp.StatusHistory[0].FromStatus)
+				s.Equal("PENDING", resp.StatusHistory[0].ToStatus)
+				s.Equal("CUSTOMER", resp.StatusHistory[0].Actor)
+				s.Equal("STOCK_RESERVED", resp.StatusHistory[1].ToStatus)
+				s.Require().Len(resp.Payments, 2)
+				s.Equal("FAILED", resp.Payments[0].Status)
+				s.Equal("E_WALLET", resp.Payments[1].Method)
+				s.Equal(int64(0), resp.PaidAmount.Units)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestAddOrderPayment() {
+	userID := uuid.New()
+	orderID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	reservedOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:            orderID,
+			UserID:        userID,
+			Status:        constanta.OrderStatusStockReserved,
+			TotalAmount:   &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+			TransactionID: "txn-1",
+		}
+	}
+
+	failedFirstPayment := &gen.ListPaymentsResponse{
+		Payments: []*gen.GetPaymentResponse{
+			{TransactionId: "txn-1", Status: "FAILED", Method: "CARD", TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+		},
+		PaidAmount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+	}
+
+	tests := []struct {
+		name             string
+		req              *gen.AddOrderPaymentRequest
+		setupMock        func()
+		expectedError    string
+		expectedPayments int
+	}{
+		{
+			name: "Success retry after the failed payment",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+				Method:  "BANK_TRANSFER",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), &gen.ListPaymentsRequest{OrderId: orderID.String()}).
+					Return(failedFirstPayment, nil)
+				// the whole unpaid amount is paid when the amount is empty
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), &gen.ProcessPaymentRequest{
+						OrderId:     orderID.String(),
+						TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+						Method:      "BANK_TRANSFER",
+						OrderAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}).
+					Return(&gen.ProcessPaymentResponse{TransactionId: "txn-2"}, nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), &gen.ListPaymentsRequest{OrderId: orderID.String()}).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							failedFirstPayment.Payments[0],
+							{TransactionId: "txn-2", Status: "WAITING", Method: "BANK_TRANSFER", TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+						},
+						PaidAmount: &gen.Money{Units: 0, CurrencyCode: "IDR"},
+					}, nil)
+			},
+			expectedPayments: 2,
+		},
+		{
+			name: "Success split payment",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+				Method:  "STORE_CREDIT",
+				Amount:  &gen.Money{Units: 300, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							{TransactionId: "txn-1", Status: "PAID", Method: "CARD", TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}},
+						},
+						PaidAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"},
+					}, nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), &gen.ProcessPaymentRequest{
+						OrderId:     orderID.String(),
+						TotalAmount: &gen.Money{Units: 300, CurrencyCode: "IDR"},
+						Method:      "STORE_CREDIT",
+						OrderAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}).
+					Return(&gen.ProcessPaymentResponse{TransactionId: "txn-2"}, nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							{TransactionId: "txn-1", Status: "PAID", Method: "CARD", TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}},
+							{TransactionId: "txn-2", Status: "WAITING", Method: "STORE_CREDIT", TotalAmount: &gen.Money{Units: 300, CurrencyCode: "IDR"}},
+						},
+						PaidAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"},
+					}, nil)
+			},
+			expectedPayments: 2,
+		},
+		{
+			name: "Error another payment of the order is created first",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(failedFirstPayment, nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "order is already paid or waiting for its payments"))
+			},
+			expectedError: "rpc error: code = FailedPrecondition desc = order is already paid or waiting for its payments",
+		},
+		{
+			name: "Error amount is more than the unpaid amount",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+				Amount:  &gen.Money{Units: 500, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							{TransactionId: "txn-1", Status: "PAID", TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+			},
+			expectedError: "payment amount cannot be more than 400",
+		},
+		{
+			name: "Error order is waiting for its payments",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(&gen.ListPaymentsResponse{
+						Payments: []*gen.GetPaymentResponse{
+							{TransactionId: "txn-1", Status: "WAITING", TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+						},
+					}, nil)
+			},
+			expectedError: "order is already paid or waiting for its payments",
+		},
+		{
+			name: "Error currency not match",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+				Amount:  &gen.Money{Units: 10, CurrencyCode: "USD"},
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(failedFirstPayment, nil)
+			},
+			expectedError: "currency code not match",
+		},
+		{
+			name: "Error unknown payment method",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+				Method:  "CHEQUE",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(), nil)
+				s.mockPaymentClient.EXPECT().
+					ListPayments(gomock.Any(), gomock.Any()).
+					Return(failedFirstPayment, nil)
+				s.mockPaymentClient.EXPECT().
+					ProcessPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.InvalidArgument, "unknown payment method CHEQUE"))
+			},
+			expectedError: "unknown payment method CHEQUE",
+		},
+		{
+			name: "Error completed order cannot be paid",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+			},
+			setupMock: func() {
+				order := reservedOrder()
+				order.Status = constanta.OrderStatusCompleted
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(order, nil)
+			},
+			expectedError: "order with status COMPLETED cannot be paid",
+		},
+		{
+			name: "Permission denied",
+			req: &gen.AddOrderPaymentRequest{
+				OrderId: orderID.String(),
+			},
+			setupMock: func() {
+				order := reservedOrder()
+				order.UserID = uuid.New()
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(order, nil)
+			},
+			expectedError: "you are not authorized to access this order",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.AddOrderPayment(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Len(resp.Payments, tt.expectedPayments)
+				s.Equal("STOCK_RESERVED", resp.Status)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestGetOrderList() {
+	userID := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.GetOrderListRequest
+		setupMock     func()
+		expectedError string
+		expectedResp  *gen.Orders
+	}{
+
+		{
+			name: "Success",
+			req: &gen.GetOrderListRequest{
+				StartDate: "2006-01-02",
+				EndDate:   "2006-01-02",
+				Status:    "PENDING",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderList(gomock.Any(), gomock.Any()).
+					Return([]entity.Order{
+						{
+							IdempotencyKey: uuid.UUID{},
+							ID:             userID,
+							UserID:         userID,
+							Status:         "",
+							TotalAmount:    &gen.Money{},
+							TransactionID:  "",
+							CreatedAt:      &time.Time{},
+							UpdatedAt:      &time.Time{},
+							Items:          []entity.OrderItem{},
+						},
+					}, nil)
+
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.GetOrderList(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCancelOrder() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	transactionID := "transaction-1"
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name           string
+		req            *gen.CancelOrderRequest
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Success",
+			req: &gen.CancelOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:            orderID,
+						UserID:        userID,
+						Status:        constanta.OrderStatusStockReserved,
+						TransactionID: transactionID,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+						TransactionId: transactionID,
+						OrderId:       orderID.String(),
+						Reason:        "cancelled by customer",
+					}).
+					Return(&gen.Empty{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "", "").
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), &gen.ReleaseStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ReleaseStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						s.Equal("cancelled by customer", change.CancelReason)
+						s.Equal(constanta.OrderActorCustomer, change.Actor)
+						return nil
+					})
+			},
+			expectedStatus: constanta.OrderStatusCancelled.String(),
+		},
+		{
+			name: "Release stock failed, order is still cancelled",
+			req: &gen.CancelOrderRequest{
+				Id:     orderID.String(),
+				Reason: "changed my mind",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:            orderID,
+						UserID:        userID,
+						Status:        constanta.OrderStatusStockReserved,
+						TransactionID: transactionID,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+						TransactionId: transactionID,
+						OrderId:       orderID.String(),
+						Reason:        "changed my mind",
+					}).
+					Return(&gen.Empty{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "", "").
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("warehouse unavailable"))
+				// the release is scheduled to be retried
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "warehouse unavailable", gomock.Not(time.Time{})).
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: constanta.OrderStatusCancelled.String(),
+		},
+		{
+			name: "Payment cannot be cancelled",
+			req: &gen.CancelOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:            orderID,
+						UserID:        userID,
+						Status:        constanta.OrderStatusStockReserved,
+						TransactionID: transactionID,
+					}, nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusStockReserved, change.From)
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("payment must be waiting rollback the payment"))
+				// cancel payment is not a compensation, it is not retried
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "", "payment must be waiting rollback the payment", time.Time{}).
+					Return(nil)
+				// the claim is released, the order can be moved again
+				s.mockOrderRepo.EXPECT().
+					ReleaseOrderTransition(gomock.Any(), orderID, constanta.OrderStatusCancelled).
+					Return(nil)
+			},
+			expectedError: "failed to cancel payment",
+		},
+		{
+			name: "Already cancelled",
+			req: &gen.CancelOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:           orderID,
+						UserID:       userID,
+						Status:       constanta.OrderStatusCancelled,
+						CancelReason: "cancelled by customer",
+					}, nil)
+			},
+			expectedStatus: constanta.OrderStatusCancelled.String(),
+		},
+		{
+			name: "Order is completed",
+			req: &gen.CancelOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: userID,
+						Status: constanta.OrderStatusCompleted,
+					}, nil)
+			},
+			expectedError: "order with status COMPLETED cannot be cancelled",
+		},
+		{
+			name: "Permission denied",
+			req: &gen.CancelOrderRequest{
+				Id: orderID.String(),
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						UserID: uuid.New(),
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+			},
+			expectedError: "you are not authorized to access this order",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.CancelOrder(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+				s.Equal(tt.expectedStatus, resp.Status)
+				s.NotEmpty(resp.CancelReason)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCancelReservedOrder() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	transactionID := "transaction-1"
+
+	serviceCtx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		string(globalcontanta.RoleKey): string(globalcontanta.RoleService),
+	}))
+	adminCtx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	}))
+	customerCtx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	}))
+
+	req := &gen.CancelReservedOrderRequest{
+		OrderId:     orderID.String(),
+		WarehouseId: 1,
+		Reason:      "warehouse 1 is deactivated",
+	}
+
+	reservedOrder := func(orderStatus constanta.OrderStatus) *entity.Order {
+		return &entity.Order{
+			ID:            orderID,
+			UserID:        userID,
+			Status:        orderStatus,
+			TransactionID: transactionID,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Success cancelled by the background job of the warehouse service",
+			ctx:  serviceCtx,
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(constanta.OrderStatusStockReserved), nil)
+
+				s.mockOrderRepo.EXPECT().
+					ClaimOrderTransition(gomock.Any(), orderID, gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "").
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RollbackPayment(gomock.Any(), &gen.RollbackPaymentRequest{
+						TransactionId: transactionID,
+						OrderId:       orderID.String(),
+						Reason:        "warehouse 1 is deactivated",
+					}).
+					DoAndReturn(func(ctx context.Context, req *gen.RollbackPaymentRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+						// the downstream services are called on behalf of the customer of the order
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						return &gen.Empty{}, nil
+					})
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepCancelPayment, "", "").
+					Return(nil)
+
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "").
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					ReleaseStock(gomock.Any(), &gen.ReleaseStockRequest{OrderId: orderID.String()}).
+					Return(&gen.ReleaseStockResponse{}, nil)
+				s.mockSagaRepo.EXPECT().
+					CompleteSagaStep(gomock.Any(), orderID, constanta.SagaStepReleaseStock, "", "").
+					Return(nil)
+
+				s.mockOrderRepo.EXPECT().
+					UpdateOrderStatus(gomock.Any(), orderID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange) error {
+						s.Equal(constanta.OrderStatusCancelled, change.To)
+						s.Equal("warehouse 1 is deactivated", change.CancelReason)
+						s.Equal(constanta.OrderActorWarehouse, change.Actor)
+						return nil
+					})
+			},
+			expectedStatus: constanta.OrderStatusCancelled.String(),
+		},
+		{
+			name: "Admin cancels the order again",
+			ctx:  adminCtx,
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(constanta.OrderStatusCancelled), nil)
+			},
+			expectedStatus: constanta.OrderStatusCancelled.String(),
+		},
+		{
+			name: "Pending order cannot be cancelled yet",
+			ctx:  serviceCtx,
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(reservedOrder(constanta.OrderStatusPending), nil)
+			},
+			expectedError: "order with status PENDING cannot be cancelled",
+		},
+		{
+			name:          "Customer is not allowed",
+			ctx:           customerCtx,
+			setupMock:     func() {},
+			expectedError: "admin or service role is required",
+		},
+		{
+			name:          "Caller without metadata is not authenticated",
+			ctx:           context.Background(),
+			setupMock:     func() {},
+			expectedError: "unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.CancelReservedOrder(tt.ctx, req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal(tt.expectedStatus, resp.Status)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestUpdateFulfillment() {
+	orderID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	packedAt := time.Now().Add(-time.Hour)
+
+	address := entity.ShippingAddress{
+		RecipientName: "Budi",
+		Street:        "Jl. Sudirman 1",
+		City:          "Jakarta",
+		Region:        "JKT",
+	}
+
+	tests := []struct {
+		name           string
+		req            *gen.UpdateFulfillmentRequest
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Success paid order is packed",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "packed",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusCompleted,
+						Shipment: &entity.Shipment{Address: address},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					UpdateFulfillment(gomock.Any(), orderID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error {
+						s.Equal(constanta.OrderStatusPacked, change.To)
+						s.Equal(constanta.OrderActorWarehouse, change.Actor)
+						s.Equal(address, shipment.Address)
+						s.NotNil(shipment.PackedAt)
+						s.Nil(shipment.ShippedAt)
+						return nil
+					})
+			},
+			expectedStatus: constanta.OrderStatusPacked.String(),
+		},
+		{
+			name: "Success packed order is shipped with the carrier",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId:        orderID.String(),
+				Status:         "SHIPPED",
+				Carrier:        "JNE",
+				TrackingNumber: "JNE123",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusPacked,
+						Shipment: &entity.Shipment{Address: address, PackedAt: &packedAt},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					UpdateFulfillment(gomock.Any(), orderID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error {
+						s.Equal(constanta.OrderStatusShipped, change.To)
+						s.Equal("shipped with JNE, tracking number JNE123", change.Reason)
+						s.Equal("JNE", shipment.Carrier)
+						s.Equal("JNE123", shipment.TrackingNumber)
+						s.Equal(&packedAt, shipment.PackedAt)
+						s.NotNil(shipment.ShippedAt)
+						return nil
+					})
+			},
+			expectedStatus: constanta.OrderStatusShipped.String(),
+		},
+		{
+			name: "Success same status is accepted again",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "PACKED",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusPacked,
+						Shipment: &entity.Shipment{Address: address, PackedAt: &packedAt},
+					}, nil)
+			},
+			expectedStatus: constanta.OrderStatusPacked.String(),
+		},
+		{
+			name: "Failed carrier is required to ship",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "SHIPPED",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusPacked,
+						Shipment: &entity.Shipment{Address: address, PackedAt: &packedAt},
+					}, nil)
+			},
+			expectedError: "carrier and tracking_number are required",
+		},
+		{
+			name: "Success partially refunded order is shipped",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId:        orderID.String(),
+				Status:         "SHIPPED",
+				Carrier:        "JNE",
+				TrackingNumber: "JNE123",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusPartiallyRefunded,
+						Shipment: &entity.Shipment{Address: address, PackedAt: &packedAt},
+					}, nil)
+				s.mockOrderRepo.EXPECT().
+					UpdateFulfillment(gomock.Any(), orderID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error {
+						s.Equal(constanta.OrderStatusPartiallyRefunded, change.From)
+						s.Equal(constanta.OrderStatusShipped, change.To)
+						s.NotNil(shipment.ShippedAt)
+						return nil
+					})
+			},
+			expectedStatus: constanta.OrderStatusShipped.String(),
+		},
+		{
+			name: "Failed partially refunded order is shipped before it is packed",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId:        orderID.String(),
+				Status:         "SHIPPED",
+				Carrier:        "JNE",
+				TrackingNumber: "JNE123",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:       orderID,
+						Status:   constanta.OrderStatusPartiallyRefunded,
+						Shipment: &entity.Shipment{Address: address},
+					}, nil)
+			},
+			expectedError: "only the packed order that is not shipped yet can be shipped",
+		},
+		{
+			name: "Failed unpaid order cannot be packed",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "PACKED",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						Status: constanta.OrderStatusStockReserved,
+					}, nil)
+			},
+			expectedError: "order with status STOCK_RESERVED cannot be PACKED",
+		},
+		{
+			name: "Failed order cannot skip a step",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "DELIVERED",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{
+						ID:     orderID,
+						Status: constanta.OrderStatusPacked,
+					}, nil)
+			},
+			expectedError: "order with status PACKED cannot be DELIVERED",
+		},
+		{
+			name: "Failed unknown status",
+			req: &gen.UpdateFulfillmentRequest{
+				OrderId: orderID.String(),
+				Status:  "COMPLETED",
+			},
+			setupMock:     func() {},
+			expectedError: "status must be one of PACKED, SHIPPED or DELIVERED",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.UpdateFulfillment(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal(tt.expectedStatus, resp.Status)
+				s.Equal("Budi", resp.Shipment.GetAddress().GetRecipientName())
+				s.NotEmpty(resp.Shipment.GetPackedAt())
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestUpdateFulfillmentRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+
+	// the order is not loaded
+	resp, err := s.svc.UpdateFulfillment(metadata.NewIncomingContext(context.Background(), md), &gen.UpdateFulfillmentRequest{
+		OrderId: uuid.NewString(),
+		Status:  "PACKED",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	// the caller without metadata is not authenticated
+	resp, err = s.svc.UpdateFulfillment(context.Background(), &gen.UpdateFulfillmentRequest{
+		OrderId: uuid.NewString(),
+		Status:  "PACKED",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrderWithShippingAddress() {
+	userID := uuid.New()
+	idempotencyKey := uuid.New()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	s.Run("Failed address without region", func() {
+		resp, err := s.svc.CreateOrder(ctx, &gen.CreateOrderRequest{
+			IdempotencyKey: idempotencyKey.String(),
+			ShippingAddress: &gen.ShippingAddress{
+				RecipientName: "Budi",
+				Street:        "Jl. Sudirman 1",
+				City:          "Jakarta",
+			},
+		})
+		s.Error(err)
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Nil(resp)
+	})
+
+	s.Run("Success region of the address is used", func() {
+		s.mockOrderRepo.EXPECT().
+			GetOrderByIdempotencyKey(gomock.Any(), idempotencyKey).
+			Return(nil, sql.ErrNoRows)
+
+		s.mockCartRepo.EXPECT().
+			GetCartByUserID(gomock.Any(), userID).
+			Return(&entity.Cart{
+				ID:     uuid.New(),
+				UserID: userID,
+				Items: []entity.CartItem{
+					{ProductID: "prod-a", Quantity: 1, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+				},
+			}, nil)
+
+		s.mockProductClient.EXPECT().
+			GetProducts(gomock.Any(), gomock.Any()).
+			Return(&gen.Products{
+				Products: []*gen.Product{
+					{Id: "prod-a", Name: "a", Stock: 5, ShopId: 1, Price: &gen.Money{Units: 10000, CurrencyCode: "IDR"}},
+				},
+			}, nil)
+
+		s.expectUntaxedFreeShipping()
+
+		// stop after the order is built, the saga is covered by TestCreateOrder
+		s.mockOrderRepo.EXPECT().
+			CreateOrder(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, order entity.Order) (uuid.UUID, error) {
+				s.Equal("BDG", order.ShippingRegion)
+				s.Require().NotNil(order.Shipment)
+				s.Equal("Budi", order.Shipment.Address.RecipientName)
+				s.Equal("BDG", order.Shipment.Address.Region)
+				s.Nil(order.Shipment.PackedAt)
+				return uuid.Nil, errors.New("db is closed")
+			})
+
+		resp, err := s.svc.CreateOrder(ctx, &gen.CreateOrderRequest{
+			IdempotencyKey: idempotencyKey.String(),
+			ShippingRegion: "JKT",
+			ShippingAddress: &gen.ShippingAddress{
+				RecipientName: "Budi",
+				Phone:         "08123456789",
+				Street:        "Jl. Asia Afrika 8",
+				City:          "Bandung",
+				Region:        "bdg",
+				PostalCode:    "40111",
+			},
+		})
+		s.Error(err)
+		s.Contains(err.Error(), "failed to persist order")
+		s.Nil(resp)
+	})
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	transactionID := "trx-1"
+	productA := uuid.NewString()
+	productB := uuid.NewString()
+	shippedAt := time.Now().Add(-time.Hour)
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	// every step of the refund saga succeeds
+	succeedRefundSteps := func(steps ...constanta.SagaStep) {
+		for _, step := range steps {
+			s.mockSagaRepo.EXPECT().
+				StartSagaStep(gomock.Any(), orderID, step, gomock.Any()).
+				Return(int64(1), nil)
+			s.mockSagaRepo.EXPECT().
+				CompleteSagaStep(gomock.Any(), orderID, step, gomock.Any(), "").
+				Return(nil)
+		}
+	}
+
+	// product A is paid 99900 after the discount with the exclusive tax, product B is paid 50000 with the inclusive tax,
+	// the total is 159900 with the shipping
+	paidOrder := func(orderStatus constanta.OrderStatus) *entity.Order {
+		return &entity.Order{
+			ID:             orderID,
+			UserID:         userID,
+			Status:         orderStatus,
+			TransactionID:  transactionID,
+			TotalAmount:    &gen.Money{Units: 159900, CurrencyCode: "IDR"},
+			ShippingAmount: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+			Items: []entity.OrderItem{
+				{
+					ProductID:       productA,
+					Quantity:        2,
+					SettlementTotal: &gen.Money{Units: 100000, CurrencyCode: "IDR"},
+					Discount:        &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+					Tax:             &gen.Money{Units: 9900, CurrencyCode: "IDR"},
+				},
+				{
+					ProductID:       productB,
+					Quantity:        1,
+					SettlementTotal: &gen.Money{Units: 50000, CurrencyCode: "IDR"},
+					Discount:        &gen.Money{Units: 0, CurrencyCode: "IDR"},
+					Tax:             &gen.Money{Units: 4545, CurrencyCode: "IDR"},
+					TaxInclusive:    true,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            *gen.RefundOrderRequest
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Partial refund of an item that is not shipped yet is restocked",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.RefundItem{{ProductId: productA, Quantity: 1}},
+				Reason:  "damaged item",
+			},
+			setupMock: func() {
+				var refundID uuid.UUID
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil).
+					Times(2)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund) error {
+						refundID = refund.ID
+						s.Equal(constanta.RefundStatusPending, refund.Status)
+						s.Equal(int64(49950), refund.Amount.Units)
+						s.Require().Len(refund.Items, 1)
+						s.Equal(productA, refund.Items[0].ProductID)
+						s.Equal(int64(1), refund.Items[0].Quantity)
+						s.False(refund.Full)
+						s.True(refund.Restock)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				succeedRefundSteps(constanta.SagaStepRefundPayment, constanta.SagaStepRestockRefund, constanta.SagaStepCompleteRefund)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RefundPaymentRequest, opts ...grpc.CallOption) (*gen.RefundPaymentResponse, error) {
+						s.Equal(transactionID, req.TransactionId)
+						s.Equal(refundID.String(), req.RefundId)
+						s.Equal(int64(49950), req.Amount.Units)
+						s.Equal("damaged item", req.Reason)
+						return &gen.RefundPaymentResponse{RefundId: req.RefundId, Status: "PARTIALLY_REFUNDED"}, nil
+					})
+				s.mockWarehouseClient.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RestockStockRequest, opts ...grpc.CallOption) (*gen.RestockStockResponse, error) {
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						s.Equal(refundID.String(), req.RefundId)
+						s.Equal([]*gen.Stock{{ProductId: productA, Quantity: 1}}, req.Stocks)
+						return &gen.RestockStockResponse{RestockedStockIds: []int64{1}}, nil
+					})
+				s.mockOrderRepo.EXPECT().
+					CompleteRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+						s.True(refund.Restocked)
+						s.Equal(constanta.OrderStatusPartiallyRefunded, change.To)
+						s.Equal(constanta.OrderActorCustomerService, change.Actor)
+						return nil
+					})
+			},
+			expectedStatus: "PARTIALLY_REFUNDED",
+		},
+		{
+			name: "Full refund of the remaining items of the shipped order is not restocked",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Reason:  "order lost by the carrier",
+			},
+			setupMock: func() {
+				order := paidOrder(constanta.OrderStatusPartiallyRefunded)
+				order.Shipment = &entity.Shipment{ShippedAt: &shippedAt}
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(order, nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{
+						{
+							ID:     uuid.New(),
+							Status: constanta.RefundStatusCompleted,
+							Amount: &gen.Money{Units: 49950, CurrencyCode: "IDR"},
+							Items:  []entity.RefundItem{{ProductID: productA, Quantity: 1}},
+						},
+						{
+							ID:     uuid.New(),
+							Status: constanta.RefundStatusFailed,
+							Amount: &gen.Money{Units: 50000, CurrencyCode: "IDR"},
+							Items:  []entity.RefundItem{{ProductID: productB, Quantity: 1}},
+						},
+					}, nil).
+					Times(2)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund) error {
+						s.True(refund.Full)
+						s.False(refund.Restock)
+						s.Equal(int64(109950), refund.Amount.Units)
+						s.Len(refund.Items, 2)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				// the shipped items are with the customer, they are not put back into the warehouse
+				succeedRefundSteps(constanta.SagaStepRefundPayment, constanta.SagaStepCompleteRefund)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(&gen.RefundPaymentResponse{Status: "REFUNDED"}, nil)
+				s.mockOrderRepo.EXPECT().
+					CompleteRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+						s.False(refund.Restocked)
+						s.Equal(constanta.OrderStatusRefunded, change.To)
+						return nil
+					})
+			},
+			expectedStatus: "REFUNDED",
+		},
+		{
+			name: "Failed restock keeps the refund pending to be retried",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.RefundItem{{ProductId: productB, Quantity: 1}},
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				succeedRefundSteps(constanta.SagaStepRefundPayment)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(&gen.RefundPaymentResponse{Status: "PARTIALLY_REFUNDED"}, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any()).
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.Unavailable, "warehouse unavailable"))
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any(), gomock.Any(), gomock.Not(time.Time{})).
+					Return(nil)
+			},
+			expectedError: "failed to restock refund",
+		},
+		{
+			name: "Payment service refuses the refund",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.RefundItem{{ProductId: productB, Quantity: 1}},
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundPayment, gomock.Any()).
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "payment with status WAITING cannot be refunded"))
+				s.mockOrderRepo.EXPECT().
+					FailRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				// the refused refund is not retried
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundPayment, gomock.Any(), gomock.Any(), time.Time{}).
+					Return(nil)
+			},
+			expectedError: "failed to refund payment",
+		},
+		{
+			name: "Quantity exceeds the ordered quantity",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.RefundItem{{ProductId: productA, Quantity: 3}},
+				Reason:  "damaged item",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+			},
+			expectedError: "only 2 of product " + productA + " can be refunded",
+		},
+		{
+			name: "Product is not in the order",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.RefundItem{{ProductId: "unknown", Quantity: 1}},
+				Reason:  "damaged item",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+			},
+			expectedError: "product unknown is not in the order",
+		},
+		{
+			name: "Unpaid order cannot be refunded",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+				Reason:  "damaged item",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusStockReserved), nil)
+			},
+			expectedError: "order with status STOCK_RESERVED cannot be refunded",
+		},
+		{
+			name: "Reason is required",
+			req: &gen.RefundOrderRequest{
+				OrderId: orderID.String(),
+			},
+			setupMock:     func() {},
+			expectedError: "reason is required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.RefundOrder(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal(tt.expectedStatus, resp.Status)
+				s.NotEmpty(resp.Refunds)
+				s.Equal("COMPLETED", resp.Refunds[len(resp.Refunds)-1].Status)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrderRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+
+	// the order is not loaded
+	resp, err := s.svc.RefundOrder(metadata.NewIncomingContext(context.Background(), md), &gen.RefundOrderRequest{
+		OrderId: uuid.NewString(),
+		Reason:  "damaged item",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	// the caller without metadata is not authenticated
+	resp, err = s.svc.RefundOrder(context.Background(), &gen.RefundOrderRequest{
+		OrderId: uuid.NewString(),
+		Reason:  "damaged item",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *OrderServiceTestSuite) TestCreateReturn() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	productA := uuid.NewString()
+	productB := uuid.NewString()
+
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	paidOrder := func(orderStatus constanta.OrderStatus) *entity.Order {
+		return &entity.Order{
+			ID:     orderID,
+			UserID: userID,
+			Status: orderStatus,
+			Items: []entity.OrderItem{
+				{ProductID: productA, Quantity: 2},
+				{ProductID: productB, Quantity: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.CreateReturnRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success with the items that are not refunded yet",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Items: []*gen.ReturnItem{
+					{ProductId: productB, Quantity: 1},
+					{ProductId: productA, Quantity: 1},
+				},
+				Reason: "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusDelivered), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{
+						{Status: constanta.RefundStatusCompleted, Items: []entity.RefundItem{{ProductID: productA, Quantity: 1}}},
+					}, nil)
+				// the rejected return does not hold its items
+				s.mockReturnRepo.EXPECT().
+					GetOrderReturns(gomock.Any(), orderID).
+					Return([]entity.Return{
+						{Status: constanta.ReturnStatusRejected, Items: []entity.ReturnItem{{ProductID: productB, Quantity: 1}}},
+					}, nil)
+				s.mockReturnRepo.EXPECT().
+					CreateReturn(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, ret entity.Return) error {
+						s.Equal(orderID, ret.OrderID)
+						s.Equal(userID, ret.UserID)
+						s.Equal(constanta.ReturnStatusRequested, ret.Status)
+						s.Equal("wrong size", ret.Reason)
+						s.Equal([]entity.ReturnItem{
+							{ProductID: productA, Quantity: 1},
+							{ProductID: productB, Quantity: 1},
+						}, ret.Items)
+						return nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Failed item is held by an open return",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.ReturnItem{{ProductId: productB, Quantity: 1}},
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockReturnRepo.EXPECT().
+					GetOrderReturns(gomock.Any(), orderID).
+					Return([]entity.Return{
+						{Status: constanta.ReturnStatusApproved, Items: []entity.ReturnItem{{ProductID: productB, Quantity: 1}}},
+					}, nil)
+			},
+			expectedError: "only 0 of product " + productB + " can be returned",
+		},
+		{
+			name: "Failed items are required",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusCompleted), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockReturnRepo.EXPECT().
+					GetOrderReturns(gomock.Any(), orderID).
+					Return([]entity.Return{}, nil)
+			},
+			expectedError: "items are required",
+		},
+		{
+			name: "Failed unpaid order cannot be returned",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.ReturnItem{{ProductId: productA, Quantity: 1}},
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(constanta.OrderStatusStockReserved), nil)
+			},
+			expectedError: "order with status STOCK_RESERVED cannot be returned",
+		},
+		{
+			name: "Permission denied",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.ReturnItem{{ProductId: productA, Quantity: 1}},
+				Reason:  "wrong size",
+			},
+			setupMock: func() {
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(&entity.Order{ID: orderID, UserID: uuid.New()}, nil)
+			},
+			expectedError: "you are not authorized to access this order",
+		},
+		{
+			name: "Failed reason is required",
+			req: &gen.CreateReturnRequest{
+				OrderId: orderID.String(),
+				Items:   []*gen.ReturnItem{{ProductId: productA, Quantity: 1}},
+			},
+			setupMock:     func() {},
+			expectedError: "reason is required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.CreateReturn(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal("REQUESTED", resp.Status)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestReviewReturn() {
+	returnID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	requestedReturn := func(returnStatus constanta.ReturnStatus) *entity.Return {
+		return &entity.Return{
+			ID:      returnID,
+			OrderID: uuid.New(),
+			Status:  returnStatus,
+			Reason:  "wrong size",
+			Items:   []entity.ReturnItem{{ProductID: uuid.NewString(), Quantity: 1}},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            *gen.ReviewReturnRequest
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Approve the requested return",
+			req: &gen.ReviewReturnRequest{
+				ReturnId: returnID.String(),
+				Approve:  true,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(requestedReturn(constanta.ReturnStatusRequested), nil)
+				s.mockReturnRepo.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Any(), constanta.ReturnStatusRequested).
+					DoAndReturn(func(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error {
+						s.Equal(constanta.ReturnStatusApproved, ret.Status)
+						return nil
+					})
+			},
+			expectedStatus: "APPROVED",
+		},
+		{
+			name: "Reject the requested return with the note",
+			req: &gen.ReviewReturnRequest{
+				ReturnId: returnID.String(),
+				Note:     " used item ",
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(requestedReturn(constanta.ReturnStatusRequested), nil)
+				s.mockReturnRepo.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Any(), constanta.ReturnStatusRequested).
+					DoAndReturn(func(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error {
+						s.Equal(constanta.ReturnStatusRejected, ret.Status)
+						s.Equal("used item", ret.Note)
+						return nil
+					})
+			},
+			expectedStatus: "REJECTED",
+		},
+		{
+			name: "Failed return is reviewed by another admin",
+			req: &gen.ReviewReturnRequest{
+				ReturnId: returnID.String(),
+				Approve:  true,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(requestedReturn(constanta.ReturnStatusRequested), nil)
+				s.mockReturnRepo.EXPECT().
+					UpdateReturnStatus(gomock.Any(), gomock.Any(), constanta.ReturnStatusRequested).
+					Return(sql.ErrNoRows)
+			},
+			expectedError: "return is not REQUESTED anymore",
+		},
+		{
+			name: "Failed approved return cannot be reviewed",
+			req: &gen.ReviewReturnRequest{
+				ReturnId: returnID.String(),
+				Approve:  true,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(requestedReturn(constanta.ReturnStatusApproved), nil)
+			},
+			expectedError: "return with status APPROVED cannot be reviewed",
+		},
+		{
+			name: "Failed note is required to reject",
+			req: &gen.ReviewReturnRequest{
+				ReturnId: returnID.String(),
+			},
+			setupMock:     func() {},
+			expectedError: "note is required to reject the return",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ReviewReturn(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal(tt.expectedStatus, resp.Status)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestReceiveReturn() {
+	userID := uuid.New()
+	orderID := uuid.New()
+	returnID := uuid.New()
+	transactionID := "trx-1"
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	// every step of the refund saga succeeds
+	succeedRefundSteps := func(steps ...constanta.SagaStep) {
+		for _, step := range steps {
+			s.mockSagaRepo.EXPECT().
+				StartSagaStep(gomock.Any(), orderID, step, gomock.Any()).
+				Return(int64(1), nil)
+			s.mockSagaRepo.EXPECT().
+				CompleteSagaStep(gomock.Any(), orderID, step, gomock.Any(), "").
+				Return(nil)
+		}
+	}
+	productA := uuid.NewString()
+	productB := uuid.NewString()
+
+	// product A is paid 99900 and product B is paid 50000, the total is 159900 with the shipping
+	paidOrder := func() *entity.Order {
+		return &entity.Order{
+			ID:             orderID,
+			UserID:         userID,
+			Status:         constanta.OrderStatusDelivered,
+			TransactionID:  transactionID,
+			TotalAmount:    &gen.Money{Units: 159900, CurrencyCode: "IDR"},
+			ShippingAmount: &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+			Items: []entity.OrderItem{
+				{
+					ProductID:       productA,
+					Quantity:        2,
+					SettlementTotal: &gen.Money{Units: 100000, CurrencyCode: "IDR"},
+					Discount:        &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+					Tax:             &gen.Money{Units: 9900, CurrencyCode: "IDR"},
+				},
+				{
+					ProductID:       productB,
+					Quantity:        1,
+					SettlementTotal: &gen.Money{Units: 50000, CurrencyCode: "IDR"},
+					Discount:        &gen.Money{Units: 0, CurrencyCode: "IDR"},
+					Tax:             &gen.Money{Units: 4545, CurrencyCode: "IDR"},
+					TaxInclusive:    true,
+				},
+			},
+		}
+	}
+
+	approvedReturn := func(returnStatus constanta.ReturnStatus, items ...entity.ReturnItem) *entity.Return {
+		return &entity.Return{
+			ID:      returnID,
+			OrderID: orderID,
+			UserID:  userID,
+			Status:  returnStatus,
+			Reason:  "wrong size",
+			Items:   items,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ReceiveReturnRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Receive the approved return into the warehouse then refund it",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 2,
+			},
+			setupMock: func() {
+				var refundID uuid.UUID
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusApproved, entity.ReturnItem{ProductID: productA, Quantity: 1}), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil).
+					Times(2)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				succeedRefundSteps(constanta.SagaStepRestockRefund, constanta.SagaStepRefundPayment, constanta.SagaStepCompleteRefund)
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusApproved, entity.ReturnItem{ProductID: productA, Quantity: 1}), nil)
+				gomock.InOrder(
+					s.mockOrderRepo.EXPECT().
+						CreateRefund(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, refund entity.Refund) error {
+							refundID = refund.ID
+							s.Equal(returnID.String(), refund.ReturnID)
+							s.Equal(int64(49950), refund.Amount.Units)
+							s.True(refund.Restock)
+							s.Equal(int64(2), refund.WarehouseID)
+							return nil
+						}),
+					s.mockWarehouseClient.EXPECT().
+						RestockStock(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, req *gen.RestockStockRequest, opts ...grpc.CallOption) (*gen.RestockStockResponse, error) {
+							md, _ := metadata.FromOutgoingContext(ctx)
+							s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+							s.Equal(refundID.String(), req.RefundId)
+							s.Equal(int64(2), req.WarehouseId)
+							s.Equal([]*gen.Stock{{ProductId: productA, Quantity: 1}}, req.Stocks)
+							return &gen.RestockStockResponse{RestockedStockIds: []int64{1}}, nil
+						}),
+					s.mockReturnRepo.EXPECT().
+						UpdateReturnStatus(gomock.Any(), gomock.Any(), constanta.ReturnStatusApproved).
+						DoAndReturn(func(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error {
+							s.Equal(constanta.ReturnStatusReceived, ret.Status)
+							s.Equal(int64(2), ret.WarehouseID)
+							return nil
+						}),
+					s.mockPaymentClient.EXPECT().
+						RefundPayment(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, req *gen.RefundPaymentRequest, opts ...grpc.CallOption) (*gen.RefundPaymentResponse, error) {
+							s.Equal(refundID.String(), req.RefundId)
+							s.Equal(int64(49950), req.Amount.Units)
+							return &gen.RefundPaymentResponse{RefundId: req.RefundId, Status: "PARTIALLY_REFUNDED"}, nil
+						}),
+					s.mockOrderRepo.EXPECT().
+						CompleteRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+							s.True(refund.Restocked)
+							s.Equal(returnID.String(), refund.ReturnID)
+							s.Equal(constanta.OrderStatusPartiallyRefunded, change.To)
+							s.Equal(constanta.OrderActorWarehouse, change.Actor)
+							return nil
+						}),
+				)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Retry the received return with its pending refund",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 2,
+			},
+			setupMock: func() {
+				pendingRefundID := uuid.New()
+				pendingRefund := entity.Refund{
+					ID:          pendingRefundID,
+					OrderID:     orderID,
+					Status:      constanta.RefundStatusPending,
+					Amount:      &gen.Money{Units: 159900, CurrencyCode: "IDR"},
+					Reason:      "return, wrong size",
+					Restock:     true,
+					WarehouseID: 2,
+					ReturnID:    returnID.String(),
+					Items: []entity.RefundItem{
+						{ProductID: productA, Quantity: 2},
+						{ProductID: productB, Quantity: 1},
+					},
+				}
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusReceived,
+						entity.ReturnItem{ProductID: productA, Quantity: 2},
+						entity.ReturnItem{ProductID: productB, Quantity: 1},
+					), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{pendingRefund}, nil).
+					Times(2)
+				// the items are already received by the previous attempt
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{
+						{Step: constanta.SagaStepRestockRefund, Reference: pendingRefundID.String(), Status: constanta.SagaStepStatusSucceeded},
+						{Step: constanta.SagaStepRefundPayment, Reference: pendingRefundID.String(), Status: constanta.SagaStepStatusFailed, Attempt: 1},
+					}, nil)
+				succeedRefundSteps(constanta.SagaStepRefundPayment, constanta.SagaStepCompleteRefund)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req *gen.RefundPaymentRequest, opts ...grpc.CallOption) (*gen.RefundPaymentResponse, error) {
+						s.Equal(pendingRefundID.String(), req.RefundId)
+						s.Equal(int64(159900), req.Amount.Units)
+						return &gen.RefundPaymentResponse{RefundId: req.RefundId, Status: "REFUNDED"}, nil
+					})
+				s.mockOrderRepo.EXPECT().
+					CompleteRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+						s.Equal(pendingRefundID, refund.ID)
+						s.True(refund.Restocked)
+						s.Equal(constanta.OrderStatusRefunded, change.To)
+						return nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Failed warehouse cannot receive the items",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 3,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusApproved, entity.ReturnItem{ProductID: productB, Quantity: 1}), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any()).
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("warehouse 3 is unavailable"))
+				// the refund is kept pending, the restock is retried
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any(), gomock.Any(), gomock.Not(time.Time{})).
+					Return(nil)
+			},
+			expectedError: "failed to receive stock: warehouse 3 is unavailable",
+		},
+		{
+			name: "Failed warehouse refuses the items",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 3,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusApproved, entity.ReturnItem{ProductID: productB, Quantity: 1}), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any()).
+					Return(int64(1), nil)
+				s.mockWarehouseClient.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "warehouse 3 is inactive"))
+				// nothing is paid back yet, the refund is failed and not retried
+				s.mockOrderRepo.EXPECT().
+					FailRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepRestockRefund, gomock.Any(), gomock.Any(), time.Time{}).
+					Return(nil)
+			},
+			expectedError: "failed to receive stock",
+		},
+		{
+			name: "Failed payment service refuses the refund",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 2,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusReceived, entity.ReturnItem{ProductID: productB, Quantity: 1}), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), orderID).
+					Return(paidOrder(), nil)
+				s.mockOrderRepo.EXPECT().
+					GetOrderRefunds(gomock.Any(), orderID).
+					Return([]entity.Refund{}, nil)
+				// the items are received by the refund that failed before, they are not restocked again
+				s.mockOrderRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, refund entity.Refund) error {
+						s.False(refund.Restock)
+						return nil
+					})
+				s.mockSagaRepo.EXPECT().
+					GetSagaSteps(gomock.Any(), orderID).
+					Return([]entity.SagaStep{}, nil)
+				s.mockSagaRepo.EXPECT().
+					StartSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundPayment, gomock.Any()).
+					Return(int64(1), nil)
+				s.mockPaymentClient.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.FailedPrecondition, "payment with status CANCELLED cannot be refunded"))
+				s.mockOrderRepo.EXPECT().
+					FailRefund(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockSagaRepo.EXPECT().
+					FailSagaStep(gomock.Any(), orderID, constanta.SagaStepRefundPayment, gomock.Any(), gomock.Any(), time.Time{}).
+					Return(nil)
+			},
+			expectedError: "failed to refund payment",
+		},
+		{
+			name: "Failed requested return cannot be received",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId:    returnID.String(),
+				WarehouseId: 2,
+			},
+			setupMock: func() {
+				s.mockReturnRepo.EXPECT().
+					GetReturnByID(gomock.Any(), returnID).
+					Return(approvedReturn(constanta.ReturnStatusRequested, entity.ReturnItem{ProductID: productB, Quantity: 1}), nil)
+			},
+			expectedError: "return with status REQUESTED cannot be received",
+		},
+		{
+			name: "Failed warehouse_id is required",
+			req: &gen.ReceiveReturnRequest{
+				ReturnId: returnID.String(),
+			},
+			setupMock:     func() {},
+			expectedError: "warehouse_id must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ReceiveReturn(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Require().NotNil(resp)
+				s.Equal("REFUNDED", resp.Status)
+				s.NotEmpty(resp.RefundId)
+			}
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestReturnRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	// the return is not loaded
+	reviewed, err := s.svc.ReviewReturn(ctx, &gen.ReviewReturnRequest{
+		ReturnId: uuid.NewString(),
+		Approve:  true,
+	})
+
+	s.Nil(reviewed)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	received, err := s.svc.ReceiveReturn(ctx, &gen.ReceiveReturnRequest{
+		ReturnId:    uuid.NewString(),
+		WarehouseId: 1,
+	})
+
+	s.Nil(received)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	// the caller without metadata is not authenticated
+	received, err = s.svc.ReceiveReturn(context.Background(), &gen.ReceiveReturnRequest{
+		ReturnId:    uuid.NewString(),
+		WarehouseId: 1,
+	})
+
+	s.Nil(received)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *OrderServiceTestSuite) TestCreatePromotionRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+
+	// the promotion is not created
+	resp, err := s.svc.CreatePromotion(metadata.NewIncomingContext(context.Background(), md), &gen.Promotion{
+		Code: "PROMO",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	// the caller without metadata is not authenticated
+	resp, err = s.svc.CreatePromotion(context.Background(), &gen.Promotion{
+		Code: "PROMO",
+	})
+
+	s.Nil(resp)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
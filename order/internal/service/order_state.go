@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+)
+
+// transitionStepError is the failed Before step of the transition, the status of the order is not changed
+type transitionStepError struct {
+	step constanta.SagaStep
+	err  error
+}
+
+func (e *transitionStepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.step, e.err)
+}
+
+func (e *transitionStepError) Unwrap() error {
+	return e.err
+}
+
+// transitionOrder moves the order to the next status with the side effects declared by the state machine.
+// The transition with Before steps is claimed first under the same condition as the status change,
+// so a late payment callback and the expiry task cannot both run their side effects. The claim is released when a Before step fails.
+// The status is changed with a guarded update, it fails with entity.ErrInvalidStatusTransition
+// when the order is moved or claimed by someone else in the meantime.
+// The After steps are run once the status is changed, a failed one is retried by the compensation retry worker
+func (s *OrderService) transitionOrder(ctx context.Context, order *entity.Order, change entity.StatusChange) error {
+	transition, ok := constanta.FindOrderTransition(order.Status, change.To)
+	if !ok {
+		return fmt.Errorf("%w: order with status %s cannot be %s", entity.ErrInvalidStatusTransition, order.Status, change.To)
+	}
+
+	change.From = order.Status
+	if len(transition.Before) > 0 {
+		err := s.orderRepo.ClaimOrderTransition(ctx, order.ID, change)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, step := range transition.Before {
+		_, err := s.runSagaStep(ctx, order.ID, step, "", s.transitionAction(step, order, change.Reason))
+		if err != nil {
+			if releaseErr := s.orderRepo.ReleaseOrderTransition(ctx, order.ID, change.To); releaseErr != nil {
+				fmt.Printf("Error when releasing transition of order %s to %s: %v\n", order.ID, change.To, releaseErr)
+			}
+			return &transitionStepError{step: step, err: err}
+		}
+	}
+
+	err := s.orderRepo.UpdateOrderStatus(ctx, order.ID, change)
+	if err != nil {
+		return err
+	}
+
+	order.Status = change.To
+	if change.TransactionID != "" {
+		order.TransactionID = change.TransactionID
+	}
+	if change.CancelReason != "" {
+		order.CancelReason = change.CancelReason
+	}
+
+	err = s.compensate(ctx, order.ID, order.TransactionID, transition.After...)
+	if err != nil {
+		fmt.Printf("Error when compensating order %s moved from %s to %s: %v\n", order.ID, change.From, change.To, err)
+	}
+
+	return nil
+}
+
+// transitionAction returns the action of the side effect of the transition
+func (s *OrderService) transitionAction(step constanta.SagaStep, order *entity.Order, reason string) func(ctx context.Context) (string, error) {
+	switch step {
+	case constanta.SagaStepConfirmStock:
+		return func(ctx context.Context) (string, error) {
+			_, err := s.warehouseServiceClient.ConfirmStock(ctx, &gen.ConfirmStockRequest{
+				OrderId: order.ID.String(),
+			})
+			return "", err
+		}
+	case constanta.SagaStepCancelPayment:
+		return func(ctx context.Context) (string, error) {
+			_, err := s.paymentServiceClient.RollbackPayment(ctx, &gen.RollbackPaymentRequest{
+				TransactionId: order.TransactionID,
+				OrderId:       order.ID.String(),
+				Reason:        reason,
+			})
+			return "", err
+		}
+	default:
+		return s.compensationAction(step, order.ID, order.TransactionID)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// priceOrder is the pricing pipeline after the coupon discount,
+// the tax of the items is calculated first then the shipping fee and the grand total
+func (s *OrderService) priceOrder(ctx context.Context, cart *entity.Cart, shippingRegion string) error {
+	rules, err := s.pricingRepo.GetTaxRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = cart.ApplyTax(rules)
+	if err != nil {
+		return err
+	}
+
+	cart.Shipping, err = s.calculateShipping(ctx, cart, shippingRegion)
+	if err != nil {
+		return err
+	}
+
+	return cart.CalculateGrandTotal()
+}
+
+// calculateShipping ships the items of every shop as one shipment from the active warehouse of the shop
+// with the cheapest rate to the region, the fee is converted into the currency of the cart
+func (s *OrderService) calculateShipping(ctx context.Context, cart *entity.Cart, shippingRegion string) (*gen.Money, error) {
+	currency := cart.Subtotal.GetCurrencyCode()
+	total, err := money.New(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[int64]int64)
+	for _, item := range cart.Items {
+		if item.Unavailable {
+			continue
+		}
+		quantities[item.ShopID] += item.Quantity
+	}
+	if len(quantities) == 0 {
+		return total, nil
+	}
+
+	rates, err := s.pricingRepo.GetShippingRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shopIDs := []int64{}
+	for shopID := range quantities {
+		shopIDs = append(shopIDs, shopID)
+	}
+	slices.Sort(shopIDs)
+
+	for _, shopID := range shopIDs {
+		res, err := s.warehouseServiceClient.GetWarehouseByShopID(ctx, &gen.GetWarehouseByShopIDRequest{
+			ShopId: shopID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var fee *gen.Money
+		for _, warehouse := range res.GetWarehouses() {
+			if !warehouse.GetIsActive() {
+				continue
+			}
+
+			rate := entity.MatchShippingRate(rates, normalizeRegion(warehouse.GetRegion()), shippingRegion)
+			if rate == nil {
+				continue
+			}
+
+			warehouseFee, err := rate.Fee(quantities[shopID])
+			if err != nil {
+				return nil, err
+			}
+
+			warehouseFee, _, err = money.Convert(ctx, s.rateProvider, warehouseFee, currency)
+			if err != nil {
+				if errors.Is(err, money.ErrRateNotFound) {
+					return nil, status.Errorf(codes.FailedPrecondition, "exchange rate from %s to %s is not available", rate.BaseFee.GetCurrencyCode(), currency)
+				}
+				return nil, err
+			}
+
+			if fee == nil || warehouseFee.Units < fee.Units {
+				fee = warehouseFee
+			}
+		}
+
+		if fee == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "shop %d cannot ship to region %q", shopID, shippingRegion)
+		}
+
+		total, err = money.Add(total, fee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return total, nil
+}
+
+func normalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApplyCoupon validates the coupon against the current cart and keeps it in the cart,
+// the discount is calculated again on every GetCart and CreateOrder
+func (s *OrderService) ApplyCoupon(ctx context.Context, req *gen.ApplyCouponRequest) (*gen.Cart, error) {
+	code := normalizeCouponCode(req.Code)
+	if code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code cannot be empty")
+	}
+
+	owner, err := cartOwnerFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.getCart(ctx, owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "cart not found")
+		}
+		return nil, err
+	}
+
+	if len(cart.Items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "cart is empty")
+	}
+
+	err = s.checkCartItems(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	cart.CouponCode = code
+	_, err = s.applyPromotion(ctx, cart, owner.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrCouponNotApplicable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	err = s.cartRepo.SetCartCoupon(ctx, cart.ID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return cart.GetGenCart(), nil
+}
+
+func (s *OrderService) RemoveCoupon(ctx context.Context, req *gen.Empty) (*gen.Empty, error) {
+	owner, err := cartOwnerFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.getCart(ctx, owner)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "cart not found")
+		}
+		return nil, err
+	}
+
+	err = s.cartRepo.SetCartCoupon(ctx, cart.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.Empty{}, nil
+}
+
+// CreatePromotion creates the promotion of a campaign, the coupon code must be unique.
+// Only the admin can create the promotion
+func (s *OrderService) CreatePromotion(ctx context.Context, req *gen.Promotion) (*gen.Promotion, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, req.GetStartsAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid starts_at format, must be RFC3339")
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, req.GetEndsAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid ends_at format, must be RFC3339")
+	}
+
+	promotion := entity.Promotion{
+		Code:              normalizeCouponCode(req.GetCode()),
+		Description:       req.GetDescription(),
+		Type:              constanta.PromotionType(strings.ToUpper(req.GetType())),
+		Scope:             constanta.PromotionScope(strings.ToUpper(req.GetScope())),
+		ScopeID:           req.GetScopeId(),
+		Percentage:        decimal.Zero,
+		Amount:            req.GetAmount(),
+		BuyQuantity:       req.GetBuyQuantity(),
+		GetQuantity:       req.GetGetQuantity(),
+		UsageLimit:        req.GetUsageLimit(),
+		UsageLimitPerUser: req.GetUsageLimitPerUser(),
+		StartsAt:          startsAt,
+		EndsAt:            endsAt,
+		IsActive:          req.GetIsActive(),
+	}
+
+	if req.GetPercentage() != "" {
+		promotion.Percentage, err = decimal.NewFromString(req.GetPercentage())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid percentage %s", req.GetPercentage())
+		}
+	}
+
+	err = promotion.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	existing, err := s.promotionRepo.GetPromotionByCode(ctx, promotion.Code)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "coupon %s already exists", promotion.Code)
+	}
+
+	promotion.ID, err = s.promotionRepo.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion.GetGenPromotion(), nil
+}
+
+// calculateCart checks the items and applies the coupon of the cart.
+// The cart is not refused when the coupon cannot be used anymore, the reason is kept as a cart issue
+func (s *OrderService) calculateCart(ctx context.Context, cart *entity.Cart) (*entity.Promotion, error) {
+	err := s.checkCartItems(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err := s.applyPromotion(ctx, cart, cart.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrCouponNotApplicable) {
+			cart.CouponIssue = err.Error()
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// applyPromotion sets the discount and the total of the cart, the total is the subtotal when the cart has no coupon.
+// The usage limit per user is only checked for logged in user, the guest is checked again when ordering
+func (s *OrderService) applyPromotion(ctx context.Context, cart *entity.Cart, userID uuid.UUID) (*entity.Promotion, error) {
+	if len(cart.Items) == 0 {
+		return nil, nil
+	}
+
+	var err error
+	cart.Discount, err = money.New(0, cart.Subtotal.GetCurrencyCode())
+	if err != nil {
+		return nil, err
+	}
+	cart.Total = cart.Subtotal
+
+	if cart.CouponCode == "" {
+		return nil, nil
+	}
+
+	promotion, err := s.promotionRepo.GetPromotionByCode(ctx, cart.CouponCode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: coupon %s is not found", entity.ErrCouponNotApplicable, cart.CouponCode)
+		}
+		return nil, err
+	}
+
+	usage, userUsage, err := s.promotionRepo.GetPromotionUsage(ctx, promotion.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = promotion.CheckAvailability(time.Now(), usage, userUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	if promotion.Type == constanta.PromotionTypeFixedAmount {
+		amount, _, err := money.Convert(ctx, s.rateProvider, promotion.Amount, cart.Subtotal.GetCurrencyCode())
+		if err != nil {
+			if errors.Is(err, money.ErrRateNotFound) {
+				return nil, fmt.Errorf("%w: coupon %s cannot be used in %s", entity.ErrCouponNotApplicable, promotion.Code, cart.Subtotal.GetCurrencyCode())
+			}
+			return nil, err
+		}
+		promotion.Amount = amount
+	}
+
+	err = promotion.Apply(cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
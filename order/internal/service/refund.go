@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/contextrequest"
+	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefundOrder refunds the paid order fully or per item, it is called by the customer service.
+// The refund is recorded first so its items cannot be refunded twice, then the payment is refunded
+// and the items that are not shipped yet are returned into the warehouse by the refund saga.
+// A failed step keeps the refund pending until it is retried. Only the admin can refund the order
+func (s *OrderService) RefundOrder(ctx context.Context, req *gen.RefundOrderRequest) (*gen.Order, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	reason := strings.TrimSpace(req.GetReason())
+	if reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required")
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if !order.Status.CanTransitionTo(constanta.OrderStatusRefunded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be refunded", order.Status)
+	}
+
+	refunds, err := s.orderRepo.GetOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order refunds: %v", err)
+	}
+
+	items := []entity.RefundItem{}
+	for _, item := range req.GetItems() {
+		items = append(items, entity.RefundItem{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		})
+	}
+
+	refund, err := order.NewRefund(items, refunds, reason)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRefund) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create refund: %v", err)
+	}
+	// the shipped items are with the customer, they are only put back into the warehouse by the return
+	refund.Restock = !order.Shipment.IsShipped()
+
+	err = s.orderRepo.CreateRefund(ctx, *refund)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRefund) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create refund: %v", err)
+	}
+
+	err = s.runRefundSaga(ctx, order, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Refunds = append(refunds, *refund)
+
+	return order.GetGenOrder(), nil
+}
+
+// runRefundSaga runs the steps of the pending refund that are not succeeded yet.
+// The steps are recorded per refund, a failed step keeps the refund pending and is retried by RetryCompensations,
+// the refund that is interrupted is continued by ResumeSagas.
+// The returned items are put into the warehouse before the payment is refunded,
+// otherwise the payment is refunded first and the items that are not shipped yet are restocked
+func (s *OrderService) runRefundSaga(ctx context.Context, order *entity.Order, refund *entity.Refund) error {
+	steps, err := s.sagaRepo.GetSagaSteps(ctx, order.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get saga steps: %v", err)
+	}
+	saga := entity.Saga{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		OrderStatus: order.Status,
+		Steps:       steps,
+	}
+
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, order.UserID)
+
+	sagaSteps := []constanta.SagaStep{constanta.SagaStepRefundPayment, constanta.SagaStepRestockRefund}
+	if refund.ReturnID != "" {
+		sagaSteps = []constanta.SagaStep{constanta.SagaStepRestockRefund, constanta.SagaStepRefundPayment}
+	}
+	sagaSteps = append(sagaSteps, constanta.SagaStepCompleteRefund)
+
+	reference := refund.ID.String()
+	for _, step := range sagaSteps {
+		if step == constanta.SagaStepRestockRefund && !refund.Restock {
+			continue
+		}
+
+		recorded := saga.GetReferenceStep(step, reference)
+		if recorded != nil && recorded.Status == constanta.SagaStepStatusDeadLetter {
+			return status.Errorf(codes.FailedPrecondition, "step %s of refund %s needs manual handling", step, refund.ID)
+		}
+
+		if recorded == nil || recorded.Status != constanta.SagaStepStatusSucceeded {
+			_, err = s.runSagaStep(ctx, order.ID, step, reference, s.refundAction(step, order, refund))
+			if err != nil {
+				return err
+			}
+		}
+
+		if step == constanta.SagaStepRestockRefund {
+			refund.Restocked = true
+		}
+	}
+
+	return nil
+}
+
+// refundAction returns the action of the step of the refund saga
+func (s *OrderService) refundAction(step constanta.SagaStep, order *entity.Order, refund *entity.Refund) func(ctx context.Context) (string, error) {
+	switch step {
+	case constanta.SagaStepRestockRefund:
+		return func(ctx context.Context) (string, error) {
+			return "", s.restockRefund(ctx, order, refund)
+		}
+	case constanta.SagaStepRefundPayment:
+		return func(ctx context.Context) (string, error) {
+			err := s.refundPayment(ctx, order, refund)
+			if err != nil && refund.Status == constanta.RefundStatusFailed {
+				return "", &permanentStepError{err: err}
+			}
+			return "", err
+		}
+	case constanta.SagaStepCompleteRefund:
+		return func(ctx context.Context) (string, error) {
+			return "", s.completeRefund(ctx, order, refund)
+		}
+	default:
+		return func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("saga step %s is not a step of the refund", step)
+		}
+	}
+}
+
+// restockRefund puts the items of the refund back into the warehouse, RestockStock is idempotent per refund.
+// The returned items are put into the warehouse that receives them and the return becomes received,
+// a refused return fails the refund since nothing is paid back yet
+func (s *OrderService) restockRefund(ctx context.Context, order *entity.Order, refund *entity.Refund) error {
+	stocks := []*gen.Stock{}
+	for _, item := range refund.Items {
+		stocks = append(stocks, &gen.Stock{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	_, err := s.warehouseServiceClient.RestockStock(ctx, &gen.RestockStockRequest{
+		OrderId:     order.ID.String(),
+		RefundId:    refund.ID.String(),
+		Stocks:      stocks,
+		WarehouseId: refund.WarehouseID,
+	})
+	if err != nil {
+		if refund.ReturnID == "" {
+			return status.Errorf(codes.FailedPrecondition, "failed to restock refund: %v", err)
+		}
+
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+			if failErr := s.orderRepo.FailRefund(ctx, refund.ID); failErr != nil {
+				fmt.Printf("Error when failing refund %s of order %s: %v\n", refund.ID, order.ID, failErr)
+			}
+			refund.Status = constanta.RefundStatusFailed
+			return &permanentStepError{err: status.Errorf(codes.FailedPrecondition, "failed to receive stock: %v", err)}
+		}
+		return status.Errorf(codes.FailedPrecondition, "failed to receive stock: %v", err)
+	}
+
+	if refund.ReturnID == "" {
+		return nil
+	}
+
+	returnID, err := uuid.Parse(refund.ReturnID)
+	if err != nil {
+		return &permanentStepError{err: status.Errorf(codes.Internal, "invalid return id of refund %s", refund.ID)}
+	}
+
+	ret, err := s.returnRepo.GetReturnByID(ctx, returnID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get return: %v", err)
+	}
+
+	// the return is already received by the previous attempt
+	if ret.Status != constanta.ReturnStatusApproved {
+		return nil
+	}
+
+	ret.Status = constanta.ReturnStatusReceived
+	ret.WarehouseID = refund.WarehouseID
+	err = s.returnRepo.UpdateReturnStatus(ctx, *ret, constanta.ReturnStatusApproved)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return status.Errorf(codes.Internal, "failed to update return: %v", err)
+	}
+
+	return nil
+}
+
+// completeRefund marks the refund as completed and moves the order.
+// The order is fully refunded only by the completed refunds, a pending refund might still fail
+func (s *OrderService) completeRefund(ctx context.Context, order *entity.Order, refund *entity.Refund) error {
+	refunds, err := s.orderRepo.GetOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get order refunds: %v", err)
+	}
+
+	completed := []entity.Refund{*refund}
+	for _, other := range refunds {
+		if other.ID != refund.ID && other.Status == constanta.RefundStatusCompleted {
+			completed = append(completed, other)
+		}
+	}
+	refund.Full = order.IsFullyRefunded(completed)
+
+	nextStatus := constanta.OrderStatusPartiallyRefunded
+	if refund.Full {
+		nextStatus = constanta.OrderStatusRefunded
+	}
+
+	actor := constanta.OrderActorCustomerService
+	if refund.ReturnID != "" {
+		actor = constanta.OrderActorWarehouse
+	}
+
+	err = s.orderRepo.CompleteRefund(ctx, *refund, entity.StatusChange{
+		To:     nextStatus,
+		Reason: fmt.Sprintf("refund %s, %s", refund.ID, refund.Reason),
+		Actor:  actor,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &permanentStepError{err: status.Errorf(codes.FailedPrecondition, "refund %s is not %s anymore", refund.ID, constanta.RefundStatusPending)}
+		}
+		return status.Errorf(codes.Internal, "failed to complete refund: %v", err)
+	}
+
+	refund.Status = constanta.RefundStatusCompleted
+	order.Status = nextStatus
+
+	return nil
+}
+
+// resumeRefund continues the refund saga of the pending refund of the order
+func (s *OrderService) resumeRefund(ctx context.Context, orderID uuid.UUID, refundID string) error {
+	order, err := s.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	refunds, err := s.orderRepo.GetOrderRefunds(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order refunds: %w", err)
+	}
+
+	for i := range refunds {
+		if refunds[i].ID.String() != refundID {
+			continue
+		}
+
+		// the refund is already completed or failed
+		if refunds[i].Status != constanta.RefundStatusPending {
+			return nil
+		}
+
+		return s.runRefundSaga(ctx, order, &refunds[i])
+	}
+
+	return fmt.Errorf("refund %s of order %s is not found", refundID, orderID)
+}
+
+// refundPayment pays back the amount of the pending refund from the paid payments of the order.
+// The refund is only failed when the payment service refused it,
+// otherwise the payment might be refunded and the refund is kept pending to be checked
+func (s *OrderService) refundPayment(ctx context.Context, order *entity.Order, refund *entity.Refund) error {
+	// the free items are only restocked
+	if refund.Amount.GetUnits() == 0 {
+		return nil
+	}
+
+	_, err := s.paymentServiceClient.RefundPayment(ctx, &gen.RefundPaymentRequest{
+		OrderId:       order.ID.String(),
+		TransactionId: order.TransactionID,
+		RefundId:      refund.ID.String(),
+		Amount:        refund.Amount,
+		Reason:        refund.Reason,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+			if failErr := s.orderRepo.FailRefund(ctx, refund.ID); failErr != nil {
+				fmt.Printf("Error when failing refund %s of order %s: %v\n", refund.ID, order.ID, failErr)
+			}
+			refund.Status = constanta.RefundStatusFailed
+		default:
+			fmt.Printf("Refund %s of order %s is kept pending: %v\n", refund.ID, order.ID, err)
+		}
+
+		return status.Errorf(codes.FailedPrecondition, "failed to refund payment: %v", err)
+	}
+
+	return nil
+}
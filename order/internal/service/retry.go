@@ -0,0 +1,35 @@
+package service
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// CompensationRetryPolicy decides how often a failed compensation is retried
+type CompensationRetryPolicy struct {
+	// MaxAttempts is the number of attempts before the compensation is moved into dead letter
+	MaxAttempts int64
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff returns the delay before the next attempt.
+// The delay grows exponentially with the attempt and capped by MaxDelay,
+// half of the delay is randomized so retries of many orders are not fired at the same time.
+func (p CompensationRetryPolicy) Backoff(attempt int64) time.Duration {
+	delay := p.BaseDelay
+	for i := int64(1); i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateReturn records the request of the customer to return the items of the paid order,
+// the return waits for the review of the admin
+func (s *OrderService) CreateReturn(ctx context.Context, req *gen.CreateReturnRequest) (*gen.Return, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id")
+	}
+
+	reason := strings.TrimSpace(req.GetReason())
+	if reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required")
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "you are not authorized to access this order")
+	}
+
+	// the items of the return are refunded when they are received
+	if !order.Status.CanTransitionTo(constanta.OrderStatusRefunded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be returned", order.Status)
+	}
+
+	refunds, err := s.orderRepo.GetOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order refunds: %v", err)
+	}
+
+	returns, err := s.returnRepo.GetOrderReturns(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order returns: %v", err)
+	}
+
+	items := []entity.ReturnItem{}
+	for _, item := range req.GetItems() {
+		items = append(items, entity.ReturnItem{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		})
+	}
+
+	ret, err := order.NewReturn(items, returns, refunds, reason)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidReturn) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create return: %v", err)
+	}
+
+	err = s.returnRepo.CreateReturn(ctx, *ret)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidReturn) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create return: %v", err)
+	}
+
+	return ret.GetGenReturn(), nil
+}
+
+// ReviewReturn approves or rejects the requested return, it is called by the admin
+func (s *OrderService) ReviewReturn(ctx context.Context, req *gen.ReviewReturnRequest) (*gen.Return, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetReturnId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid return id")
+	}
+
+	note := strings.TrimSpace(req.GetNote())
+	if !req.GetApprove() && note == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "note is required to reject the return")
+	}
+
+	ret, err := s.returnRepo.GetReturnByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "return not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get return: %v", err)
+	}
+
+	if ret.Status != constanta.ReturnStatusRequested {
+		return nil, status.Errorf(codes.FailedPrecondition, "return with status %s cannot be reviewed", ret.Status)
+	}
+
+	ret.Status = constanta.ReturnStatusRejected
+	if req.GetApprove() {
+		ret.Status = constanta.ReturnStatusApproved
+	}
+	ret.Note = note
+
+	err = s.returnRepo.UpdateReturnStatus(ctx, *ret, constanta.ReturnStatusRequested)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "return is not %s anymore", constanta.ReturnStatusRequested)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update return: %v", err)
+	}
+
+	return ret.GetGenReturn(), nil
+}
+
+// ReceiveReturn puts the items of the approved return into the warehouse then refunds them, it is called by the warehouse service on behalf of the admin.
+// The refund is recorded first and runs the restock, the payment refund and the completion as the steps of the refund saga.
+// A failed step keeps the refund pending, it is retried in the background or by receiving the return again
+func (s *OrderService) ReceiveReturn(ctx context.Context, req *gen.ReceiveReturnRequest) (*gen.Return, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetReturnId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid return id")
+	}
+
+	if req.GetWarehouseId() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "warehouse_id must be greater than 0")
+	}
+
+	ret, err := s.returnRepo.GetReturnByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "return not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get return: %v", err)
+	}
+
+	switch ret.Status {
+	case constanta.ReturnStatusRefunded:
+		return ret.GetGenReturn(), nil
+	case constanta.ReturnStatusApproved, constanta.ReturnStatusReceived:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "return with status %s cannot be received", ret.Status)
+	}
+
+	order, err := s.orderRepo.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	}
+
+	if !order.Status.CanTransitionTo(constanta.OrderStatusRefunded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order with status %s cannot be refunded", order.Status)
+	}
+
+	refund, err := s.getReturnRefund(ctx, order, ret, req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.runRefundSaga(ctx, order, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Status = constanta.ReturnStatusRefunded
+	if refund.Restock {
+		ret.WarehouseID = refund.WarehouseID
+	}
+	ret.RefundID = refund.ID.String()
+
+	return ret.GetGenReturn(), nil
+}
+
+// getReturnRefund returns the pending refund of the return that is left by the previous attempt,
+// otherwise a new refund of the items of the return is recorded, the items are put into the warehouse that receives them
+func (s *OrderService) getReturnRefund(ctx context.Context, order *entity.Order, ret *entity.Return, warehouseID int64) (*entity.Refund, error) {
+	refunds, err := s.orderRepo.GetOrderRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order refunds: %v", err)
+	}
+
+	for _, refund := range refunds {
+		if refund.ReturnID == ret.ID.String() && refund.Status == constanta.RefundStatusPending {
+			return &refund, nil
+		}
+	}
+
+	refund, err := order.NewRefund(ret.GetRefundItems(), refunds, fmt.Sprintf("return %s, %s", ret.ID, ret.Reason))
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRefund) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create refund: %v", err)
+	}
+	refund.ReturnID = ret.ID.String()
+	// the items that are already received by the refund that failed are not put into the warehouse again
+	refund.Restock = ret.Status == constanta.ReturnStatusApproved
+	refund.WarehouseID = warehouseID
+
+	err = s.orderRepo.CreateRefund(ctx, *refund)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRefund) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create refund: %v", err)
+	}
+
+	return refund, nil
+}
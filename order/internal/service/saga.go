@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/contextrequest"
+	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/elangreza/e-commerce/pkg/money"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// permanentStepError is the failure of a retried step that is final, e.g. the payment service refused the refund,
+// the step is not retried anymore
+type permanentStepError struct {
+	err error
+}
+
+func (e *permanentStepError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentStepError) Unwrap() error {
+	return e.err
+}
+
+// runSagaStep records the step into saga log before and after running the action.
+// The step is not executed when it cannot be recorded, so every executed step is traceable after a crash.
+// The reference is the id of the refund for the steps of the refund saga, empty for the steps of the order
+func (s *OrderService) runSagaStep(
+	ctx context.Context,
+	orderID uuid.UUID,
+	step constanta.SagaStep,
+	reference string,
+	action func(ctx context.Context) (string, error),
+) (string, error) {
+	attempt, err := s.sagaRepo.StartSagaStep(ctx, orderID, step, reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to record saga step %s: %w", step, err)
+	}
+
+	result, err := action(ctx)
+	if err != nil {
+		if logErr := s.recordFailedSagaStep(ctx, orderID, step, reference, attempt, err); logErr != nil {
+			fmt.Printf("Error when recording failed saga step %s of order %s: %v\n", step, orderID, logErr)
+		}
+		return "", err
+	}
+
+	// the step is already done, a failure here only leaves the step as started
+	if logErr := s.sagaRepo.CompleteSagaStep(ctx, orderID, step, reference, result); logErr != nil {
+		fmt.Printf("Error when recording succeeded saga step %s of order %s: %v\n", step, orderID, logErr)
+	}
+
+	return result, nil
+}
+
+// recordFailedSagaStep records the failure of the step.
+// A failed compensation or step of the refund saga is scheduled to be retried with backoff,
+// once the attempts are exhausted it is moved into dead letter and needs manual handling.
+func (s *OrderService) recordFailedSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference string, attempt int64, stepErr error) error {
+	var permanentErr *permanentStepError
+	if !step.IsRetried() || errors.As(stepErr, &permanentErr) {
+		return s.sagaRepo.FailSagaStep(ctx, orderID, step, reference, stepErr.Error(), time.Time{})
+	}
+
+	if attempt >= s.retryPolicy.MaxAttempts {
+		fmt.Printf("Step %s of order %s is moved into dead letter after %d attempt(s)\n", step, orderID, attempt)
+		return s.sagaRepo.DeadLetterSagaStep(ctx, orderID, step, reference, stepErr.Error())
+	}
+
+	retryAt := time.Now().Add(s.retryPolicy.Backoff(attempt))
+	return s.sagaRepo.FailSagaStep(ctx, orderID, step, reference, stepErr.Error(), retryAt)
+}
+
+// compensationAction returns the action that undoes a forward step of create order saga, or refunds the overpaid order
+func (s *OrderService) compensationAction(step constanta.SagaStep, orderID uuid.UUID, transactionID string) func(ctx context.Context) (string, error) {
+	switch step {
+	case constanta.SagaStepReleaseStock:
+		return func(ctx context.Context) (string, error) {
+			_, err := s.warehouseServiceClient.ReleaseStock(ctx, &gen.ReleaseStockRequest{
+				OrderId: orderID.String(),
+			})
+			// the stock of the order is never reserved, e.g. the service died before the reservation is sent
+			if status.Code(err) == codes.NotFound {
+				return "", nil
+			}
+			return "", err
+		}
+	case constanta.SagaStepRollbackPayment:
+		return func(ctx context.Context) (string, error) {
+			// every payment of the order is rolled back, the paid part of the order is refunded
+			_, err := s.paymentServiceClient.RollbackPayment(ctx, &gen.RollbackPaymentRequest{
+				TransactionId: transactionID,
+				OrderId:       orderID.String(),
+				Reason:        "order failed",
+			})
+			return "", err
+		}
+	case constanta.SagaStepRefundOverpayment:
+		return func(ctx context.Context) (string, error) {
+			return "", s.refundOverpayment(ctx, orderID)
+		}
+	default:
+		return func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("saga step %s is not a compensation", step)
+		}
+	}
+}
+
+// refundOverpayment pays back the part of the paid amount that is more than the total amount of the order.
+// The refund id is derived from the order, so the retried step does not pay it back twice
+func (s *OrderService) refundOverpayment(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	payments, err := s.paymentServiceClient.ListPayments(ctx, &gen.ListPaymentsRequest{
+		OrderId: orderID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if payments.GetPaidAmount().GetUnits() <= order.TotalAmount.GetUnits() {
+		return nil
+	}
+
+	overpaid, err := money.Subtract(payments.PaidAmount, order.TotalAmount)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.paymentServiceClient.RefundPayment(ctx, &gen.RefundPaymentRequest{
+		OrderId:  orderID.String(),
+		RefundId: "overpaid:" + orderID.String(),
+		Amount:   overpaid,
+		Reason:   "order is paid more than its total amount",
+	})
+	return err
+}
+
+// compensate runs the compensation steps in order.
+// A failing step does not stop the next one, all errors are returned together.
+func (s *OrderService) compensate(ctx context.Context, orderID uuid.UUID, transactionID string, steps ...constanta.SagaStep) error {
+	var errs []error
+	for _, step := range steps {
+		_, err := s.runSagaStep(ctx, orderID, step, "", s.compensationAction(step, orderID, transactionID))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", step, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// failOrder compensates the done steps and marks the pending order as failed with the reason.
+// The steps are compensated even when the order cannot be failed, they are done by the create order saga itself
+func (s *OrderService) failOrder(ctx context.Context, orderID uuid.UUID, transactionID, reason string, steps ...constanta.SagaStep) error {
+	compensateErr := s.compensate(ctx, orderID, transactionID, steps...)
+
+	order := &entity.Order{
+		ID:            orderID,
+		Status:        constanta.OrderStatusPending,
+		TransactionID: transactionID,
+	}
+	updateErr := s.transitionOrder(ctx, order, entity.StatusChange{
+		To:     constanta.OrderStatusFailed,
+		Reason: reason,
+		Actor:  constanta.OrderActorSystem,
+	})
+
+	return errors.Join(compensateErr, updateErr)
+}
+
+// ResumeSagas continues or compensates every saga that was interrupted and continues every pending refund,
+// it must be called when the service starts before accepting new orders.
+func (s *OrderService) ResumeSagas(ctx context.Context) (int, error) {
+	sagas, err := s.sagaRepo.GetInFlightSagas(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	refunds, err := s.orderRepo.GetPendingRefunds(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, saga := range sagas {
+		err = s.resumeSaga(ctx, saga)
+		if err != nil {
+			fmt.Printf("Error when resuming saga of order %s: %v\n", saga.OrderID, err)
+		}
+	}
+
+	for _, refund := range refunds {
+		err = s.resumeRefund(ctx, refund.OrderID, refund.ID.String())
+		if err != nil {
+			fmt.Printf("Error when resuming refund %s of order %s: %v\n", refund.ID, refund.OrderID, err)
+		}
+	}
+
+	return len(sagas) + len(refunds), nil
+}
+
+func (s *OrderService) resumeSaga(ctx context.Context, saga entity.Saga) error {
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, saga.UserID)
+
+	transactionID := saga.GetTransactionID()
+
+	if saga.PendingStatus != "" {
+		return s.resumeTransition(ctx, saga)
+	}
+
+	if saga.OrderStatus != constanta.OrderStatusPending {
+		// the order is already final, only retry the unfinished compensation
+		compensations := []constanta.SagaStep{}
+		for _, step := range saga.Steps {
+			if step.Step.IsCompensation() &&
+				step.Status != constanta.SagaStepStatusSucceeded &&
+				step.Status != constanta.SagaStepStatusDeadLetter {
+				compensations = append(compensations, step.Step)
+			}
+		}
+
+		return s.compensate(ctx, saga.OrderID, transactionID, compensations...)
+	}
+
+	// payment is created, only the last update is missing. move the saga forward
+	if saga.IsStepSucceeded(constanta.SagaStepProcessPayment) && transactionID != "" {
+		order := &entity.Order{
+			ID:     saga.OrderID,
+			Status: saga.OrderStatus,
+		}
+		return s.transitionOrder(ctx, order, entity.StatusChange{
+			To:            constanta.OrderStatusStockReserved,
+			Reason:        "payment processed, recovered after restart",
+			Actor:         constanta.OrderActorSystem,
+			TransactionID: transactionID,
+		})
+	}
+
+	// the saga cannot be continued, undo what might be done.
+	// when the service died while reserving stock, the stock might be reserved.
+	// when the service died while processing payment, the transaction id is unknown,
+	// the payments are rolled back by the order id so the customer cannot pay the failed order
+	compensations := []constanta.SagaStep{}
+	if saga.GetStep(constanta.SagaStepProcessPayment) != nil &&
+		!saga.IsStepSucceeded(constanta.SagaStepRollbackPayment) {
+		compensations = append(compensations, constanta.SagaStepRollbackPayment)
+	}
+	if reserve := saga.GetStep(constanta.SagaStepReserveStock); reserve != nil &&
+		reserve.Status != constanta.SagaStepStatusFailed &&
+		!saga.IsStepSucceeded(constanta.SagaStepReleaseStock) {
+		compensations = append(compensations, constanta.SagaStepReleaseStock)
+	}
+
+	return s.failOrder(ctx, saga.OrderID, transactionID, "interrupted before the payment, recovered after restart", compensations...)
+}
+
+// resumeTransition finishes the claimed transition when its Before steps are done, the status change is the only missing part.
+// Otherwise the claim is released, the order can be moved again by the caller that retries it or by the expiry task
+func (s *OrderService) resumeTransition(ctx context.Context, saga entity.Saga) error {
+	transition, ok := constanta.FindOrderTransition(saga.OrderStatus, saga.PendingStatus)
+	done := ok
+	for _, step := range transition.Before {
+		if !saga.IsStepSucceeded(step) {
+			done = false
+		}
+	}
+
+	if !done {
+		return s.orderRepo.ReleaseOrderTransition(ctx, saga.OrderID, saga.PendingStatus)
+	}
+
+	err := s.orderRepo.UpdateOrderStatus(ctx, saga.OrderID, entity.StatusChange{
+		From:   saga.OrderStatus,
+		To:     saga.PendingStatus,
+		Reason: "side effects are done, recovered after restart",
+		Actor:  constanta.OrderActorSystem,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.compensate(ctx, saga.OrderID, saga.GetTransactionID(), transition.After...)
+}
+
+// RetryCompensations retries the failed compensations which are due.
+// It returns the number of sagas that are retried.
+func (s *OrderService) RetryCompensations(ctx context.Context) (int, error) {
+	now := time.Now()
+	sagas, err := s.sagaRepo.GetSagasWithDueCompensations(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, saga := range sagas {
+		ctx := contextrequest.AppendUserIDintoContextGrpcClient(ctx, saga.UserID)
+
+		// the refund saga is continued from its failed step, once per refund
+		refundIDs := []string{}
+		for _, step := range saga.Steps {
+			if !step.IsRetryDue(now) {
+				continue
+			}
+
+			if step.Step.IsRefund() {
+				if !slices.Contains(refundIDs, step.Reference) {
+					refundIDs = append(refundIDs, step.Reference)
+				}
+				continue
+			}
+
+			_, err := s.runSagaStep(ctx, saga.OrderID, step.Step, "", s.compensationAction(step.Step, saga.OrderID, saga.GetTransactionID()))
+			if err != nil {
+				fmt.Printf("Error when retrying compensation %s of order %s: %v\n", step.Step, saga.OrderID, err)
+			}
+		}
+
+		for _, refundID := range refundIDs {
+			err := s.resumeRefund(ctx, saga.OrderID, refundID)
+			if err != nil {
+				fmt.Printf("Error when retrying refund %s of order %s: %v\n", refundID, saga.OrderID, err)
+			}
+		}
+	}
+
+	return len(sagas), nil
+}
+
+// ListDeadLetterCompensations lists the compensations which are out of attempts, only the admin can list them
+func (s *OrderService) ListDeadLetterCompensations(ctx context.Context, req *gen.Empty) (*gen.DeadLetterCompensations, error) {
+	_, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	compensations, err := s.sagaRepo.GetDeadLetterCompensations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get dead letter compensations: %v", err)
+	}
+
+	res := &gen.DeadLetterCompensations{
+		Compensations: make([]*gen.DeadLetterCompensation, 0, len(compensations)),
+	}
+	for _, compensation := range compensations {
+		res.Compensations = append(res.Compensations, compensation.GetGenDeadLetterCompensation())
+	}
+
+	return res, nil
+}
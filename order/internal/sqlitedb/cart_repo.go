@@ -3,6 +3,7 @@ package sqlitedb
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/elangreza/e-commerce/pkg/dbsql"
 	"github.com/elangreza/e-commerce/pkg/money"
@@ -25,13 +26,28 @@ func (r *CartRepository) GetCartByUserID(ctx context.Context, userID uuid.UUID)
 	// Implementation to retrieve cart by user ID from the database
 
 	q := `
-	SELECT id, user_id
+	SELECT id, user_id, COALESCE(cart_token, ''), COALESCE(coupon_code, '')
 	FROM carts WHERE user_id = ? AND is_active IS TRUE;`
 
+	return r.getCart(ctx, q, userID)
+}
+
+// GetCartByToken returns the active guest cart of the cart token
+func (r *CartRepository) GetCartByToken(ctx context.Context, cartToken string) (*entity.Cart, error) {
+	q := `
+	SELECT id, COALESCE(user_id, ?), cart_token, COALESCE(coupon_code, '')
+	FROM carts WHERE cart_token = ? AND user_id IS NULL AND is_active IS TRUE;`
+
+	return r.getCart(ctx, q, uuid.Nil, cartToken)
+}
+
+func (r *CartRepository) getCart(ctx context.Context, q string, args ...any) (*entity.Cart, error) {
 	var cart entity.Cart
-	err := r.db.QueryRowContext(ctx, q, userID).Scan(
+	err := r.db.QueryRowContext(ctx, q, args...).Scan(
 		&cart.ID,
 		&cart.UserID,
+		&cart.CartToken,
+		&cart.CouponCode,
 	)
 	if err != nil {
 		return nil, err
@@ -87,12 +103,7 @@ func (r *CartRepository) CreateCart(ctx context.Context, cart entity.Cart) error
 	}
 
 	err = dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
-		q := `INSERT INTO carts (id, user_id, is_active) VALUES (?, ?, ?);`
-		_, err := tx.ExecContext(ctx, q,
-			cartID,
-			cart.UserID,
-			true,
-		)
+		err := insertCart(ctx, tx, cartID, cart)
 		if err != nil {
 			return err
 		}
@@ -135,11 +146,154 @@ func (r *CartRepository) UpdateCartItem(ctx context.Context, item entity.CartIte
 	// Implementation to update an existing cart item in the database
 
 	q := `UPDATE cart_items
-		SET quantity = ?, name = ?, price = ?
+		SET quantity = ?, name = ?, price = ?, currency = ?, updated_at = ?
 		WHERE cart_id = ? AND product_id = ?;`
-	_, err := r.db.ExecContext(ctx, q, item.Quantity, item.Name, item.Price.Units, item.CartID, item.ProductID)
+	_, err := r.db.ExecContext(ctx, q, item.Quantity, item.Name, item.Price.GetUnits(), item.Price.GetCurrencyCode(), time.Now(), item.CartID, item.ProductID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// RemoveCartItem removes the product from the cart, sql.ErrNoRows is returned when the product is not in the cart
+func (r *CartRepository) RemoveCartItem(ctx context.Context, cartID uuid.UUID, productID string) error {
+	q := `DELETE FROM cart_items WHERE cart_id = ? AND product_id = ?;`
+	result, err := r.db.ExecContext(ctx, q, cartID, productID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+// SetCartItemQuantity replaces the quantity of the product, sql.ErrNoRows is returned when the product is not in the cart
+func (r *CartRepository) SetCartItemQuantity(ctx context.Context, cartID uuid.UUID, productID string, quantity int64) error {
+	q := `UPDATE cart_items
+		SET quantity = ?, updated_at = ?
+		WHERE cart_id = ? AND product_id = ?;`
+	result, err := r.db.ExecContext(ctx, q, quantity, time.Now(), cartID, productID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+func (r *CartRepository) ClearCart(ctx context.Context, cartID uuid.UUID) error {
+	q := `DELETE FROM cart_items WHERE cart_id = ?;`
+	_, err := r.db.ExecContext(ctx, q, cartID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MergeCart upserts the items into the user cart and deactivates the guest cart in one transaction.
+// The user cart is created when cart.ID is empty
+func (r *CartRepository) MergeCart(ctx context.Context, guestCartID uuid.UUID, cart entity.Cart) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		cartID := cart.ID
+		if cartID == uuid.Nil {
+			var err error
+			cartID, err = uuid.NewV7()
+			if err != nil {
+				return err
+			}
+
+			err = insertCart(ctx, tx, cartID, cart)
+			if err != nil {
+				return err
+			}
+		}
+
+		qItem := `INSERT INTO cart_items 
+		(id, cart_id, name, product_id, quantity, price, currency)
+		VALUES (?,?,?,?,?,?,?)
+		ON CONFLICT(cart_id, product_id) DO UPDATE SET
+			quantity = excluded.quantity,
+			name = excluded.name,
+			price = excluded.price,
+			currency = excluded.currency,
+			updated_at = ?;`
+
+		for _, item := range cart.Items {
+			cartItemID, err := uuid.NewV7()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, qItem,
+				cartItemID,
+				cartID,
+				item.Name,
+				item.ProductID,
+				item.Quantity,
+				item.Price.GetUnits(),
+				item.Price.GetCurrencyCode(),
+				time.Now(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if cart.CouponCode != "" {
+			_, err := tx.ExecContext(ctx, `UPDATE carts SET coupon_code = ?, updated_at = ? WHERE id = ?;`, cart.CouponCode, time.Now(), cartID)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE carts SET is_active = FALSE, updated_at = ? WHERE id = ?;`, time.Now(), guestCartID)
+		return err
+	})
+}
+
+// SetCartCoupon keeps the coupon code in the cart, empty code removes the coupon
+func (r *CartRepository) SetCartCoupon(ctx context.Context, cartID uuid.UUID, couponCode string) error {
+	var code any
+	if couponCode != "" {
+		code = couponCode
+	}
+
+	q := `UPDATE carts SET coupon_code = ?, updated_at = ? WHERE id = ?;`
+	result, err := r.db.ExecContext(ctx, q, code, time.Now(), cartID)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+func insertCart(ctx context.Context, tx *sql.Tx, cartID uuid.UUID, cart entity.Cart) error {
+	// guest cart has no user and user cart has no token
+	var userID, cartToken any
+	if cart.IsGuest() {
+		cartToken = cart.CartToken
+	} else {
+		userID = cart.UserID
+	}
+
+	q := `INSERT INTO carts (id, user_id, cart_token, is_active) VALUES (?, ?, ?, ?);`
+	_, err := tx.ExecContext(ctx, q,
+		cartID,
+		userID,
+		cartToken,
+		true,
+	)
+	return err
+}
+
+func checkRowsAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package sqlitedb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,18 +34,54 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order entity.Order) (
 	}
 
 	err = dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `INSERT INTO orders(idempotency_key, id, user_id, status, total_amount, currency) VALUES(?, ?, ?, ?, ?, ?)`,
+		var couponCode any
+		if order.CouponCode != "" {
+			couponCode = order.CouponCode
+		}
+
+		var shippingRegion any
+		if order.ShippingRegion != "" {
+			shippingRegion = order.ShippingRegion
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO orders(
+			idempotency_key,
+			id,
+			user_id,
+			status,
+			total_amount,
+			currency,
+			subtotal_amount,
+			discount_amount,
+			coupon_code,
+			tax_amount,
+			shipping_amount,
+			shipping_region
+		) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			order.IdempotencyKey,
 			orderID,
 			order.UserID,
 			order.Status,
 			order.TotalAmount.Units,
 			order.TotalAmount.CurrencyCode,
+			order.SubtotalAmount.GetUnits(),
+			order.DiscountAmount.GetUnits(),
+			couponCode,
+			order.TaxAmount.GetUnits(),
+			order.ShippingAmount.GetUnits(),
+			shippingRegion,
 		)
 		if err != nil {
 			return err
 		}
 
+		if order.PromotionID != uuid.Nil {
+			err = insertPromotionUsage(ctx, tx, order, orderID)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, item := range order.Items {
 
 			orderItemID, err := uuid.NewV7()
@@ -60,8 +97,14 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order entity.Order) (
 				price_per_unit_units,
 				currency,
 				quantity,
-				total_price_units
-			) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+				total_price_units,
+				settlement_total_units,
+				exchange_rate,
+				discount_units,
+				tax_units,
+				tax_rate,
+				tax_inclusive
+			) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 				orderItemID,
 				orderID,
 				item.ProductID,
@@ -70,12 +113,35 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order entity.Order) (
 				item.PricePerUnit.GetCurrencyCode(),
 				item.Quantity,
 				item.TotalPricePerUnit.GetUnits(),
+				item.SettlementTotal.GetUnits(),
+				item.ExchangeRate,
+				item.Discount.GetUnits(),
+				item.Tax.GetUnits(),
+				item.TaxRate,
+				item.TaxInclusive,
 			)
 			if err != nil {
 				return err
 			}
 		}
 
+		if order.Shipment != nil {
+			err = upsertOrderShipment(ctx, tx, orderID, *order.Shipment)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = insertOrderStatusHistory(ctx, tx, entity.OrderStatusHistory{
+			OrderID:  orderID,
+			ToStatus: order.Status,
+			Reason:   "order created",
+			Actor:    constanta.OrderActorCustomer,
+		})
+		if err != nil {
+			return err
+		}
+
 		_, err = tx.ExecContext(ctx, "UPDATE carts SET is_active = FALSE WHERE user_id = ?", order.UserID)
 		if err != nil {
 			return err
@@ -91,6 +157,46 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, order entity.Order) (
 	return orderID, nil
 }
 
+// insertPromotionUsage records the usage of the promotion by the order.
+// The usage limits are checked again by the insert, so the orders that are created at the same time cannot use more than the limit
+func insertPromotionUsage(ctx context.Context, tx *sql.Tx, order entity.Order, orderID uuid.UUID) error {
+	q := `INSERT INTO promotion_usages(promotion_id, order_id, user_id)
+	SELECT p.id, ?, ? FROM promotions p
+	WHERE p.id = ?
+		AND (p.usage_limit = 0 OR p.usage_limit > (
+			SELECT COUNT(*) FROM promotion_usages pu
+			JOIN orders o ON o.id = pu.order_id
+			WHERE pu.promotion_id = p.id AND o.status NOT IN (?, ?)))
+		AND (p.usage_limit_per_user = 0 OR p.usage_limit_per_user > (
+			SELECT COUNT(*) FROM promotion_usages pu
+			JOIN orders o ON o.id = pu.order_id
+			WHERE pu.promotion_id = p.id AND pu.user_id = ? AND o.status NOT IN (?, ?)));`
+
+	res, err := tx.ExecContext(ctx, q,
+		orderID,
+		order.UserID,
+		order.PromotionID,
+		constanta.OrderStatusFailed,
+		constanta.OrderStatusCancelled,
+		order.UserID,
+		constanta.OrderStatusFailed,
+		constanta.OrderStatusCancelled,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: coupon %s has reached its usage limit", entity.ErrCouponNotApplicable, order.CouponCode)
+	}
+
+	return nil
+}
+
 func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempotencyKey uuid.UUID) (*entity.Order, error) {
 	q := `SELECT id, 
 	idempotency_key, 
@@ -99,10 +205,17 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 	total_amount, 
 	currency, 
 	transaction_id,
-	created_at, 
+	COALESCE(cancel_reason, ''),
+	COALESCE(subtotal_amount, total_amount),
+	discount_amount,
+	COALESCE(coupon_code, ''),
+	tax_amount,
+	shipping_amount,
+	COALESCE(shipping_region, ''),
+	created_at,
 	updated_at FROM orders WHERE idempotency_key = ?;`
 
-	var totalAmount int64
+	var totalAmount, subtotalAmount, discountAmount, taxAmount, shippingAmount int64
 	var currencyCode string
 	var ord entity.Order
 	err := r.db.QueryRowContext(ctx, q, idempotencyKey).Scan(
@@ -113,6 +226,13 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 		&totalAmount,
 		&currencyCode,
 		&ord.TransactionID,
+		&ord.CancelReason,
+		&subtotalAmount,
+		&discountAmount,
+		&ord.CouponCode,
+		&taxAmount,
+		&shippingAmount,
+		&ord.ShippingRegion,
 		&ord.CreatedAt,
 		&ord.UpdatedAt,
 	)
@@ -125,6 +245,26 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 		return nil, err
 	}
 
+	ord.SubtotalAmount, err = money.New(subtotalAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.DiscountAmount, err = money.New(discountAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.TaxAmount, err = money.New(taxAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.ShippingAmount, err = money.New(shippingAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	qItems := `SELECT 
 	id, 
 	order_id, 
@@ -133,7 +273,13 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 	price_per_unit_units, 
 	currency, 
 	quantity, 
-	total_price_units
+	total_price_units,
+	COALESCE(settlement_total_units, total_price_units),
+	COALESCE(exchange_rate, '1'),
+	discount_units,
+	tax_units,
+	COALESCE(tax_rate, '0'),
+	tax_inclusive
 	FROM order_items WHERE order_id = ?;`
 
 	rows, err := r.db.QueryContext(ctx, qItems, ord.ID)
@@ -145,6 +291,8 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 		var orderItem entity.OrderItem
 		var pricePerUnit int64
 		var totalPricePerUnit int64
+		var settlementTotal int64
+		var discount, tax int64
 		var currencyCode string
 		err = rows.Scan(
 			&orderItem.ID,
@@ -155,6 +303,12 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 			&currencyCode,
 			&orderItem.Quantity,
 			&totalPricePerUnit,
+			&settlementTotal,
+			&orderItem.ExchangeRate,
+			&discount,
+			&tax,
+			&orderItem.TaxRate,
+			&orderItem.TaxInclusive,
 		)
 		if err != nil {
 			return nil, err
@@ -170,35 +324,197 @@ func (r *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, idempote
 			return nil, err
 		}
 
+		orderItem.SettlementTotal, err = money.New(settlementTotal, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
+		orderItem.Discount, err = money.New(discount, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
+		orderItem.Tax, err = money.New(tax, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
 		ord.Items = append(ord.Items, orderItem)
 	}
 
+	ord.Shipment, err = r.getOrderShipment(ctx, ord.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ord, nil
 }
 
-func (r *OrderRepository) UpdateOrder(ctx context.Context, payloads map[string]any, orderID uuid.UUID) error {
-
-	q := `UPDATE orders SET `
-	fields := map[string]struct{}{
-		"transaction_id": {},
-		"status":         {},
+// ClaimOrderTransition reserves the transition of the change before its side effects run.
+// The next status is claimed under the same condition as the status change, the order must still have the From status
+// and must not be claimed by a transition to another status. Claiming the same transition again is allowed,
+// so the interrupted transition can be retried. entity.ErrInvalidStatusTransition is returned when another writer wins
+func (r *OrderRepository) ClaimOrderTransition(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error {
+	if !change.From.CanTransitionTo(change.To) {
+		return fmt.Errorf("%w: order with status %s cannot be %s", entity.ErrInvalidStatusTransition, change.From, change.To)
 	}
-	args := []any{}
-	for key, payload := range payloads {
-		if _, ok := fields[key]; ok {
-			q += fmt.Sprintf(" %s = ?,", key)
+
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `UPDATE orders SET pending_status = ?, updated_at = ?
+			WHERE id = ? AND status = ? AND pending_status IN ('', ?);`,
+			change.To,
+			time.Now(),
+			orderID,
+			change.From,
+			change.To,
+		)
+		if err != nil {
+			return err
 		}
-		args = append(args, payload)
+
+		return checkOrderMoved(ctx, tx, result, orderID, change)
+	})
+}
+
+// ReleaseOrderTransition drops the claim of the transition whose side effects failed, so the order can be moved again
+func (r *OrderRepository) ReleaseOrderTransition(ctx context.Context, orderID uuid.UUID, to constanta.OrderStatus) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE orders SET pending_status = '', updated_at = ? WHERE id = ? AND pending_status = ?;`,
+		time.Now(),
+		orderID,
+		to,
+	)
+
+	return err
+}
+
+// UpdateOrderStatus moves the order to the next status and records the transition in the status history.
+// The status is only changed when the order still has the From status of the change and it is not claimed by a transition to another status,
+// entity.ErrInvalidStatusTransition is returned when the transition is not allowed or the order has been moved in the meantime
+func (r *OrderRepository) UpdateOrderStatus(ctx context.Context, orderID uuid.UUID, change entity.StatusChange) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		return updateOrderStatus(ctx, tx, orderID, change)
+	})
+}
+
+func updateOrderStatus(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, change entity.StatusChange) error {
+	if !change.From.CanTransitionTo(change.To) {
+		return fmt.Errorf("%w: order with status %s cannot be %s", entity.ErrInvalidStatusTransition, change.From, change.To)
 	}
-	q += "updated_at = ? WHERE id = ?;"
-	args = append(args, time.Now(), orderID)
 
-	_, err := r.db.ExecContext(ctx, q, args...)
+	q := `UPDATE orders SET status = ?,`
+	args := []any{change.To}
+	if change.TransactionID != "" {
+		q += ` transaction_id = ?,`
+		args = append(args, change.TransactionID)
+	}
+	if change.CancelReason != "" {
+		q += ` cancel_reason = ?,`
+		args = append(args, change.CancelReason)
+	}
+	q += ` pending_status = '', updated_at = ? WHERE id = ? AND status = ? AND pending_status IN ('', ?);`
+	args = append(args, time.Now(), orderID, change.From, change.To)
+
+	result, err := tx.ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = checkOrderMoved(ctx, tx, result, orderID, change)
+	if err != nil {
+		return err
+	}
+
+	return insertOrderStatusHistory(ctx, tx, entity.OrderStatusHistory{
+		OrderID:    orderID,
+		FromStatus: change.From,
+		ToStatus:   change.To,
+		Reason:     change.Reason,
+		Actor:      change.Actor,
+	})
+}
+
+// checkOrderMoved returns entity.ErrInvalidStatusTransition when the guarded update of the change did not match the order
+func checkOrderMoved(ctx context.Context, tx *sql.Tx, result sql.Result, orderID uuid.UUID, change entity.StatusChange) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var current, pending constanta.OrderStatus
+	err = tx.QueryRowContext(ctx, `SELECT status, pending_status FROM orders WHERE id = ?;`, orderID).Scan(&current, &pending)
+	if err != nil {
+		return err
+	}
+
+	if current == change.From {
+		return fmt.Errorf("%w: order is being moved to %s", entity.ErrInvalidStatusTransition, pending)
+	}
+
+	return fmt.Errorf("%w: order is %s, not %s anymore", entity.ErrInvalidStatusTransition, current, change.From)
+}
+
+func insertOrderStatusHistory(ctx context.Context, tx *sql.Tx, history entity.OrderStatusHistory) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO order_status_history(
+		order_id,
+		from_status,
+		to_status,
+		reason,
+		actor,
+		created_at
+	) VALUES(?, ?, ?, ?, ?, ?);`,
+		history.OrderID,
+		history.FromStatus,
+		history.ToStatus,
+		history.Reason,
+		history.Actor,
+		time.Now(),
+	)
+
+	return err
+}
+
+// GetOrderStatusHistory returns every status of the order, oldest first
+func (r *OrderRepository) GetOrderStatusHistory(ctx context.Context, orderID uuid.UUID) ([]entity.OrderStatusHistory, error) {
+	q := `SELECT
+	id,
+	order_id,
+	from_status,
+	to_status,
+	reason,
+	actor,
+	created_at
+	FROM order_status_history WHERE order_id = ? ORDER BY id;`
+
+	rows, err := r.db.QueryContext(ctx, q, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histories := []entity.OrderStatusHistory{}
+	for rows.Next() {
+		var history entity.OrderStatusHistory
+		err = rows.Scan(
+			&history.ID,
+			&history.OrderID,
+			&history.FromStatus,
+			&history.ToStatus,
+			&history.Reason,
+			&history.Actor,
+			&history.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		histories = append(histories, history)
+	}
+
+	return histories, nil
 }
 
 func (r *OrderRepository) GetExpiryOrders(ctx context.Context, duration time.Duration) ([]entity.Order, error) {
@@ -235,32 +551,13 @@ func (r *OrderRepository) GetExpiryOrders(ctx context.Context, duration time.Dur
 	return orders, nil
 }
 
-func (r *OrderRepository) UpdateOrderStatusWithCallback(ctx context.Context, status constanta.OrderStatus, orderID uuid.UUID, callback func() error) error {
-	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `UPDATE orders SET status = ? WHERE id = ?`, status, orderID)
-		if err != nil {
-			return err
-		}
-
-		err = callback()
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (r *OrderRepository) GetOrderByTransactionID(ctx context.Context, transactionID string) (*entity.Order, error) {
-	q := `SELECT id, status FROM orders WHERE transaction_id = ?;`
+	q := `SELECT id, user_id, status FROM orders WHERE transaction_id = ?;`
 
 	var ord entity.Order
 	err := r.db.QueryRowContext(ctx, q, transactionID).Scan(
 		&ord.ID,
+		&ord.UserID,
 		&ord.Status,
 	)
 	if err != nil {
@@ -278,10 +575,17 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 	total_amount, 
 	currency, 
 	transaction_id, 
+	COALESCE(cancel_reason, ''),
+	COALESCE(subtotal_amount, total_amount),
+	discount_amount,
+	COALESCE(coupon_code, ''),
+	tax_amount,
+	shipping_amount,
+	COALESCE(shipping_region, ''),
 	created_at,
 	updated_at FROM orders WHERE id = ?;`
 
-	var totalAmount int64
+	var totalAmount, subtotalAmount, discountAmount, taxAmount, shippingAmount int64
 	var currencyCode string
 	var ord entity.Order
 	err := r.db.QueryRowContext(ctx, q, orderID).Scan(
@@ -292,6 +596,13 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 		&totalAmount,
 		&currencyCode,
 		&ord.TransactionID,
+		&ord.CancelReason,
+		&subtotalAmount,
+		&discountAmount,
+		&ord.CouponCode,
+		&taxAmount,
+		&shippingAmount,
+		&ord.ShippingRegion,
 		&ord.CreatedAt,
 		&ord.UpdatedAt,
 	)
@@ -304,6 +615,26 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 		return nil, err
 	}
 
+	ord.SubtotalAmount, err = money.New(subtotalAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.DiscountAmount, err = money.New(discountAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.TaxAmount, err = money.New(taxAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	ord.ShippingAmount, err = money.New(shippingAmount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	qItems := `SELECT 
 	id, 
 	order_id, 
@@ -312,7 +643,13 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 	price_per_unit_units, 
 	currency, 
 	quantity, 
-	total_price_units
+	total_price_units,
+	COALESCE(settlement_total_units, total_price_units),
+	COALESCE(exchange_rate, '1'),
+	discount_units,
+	tax_units,
+	COALESCE(tax_rate, '0'),
+	tax_inclusive
 	FROM order_items WHERE order_id = ?;`
 
 	rows, err := r.db.QueryContext(ctx, qItems, ord.ID)
@@ -324,6 +661,8 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 		var orderItem entity.OrderItem
 		var pricePerUnit int64
 		var totalPricePerUnit int64
+		var settlementTotal int64
+		var discount, tax int64
 		var currencyCode string
 		err = rows.Scan(
 			&orderItem.ID,
@@ -334,6 +673,12 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 			&currencyCode,
 			&orderItem.Quantity,
 			&totalPricePerUnit,
+			&settlementTotal,
+			&orderItem.ExchangeRate,
+			&discount,
+			&tax,
+			&orderItem.TaxRate,
+			&orderItem.TaxInclusive,
 		)
 		if err != nil {
 			return nil, err
@@ -349,9 +694,29 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, orderID uuid.UUID) (
 			return nil, err
 		}
 
+		orderItem.SettlementTotal, err = money.New(settlementTotal, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
+		orderItem.Discount, err = money.New(discount, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
+		orderItem.Tax, err = money.New(tax, ord.TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+
 		ord.Items = append(ord.Items, orderItem)
 	}
 
+	ord.Shipment, err = r.getOrderShipment(ctx, ord.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ord, nil
 }
 
@@ -363,6 +728,13 @@ func (r *OrderRepository) GetOrderList(ctx context.Context, req entity.GetOrderL
 	total_amount, 
 	currency, 
 	transaction_id,
+	COALESCE(cancel_reason, ''),
+	COALESCE(subtotal_amount, total_amount),
+	discount_amount,
+	COALESCE(coupon_code, ''),
+	tax_amount,
+	shipping_amount,
+	COALESCE(shipping_region, ''),
 	created_at, 
 	updated_at FROM orders WHERE user_id = ?`
 
@@ -393,7 +765,7 @@ func (r *OrderRepository) GetOrderList(ctx context.Context, req entity.GetOrderL
 	orders := []entity.Order{}
 	for rows.Next() {
 		var order entity.Order
-		var totalAmount int64
+		var totalAmount, subtotalAmount, discountAmount, taxAmount, shippingAmount int64
 		var currencyCode string
 		err := rows.Scan(
 			&order.ID,
@@ -403,6 +775,13 @@ func (r *OrderRepository) GetOrderList(ctx context.Context, req entity.GetOrderL
 			&totalAmount,
 			&currencyCode,
 			&order.TransactionID,
+			&order.CancelReason,
+			&subtotalAmount,
+			&discountAmount,
+			&order.CouponCode,
+			&taxAmount,
+			&shippingAmount,
+			&order.ShippingRegion,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 		)
@@ -415,8 +794,359 @@ func (r *OrderRepository) GetOrderList(ctx context.Context, req entity.GetOrderL
 			return nil, err
 		}
 
+		order.SubtotalAmount, err = money.New(subtotalAmount, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+
+		order.DiscountAmount, err = money.New(discountAmount, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+
+		order.TaxAmount, err = money.New(taxAmount, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+
+		order.ShippingAmount, err = money.New(shippingAmount, currencyCode)
+		if err != nil {
+			return nil, err
+		}
+
 		orders = append(orders, order)
 	}
 
 	return orders, nil
 }
+
+// UpdateFulfillment moves the order to the fulfillment status and records the shipment in one transaction
+func (r *OrderRepository) UpdateFulfillment(ctx context.Context, orderID uuid.UUID, change entity.StatusChange, shipment entity.Shipment) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		err := updateOrderStatus(ctx, tx, orderID, change)
+		if err != nil {
+			return err
+		}
+
+		return upsertOrderShipment(ctx, tx, orderID, shipment)
+	})
+}
+
+func upsertOrderShipment(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, shipment entity.Shipment) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO order_shipments(
+		order_id,
+		recipient_name,
+		phone,
+		street,
+		city,
+		region,
+		postal_code,
+		carrier,
+		tracking_number,
+		packed_at,
+		shipped_at,
+		delivered_at
+	) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(order_id) DO UPDATE SET
+		carrier = excluded.carrier,
+		tracking_number = excluded.tracking_number,
+		packed_at = excluded.packed_at,
+		shipped_at = excluded.shipped_at,
+		delivered_at = excluded.delivered_at,
+		updated_at = CURRENT_TIMESTAMP;`,
+		orderID,
+		shipment.Address.RecipientName,
+		shipment.Address.Phone,
+		shipment.Address.Street,
+		shipment.Address.City,
+		shipment.Address.Region,
+		shipment.Address.PostalCode,
+		shipment.Carrier,
+		shipment.TrackingNumber,
+		shipment.PackedAt,
+		shipment.ShippedAt,
+		shipment.DeliveredAt,
+	)
+
+	return err
+}
+
+// getOrderShipment returns nil when the order has no shipment
+func (r *OrderRepository) getOrderShipment(ctx context.Context, orderID uuid.UUID) (*entity.Shipment, error) {
+	q := `SELECT
+	recipient_name,
+	phone,
+	street,
+	city,
+	region,
+	postal_code,
+	carrier,
+	tracking_number,
+	packed_at,
+	shipped_at,
+	delivered_at
+	FROM order_shipments WHERE order_id = ?;`
+
+	var shipment entity.Shipment
+	err := r.db.QueryRowContext(ctx, q, orderID).Scan(
+		&shipment.Address.RecipientName,
+		&shipment.Address.Phone,
+		&shipment.Address.Street,
+		&shipment.Address.City,
+		&shipment.Address.Region,
+		&shipment.Address.PostalCode,
+		&shipment.Carrier,
+		&shipment.TrackingNumber,
+		&shipment.PackedAt,
+		&shipment.ShippedAt,
+		&shipment.DeliveredAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &shipment, nil
+}
+
+// CreateRefund records the pending refund with its items.
+// The quantity of the items is checked again against the refunds that are not failed,
+// entity.ErrInvalidRefund is returned when the item is refunded more than it is ordered
+func (r *OrderRepository) CreateRefund(ctx context.Context, refund entity.Refund) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		for _, item := range refund.Items {
+			var ordered, refunded int64
+			err := tx.QueryRowContext(ctx, `SELECT
+				oi.quantity,
+				COALESCE((SELECT SUM(ri.quantity) FROM order_refund_items ri
+					JOIN order_refunds rf ON rf.id = ri.refund_id
+					WHERE rf.order_id = oi.order_id AND rf.status != ? AND ri.product_id = oi.product_id), 0)
+				FROM order_items oi WHERE oi.order_id = ? AND oi.product_id = ?;`,
+				constanta.RefundStatusFailed,
+				refund.OrderID,
+				item.ProductID,
+			).Scan(&ordered, &refunded)
+			if err != nil {
+				return err
+			}
+
+			if refunded+item.Quantity > ordered {
+				return fmt.Errorf("%w: only %d of product %s can be refunded", entity.ErrInvalidRefund, ordered-refunded, item.ProductID)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO order_refunds(id, order_id, status, amount, currency, reason, restock, warehouse_id, return_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+			refund.ID,
+			refund.OrderID,
+			refund.Status,
+			refund.Amount.GetUnits(),
+			refund.Amount.GetCurrencyCode(),
+			refund.Reason,
+			refund.Restock,
+			refund.WarehouseID,
+			refund.ReturnID,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range refund.Items {
+			_, err = tx.ExecContext(ctx, `INSERT INTO order_refund_items(refund_id, product_id, quantity, amount)
+				VALUES (?, ?, ?, ?);`,
+				refund.ID,
+				item.ProductID,
+				item.Quantity,
+				item.Amount.GetUnits(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetOrderRefunds returns every refund of the order with its items, the oldest first
+func (r *OrderRepository) GetOrderRefunds(ctx context.Context, orderID uuid.UUID) ([]entity.Refund, error) {
+	q := `SELECT
+	id,
+	order_id,
+	status,
+	amount,
+	currency,
+	reason,
+	restock,
+	restocked,
+	warehouse_id,
+	return_id,
+	created_at
+	FROM order_refunds WHERE order_id = ? ORDER BY created_at, id;`
+
+	return r.getRefunds(ctx, q, orderID)
+}
+
+// GetPendingRefunds returns every refund that is not completed or failed yet with its items, the oldest first
+func (r *OrderRepository) GetPendingRefunds(ctx context.Context) ([]entity.Refund, error) {
+	q := `SELECT
+	id,
+	order_id,
+	status,
+	amount,
+	currency,
+	reason,
+	restock,
+	restocked,
+	warehouse_id,
+	return_id,
+	created_at
+	FROM order_refunds WHERE status = ? ORDER BY created_at, id;`
+
+	return r.getRefunds(ctx, q, constanta.RefundStatusPending)
+}
+
+func (r *OrderRepository) getRefunds(ctx context.Context, q string, args ...any) ([]entity.Refund, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []entity.Refund{}
+	for rows.Next() {
+		var refund entity.Refund
+		var amount int64
+		var currency string
+		err = rows.Scan(
+			&refund.ID,
+			&refund.OrderID,
+			&refund.Status,
+			&amount,
+			&currency,
+			&refund.Reason,
+			&refund.Restock,
+			&refund.Restocked,
+			&refund.WarehouseID,
+			&refund.ReturnID,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refund.Amount, err = money.New(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range refunds {
+		refunds[i].Items, err = r.getRefundItems(ctx, refunds[i].ID, refunds[i].Amount.GetCurrencyCode())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return refunds, nil
+}
+
+func (r *OrderRepository) getRefundItems(ctx context.Context, refundID uuid.UUID, currency string) ([]entity.RefundItem, error) {
+	q := `SELECT product_id, quantity, amount FROM order_refund_items WHERE refund_id = ? ORDER BY product_id;`
+
+	rows, err := r.db.QueryContext(ctx, q, refundID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []entity.RefundItem{}
+	for rows.Next() {
+		var item entity.RefundItem
+		var amount int64
+		err = rows.Scan(&item.ProductID, &item.Quantity, &amount)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Amount, err = money.New(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// FailRefund marks the pending refund that is refused by the payment service,
+// its items can be refunded again
+func (r *OrderRepository) FailRefund(ctx context.Context, refundID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE order_refunds SET status = ?, updated_at = ? WHERE id = ? AND status = ?;`,
+		constanta.RefundStatusFailed,
+		time.Now(),
+		refundID,
+		constanta.RefundStatusPending,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
+
+// CompleteRefund marks the pending refund as completed and moves the order to the next status of the change in a single transaction.
+// The payment is already refunded at this point, so the order is moved from the status it has now instead of the From status of the change.
+// The received return of the refund becomes refunded in the same transaction
+func (r *OrderRepository) CompleteRefund(ctx context.Context, refund entity.Refund, change entity.StatusChange) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `UPDATE order_refunds SET status = ?, restocked = ?, updated_at = ? WHERE id = ? AND status = ?;`,
+			constanta.RefundStatusCompleted,
+			refund.Restocked,
+			time.Now(),
+			refund.ID,
+			constanta.RefundStatusPending,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = checkRowsAffected(result)
+		if err != nil {
+			return err
+		}
+
+		if refund.ReturnID != "" {
+			result, err = tx.ExecContext(ctx, `UPDATE order_returns SET status = ?, refund_id = ?, updated_at = ? WHERE id = ? AND status = ?;`,
+				constanta.ReturnStatusRefunded,
+				refund.ID,
+				time.Now(),
+				refund.ReturnID,
+				constanta.ReturnStatusReceived,
+			)
+			if err != nil {
+				return err
+			}
+
+			err = checkRowsAffected(result)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = ?;`, refund.OrderID).Scan(&change.From)
+		if err != nil {
+			return err
+		}
+
+		return updateOrderStatus(ctx, tx, refund.OrderID, change)
+	})
+}
@@ -0,0 +1,220 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/order/internal/sqlitedb"
+	"github.com/elangreza/e-commerce/pkg/dbsql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := dbsql.NewDbSql(
+		dbsql.WithSqliteDB(filepath.Join(t.TempDir(), "order.db")),
+		dbsql.WithSqliteDBWalMode(),
+		dbsql.WithAutoMigrate("file://../../migrations"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func insertOrder(t *testing.T, db *sql.DB, status constanta.OrderStatus) uuid.UUID {
+	t.Helper()
+
+	orderID := uuid.New()
+	_, err := db.Exec(`INSERT INTO orders(id, idempotency_key, user_id, status, total_amount) VALUES (?, ?, ?, ?, ?);`,
+		orderID,
+		uuid.NewString(),
+		uuid.NewString(),
+		status,
+		10000,
+	)
+	require.NoError(t, err)
+
+	return orderID
+}
+
+func TestClaimOrderTransitionHasOneWinner(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewOrderRepository(db)
+	ctx := context.Background()
+	orderID := insertOrder(t, db, constanta.OrderStatusStockReserved)
+
+	// the late payment callback and the customer cancellation race for the same order
+	targets := []constanta.OrderStatus{}
+	for range 5 {
+		targets = append(targets, constanta.OrderStatusCompleted, constanta.OrderStatusCancelled)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, to := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repo.ClaimOrderTransition(ctx, orderID, entity.StatusChange{
+				From: constanta.OrderStatusStockReserved,
+				To:   to,
+			})
+		}()
+	}
+	wg.Wait()
+
+	winners := map[constanta.OrderStatus]bool{}
+	for i, err := range errs {
+		if err == nil {
+			winners[targets[i]] = true
+			continue
+		}
+		require.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+	}
+	require.Len(t, winners, 1)
+
+	var winner, loser constanta.OrderStatus
+	for to := range winners {
+		winner = to
+	}
+	loser = constanta.OrderStatusCancelled
+	if winner == constanta.OrderStatusCancelled {
+		loser = constanta.OrderStatusCompleted
+	}
+
+	// the expiry task cannot move the claimed order either
+	err := repo.UpdateOrderStatus(ctx, orderID, entity.StatusChange{
+		From: constanta.OrderStatusStockReserved,
+		To:   constanta.OrderStatusFailed,
+	})
+	require.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+
+	err = repo.UpdateOrderStatus(ctx, orderID, entity.StatusChange{
+		From: constanta.OrderStatusStockReserved,
+		To:   loser,
+	})
+	require.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+
+	// only the winner finishes the transition
+	err = repo.UpdateOrderStatus(ctx, orderID, entity.StatusChange{
+		From: constanta.OrderStatusStockReserved,
+		To:   winner,
+	})
+	require.NoError(t, err)
+
+	var status, pending constanta.OrderStatus
+	err = db.QueryRow(`SELECT status, pending_status FROM orders WHERE id = ?;`, orderID).Scan(&status, &pending)
+	require.NoError(t, err)
+	require.Equal(t, winner, status)
+	require.Empty(t, pending)
+}
+
+func TestReleaseOrderTransition(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewOrderRepository(db)
+	ctx := context.Background()
+	orderID := insertOrder(t, db, constanta.OrderStatusStockReserved)
+
+	cancel := entity.StatusChange{
+		From: constanta.OrderStatusStockReserved,
+		To:   constanta.OrderStatusCancelled,
+	}
+	require.NoError(t, repo.ClaimOrderTransition(ctx, orderID, cancel))
+
+	// claiming the same transition again is allowed to retry it
+	require.NoError(t, repo.ClaimOrderTransition(ctx, orderID, cancel))
+
+	// the side effect of the cancellation failed, the order can be moved by another writer again
+	require.NoError(t, repo.ReleaseOrderTransition(ctx, orderID, constanta.OrderStatusCancelled))
+
+	err := repo.UpdateOrderStatus(ctx, orderID, entity.StatusChange{
+		From: constanta.OrderStatusStockReserved,
+		To:   constanta.OrderStatusFailed,
+	})
+	require.NoError(t, err)
+
+	err = repo.ClaimOrderTransition(ctx, orderID, cancel)
+	require.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+}
+
+func TestCreateOrderKeepsPromotionUsageLimit(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewOrderRepository(db)
+	ctx := context.Background()
+
+	promotionID := uuid.New()
+	_, err := db.Exec(`INSERT INTO promotions(id, code, type, scope, usage_limit, usage_limit_per_user, starts_at, ends_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`,
+		promotionID,
+		"PROMO",
+		constanta.PromotionTypePercentage,
+		constanta.PromotionScopeOrder,
+		3,
+		1,
+		time.Now().Add(-time.Hour),
+		time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	newOrder := func(userID uuid.UUID) entity.Order {
+		return entity.Order{
+			IdempotencyKey: uuid.New(),
+			UserID:         userID,
+			Status:         constanta.OrderStatusPending,
+			TotalAmount:    &gen.Money{Units: 10000, CurrencyCode: "IDR"},
+			CouponCode:     "PROMO",
+			PromotionID:    promotionID,
+		}
+	}
+
+	// the same user orders twice at the same time, only one order can use the coupon
+	userID := uuid.New()
+	orders := []entity.Order{newOrder(userID), newOrder(userID)}
+	// the other users order at the same time, the last usages of the coupon are taken once
+	for range 8 {
+		orders = append(orders, newOrder(uuid.New()))
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(orders))
+	for i, order := range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = repo.CreateOrder(ctx, order)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		require.ErrorIs(t, err, entity.ErrCouponNotApplicable)
+	}
+	require.Equal(t, 3, created)
+
+	var usage, userUsage int64
+	err = db.QueryRow(`SELECT COUNT(*), COALESCE(SUM(CASE WHEN user_id = ? THEN 1 ELSE 0 END), 0) FROM promotion_usages WHERE promotion_id = ?;`,
+		userID, promotionID).Scan(&usage, &userUsage)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), usage)
+	require.LessOrEqual(t, userUsage, int64(1))
+
+	// the refused order is not persisted without its coupon
+	var count int64
+	err = db.QueryRow(`SELECT COUNT(*) FROM orders;`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
@@ -0,0 +1,106 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/order/internal/entity"
+)
+
+type PricingRepository struct {
+	db *sql.DB
+}
+
+func NewPricingRepository(db *sql.DB) *PricingRepository {
+	return &PricingRepository{
+		db: db,
+	}
+}
+
+func (r *PricingRepository) GetTaxRules(ctx context.Context) ([]entity.TaxRule, error) {
+	q := `SELECT
+		id,
+		name,
+		shop_id,
+		category,
+		rate,
+		is_inclusive
+	FROM tax_rules;`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []entity.TaxRule{}
+	for rows.Next() {
+		var rule entity.TaxRule
+		err = rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.ShopID,
+			&rule.Category,
+			&rule.Rate,
+			&rule.Inclusive,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (r *PricingRepository) GetShippingRates(ctx context.Context) ([]entity.ShippingRate, error) {
+	q := `SELECT
+		id,
+		origin_region,
+		destination_region,
+		base_fee_units,
+		per_item_fee_units,
+		currency
+	FROM shipping_rates;`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []entity.ShippingRate{}
+	for rows.Next() {
+		var rate entity.ShippingRate
+		var baseFee, perItemFee int64
+		var currency string
+		err = rows.Scan(
+			&rate.ID,
+			&rate.OriginRegion,
+			&rate.DestinationRegion,
+			&baseFee,
+			&perItemFee,
+			&currency,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rate.BaseFee, err = money.New(baseFee, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		rate.PerItemFee, err = money.New(perItemFee, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,158 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/e-commerce/pkg/money"
+
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/google/uuid"
+)
+
+type PromotionRepository struct {
+	db *sql.DB
+}
+
+func NewPromotionRepository(db *sql.DB) *PromotionRepository {
+	return &PromotionRepository{
+		db: db,
+	}
+}
+
+func (r *PromotionRepository) CreatePromotion(ctx context.Context, promotion entity.Promotion) (uuid.UUID, error) {
+	promotionID, err := uuid.NewV7()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	q := `INSERT INTO promotions (
+		id,
+		code,
+		description,
+		type,
+		scope,
+		scope_id,
+		percentage,
+		amount_units,
+		currency,
+		buy_quantity,
+		get_quantity,
+		usage_limit,
+		usage_limit_per_user,
+		starts_at,
+		ends_at,
+		is_active
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	// the currency column is not nullable, the amount of other types is zero
+	currency := promotion.Amount.GetCurrencyCode()
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	_, err = r.db.ExecContext(ctx, q,
+		promotionID,
+		promotion.Code,
+		promotion.Description,
+		promotion.Type,
+		promotion.Scope,
+		promotion.ScopeID,
+		promotion.Percentage,
+		promotion.Amount.GetUnits(),
+		currency,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.UsageLimit,
+		promotion.UsageLimitPerUser,
+		promotion.StartsAt.UTC(),
+		promotion.EndsAt.UTC(),
+		promotion.IsActive,
+	)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return promotionID, nil
+}
+
+func (r *PromotionRepository) GetPromotionByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	q := `SELECT
+		id,
+		code,
+		description,
+		type,
+		scope,
+		scope_id,
+		percentage,
+		amount_units,
+		currency,
+		buy_quantity,
+		get_quantity,
+		usage_limit,
+		usage_limit_per_user,
+		starts_at,
+		ends_at,
+		is_active
+	FROM promotions WHERE code = ?;`
+
+	var promotion entity.Promotion
+	var promotionType, scope string
+	var amount int64
+	var currency string
+	err := r.db.QueryRowContext(ctx, q, code).Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Description,
+		&promotionType,
+		&scope,
+		&promotion.ScopeID,
+		&promotion.Percentage,
+		&amount,
+		&currency,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.UsageLimit,
+		&promotion.UsageLimitPerUser,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion.Type = constanta.PromotionType(promotionType)
+	promotion.Scope = constanta.PromotionScope(scope)
+	promotion.Amount, err = money.New(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+// GetPromotionUsage counts the orders that used the promotion, in total and by the user.
+// Orders that are failed or cancelled give the usage back
+func (r *PromotionRepository) GetPromotionUsage(ctx context.Context, promotionID, userID uuid.UUID) (int64, int64, error) {
+	q := `SELECT
+		COUNT(*),
+		COALESCE(SUM(CASE WHEN pu.user_id = ? THEN 1 ELSE 0 END), 0)
+	FROM promotion_usages pu
+	JOIN orders o ON o.id = pu.order_id
+	WHERE pu.promotion_id = ? AND o.status NOT IN (?, ?);`
+
+	var usage, userUsage int64
+	err := r.db.QueryRowContext(ctx, q,
+		userID,
+		promotionID,
+		constanta.OrderStatusFailed,
+		constanta.OrderStatusCancelled,
+	).Scan(&usage, &userUsage)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return usage, userUsage, nil
+}
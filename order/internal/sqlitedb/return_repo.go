@@ -0,0 +1,220 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/dbsql"
+	"github.com/google/uuid"
+)
+
+type ReturnRepository struct {
+	db *sql.DB
+}
+
+func NewReturnRepository(db *sql.DB) *ReturnRepository {
+	return &ReturnRepository{
+		db: db,
+	}
+}
+
+// CreateReturn records the requested return, the quantity of every item is checked again
+// against the refunds and the open returns of the order so the same item cannot be returned twice
+func (r *ReturnRepository) CreateReturn(ctx context.Context, ret entity.Return) error {
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		for _, item := range ret.Items {
+			var ordered, refunded, returned int64
+			err := tx.QueryRowContext(ctx, `SELECT
+				oi.quantity,
+				COALESCE((SELECT SUM(ri.quantity) FROM order_refund_items ri
+					JOIN order_refunds rf ON rf.id = ri.refund_id
+					WHERE rf.order_id = oi.order_id AND rf.status != ? AND ri.product_id = oi.product_id), 0),
+				COALESCE((SELECT SUM(rti.quantity) FROM order_return_items rti
+					JOIN order_returns rt ON rt.id = rti.return_id
+					WHERE rt.order_id = oi.order_id AND rt.status IN (?, ?, ?) AND rti.product_id = oi.product_id), 0)
+				FROM order_items oi WHERE oi.order_id = ? AND oi.product_id = ?;`,
+				constanta.RefundStatusFailed,
+				constanta.ReturnStatusRequested,
+				constanta.ReturnStatusApproved,
+				constanta.ReturnStatusReceived,
+				ret.OrderID,
+				item.ProductID,
+			).Scan(&ordered, &refunded, &returned)
+			if err != nil {
+				return err
+			}
+
+			if refunded+returned+item.Quantity > ordered {
+				return fmt.Errorf("%w: only %d of product %s can be returned", entity.ErrInvalidReturn, max(ordered-refunded-returned, 0), item.ProductID)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO order_returns(id, order_id, user_id, status, reason)
+			VALUES (?, ?, ?, ?, ?);`,
+			ret.ID,
+			ret.OrderID,
+			ret.UserID,
+			ret.Status,
+			ret.Reason,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range ret.Items {
+			_, err = tx.ExecContext(ctx, `INSERT INTO order_return_items(return_id, product_id, quantity)
+				VALUES (?, ?, ?);`,
+				ret.ID,
+				item.ProductID,
+				item.Quantity,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *ReturnRepository) GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	q := `SELECT
+	id,
+	order_id,
+	user_id,
+	status,
+	reason,
+	note,
+	warehouse_id,
+	refund_id,
+	created_at,
+	updated_at
+	FROM order_returns WHERE id = ?;`
+
+	var ret entity.Return
+	err := r.db.QueryRowContext(ctx, q, returnID).Scan(
+		&ret.ID,
+		&ret.OrderID,
+		&ret.UserID,
+		&ret.Status,
+		&ret.Reason,
+		&ret.Note,
+		&ret.WarehouseID,
+		&ret.RefundID,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Items, err = r.getReturnItems(ctx, ret.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// GetOrderReturns returns every return of the order with its items, the oldest first
+func (r *ReturnRepository) GetOrderReturns(ctx context.Context, orderID uuid.UUID) ([]entity.Return, error) {
+	q := `SELECT
+	id,
+	order_id,
+	user_id,
+	status,
+	reason,
+	note,
+	warehouse_id,
+	refund_id,
+	created_at,
+	updated_at
+	FROM order_returns WHERE order_id = ? ORDER BY created_at, id;`
+
+	rows, err := r.db.QueryContext(ctx, q, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	returns := []entity.Return{}
+	for rows.Next() {
+		var ret entity.Return
+		err = rows.Scan(
+			&ret.ID,
+			&ret.OrderID,
+			&ret.UserID,
+			&ret.Status,
+			&ret.Reason,
+			&ret.Note,
+			&ret.WarehouseID,
+			&ret.RefundID,
+			&ret.CreatedAt,
+			&ret.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		returns = append(returns, ret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range returns {
+		returns[i].Items, err = r.getReturnItems(ctx, returns[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return returns, nil
+}
+
+func (r *ReturnRepository) getReturnItems(ctx context.Context, returnID uuid.UUID) ([]entity.ReturnItem, error) {
+	q := `SELECT product_id, quantity FROM order_return_items WHERE return_id = ? ORDER BY product_id;`
+
+	rows, err := r.db.QueryContext(ctx, q, returnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []entity.ReturnItem{}
+	for rows.Next() {
+		var item entity.ReturnItem
+		err = rows.Scan(&item.ProductID, &item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// UpdateReturnStatus moves the return from the status to the status of the return with its note and warehouse.
+// sql.ErrNoRows is returned when the return is not in the status anymore
+func (r *ReturnRepository) UpdateReturnStatus(ctx context.Context, ret entity.Return, from constanta.ReturnStatus) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE order_returns
+		SET status = ?, note = ?, warehouse_id = ?, updated_at = ?
+		WHERE id = ? AND status = ?;`,
+		ret.Status,
+		ret.Note,
+		ret.WarehouseID,
+		time.Now(),
+		ret.ID,
+		from,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkRowsAffected(result)
+}
@@ -0,0 +1,318 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elangreza/e-commerce/pkg/dbsql"
+
+	"github.com/elangreza/e-commerce/order/internal/constanta"
+	"github.com/elangreza/e-commerce/order/internal/entity"
+	"github.com/google/uuid"
+)
+
+type SagaRepository struct {
+	db *sql.DB
+}
+
+func NewSagaRepository(db *sql.DB) *SagaRepository {
+	return &SagaRepository{
+		db: db,
+	}
+}
+
+// StartSagaStep records the step as started and increments its attempt count.
+// It must be called before the step is executed, so a crash in the middle of the step can be detected.
+// The returned value is the current attempt of the step.
+func (r *SagaRepository) StartSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference string) (int64, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return 0, err
+	}
+
+	q := `INSERT INTO saga_steps (id, order_id, step, reference, status, attempt)
+	VALUES (?, ?, ?, ?, ?, 1)
+	ON CONFLICT(order_id, step, reference)
+	DO UPDATE SET status = excluded.status, attempt = saga_steps.attempt + 1, error = NULL, next_retry_at = NULL, updated_at = ?
+	RETURNING attempt;`
+
+	var attempt int64
+	err = r.db.QueryRowContext(ctx, q,
+		id,
+		orderID,
+		step,
+		reference,
+		constanta.SagaStepStatusStarted,
+		time.Now(),
+	).Scan(&attempt)
+	if err != nil {
+		return 0, err
+	}
+
+	return attempt, nil
+}
+
+func (r *SagaRepository) CompleteSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, result string) error {
+	q := `UPDATE saga_steps
+		SET status = ?, result = ?, error = NULL, updated_at = ?
+		WHERE order_id = ? AND step = ? AND reference = ?;`
+
+	_, err := r.db.ExecContext(ctx, q,
+		constanta.SagaStepStatusSucceeded,
+		result,
+		time.Now(),
+		orderID,
+		step,
+		reference,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FailSagaStep records the error of the step.
+// retryAt is the time the step can be retried, zero value means the step is not retried.
+func (r *SagaRepository) FailSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string, retryAt time.Time) error {
+	q := `UPDATE saga_steps
+		SET status = ?, error = ?, next_retry_at = ?, updated_at = ?
+		WHERE order_id = ? AND step = ? AND reference = ?;`
+
+	var nextRetryAt sql.NullTime
+	if !retryAt.IsZero() {
+		nextRetryAt = sql.NullTime{Time: retryAt.UTC(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, q,
+		constanta.SagaStepStatusFailed,
+		errMessage,
+		nextRetryAt,
+		time.Now(),
+		orderID,
+		step,
+		reference,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeadLetterSagaStep stops retrying the step and moves it into dead letter compensations
+func (r *SagaRepository) DeadLetterSagaStep(ctx context.Context, orderID uuid.UUID, step constanta.SagaStep, reference, errMessage string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	return dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		var sagaStepID uuid.UUID
+		var attempt int64
+		err := tx.QueryRowContext(ctx, `UPDATE saga_steps
+			SET status = ?, error = ?, next_retry_at = NULL, updated_at = ?
+			WHERE order_id = ? AND step = ? AND reference = ?
+			RETURNING id, attempt;`,
+			constanta.SagaStepStatusDeadLetter,
+			errMessage,
+			time.Now(),
+			orderID,
+			step,
+			reference,
+		).Scan(&sagaStepID, &attempt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO dead_letter_compensations 
+			(id, saga_step_id, order_id, step, attempt, last_error)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+			id,
+			sagaStepID,
+			orderID,
+			step,
+			attempt,
+			errMessage,
+		)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// GetInFlightSagas returns the sagas that did not reach a final state,
+// which are the orders that are still pending, have a claimed transition or have unfinished compensation.
+func (r *SagaRepository) GetInFlightSagas(ctx context.Context) ([]entity.Saga, error) {
+	q := `SELECT 
+		o.id, 
+		o.user_id, 
+		o.status,
+		o.pending_status
+	FROM orders o
+	WHERE o.status = ? 
+	OR o.pending_status != ''
+	OR EXISTS (
+		SELECT 1 FROM saga_steps s 
+		WHERE s.order_id = o.id AND s.step IN (?, ?, ?) AND s.status NOT IN (?, ?)
+	)
+	ORDER BY o.created_at;`
+
+	return r.getSagas(ctx, q,
+		constanta.OrderStatusPending,
+		constanta.SagaStepReleaseStock,
+		constanta.SagaStepRollbackPayment,
+		constanta.SagaStepRefundOverpayment,
+		constanta.SagaStepStatusSucceeded,
+		constanta.SagaStepStatusDeadLetter,
+	)
+}
+
+// GetSagasWithDueCompensations returns the sagas that have failed compensation or failed step of the refund saga ready to be retried
+func (r *SagaRepository) GetSagasWithDueCompensations(ctx context.Context, now time.Time) ([]entity.Saga, error) {
+	q := `SELECT 
+		o.id, 
+		o.user_id, 
+		o.status,
+		o.pending_status
+	FROM orders o
+	WHERE EXISTS (
+		SELECT 1 FROM saga_steps s 
+		WHERE s.order_id = o.id AND s.step IN (?, ?, ?, ?, ?, ?) AND s.status = ? 
+		AND s.next_retry_at IS NOT NULL AND s.next_retry_at <= ?
+	)
+	ORDER BY o.created_at;`
+
+	return r.getSagas(ctx, q,
+		constanta.SagaStepReleaseStock,
+		constanta.SagaStepRollbackPayment,
+		constanta.SagaStepRefundOverpayment,
+		constanta.SagaStepRestockRefund,
+		constanta.SagaStepRefundPayment,
+		constanta.SagaStepCompleteRefund,
+		constanta.SagaStepStatusFailed,
+		now.UTC(),
+	)
+}
+
+func (r *SagaRepository) getSagas(ctx context.Context, q string, args ...any) ([]entity.Saga, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sagas := []entity.Saga{}
+	for rows.Next() {
+		var saga entity.Saga
+		err := rows.Scan(&saga.OrderID, &saga.UserID, &saga.OrderStatus, &saga.PendingStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range sagas {
+		sagas[i].Steps, err = r.GetSagaSteps(ctx, sagas[i].OrderID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sagas, nil
+}
+
+func (r *SagaRepository) GetSagaSteps(ctx context.Context, orderID uuid.UUID) ([]entity.SagaStep, error) {
+	q := `SELECT 
+		id, 
+		order_id, 
+		step, 
+		reference,
+		status, 
+		attempt, 
+		COALESCE(result, ''), 
+		COALESCE(error, ''), 
+		next_retry_at,
+		created_at, 
+		updated_at
+	FROM saga_steps WHERE order_id = ?
+	ORDER BY created_at, id;`
+
+	rows, err := r.db.QueryContext(ctx, q, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	steps := []entity.SagaStep{}
+	for rows.Next() {
+		var step entity.SagaStep
+		err := rows.Scan(
+			&step.ID,
+			&step.OrderID,
+			&step.Step,
+			&step.Reference,
+			&step.Status,
+			&step.Attempt,
+			&step.Result,
+			&step.Error,
+			&step.NextRetryAt,
+			&step.CreatedAt,
+			&step.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func (r *SagaRepository) GetDeadLetterCompensations(ctx context.Context) ([]entity.DeadLetterCompensation, error) {
+	q := `SELECT 
+		id, 
+		saga_step_id, 
+		order_id, 
+		step, 
+		attempt, 
+		last_error, 
+		created_at
+	FROM dead_letter_compensations
+	ORDER BY created_at DESC;`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	compensations := []entity.DeadLetterCompensation{}
+	for rows.Next() {
+		var compensation entity.DeadLetterCompensation
+		err := rows.Scan(
+			&compensation.ID,
+			&compensation.SagaStepID,
+			&compensation.OrderID,
+			&compensation.Step,
+			&compensation.Attempt,
+			&compensation.LastError,
+			&compensation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		compensations = append(compensations, compensation)
+	}
+
+	return compensations, nil
+}
@@ -9,6 +9,7 @@ import (
 type (
 	orderService interface {
 		RemoveExpiryOrder(ctx context.Context, duration time.Duration) (int, error)
+		RetryCompensations(ctx context.Context) (int, error)
 	}
 
 	TaskOrder struct {
@@ -45,6 +46,19 @@ func (to *TaskOrder) removeExpiryOrder() error {
 	return nil
 }
 
+func (to *TaskOrder) retryCompensations() error {
+	retried, err := to.svc.RetryCompensations(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if retried > 0 {
+		fmt.Printf("retrying %d compensation(s)\n", retried)
+	}
+
+	return nil
+}
+
 func (to *TaskOrder) Close() {
 	to.closeChan <- struct{}{}
 }
@@ -64,6 +78,11 @@ func (to *TaskOrder) backgroundJobs() {
 				}
 			}
 
+			err := to.retryCompensations()
+			if err != nil {
+				fmt.Println("getting error from RetryCompensations", err)
+			}
+
 		case <-to.closeChan:
 			fmt.Println("order task closed")
 			return
@@ -0,0 +1,9 @@
+ALTER TABLE order_items DROP COLUMN tax_inclusive;
+ALTER TABLE order_items DROP COLUMN tax_rate;
+ALTER TABLE order_items DROP COLUMN tax_units;
+ALTER TABLE orders DROP COLUMN shipping_region;
+ALTER TABLE orders DROP COLUMN shipping_amount;
+ALTER TABLE orders DROP COLUMN tax_amount;
+
+DROP TABLE IF EXISTS shipping_rates;
+DROP TABLE IF EXISTS tax_rules;
@@ -0,0 +1,43 @@
+-- the most specific rule of the item is used:
+-- shop and category, then shop, then category, then the rule of every shop and category
+CREATE TABLE tax_rules (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    -- 0 is every shop
+    shop_id INTEGER NOT NULL DEFAULT 0,
+    -- empty is every category
+    category TEXT NOT NULL DEFAULT '',
+    -- percent as decimal text, e.g., 11 is 11%
+    rate TEXT NOT NULL,
+    -- the price already contains the tax, so the tax is not added to the total
+    is_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shop_id, category)
+);
+
+-- the fee of a shipment from the region of the warehouse to the region of the order,
+-- * is every region and the most specific rate is used
+CREATE TABLE shipping_rates (
+    id INTEGER PRIMARY KEY,
+    origin_region TEXT NOT NULL DEFAULT '*',
+    destination_region TEXT NOT NULL DEFAULT '*',
+    base_fee_units INTEGER NOT NULL DEFAULT 0 CHECK (base_fee_units >= 0),
+    per_item_fee_units INTEGER NOT NULL DEFAULT 0 CHECK (per_item_fee_units >= 0),
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (origin_region, destination_region)
+);
+
+-- shipping is free everywhere until the rates are configured
+INSERT INTO shipping_rates (origin_region, destination_region, base_fee_units, per_item_fee_units, currency)
+VALUES ('*', '*', 0, 0, 'IDR');
+
+-- total_amount = subtotal_amount - discount_amount + exclusive tax + shipping_amount
+ALTER TABLE orders ADD COLUMN tax_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_region TEXT;
+ALTER TABLE order_items ADD COLUMN tax_units INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_rate TEXT;
+ALTER TABLE order_items ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+-- every status change of the order, from_status is empty when the order is created
+CREATE TABLE order_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- actor can be "CUSTOMER", "PAYMENT_SERVICE", "WAREHOUSE_SERVICE" or "SYSTEM"
+    actor TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id);
+
+-- the orders created before the history only have their current status
+INSERT INTO order_status_history (order_id, from_status, to_status, reason, actor, created_at)
+SELECT id, '', status, 'recorded before the status history', 'SYSTEM', COALESCE(updated_at, created_at)
+FROM orders;
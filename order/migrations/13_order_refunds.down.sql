@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS order_refund_items;
+DROP TABLE IF EXISTS order_refunds;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- money paid back for the returned items of the paid order, the amount is in the currency of the order
+CREATE TABLE order_refunds (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- status can be "PENDING", "COMPLETED" or "FAILED"
+    status TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount >= 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- the refunded items are returned into the warehouse
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_refunds_order_id ON order_refunds(order_id);
+
+CREATE TABLE order_refund_items (
+    refund_id TEXT NOT NULL REFERENCES order_refunds(id) ON DELETE CASCADE,
+    product_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    amount INTEGER NOT NULL CHECK (amount >= 0),
+    PRIMARY KEY (refund_id, product_id)
+);
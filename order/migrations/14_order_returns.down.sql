@@ -0,0 +1,4 @@
+ALTER TABLE order_refunds DROP COLUMN return_id;
+
+DROP TABLE IF EXISTS order_return_items;
+DROP TABLE IF EXISTS order_returns;
\ No newline at end of file
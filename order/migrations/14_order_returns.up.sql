@@ -0,0 +1,30 @@
+-- items of the paid order that are sent back by the customer,
+-- the return is reviewed by the admin then received by the warehouse and refunded
+CREATE TABLE order_returns (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    -- status can be "REQUESTED", "APPROVED", "REJECTED", "RECEIVED" or "REFUNDED"
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- note of the admin review
+    note TEXT NOT NULL DEFAULT '',
+    -- warehouse that received the items, 0 until received
+    warehouse_id INTEGER NOT NULL DEFAULT 0,
+    -- refund of the items, empty until refunded
+    refund_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_returns_order_id ON order_returns(order_id);
+
+CREATE TABLE order_return_items (
+    return_id TEXT NOT NULL REFERENCES order_returns(id) ON DELETE CASCADE,
+    product_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (return_id, product_id)
+);
+
+-- the return the refund is for, empty when it is refunded by the customer service directly
+ALTER TABLE order_refunds ADD COLUMN return_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+ALTER TABLE order_refunds DROP COLUMN warehouse_id;
+ALTER TABLE order_refunds DROP COLUMN restock;
+
+CREATE TABLE saga_steps_old (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    step TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    next_retry_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(order_id, step)
+);
+
+-- the steps of the refund saga cannot be kept without the reference
+INSERT INTO saga_steps_old (id, order_id, step, status, attempt, result, error, next_retry_at, created_at, updated_at)
+SELECT id, order_id, step, status, attempt, result, error, next_retry_at, created_at, updated_at FROM saga_steps WHERE reference = '';
+
+DROP TABLE saga_steps;
+ALTER TABLE saga_steps_old RENAME TO saga_steps;
+
+CREATE INDEX idx_saga_steps_order_id ON saga_steps(order_id);
+CREATE INDEX idx_saga_steps_status ON saga_steps(status);
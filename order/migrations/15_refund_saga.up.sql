@@ -0,0 +1,34 @@
+-- the steps of the refund saga are recorded per refund, the reference is the id of the refund.
+-- it is empty for the steps of the order
+CREATE TABLE saga_steps_new (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    step TEXT NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    next_retry_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(order_id, step, reference)
+);
+
+INSERT INTO saga_steps_new (id, order_id, step, status, attempt, result, error, next_retry_at, created_at, updated_at)
+SELECT id, order_id, step, status, attempt, result, error, next_retry_at, created_at, updated_at FROM saga_steps;
+
+DROP TABLE saga_steps;
+ALTER TABLE saga_steps_new RENAME TO saga_steps;
+
+CREATE INDEX idx_saga_steps_order_id ON saga_steps(order_id);
+CREATE INDEX idx_saga_steps_status ON saga_steps(status);
+
+-- the items of the refund are put back into the warehouse only when they are not shipped yet or they are returned.
+-- warehouse_id is the warehouse that receives the returned items, 0 is the warehouse the items are sold from
+ALTER TABLE order_refunds ADD COLUMN restock BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE order_refunds ADD COLUMN warehouse_id INTEGER NOT NULL DEFAULT 0;
+
+UPDATE order_refunds SET restock = TRUE WHERE restocked OR return_id != '';
+UPDATE order_refunds SET warehouse_id = COALESCE((SELECT r.warehouse_id FROM order_returns r WHERE r.id = order_refunds.return_id), 0)
+WHERE return_id != '';
@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN pending_status;
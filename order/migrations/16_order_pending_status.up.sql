@@ -0,0 +1,3 @@
+-- the next status that is claimed by the transition of the order while its side effects run, empty when none.
+-- it is set under the same condition as the status change, so only one transition from the status can run its side effects
+ALTER TABLE orders ADD COLUMN pending_status TEXT NOT NULL DEFAULT '';
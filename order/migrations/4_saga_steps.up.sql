@@ -0,0 +1,18 @@
+CREATE TABLE saga_steps (
+    id TEXT PRIMARY KEY,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    -- step can be "RESERVE_STOCK", "PROCESS_PAYMENT", "RELEASE_STOCK" or "ROLLBACK_PAYMENT"
+    step TEXT NOT NULL,
+    -- status can be "STARTED", "SUCCEEDED" or "FAILED"
+    status TEXT NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 0,
+    -- output of the step that is needed to resume the saga, e.g. transaction_id of the payment
+    result TEXT,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(order_id, step)
+);
+
+CREATE INDEX idx_saga_steps_order_id ON saga_steps(order_id);
+CREATE INDEX idx_saga_steps_status ON saga_steps(status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dead_letter_compensations;
+ALTER TABLE saga_steps DROP COLUMN next_retry_at;
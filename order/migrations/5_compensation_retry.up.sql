@@ -0,0 +1,14 @@
+-- failed compensation is retried by background task after next_retry_at
+ALTER TABLE saga_steps ADD COLUMN next_retry_at TIMESTAMP;
+
+CREATE TABLE dead_letter_compensations (
+    id TEXT PRIMARY KEY,
+    saga_step_id TEXT NOT NULL REFERENCES saga_steps(id) ON DELETE CASCADE,
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    step TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_dead_letter_compensations_order_id ON dead_letter_compensations(order_id);
@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN cancel_reason;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- the reason is shown to the customer when the order is cancelled
+ALTER TABLE orders ADD COLUMN cancel_reason TEXT;
\ No newline at end of file
@@ -0,0 +1,18 @@
+DELETE FROM cart_items WHERE cart_id IN (SELECT id FROM carts WHERE user_id IS NULL);
+
+CREATE TABLE carts_old (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    is_active BOOLEAN,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO carts_old (id, user_id, is_active, created_at, updated_at)
+SELECT id, user_id, is_active, created_at, updated_at FROM carts WHERE user_id IS NOT NULL;
+
+DROP TABLE carts;
+
+ALTER TABLE carts_old RENAME TO carts;
+
+CREATE INDEX idx_carts_user_id ON carts(user_id);
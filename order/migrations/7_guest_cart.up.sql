@@ -0,0 +1,21 @@
+-- guest carts have no user yet, sqlite cannot drop NOT NULL so the table is rebuilt
+-- and the guest cart is keyed by the cart_token issued by the api gateway
+CREATE TABLE carts_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT,
+    cart_token TEXT,
+    is_active BOOLEAN,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id IS NOT NULL OR cart_token IS NOT NULL)
+);
+
+INSERT INTO carts_new (id, user_id, is_active, created_at, updated_at)
+SELECT id, user_id, is_active, created_at, updated_at FROM carts;
+
+DROP TABLE carts;
+
+ALTER TABLE carts_new RENAME TO carts;
+
+CREATE INDEX idx_carts_user_id ON carts(user_id);
+CREATE INDEX idx_carts_cart_token ON carts(cart_token);
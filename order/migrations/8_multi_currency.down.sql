@@ -0,0 +1,4 @@
+ALTER TABLE order_items DROP COLUMN exchange_rate;
+ALTER TABLE order_items DROP COLUMN settlement_total_units;
+
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,13 @@
+-- rate of one major unit of from_currency in to_currency, stored as decimal text
+CREATE TABLE exchange_rates (
+    from_currency TEXT NOT NULL,
+    to_currency TEXT NOT NULL,
+    rate TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (from_currency, to_currency)
+);
+
+-- total_price_units stays in the original currency of the product,
+-- the settlement total is in the currency of orders.currency
+ALTER TABLE order_items ADD COLUMN settlement_total_units INTEGER;
+ALTER TABLE order_items ADD COLUMN exchange_rate TEXT;
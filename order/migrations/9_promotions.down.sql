@@ -0,0 +1,8 @@
+ALTER TABLE order_items DROP COLUMN discount_units;
+ALTER TABLE orders DROP COLUMN coupon_code;
+ALTER TABLE orders DROP COLUMN discount_amount;
+ALTER TABLE orders DROP COLUMN subtotal_amount;
+ALTER TABLE carts DROP COLUMN coupon_code;
+
+DROP TABLE IF EXISTS promotion_usages;
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,45 @@
+CREATE TABLE promotions (
+    id TEXT PRIMARY KEY,
+    -- upper case coupon code
+    code TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    -- PERCENTAGE, FIXED_AMOUNT or BUY_X_GET_Y
+    type TEXT NOT NULL,
+    -- ORDER, SHOP or PRODUCT
+    scope TEXT NOT NULL,
+    scope_id TEXT NOT NULL DEFAULT '',
+    -- decimal text, e.g., 12.5
+    percentage TEXT NOT NULL DEFAULT '0',
+    amount_units INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    -- 0 is unlimited
+    usage_limit INTEGER NOT NULL DEFAULT 0,
+    usage_limit_per_user INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- one row for each order that used the promotion,
+-- the usage of failed or cancelled orders is not counted
+CREATE TABLE promotion_usages (
+    promotion_id TEXT NOT NULL REFERENCES promotions(id),
+    order_id TEXT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX idx_promotion_usages_user_id ON promotion_usages(promotion_id, user_id);
+
+ALTER TABLE carts ADD COLUMN coupon_code TEXT;
+
+-- the discount is in the currency of orders.currency, total_amount = subtotal_amount - discount_amount
+ALTER TABLE orders ADD COLUMN subtotal_amount INTEGER;
+ALTER TABLE orders ADD COLUMN discount_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN coupon_code TEXT;
+ALTER TABLE order_items ADD COLUMN discount_units INTEGER NOT NULL DEFAULT 0;
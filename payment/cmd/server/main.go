@@ -10,6 +10,7 @@ import (
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/handler"
+	"github.com/elangreza/e-commerce/payment/internal/provider"
 	"github.com/elangreza/e-commerce/payment/internal/server"
 	"github.com/elangreza/e-commerce/payment/internal/service"
 	"github.com/elangreza/e-commerce/payment/internal/sqlitedb"
@@ -31,6 +32,18 @@ type Config struct {
 	DBPath             string        `koanf:"DB_PATH"`
 	MaxTimeToBeExpired time.Duration `koanf:"MAX_TIME_TO_BE_EXPIRED"`
 	OrderServiceAddr   string        `koanf:"ORDER_SERVICE_ADDR"`
+	// CallbackInterval is how often the callback outbox is delivered to order service
+	CallbackInterval time.Duration `koanf:"CALLBACK_INTERVAL"`
+	// PaymentProvider is mock or http, the mock provider is paid on the UI of this service
+	PaymentProvider        string        `koanf:"PAYMENT_PROVIDER"`
+	PaymentProviderName    string        `koanf:"PAYMENT_PROVIDER_NAME"`
+	PaymentProviderURL     string        `koanf:"PAYMENT_PROVIDER_URL"`
+	PaymentProviderAPIKey  string        `koanf:"PAYMENT_PROVIDER_API_KEY"`
+	PaymentProviderTimeout time.Duration `koanf:"PAYMENT_PROVIDER_TIMEOUT"`
+	// PaymentProviderStatuses maps the statuses of the charge, e.g. succeeded=PAID,canceled=FAILED
+	PaymentProviderStatuses string        `koanf:"PAYMENT_PROVIDER_STATUSES"`
+	WebhookSecret           string        `koanf:"WEBHOOK_SECRET"`
+	WebhookTolerance        time.Duration `koanf:"WEBHOOK_TOLERANCE"`
 }
 
 func main() {
@@ -58,8 +71,11 @@ func main() {
 	grpcClientOrder, err := grpc.NewClient(cfg.OrderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	errChecker(err)
 
+	paymentProvider, err := newPaymentProvider(cfg)
+	errChecker(err)
+
 	paymentRepo := sqlitedb.NewPaymentRepository(db)
-	paymentService := service.NewPaymentService(paymentRepo, cfg.MaxTimeToBeExpired, gen.NewOrderServiceClient(grpcClientOrder))
+	paymentService := service.NewPaymentService(paymentRepo, cfg.MaxTimeToBeExpired, gen.NewOrderServiceClient(grpcClientOrder), paymentProvider)
 	srv := server.New(paymentService)
 
 	addr := fmt.Sprintf(":%s", cfg.ServicePort)
@@ -103,7 +119,13 @@ func main() {
 
 	taskPayment := task.NewTaskPayment(paymentService, cfg.MaxTimeToBeExpired)
 
-	fmt.Printf("MOCKED-PAYMENT-service running at %s\n", addr)
+	callbackInterval := cfg.CallbackInterval
+	if callbackInterval <= 0 {
+		callbackInterval = 2 * time.Second
+	}
+	callbackDispatcher := task.NewCallbackDispatcher(paymentService, callbackInterval)
+
+	fmt.Printf("PAYMENT-service with provider %s running at %s\n", paymentProvider.Name(), addr)
 	fmt.Println("UI-MOCKED-PAYMENT-service running on :8081")
 
 	gs := gracefulshutdown.New(context.Background(), 5*time.Second,
@@ -133,10 +155,46 @@ func main() {
 				return nil
 			},
 		},
+		gracefulshutdown.Operation{
+			Name: "callback dispatcher",
+			ShutdownFunc: func(ctx context.Context) error {
+				callbackDispatcher.Close()
+				return nil
+			},
+		},
 	)
 	<-gs
 }
 
+func newPaymentProvider(cfg Config) (provider.PaymentProvider, error) {
+	switch cfg.PaymentProvider {
+	case "", provider.MockProviderName:
+		return provider.NewMockProvider(), nil
+	case "http":
+		name := cfg.PaymentProviderName
+		if name == "" {
+			name = "http"
+		}
+
+		statuses, err := provider.ParseStatuses(cfg.PaymentProviderStatuses)
+		if err != nil {
+			return nil, err
+		}
+
+		return provider.NewHTTPProvider(provider.HTTPProviderConfig{
+			Name:             name,
+			BaseURL:          cfg.PaymentProviderURL,
+			APIKey:           cfg.PaymentProviderAPIKey,
+			WebhookSecret:    cfg.WebhookSecret,
+			Timeout:          cfg.PaymentProviderTimeout,
+			WebhookTolerance: cfg.WebhookTolerance,
+			Statuses:         statuses,
+		})
+	default:
+		return nil, fmt.Errorf("unknown payment provider %s", cfg.PaymentProvider)
+	}
+}
+
 func errChecker(err error) {
 	if err != nil {
 		log.Fatal(err)
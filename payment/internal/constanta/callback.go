@@ -0,0 +1,54 @@
+package constanta
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type CallbackStatus string
+
+const (
+	// waiting to be delivered to order service
+	CallbackPending CallbackStatus = "PENDING"
+	// order service accepted the callback
+	CallbackDelivered CallbackStatus = "DELIVERED"
+	// order service refused the callback, retrying will not change the result
+	CallbackRejected CallbackStatus = "REJECTED"
+)
+
+// Implement driver.Valuer interface for writing to database
+func (cs CallbackStatus) Value() (driver.Value, error) {
+	return string(cs), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (cs *CallbackStatus) Scan(value interface{}) error {
+	if value == nil {
+		*cs = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*cs = CallbackStatus(v)
+	case []byte:
+		*cs = CallbackStatus(v)
+	default:
+		return fmt.Errorf("cannot scan %T into CallbackStatus", value)
+	}
+
+	return nil
+}
+
+func (cs CallbackStatus) String() string {
+	switch cs {
+	case CallbackPending:
+		return "PENDING"
+	case CallbackDelivered:
+		return "DELIVERED"
+	case CallbackRejected:
+		return "REJECTED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,54 @@
+package constanta
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+type PaymentMethod string
+
+const (
+	CARD          PaymentMethod = "CARD"
+	BANK_TRANSFER PaymentMethod = "BANK_TRANSFER"
+	E_WALLET      PaymentMethod = "E_WALLET"
+	STORE_CREDIT  PaymentMethod = "STORE_CREDIT"
+)
+
+// Implement driver.Valuer interface for writing to database
+func (pm PaymentMethod) Value() (driver.Value, error) {
+	return string(pm), nil
+}
+
+// Implement sql.Scanner interface for reading from database
+func (pm *PaymentMethod) Scan(value interface{}) error {
+	if value == nil {
+		*pm = ""
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		*pm = PaymentMethod(v)
+	case []byte:
+		*pm = PaymentMethod(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PaymentMethod", value)
+	}
+
+	return nil
+}
+
+func (pm PaymentMethod) String() string {
+	switch pm {
+	case CARD:
+		return "CARD"
+	case BANK_TRANSFER:
+		return "BANK_TRANSFER"
+	case E_WALLET:
+		return "E_WALLET"
+	case STORE_CREDIT:
+		return "STORE_CREDIT"
+	default:
+		return "UNKNOWN"
+	}
+}
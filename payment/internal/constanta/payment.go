@@ -16,6 +16,10 @@ const (
 	FAILED PaymentStatus = "FAILED"
 	// if payment is expired or being cancelled directly by service
 	CANCELLED PaymentStatus = "CANCELLED"
+	// a part of the paid amount is paid back
+	PARTIALLY_REFUNDED PaymentStatus = "PARTIALLY_REFUNDED"
+	// the whole paid amount is paid back
+	REFUNDED PaymentStatus = "REFUNDED"
 )
 
 // Implement driver.Valuer interface for writing to database
@@ -54,6 +58,10 @@ func (ps PaymentStatus) String() string {
 		return "FAILED"
 	case CANCELLED:
 		return "CANCELLED"
+	case PARTIALLY_REFUNDED:
+		return "PARTIALLY_REFUNDED"
+	case REFUNDED:
+		return "REFUNDED"
 	default:
 		return "UNKNOWN"
 	}
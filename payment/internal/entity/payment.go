@@ -1,19 +1,100 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/constanta"
+	"github.com/elangreza/e-commerce/pkg/money"
 	"github.com/google/uuid"
 )
 
+// ErrRefundExceedsPayment is returned when the sum of the refunds is more than the paid amount
+var ErrRefundExceedsPayment = errors.New("refund exceeds the paid amount")
+
+// ErrPaymentExceedsOrder is returned when the waiting and paid payments of the order would be more than its total amount
+var ErrPaymentExceedsOrder = errors.New("payment exceeds the order amount")
+
 type Payment struct {
 	ID            uuid.UUID               `json:"id" db:"id"`
 	Status        constanta.PaymentStatus `json:"status" db:"status"`
 	TotalAmount   *gen.Money              `json:"total_amount" db:"total_amount"`
 	TransactionID string                  `json:"transaction_id" db:"transaction_id"` // Add this field
 	OrderID       string                  `json:"order_id" db:"order_id"`             // Link back to the order
-	CreatedAt     time.Time               `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time               `json:"updated_at" db:"updated_at"`
+	Method        constanta.PaymentMethod `json:"method" db:"method"`
+	// Provider is the name of the provider that charges the payment
+	Provider         string `json:"provider" db:"provider"`
+	ProviderChargeID string `json:"provider_charge_id" db:"provider_charge_id"`
+	PaymentURL       string `json:"payment_url" db:"payment_url"`
+	// RefundedAmount is the sum of every refund, it is not loaded with the expired payments
+	RefundedAmount *gen.Money `json:"refunded_amount" db:"-"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsPaid is true when the payment is captured, a refunded payment is still paid
+func (p Payment) IsPaid() bool {
+	switch p.Status {
+	case constanta.PAID, constanta.PARTIALLY_REFUNDED, constanta.REFUNDED:
+		return true
+	default:
+		return false
+	}
+}
+
+// PaidAmount sums the total amount of the paid payments
+func PaidAmount(payments []Payment, currency string) (*gen.Money, error) {
+	paidAmount, err := money.New(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payment := range payments {
+		if !payment.IsPaid() {
+			continue
+		}
+
+		paidAmount, err = money.Add(paidAmount, payment.TotalAmount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paidAmount, nil
+}
+
+// CallbackOutbox is the payment status change that must be sent to order service
+type CallbackOutbox struct {
+	ID            uuid.UUID                `json:"id" db:"id"`
+	TransactionID string                   `json:"transaction_id" db:"transaction_id"`
+	OrderID       string                   `json:"order_id" db:"order_id"`
+	PaymentStatus constanta.PaymentStatus  `json:"payment_status" db:"payment_status"`
+	Status        constanta.CallbackStatus `json:"status" db:"status"`
+	Attempt       int64                    `json:"attempt" db:"attempt"`
+	LastError     string                   `json:"last_error" db:"last_error"`
+	NextAttemptAt time.Time                `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time                `json:"created_at" db:"created_at"`
+}
+
+// Refund is the part of the paid amount that is paid back, the ID is given by the caller
+type Refund struct {
+	ID            string     `json:"id" db:"id"`
+	TransactionID string     `json:"transaction_id" db:"transaction_id"`
+	Amount        *gen.Money `json:"amount" db:"amount"`
+	Reason        string     `json:"reason" db:"reason"`
+	// OrderRefundID is the id of the refund of the order that is spread over its payments
+	OrderRefundID string    `json:"order_refund_id" db:"order_refund_id"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// WebhookEvent is the webhook that is handled, the same event of the provider is only handled once
+type WebhookEvent struct {
+	Provider      string                  `json:"provider" db:"provider"`
+	EventID       string                  `json:"event_id" db:"event_id"`
+	TransactionID string                  `json:"transaction_id" db:"transaction_id"`
+	Status        constanta.PaymentStatus `json:"status" db:"status"`
+	// Duplicate is true when the event is already handled before
+	Duplicate bool      `json:"duplicate" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
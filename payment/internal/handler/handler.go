@@ -2,29 +2,36 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/constanta"
+	"github.com/elangreza/e-commerce/payment/internal/entity"
 	"github.com/elangreza/e-commerce/pkg/money"
 	"github.com/go-chi/chi/v5"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+type paymentService interface {
+	gen.PaymentServiceServer
+	HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) (*entity.WebhookEvent, error)
+}
+
 type handler struct {
-	svc  gen.PaymentServiceServer
+	svc  paymentService
 	tmpl *template.Template
 }
 
 func NewHandler(
 	tmpl *template.Template,
 	publicRoute chi.Router,
-	svc gen.PaymentServiceServer,
+	svc paymentService,
 ) {
 
 	h := handler{
@@ -38,6 +45,8 @@ func NewHandler(
 	publicRoute.Get("/transactions/{transactionID}", h.detailGet)
 	publicRoute.Post("/transactions/{transactionID}", h.detailPost)
 
+	publicRoute.Post("/webhooks/{provider}", h.webhookPost)
+
 	publicRoute.NotFound(func(w http.ResponseWriter, r *http.Request) {
 		h.tmpl.ExecuteTemplate(w, "404.html", nil)
 	})
@@ -54,6 +63,7 @@ type PageData struct {
 type Transaction struct {
 	ID          string
 	TotalAmount string
+	Currency    string
 	Status      constanta.PaymentStatus
 	ExpiredAt   string
 	CreatedAt   string
@@ -100,6 +110,7 @@ func (h *handler) detailGet(w http.ResponseWriter, r *http.Request) {
 		Transaction: &Transaction{
 			ID:          id,
 			TotalAmount: total,
+			Currency:    payment.TotalAmount.GetCurrencyCode(),
 			Status:      constanta.PaymentStatus(payment.Status),
 			CreatedAt:   payment.CreatedAt,
 			ExpiredAt:   payment.ExpiredAt,
@@ -129,8 +140,9 @@ func (h *handler) detailPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the amount is entered in major unit of the currency of the payment
 	paymentStr := strings.TrimSpace(r.FormValue("payment_amount"))
-	amount, err := strconv.Atoi(paymentStr)
+	amount, err := money.FromMajorAmount(paymentStr, payment.TotalAmount.GetCurrencyCode())
 	if err != nil {
 		// Re-render with error
 		total, _ := money.ToMajorString(payment.TotalAmount) // safe since already validated in GET
@@ -138,6 +150,7 @@ func (h *handler) detailPost(w http.ResponseWriter, r *http.Request) {
 			Transaction: &Transaction{
 				ID:          id,
 				TotalAmount: total,
+				Currency:    payment.TotalAmount.GetCurrencyCode(),
 				Status:      constanta.WAITING,
 			},
 			Error: "Invalid payment amount",
@@ -148,7 +161,7 @@ func (h *handler) detailPost(w http.ResponseWriter, r *http.Request) {
 
 	_, err = h.svc.UpdatePayment(context.Background(), &gen.UpdatePaymentRequest{
 		TransactionId: id,
-		TotalAmount:   &gen.Money{Units: int64(amount), CurrencyCode: "IDR"},
+		TotalAmount:   amount,
 	})
 
 	if err != nil {
@@ -159,6 +172,7 @@ func (h *handler) detailPost(w http.ResponseWriter, r *http.Request) {
 			Transaction: &Transaction{
 				ID:          id,
 				TotalAmount: total,
+				Currency:    payment.TotalAmount.GetCurrencyCode(),
 				Status:      constanta.WAITING,
 			},
 			Error: "Failed to process payment",
@@ -171,6 +185,36 @@ func (h *handler) detailPost(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/transactions/"+id, http.StatusSeeOther)
 }
 
+// --- Webhook Handlers ---
+
+// webhookPost receives the status of the charge from the provider,
+// the provider retries the webhook until it is answered with 2xx
+func (h *handler) webhookPost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid body"})
+		return
+	}
+
+	event, err := h.svc.HandleWebhook(r.Context(), chi.URLParam(r, "provider"), r.Header, body)
+	if err != nil {
+		fmt.Println("err when handle webhook", err)
+		code := http.StatusInternalServerError
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			code = http.StatusUnauthorized
+		case codes.NotFound, codes.Unimplemented:
+			code = http.StatusNotFound
+		case codes.InvalidArgument:
+			code = http.StatusUnprocessableEntity
+		}
+		h.writeJSON(w, code, map[string]string{"error": status.Convert(err).Message()})
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, event)
+}
+
 // --- Helpers ---
 
 func (h *handler) handleDetailError(w http.ResponseWriter, id string, err error) {
@@ -189,6 +233,12 @@ func (h *handler) handleDetailError(w http.ResponseWriter, id string, err error)
 	})
 }
 
+func (h *handler) writeJSON(w http.ResponseWriter, code int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(data)
+}
+
 func (h *handler) renderError(w http.ResponseWriter, tmplName string, data interface{}) {
 	h.tmpl.ExecuteTemplate(w, tmplName, data)
 }
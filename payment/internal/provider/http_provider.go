@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elangreza/e-commerce/payment/internal/constanta"
+)
+
+const (
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+
+	defaultHTTPTimeout      = 10 * time.Second
+	defaultWebhookTolerance = 5 * time.Minute
+)
+
+type HTTPProviderConfig struct {
+	Name    string
+	BaseURL string
+	APIKey  string
+	// WebhookSecret is the key of the HMAC-SHA256 signature of the webhook
+	WebhookSecret string
+	Timeout       time.Duration
+	// WebhookTolerance is how old the webhook can be, the older webhook is refused as a replay
+	WebhookTolerance time.Duration
+	// Statuses maps the status of the charge into the status of the payment, the default statuses are used when it is empty
+	Statuses map[string]constanta.PaymentStatus
+}
+
+// defaultStatuses maps the common statuses of the providers,
+// the cancelled or expired charge is FAILED because order service only knows PAID and FAILED
+var defaultStatuses = map[string]constanta.PaymentStatus{
+	"PENDING":   constanta.WAITING,
+	"WAITING":   constanta.WAITING,
+	"PAID":      constanta.PAID,
+	"SUCCEEDED": constanta.PAID,
+	"CAPTURED":  constanta.PAID,
+	"FAILED":    constanta.FAILED,
+	"CANCELLED": constanta.FAILED,
+	"CANCELED":  constanta.FAILED,
+	"EXPIRED":   constanta.FAILED,
+}
+
+// HTTPProvider talks to the provider with the generic JSON API
+//
+//	POST {base_url}/charges      {"reference", "order_id", "amount", "currency", "method"} -> {"id", "status", "payment_url"}
+//	GET  {base_url}/charges/{id} -> {"id", "status"}
+//	POST {base_url}/charges/{id}/cancel -> {"id", "status"}
+//	POST {base_url}/charges/{id}/refunds {"reference", "amount", "currency", "reason"} -> {"id", "status"}
+//
+// The webhook is signed with hex(HMAC-SHA256(secret, timestamp + "." + body)),
+// the timestamp is the unix seconds that is sent in X-Webhook-Timestamp
+type HTTPProvider struct {
+	name             string
+	baseURL          string
+	apiKey           string
+	webhookSecret    []byte
+	webhookTolerance time.Duration
+	client           *http.Client
+	statuses         map[string]constanta.PaymentStatus
+	now              func() time.Time
+}
+
+func NewHTTPProvider(cfg HTTPProviderConfig) (*HTTPProvider, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("name of the provider is required")
+	}
+
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("base url of the provider %s is invalid", cfg.Name)
+	}
+
+	if cfg.WebhookSecret == "" {
+		return nil, fmt.Errorf("webhook secret of the provider %s is required", cfg.Name)
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	tolerance := cfg.WebhookTolerance
+	if tolerance <= 0 {
+		tolerance = defaultWebhookTolerance
+	}
+
+	statuses := defaultStatuses
+	if len(cfg.Statuses) > 0 {
+		statuses = make(map[string]constanta.PaymentStatus)
+		for chargeStatus, paymentStatus := range cfg.Statuses {
+			switch paymentStatus {
+			case constanta.WAITING, constanta.PAID, constanta.FAILED:
+			default:
+				return nil, fmt.Errorf("status %s of the provider %s must be mapped into %s, %s or %s", chargeStatus, cfg.Name, constanta.WAITING, constanta.PAID, constanta.FAILED)
+			}
+			statuses[strings.ToUpper(chargeStatus)] = paymentStatus
+		}
+	}
+
+	return &HTTPProvider{
+		name:             cfg.Name,
+		baseURL:          strings.TrimSuffix(baseURL.String(), "/"),
+		apiKey:           cfg.APIKey,
+		webhookSecret:    []byte(cfg.WebhookSecret),
+		webhookTolerance: tolerance,
+		client:           &http.Client{Timeout: timeout},
+		statuses:         statuses,
+		now:              time.Now,
+	}, nil
+}
+
+func (h *HTTPProvider) Name() string {
+	return h.name
+}
+
+type chargeRequest struct {
+	Reference string `json:"reference"`
+	OrderID   string `json:"order_id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Method    string `json:"method"`
+}
+
+type chargeResponse struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	PaymentURL string `json:"payment_url"`
+}
+
+// CreateCharge sends the transaction id as the idempotency key, creating the same charge again returns the first one
+func (h *HTTPProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	body, err := json.Marshal(chargeRequest{
+		Reference: req.TransactionID,
+		OrderID:   req.OrderID,
+		Amount:    req.Amount.GetUnits(),
+		Currency:  req.Amount.GetCurrencyCode(),
+		Method:    string(req.Method),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/charges", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", req.TransactionID)
+
+	var res chargeResponse
+	if err := h.do(httpReq, &res); err != nil {
+		return nil, err
+	}
+
+	if res.ID == "" {
+		return nil, fmt.Errorf("provider %s returns the charge without id", h.name)
+	}
+
+	chargeStatus, err := h.parseChargeStatus(res.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Charge{
+		ID:         res.ID,
+		Status:     chargeStatus,
+		PaymentURL: res.PaymentURL,
+	}, nil
+}
+
+func (h *HTTPProvider) GetChargeStatus(ctx context.Context, chargeID string) (constanta.PaymentStatus, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"/charges/"+url.PathEscape(chargeID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var res chargeResponse
+	if err := h.do(httpReq, &res); err != nil {
+		return "", err
+	}
+
+	return h.parseChargeStatus(res.Status)
+}
+
+// CancelCharge returns ErrChargePaid when the provider responds with the paid charge, the customer paid it before it is cancelled
+func (h *HTTPProvider) CancelCharge(ctx context.Context, chargeID string) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/charges/"+url.PathEscape(chargeID)+"/cancel", nil)
+	if err != nil {
+		return err
+	}
+
+	var res chargeResponse
+	if err := h.do(httpReq, &res); err != nil {
+		return err
+	}
+
+	chargeStatus, err := h.parseChargeStatus(res.Status)
+	if err != nil {
+		return err
+	}
+
+	switch chargeStatus {
+	case constanta.FAILED:
+		return nil
+	case constanta.PAID:
+		return fmt.Errorf("%w: charge %s of provider %s", ErrChargePaid, chargeID, h.name)
+	}
+
+	return fmt.Errorf("provider %s does not cancel charge %s, it is %s", h.name, chargeID, res.Status)
+}
+
+type refundRequest struct {
+	Reference string `json:"reference"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reason    string `json:"reason"`
+}
+
+type refundResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// RefundCharge sends the refund id as the idempotency key, refunding the same refund again returns the first one
+func (h *HTTPProvider) RefundCharge(ctx context.Context, req RefundRequest) error {
+	body, err := json.Marshal(refundRequest{
+		Reference: req.RefundID,
+		Amount:    req.Amount.GetUnits(),
+		Currency:  req.Amount.GetCurrencyCode(),
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.baseURL+"/charges/"+url.PathEscape(req.ChargeID)+"/refunds", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", req.RefundID)
+
+	var res refundResponse
+	if err := h.do(httpReq, &res); err != nil {
+		return err
+	}
+
+	if res.ID == "" {
+		return fmt.Errorf("provider %s returns the refund without id", h.name)
+	}
+
+	return nil
+}
+
+func (h *HTTPProvider) do(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call provider %s: %w", h.name, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response of provider %s: %w", h.name, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("provider %s responds %d: %s", h.name, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response of provider %s: %w", h.name, err)
+	}
+
+	return nil
+}
+
+type webhookPayload struct {
+	ID        string `json:"id"`
+	ChargeID  string `json:"charge_id"`
+	Reference string `json:"reference"`
+	Status    string `json:"status"`
+}
+
+// VerifyWebhook checks the signature before the body is read,
+// the webhook that is older than the tolerance is refused so a captured webhook cannot be replayed later
+func (h *HTTPProvider) VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	timestamp := header.Get(HeaderWebhookTimestamp)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid timestamp", ErrInvalidWebhook)
+	}
+
+	signature, err := hex.DecodeString(header.Get(HeaderWebhookSignature))
+	if err != nil || !hmac.Equal(signature, signWebhook(h.webhookSecret, timestamp, body)) {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidWebhook)
+	}
+
+	age := h.now().Sub(time.Unix(unix, 0))
+	if age > h.webhookTolerance || age < -h.webhookTolerance {
+		return nil, fmt.Errorf("%w: timestamp is outside the tolerance", ErrInvalidWebhook)
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	if payload.ID == "" || payload.Reference == "" {
+		return nil, fmt.Errorf("%w: id and reference are required", ErrInvalidWebhook)
+	}
+
+	chargeStatus, err := h.parseChargeStatus(payload.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	return &WebhookEvent{
+		ID:            payload.ID,
+		ChargeID:      payload.ChargeID,
+		TransactionID: payload.Reference,
+		Status:        chargeStatus,
+	}, nil
+}
+
+// SignWebhook returns the value of X-Webhook-Signature, it is used by the provider that sends the webhook
+func SignWebhook(secret, timestamp string, body []byte) string {
+	return hex.EncodeToString(signWebhook([]byte(secret), timestamp, body))
+}
+
+func signWebhook(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// parseChargeStatus maps the status of the charge into the status of the payment
+func (h *HTTPProvider) parseChargeStatus(s string) (constanta.PaymentStatus, error) {
+	paymentStatus, ok := h.statuses[strings.ToUpper(s)]
+	if !ok {
+		return "", fmt.Errorf("unknown charge status %q", s)
+	}
+
+	return paymentStatus, nil
+}
+
+// ParseStatuses reads the statuses of the charge from the format "requires_payment=WAITING,succeeded=PAID,canceled=FAILED"
+func ParseStatuses(s string) (map[string]constanta.PaymentStatus, error) {
+	statuses := make(map[string]constanta.PaymentStatus)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		chargeStatus, paymentStatus, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(chargeStatus) == "" {
+			return nil, fmt.Errorf("invalid status %q", pair)
+		}
+
+		statuses[strings.TrimSpace(chargeStatus)] = constanta.PaymentStatus(strings.ToUpper(strings.TrimSpace(paymentStatus)))
+	}
+
+	return statuses, nil
+}
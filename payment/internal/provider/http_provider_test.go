@@ -0,0 +1,348 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/payment/internal/constanta"
+	"github.com/elangreza/e-commerce/payment/internal/provider"
+	"github.com/elangreza/e-commerce/payment/internal/provider/providertest"
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPProviderTestSuite struct {
+	suite.Suite
+	stub     *providertest.Server
+	provider *provider.HTTPProvider
+}
+
+func (s *HTTPProviderTestSuite) SetupTest() {
+	s.stub = providertest.NewServer("api-key", "webhook-secret")
+
+	var err error
+	s.provider, err = provider.NewHTTPProvider(provider.HTTPProviderConfig{
+		Name:          "stub",
+		BaseURL:       s.stub.URL,
+		APIKey:        s.stub.APIKey,
+		WebhookSecret: s.stub.WebhookSecret,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *HTTPProviderTestSuite) TearDownTest() {
+	s.stub.Close()
+}
+
+func TestHTTPProviderSuite(t *testing.T) {
+	suite.Run(t, new(HTTPProviderTestSuite))
+}
+
+func (s *HTTPProviderTestSuite) createCharge() *provider.Charge {
+	charge, err := s.provider.CreateCharge(context.Background(), provider.ChargeRequest{
+		TransactionID: "TRX12345",
+		OrderID:       "order-1",
+		Amount:        &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+		Method:        constanta.E_WALLET,
+	})
+	s.Require().NoError(err)
+
+	return charge
+}
+
+func (s *HTTPProviderTestSuite) TestNewHTTPProvider() {
+	tests := []struct {
+		name          string
+		cfg           provider.HTTPProviderConfig
+		expectedError string
+	}{
+		{
+			name:          "Error name is required",
+			cfg:           provider.HTTPProviderConfig{BaseURL: "http://localhost", WebhookSecret: "secret"},
+			expectedError: "name of the provider is required",
+		},
+		{
+			name:          "Error invalid base url",
+			cfg:           provider.HTTPProviderConfig{Name: "stub", BaseURL: "localhost", WebhookSecret: "secret"},
+			expectedError: "base url of the provider stub is invalid",
+		},
+		{
+			name:          "Error webhook secret is required",
+			cfg:           provider.HTTPProviderConfig{Name: "stub", BaseURL: "http://localhost"},
+			expectedError: "webhook secret of the provider stub is required",
+		},
+		{
+			name: "Error status is mapped into unknown payment status",
+			cfg: provider.HTTPProviderConfig{
+				Name:          "stub",
+				BaseURL:       "http://localhost",
+				WebhookSecret: "secret",
+				Statuses:      map[string]constanta.PaymentStatus{"refunded": constanta.REFUNDED},
+			},
+			expectedError: "status refunded of the provider stub must be mapped into WAITING, PAID or FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := provider.NewHTTPProvider(tt.cfg)
+			s.EqualError(err, tt.expectedError)
+		})
+	}
+}
+
+func (s *HTTPProviderTestSuite) TestCreateCharge() {
+	s.Run("Success", func() {
+		charge := s.createCharge()
+
+		s.NotEmpty(charge.ID)
+		s.Equal(constanta.WAITING, charge.Status)
+		s.Equal(s.stub.URL+"/pay/"+charge.ID, charge.PaymentURL)
+
+		charges := s.stub.Charges()
+		s.Len(charges, 1)
+		s.Equal("TRX12345", charges[0].Reference)
+		s.Equal(int64(1000), charges[0].Amount)
+		s.Equal("IDR", charges[0].Currency)
+		s.Equal("E_WALLET", charges[0].Method)
+	})
+
+	s.Run("Success same transaction is charged once", func() {
+		first := s.createCharge()
+		second := s.createCharge()
+
+		s.Equal(first.ID, second.ID)
+		s.Len(s.stub.Charges(), 1)
+	})
+
+	s.Run("Error unauthorized", func() {
+		p, err := provider.NewHTTPProvider(provider.HTTPProviderConfig{
+			Name:          "stub",
+			BaseURL:       s.stub.URL,
+			APIKey:        "wrong",
+			WebhookSecret: s.stub.WebhookSecret,
+		})
+		s.Require().NoError(err)
+
+		_, err = p.CreateCharge(context.Background(), provider.ChargeRequest{
+			TransactionID: "TRX12345",
+			Amount:        &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+		})
+		s.ErrorContains(err, "provider stub responds 401")
+	})
+}
+
+func (s *HTTPProviderTestSuite) TestGetChargeStatus() {
+	charge := s.createCharge()
+
+	tests := []struct {
+		name           string
+		chargeID       string
+		status         string
+		expectedStatus constanta.PaymentStatus
+		expectedError  string
+	}{
+		{
+			name:           "Success pending",
+			chargeID:       charge.ID,
+			status:         "PENDING",
+			expectedStatus: constanta.WAITING,
+		},
+		{
+			name:           "Success paid",
+			chargeID:       charge.ID,
+			status:         "succeeded",
+			expectedStatus: constanta.PAID,
+		},
+		{
+			name:           "Success expired is failed",
+			chargeID:       charge.ID,
+			status:         "EXPIRED",
+			expectedStatus: constanta.FAILED,
+		},
+		{
+			name:          "Error unknown status",
+			chargeID:      charge.ID,
+			status:        "REVIEW",
+			expectedError: `unknown charge status "REVIEW"`,
+		},
+		{
+			name:          "Error charge not found",
+			chargeID:      "ch_404",
+			expectedError: "provider stub responds 404",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.stub.SetStatus(charge.ID, tt.status)
+
+			status, err := s.provider.GetChargeStatus(context.Background(), tt.chargeID)
+			if tt.expectedError != "" {
+				s.ErrorContains(err, tt.expectedError)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.expectedStatus, status)
+		})
+	}
+}
+
+func (s *HTTPProviderTestSuite) TestGetChargeStatusWithStatuses() {
+	statuses, err := provider.ParseStatuses("requires_payment=WAITING, succeeded=paid,canceled=FAILED")
+	s.Require().NoError(err)
+
+	p, err := provider.NewHTTPProvider(provider.HTTPProviderConfig{
+		Name:          "stub",
+		BaseURL:       s.stub.URL,
+		APIKey:        s.stub.APIKey,
+		WebhookSecret: s.stub.WebhookSecret,
+		Statuses:      statuses,
+	})
+	s.Require().NoError(err)
+
+	charge := s.createCharge()
+
+	s.stub.SetStatus(charge.ID, "succeeded")
+	status, err := p.GetChargeStatus(context.Background(), charge.ID)
+	s.NoError(err)
+	s.Equal(constanta.PAID, status)
+
+	// the default statuses are not used anymore
+	s.stub.SetStatus(charge.ID, "PAID")
+	_, err = p.GetChargeStatus(context.Background(), charge.ID)
+	s.EqualError(err, `unknown charge status "PAID"`)
+}
+
+func (s *HTTPProviderTestSuite) TestParseStatuses() {
+	statuses, err := provider.ParseStatuses("")
+	s.NoError(err)
+	s.Empty(statuses)
+
+	_, err = provider.ParseStatuses("succeeded")
+	s.EqualError(err, `invalid status "succeeded"`)
+}
+
+func (s *HTTPProviderTestSuite) TestCancelCharge() {
+	s.Run("Success", func() {
+		charge := s.createCharge()
+
+		err := s.provider.CancelCharge(context.Background(), charge.ID)
+		s.NoError(err)
+
+		status, err := s.provider.GetChargeStatus(context.Background(), charge.ID)
+		s.NoError(err)
+		s.Equal(constanta.FAILED, status)
+	})
+
+	s.Run("Error charge is paid before it is cancelled", func() {
+		charge := s.createCharge()
+		s.stub.SetStatus(charge.ID, "PAID")
+
+		err := s.provider.CancelCharge(context.Background(), charge.ID)
+		s.True(errors.Is(err, provider.ErrChargePaid))
+	})
+
+	s.Run("Error charge not found", func() {
+		err := s.provider.CancelCharge(context.Background(), "ch_404")
+		s.ErrorContains(err, "provider stub responds 404")
+	})
+}
+
+func (s *HTTPProviderTestSuite) TestRefundCharge() {
+	charge := s.createCharge()
+
+	refund := func(refundID string, units int64) error {
+		return s.provider.RefundCharge(context.Background(), provider.RefundRequest{
+			RefundID: refundID,
+			ChargeID: charge.ID,
+			Amount:   &gen.Money{Units: units, CurrencyCode: "IDR"},
+			Reason:   "order cancelled",
+		})
+	}
+
+	s.Run("Error charge is not paid", func() {
+		err := refund("refund-1", 400)
+		s.ErrorContains(err, "provider stub responds 409")
+	})
+
+	s.stub.SetStatus(charge.ID, "PAID")
+
+	s.Run("Success", func() {
+		err := refund("refund-1", 400)
+		s.NoError(err)
+
+		refunds := s.stub.Refunds()
+		s.Len(refunds, 1)
+		s.Equal(charge.ID, refunds[0].ChargeID)
+		s.Equal("refund-1", refunds[0].Reference)
+		s.Equal(int64(400), refunds[0].Amount)
+		s.Equal("IDR", refunds[0].Currency)
+		s.Equal("order cancelled", refunds[0].Reason)
+	})
+
+	s.Run("Success same refund is refunded once", func() {
+		err := refund("refund-1", 400)
+		s.NoError(err)
+		s.Len(s.stub.Refunds(), 1)
+	})
+
+	s.Run("Error refund is more than the charge", func() {
+		err := refund("refund-2", 700)
+		s.ErrorContains(err, "provider stub responds 409")
+	})
+}
+
+func (s *HTTPProviderTestSuite) TestVerifyWebhook() {
+	charge := s.createCharge()
+	s.stub.SetStatus(charge.ID, "PAID")
+
+	s.Run("Success", func() {
+		header, body := s.stub.Webhook("evt_1", charge.ID, time.Now())
+
+		event, err := s.provider.VerifyWebhook(header, body)
+		s.NoError(err)
+		s.Equal(&provider.WebhookEvent{
+			ID:            "evt_1",
+			ChargeID:      charge.ID,
+			TransactionID: "TRX12345",
+			Status:        constanta.PAID,
+		}, event)
+	})
+
+	s.Run("Error body is changed", func() {
+		header, _ := s.stub.Webhook("evt_1", charge.ID, time.Now())
+		_, body := s.stub.Webhook("evt_2", charge.ID, time.Now())
+
+		_, err := s.provider.VerifyWebhook(header, body)
+		s.True(errors.Is(err, provider.ErrInvalidWebhook))
+		s.ErrorContains(err, "invalid signature")
+	})
+
+	s.Run("Error signed with another secret", func() {
+		header, body := s.stub.Webhook("evt_1", charge.ID, time.Now())
+		header.Set(provider.HeaderWebhookSignature, provider.SignWebhook("other-secret", header.Get(provider.HeaderWebhookTimestamp), body))
+
+		_, err := s.provider.VerifyWebhook(header, body)
+		s.ErrorContains(err, "invalid signature")
+	})
+
+	s.Run("Error replayed after the tolerance", func() {
+		header, body := s.stub.Webhook("evt_1", charge.ID, time.Now().Add(-10*time.Minute))
+
+		_, err := s.provider.VerifyWebhook(header, body)
+		s.True(errors.Is(err, provider.ErrInvalidWebhook))
+		s.ErrorContains(err, "timestamp is outside the tolerance")
+	})
+
+	s.Run("Error missing timestamp", func() {
+		header, body := s.stub.Webhook("evt_1", charge.ID, time.Now())
+		header.Del(provider.HeaderWebhookTimestamp)
+
+		_, err := s.provider.VerifyWebhook(header, body)
+		s.ErrorContains(err, "invalid timestamp")
+	})
+}
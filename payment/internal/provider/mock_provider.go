@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/elangreza/e-commerce/payment/internal/constanta"
+)
+
+const MockProviderName = "mock"
+
+// MockProvider does not charge anyone, the payment waits until its amount is entered on the UI of the payment service
+type MockProvider struct{}
+
+func NewMockProvider() *MockProvider {
+	return &MockProvider{}
+}
+
+func (m *MockProvider) Name() string {
+	return MockProviderName
+}
+
+// CreateCharge uses the transaction id as the id of the charge
+func (m *MockProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	return &Charge{
+		ID:     req.TransactionID,
+		Status: constanta.WAITING,
+	}, nil
+}
+
+// GetChargeStatus is always WAITING, the status is only changed by the UI
+func (m *MockProvider) GetChargeStatus(ctx context.Context, chargeID string) (constanta.PaymentStatus, error) {
+	return constanta.WAITING, nil
+}
+
+// CancelCharge does nothing, the payment is only paid by the UI of the payment service
+func (m *MockProvider) CancelCharge(ctx context.Context, chargeID string) error {
+	return nil
+}
+
+// RefundCharge does nothing, there is no money to pay back
+func (m *MockProvider) RefundCharge(ctx context.Context, req RefundRequest) error {
+	return nil
+}
+
+func (m *MockProvider) VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	return nil, ErrWebhookNotSupported
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/payment/internal/constanta"
+)
+
+var (
+	// ErrInvalidWebhook is returned when the webhook is not signed by the provider or is too old to be accepted
+	ErrInvalidWebhook = errors.New("invalid webhook")
+	// ErrWebhookNotSupported is returned by the provider that does not send webhooks
+	ErrWebhookNotSupported = errors.New("webhook is not supported")
+	// ErrChargePaid is returned when the charge is paid before it is cancelled, the charge must be refunded instead
+	ErrChargePaid = errors.New("charge is already paid")
+)
+
+// PaymentProvider charges the customer on behalf of the payment service.
+// The status of the charge is either queried or notified by a webhook
+type PaymentProvider interface {
+	// Name is stored with the payment so the payment is always handled by the provider that charged it
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	GetChargeStatus(ctx context.Context, chargeID string) (constanta.PaymentStatus, error)
+	// CancelCharge stops the unpaid charge so the customer cannot pay it anymore, ErrChargePaid is returned when it is paid
+	CancelCharge(ctx context.Context, chargeID string) error
+	// RefundCharge pays back the amount of the paid charge, the provider refunds the same refund id only once
+	RefundCharge(ctx context.Context, req RefundRequest) error
+	// VerifyWebhook authenticates the webhook and returns the event it carries
+	VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+}
+
+type ChargeRequest struct {
+	// TransactionID is the reference of the charge, the provider charges the same transaction only once
+	TransactionID string
+	OrderID       string
+	Amount        *gen.Money
+	Method        constanta.PaymentMethod
+}
+
+type RefundRequest struct {
+	// RefundID is the reference of the refund, the provider refunds the same refund only once
+	RefundID string
+	ChargeID string
+	Amount   *gen.Money
+	Reason   string
+}
+
+type Charge struct {
+	ID     string
+	Status constanta.PaymentStatus
+	// PaymentURL is the page where the customer pays the charge, it is empty when the provider has no page
+	PaymentURL string
+}
+
+// WebhookEvent is the change of the status of a charge that is notified by the provider
+type WebhookEvent struct {
+	ID            string
+	ChargeID      string
+	TransactionID string
+	Status        constanta.PaymentStatus
+}
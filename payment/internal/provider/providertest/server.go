@@ -0,0 +1,246 @@
+// Package providertest runs a local provider that speaks the API of provider.HTTPProvider,
+// so the provider can be replaced in tests
+package providertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elangreza/e-commerce/payment/internal/provider"
+)
+
+type Charge struct {
+	ID        string `json:"id"`
+	Reference string `json:"reference"`
+	OrderID   string `json:"order_id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Method    string `json:"method"`
+	Status    string `json:"status"`
+}
+
+type Refund struct {
+	ID        string `json:"id"`
+	ChargeID  string `json:"charge_id"`
+	Reference string `json:"reference"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reason    string `json:"reason"`
+}
+
+type Server struct {
+	*httptest.Server
+	APIKey        string
+	WebhookSecret string
+
+	mu          sync.Mutex
+	charges     map[string]*Charge
+	byReference map[string]string
+	refunds     map[string]*Refund
+}
+
+// NewServer starts the provider, every request must be authorized with the api key
+func NewServer(apiKey, webhookSecret string) *Server {
+	s := &Server{
+		APIKey:        apiKey,
+		WebhookSecret: webhookSecret,
+		charges:       make(map[string]*Charge),
+		byReference:   make(map[string]string),
+		refunds:       make(map[string]*Refund),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /charges", s.createCharge)
+	mux.HandleFunc("GET /charges/{id}", s.getCharge)
+	mux.HandleFunc("POST /charges/{id}/cancel", s.cancelCharge)
+	mux.HandleFunc("POST /charges/{id}/refunds", s.refundCharge)
+	s.Server = httptest.NewServer(s.authorize(mux))
+
+	return s
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) createCharge(w http.ResponseWriter, r *http.Request) {
+	var charge Charge
+	if err := json.NewDecoder(r.Body).Decode(&charge); err != nil || charge.Reference == "" || charge.Amount <= 0 {
+		http.Error(w, `{"error":"invalid charge"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the same idempotency key returns the first charge
+	if id, ok := s.byReference[r.Header.Get("Idempotency-Key")]; ok {
+		s.writeCharge(w, s.charges[id])
+		return
+	}
+
+	charge.ID = fmt.Sprintf("ch_%d", len(s.charges)+1)
+	charge.Status = "PENDING"
+	s.charges[charge.ID] = &charge
+	s.byReference[charge.Reference] = charge.ID
+
+	w.WriteHeader(http.StatusCreated)
+	s.writeCharge(w, &charge)
+}
+
+func (s *Server) getCharge(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charge, ok := s.charges[r.PathValue("id")]
+	if !ok {
+		http.Error(w, `{"error":"charge not found"}`, http.StatusNotFound)
+		return
+	}
+
+	s.writeCharge(w, charge)
+}
+
+// cancelCharge keeps the paid charge as it is, the caller sees that it must be refunded
+func (s *Server) cancelCharge(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charge, ok := s.charges[r.PathValue("id")]
+	if !ok {
+		http.Error(w, `{"error":"charge not found"}`, http.StatusNotFound)
+		return
+	}
+
+	if charge.Status != "PAID" {
+		charge.Status = "CANCELLED"
+	}
+
+	s.writeCharge(w, charge)
+}
+
+func (s *Server) refundCharge(w http.ResponseWriter, r *http.Request) {
+	var refund Refund
+	if err := json.NewDecoder(r.Body).Decode(&refund); err != nil || refund.Reference == "" || refund.Amount <= 0 {
+		http.Error(w, `{"error":"invalid refund"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the same idempotency key returns the first refund
+	if first, ok := s.refunds[r.Header.Get("Idempotency-Key")]; ok {
+		s.writeRefund(w, first)
+		return
+	}
+
+	charge, ok := s.charges[r.PathValue("id")]
+	if !ok {
+		http.Error(w, `{"error":"charge not found"}`, http.StatusNotFound)
+		return
+	}
+
+	refunded := refund.Amount
+	for _, other := range s.refunds {
+		if other.ChargeID == charge.ID {
+			refunded += other.Amount
+		}
+	}
+
+	if charge.Status != "PAID" || refunded > charge.Amount {
+		http.Error(w, `{"error":"charge cannot be refunded"}`, http.StatusConflict)
+		return
+	}
+
+	refund.ID = fmt.Sprintf("re_%d", len(s.refunds)+1)
+	refund.ChargeID = charge.ID
+	s.refunds[refund.Reference] = &refund
+
+	w.WriteHeader(http.StatusCreated)
+	s.writeRefund(w, &refund)
+}
+
+func (s *Server) writeRefund(w http.ResponseWriter, refund *Refund) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"id":     refund.ID,
+		"status": "SUCCEEDED",
+	})
+}
+
+func (s *Server) writeCharge(w http.ResponseWriter, charge *Charge) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"id":          charge.ID,
+		"status":      charge.Status,
+		"payment_url": s.URL + "/pay/" + charge.ID,
+	})
+}
+
+// Charges returns every created charge
+func (s *Server) Charges() []Charge {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charges := []Charge{}
+	for _, charge := range s.charges {
+		charges = append(charges, *charge)
+	}
+
+	return charges
+}
+
+// Refunds returns every refund of the charges
+func (s *Server) Refunds() []Refund {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refunds := []Refund{}
+	for _, refund := range s.refunds {
+		refunds = append(refunds, *refund)
+	}
+
+	return refunds
+}
+
+// SetStatus changes the status of the charge as if the customer paid or abandoned it
+func (s *Server) SetStatus(chargeID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if charge, ok := s.charges[chargeID]; ok {
+		charge.Status = status
+	}
+}
+
+// Webhook returns the signed notification about the current status of the charge, sent at the given time
+func (s *Server) Webhook(eventID, chargeID string, at time.Time) (http.Header, []byte) {
+	s.mu.Lock()
+	charge := s.charges[chargeID]
+	s.mu.Unlock()
+
+	payload := map[string]string{"id": eventID, "charge_id": chargeID}
+	if charge != nil {
+		payload["reference"] = charge.Reference
+		payload["status"] = charge.Status
+	}
+	body, _ := json.Marshal(payload)
+
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(provider.HeaderWebhookTimestamp, timestamp)
+	header.Set(provider.HeaderWebhookSignature, provider.SignWebhook(s.WebhookSecret, timestamp, body))
+
+	return header, body
+}
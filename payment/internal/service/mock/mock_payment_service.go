@@ -14,8 +14,10 @@ import (
 	reflect "reflect"
 	time "time"
 
+	gen "github.com/elangreza/e-commerce/gen"
 	constanta "github.com/elangreza/e-commerce/payment/internal/constanta"
 	entity "github.com/elangreza/e-commerce/payment/internal/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,18 +45,60 @@ func (m *MockpaymentRepo) EXPECT() *MockpaymentRepoMockRecorder {
 	return m.recorder
 }
 
+// CancelPayment mocks base method.
+func (m *MockpaymentRepo) CancelPayment(ctx context.Context, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPayment", ctx, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPayment indicates an expected call of CancelPayment.
+func (mr *MockpaymentRepoMockRecorder) CancelPayment(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPayment", reflect.TypeOf((*MockpaymentRepo)(nil).CancelPayment), ctx, transactionID)
+}
+
 // CreatePayment mocks base method.
-func (m *MockpaymentRepo) CreatePayment(ctx context.Context, payment entity.Payment) error {
+func (m *MockpaymentRepo) CreatePayment(ctx context.Context, payment entity.Payment, orderAmount *gen.Money) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment, orderAmount)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePayment indicates an expected call of CreatePayment.
-func (mr *MockpaymentRepoMockRecorder) CreatePayment(ctx, payment any) *gomock.Call {
+func (mr *MockpaymentRepoMockRecorder) CreatePayment(ctx, payment, orderAmount any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockpaymentRepo)(nil).CreatePayment), ctx, payment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockpaymentRepo)(nil).CreatePayment), ctx, payment, orderAmount)
+}
+
+// CreateWebhookEvent mocks base method.
+func (m *MockpaymentRepo) CreateWebhookEvent(ctx context.Context, event entity.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookEvent indicates an expected call of CreateWebhookEvent.
+func (mr *MockpaymentRepoMockRecorder) CreateWebhookEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEvent", reflect.TypeOf((*MockpaymentRepo)(nil).CreateWebhookEvent), ctx, event)
+}
+
+// FailCallback mocks base method.
+func (m *MockpaymentRepo) FailCallback(ctx context.Context, id uuid.UUID, status constanta.CallbackStatus, errMessage string, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailCallback", ctx, id, status, errMessage, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailCallback indicates an expected call of FailCallback.
+func (mr *MockpaymentRepoMockRecorder) FailCallback(ctx, id, status, errMessage, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailCallback", reflect.TypeOf((*MockpaymentRepo)(nil).FailCallback), ctx, id, status, errMessage, nextAttemptAt)
 }
 
 // GetExpiredPayments mocks base method.
@@ -87,6 +131,108 @@ func (mr *MockpaymentRepoMockRecorder) GetPaymentByTransactionID(ctx, transactio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByTransactionID", reflect.TypeOf((*MockpaymentRepo)(nil).GetPaymentByTransactionID), ctx, transactionID)
 }
 
+// GetPaymentsByOrderID mocks base method.
+func (m *MockpaymentRepo) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentsByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentsByOrderID indicates an expected call of GetPaymentsByOrderID.
+func (mr *MockpaymentRepoMockRecorder) GetPaymentsByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentsByOrderID", reflect.TypeOf((*MockpaymentRepo)(nil).GetPaymentsByOrderID), ctx, orderID)
+}
+
+// GetPendingCallbacks mocks base method.
+func (m *MockpaymentRepo) GetPendingCallbacks(ctx context.Context, now time.Time, limit int) ([]entity.CallbackOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingCallbacks", ctx, now, limit)
+	ret0, _ := ret[0].([]entity.CallbackOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingCallbacks indicates an expected call of GetPendingCallbacks.
+func (mr *MockpaymentRepoMockRecorder) GetPendingCallbacks(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingCallbacks", reflect.TypeOf((*MockpaymentRepo)(nil).GetPendingCallbacks), ctx, now, limit)
+}
+
+// GetRefunds mocks base method.
+func (m *MockpaymentRepo) GetRefunds(ctx context.Context, refundID string) ([]entity.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefunds", ctx, refundID)
+	ret0, _ := ret[0].([]entity.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefunds indicates an expected call of GetRefunds.
+func (mr *MockpaymentRepoMockRecorder) GetRefunds(ctx, refundID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefunds", reflect.TypeOf((*MockpaymentRepo)(nil).GetRefunds), ctx, refundID)
+}
+
+// GetWebhookEvent mocks base method.
+func (m *MockpaymentRepo) GetWebhookEvent(ctx context.Context, provider, eventID string) (*entity.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEvent", ctx, provider, eventID)
+	ret0, _ := ret[0].(*entity.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEvent indicates an expected call of GetWebhookEvent.
+func (mr *MockpaymentRepoMockRecorder) GetWebhookEvent(ctx, provider, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEvent", reflect.TypeOf((*MockpaymentRepo)(nil).GetWebhookEvent), ctx, provider, eventID)
+}
+
+// MarkCallbackDelivered mocks base method.
+func (m *MockpaymentRepo) MarkCallbackDelivered(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCallbackDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCallbackDelivered indicates an expected call of MarkCallbackDelivered.
+func (mr *MockpaymentRepoMockRecorder) MarkCallbackDelivered(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCallbackDelivered", reflect.TypeOf((*MockpaymentRepo)(nil).MarkCallbackDelivered), ctx, id)
+}
+
+// RefundOrder mocks base method.
+func (m *MockpaymentRepo) RefundOrder(ctx context.Context, orderID string, refund entity.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", ctx, orderID, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockpaymentRepoMockRecorder) RefundOrder(ctx, orderID, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockpaymentRepo)(nil).RefundOrder), ctx, orderID, refund)
+}
+
+// RefundPayment mocks base method.
+func (m *MockpaymentRepo) RefundPayment(ctx context.Context, refund entity.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", ctx, refund)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockpaymentRepoMockRecorder) RefundPayment(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockpaymentRepo)(nil).RefundPayment), ctx, refund)
+}
+
 // UpdatePaymentStatusByTransactionID mocks base method.
 func (m *MockpaymentRepo) UpdatePaymentStatusByTransactionID(ctx context.Context, paymentStatus constanta.PaymentStatus, transactionID string) error {
 	m.ctrl.T.Helper()
@@ -100,3 +246,17 @@ func (mr *MockpaymentRepoMockRecorder) UpdatePaymentStatusByTransactionID(ctx, p
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatusByTransactionID", reflect.TypeOf((*MockpaymentRepo)(nil).UpdatePaymentStatusByTransactionID), ctx, paymentStatus, transactionID)
 }
+
+// UpdatePaymentStatusWithCallback mocks base method.
+func (m *MockpaymentRepo) UpdatePaymentStatusWithCallback(ctx context.Context, paymentStatus constanta.PaymentStatus, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatusWithCallback", ctx, paymentStatus, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentStatusWithCallback indicates an expected call of UpdatePaymentStatusWithCallback.
+func (mr *MockpaymentRepoMockRecorder) UpdatePaymentStatusWithCallback(ctx, paymentStatus, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatusWithCallback", reflect.TypeOf((*MockpaymentRepo)(nil).UpdatePaymentStatusWithCallback), ctx, paymentStatus, transactionID)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/e-commerce/payment/internal/provider (interfaces: PaymentProvider)
+//
+// Generated by this command:
+//
+//	mockgen -package=mock -destination=mock/mock_provider.go github.com/elangreza/e-commerce/payment/internal/provider PaymentProvider
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	constanta "github.com/elangreza/e-commerce/payment/internal/constanta"
+	provider "github.com/elangreza/e-commerce/payment/internal/provider"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentProvider is a mock of PaymentProvider interface.
+type MockPaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentProviderMockRecorder is the mock recorder for MockPaymentProvider.
+type MockPaymentProviderMockRecorder struct {
+	mock *MockPaymentProvider
+}
+
+// NewMockPaymentProvider creates a new mock instance.
+func NewMockPaymentProvider(ctrl *gomock.Controller) *MockPaymentProvider {
+	mock := &MockPaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProvider) EXPECT() *MockPaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// CancelCharge mocks base method.
+func (m *MockPaymentProvider) CancelCharge(ctx context.Context, chargeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCharge", ctx, chargeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelCharge indicates an expected call of CancelCharge.
+func (mr *MockPaymentProviderMockRecorder) CancelCharge(ctx, chargeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCharge", reflect.TypeOf((*MockPaymentProvider)(nil).CancelCharge), ctx, chargeID)
+}
+
+// CreateCharge mocks base method.
+func (m *MockPaymentProvider) CreateCharge(ctx context.Context, req provider.ChargeRequest) (*provider.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCharge", ctx, req)
+	ret0, _ := ret[0].(*provider.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCharge indicates an expected call of CreateCharge.
+func (mr *MockPaymentProviderMockRecorder) CreateCharge(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCharge", reflect.TypeOf((*MockPaymentProvider)(nil).CreateCharge), ctx, req)
+}
+
+// GetChargeStatus mocks base method.
+func (m *MockPaymentProvider) GetChargeStatus(ctx context.Context, chargeID string) (constanta.PaymentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargeStatus", ctx, chargeID)
+	ret0, _ := ret[0].(constanta.PaymentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChargeStatus indicates an expected call of GetChargeStatus.
+func (mr *MockPaymentProviderMockRecorder) GetChargeStatus(ctx, chargeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargeStatus", reflect.TypeOf((*MockPaymentProvider)(nil).GetChargeStatus), ctx, chargeID)
+}
+
+// Name mocks base method.
+func (m *MockPaymentProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockPaymentProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockPaymentProvider)(nil).Name))
+}
+
+// RefundCharge mocks base method.
+func (m *MockPaymentProvider) RefundCharge(ctx context.Context, req provider.RefundRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundCharge", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundCharge indicates an expected call of RefundCharge.
+func (mr *MockPaymentProviderMockRecorder) RefundCharge(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundCharge", reflect.TypeOf((*MockPaymentProvider)(nil).RefundCharge), ctx, req)
+}
+
+// VerifyWebhook mocks base method.
+func (m *MockPaymentProvider) VerifyWebhook(header http.Header, body []byte) (*provider.WebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebhook", header, body)
+	ret0, _ := ret[0].(*provider.WebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWebhook indicates an expected call of VerifyWebhook.
+func (mr *MockPaymentProviderMockRecorder) VerifyWebhook(header, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebhook", reflect.TypeOf((*MockPaymentProvider)(nil).VerifyWebhook), header, body)
+}
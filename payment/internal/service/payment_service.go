@@ -7,24 +7,42 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/constanta"
 	"github.com/elangreza/e-commerce/payment/internal/entity"
+	"github.com/elangreza/e-commerce/payment/internal/provider"
+	"github.com/elangreza/e-commerce/pkg/money"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 //go:generate mockgen -source=payment_service.go -destination=mock/mock_payment_service.go -package=mock
 //go:generate mockgen -package=mock -destination=mock/mock_deps.go github.com/elangreza/e-commerce/gen OrderServiceClient
+//go:generate mockgen -package=mock -destination=mock/mock_provider.go github.com/elangreza/e-commerce/payment/internal/provider PaymentProvider
 
 type (
 	paymentRepo interface {
-		CreatePayment(ctx context.Context, payment entity.Payment) error
+		CreatePayment(ctx context.Context, payment entity.Payment, orderAmount *gen.Money) error
 		UpdatePaymentStatusByTransactionID(ctx context.Context, paymentStatus constanta.PaymentStatus, transactionID string) error
 		GetPaymentByTransactionID(ctx context.Context, transactionID string) (*entity.Payment, error)
 		GetExpiredPayments(ctx context.Context, duration time.Duration) ([]entity.Payment, error)
+		UpdatePaymentStatusWithCallback(ctx context.Context, paymentStatus constanta.PaymentStatus, transactionID string) error
+		GetPendingCallbacks(ctx context.Context, now time.Time, limit int) ([]entity.CallbackOutbox, error)
+		MarkCallbackDelivered(ctx context.Context, id uuid.UUID) error
+		FailCallback(ctx context.Context, id uuid.UUID, status constanta.CallbackStatus, errMessage string, nextAttemptAt time.Time) error
+		RefundPayment(ctx context.Context, refund entity.Refund) error
+		GetWebhookEvent(ctx context.Context, provider, eventID string) (*entity.WebhookEvent, error)
+		CreateWebhookEvent(ctx context.Context, event entity.WebhookEvent) error
+		GetPaymentsByOrderID(ctx context.Context, orderID string) ([]entity.Payment, error)
+		CancelPayment(ctx context.Context, transactionID string) error
+		RefundOrder(ctx context.Context, orderID string, refund entity.Refund) error
+		GetRefunds(ctx context.Context, refundID string) ([]entity.Refund, error)
 	}
 )
 
@@ -32,6 +50,7 @@ type PaymentService struct {
 	paymentRepo        paymentRepo
 	maxTimeToBeExpired time.Duration
 	orderService       gen.OrderServiceClient
+	paymentProvider    provider.PaymentProvider
 	gen.UnimplementedPaymentServiceServer
 }
 
@@ -39,23 +58,78 @@ func NewPaymentService(
 	paymentRepo paymentRepo,
 	maxTimeToBeExpired time.Duration,
 	orderService gen.OrderServiceClient,
+	paymentProvider provider.PaymentProvider,
 ) *PaymentService {
 	return &PaymentService{
 		paymentRepo:        paymentRepo,
 		maxTimeToBeExpired: maxTimeToBeExpired,
 		orderService:       orderService,
+		paymentProvider:    paymentProvider,
 	}
 }
 
+// ProcessPayment charges the amount with the provider, the payment waits until the charge is paid.
+// The order can be paid by several payments, every payment is its own transaction.
+// The waiting and paid payments of the order cannot be more than the order amount,
+// the charge of the payment that loses against another payment of the same order is cancelled
 func (p *PaymentService) ProcessPayment(ctx context.Context, req *gen.ProcessPaymentRequest) (*gen.ProcessPaymentResponse, error) {
+	if req.GetTotalAmount().GetUnits() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "total_amount must be greater than 0")
+	}
+
+	if req.GetOrderAmount().GetUnits() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_amount must be greater than 0")
+	}
+
+	if req.TotalAmount.GetCurrencyCode() != req.OrderAmount.GetCurrencyCode() {
+		return nil, status.Error(codes.InvalidArgument, "currency code not match")
+	}
+
+	if req.TotalAmount.GetUnits() > req.OrderAmount.GetUnits() {
+		return nil, status.Error(codes.InvalidArgument, "total_amount cannot be more than order_amount")
+	}
+
+	method := constanta.CARD
+	if req.GetMethod() != "" {
+		method = constanta.PaymentMethod(strings.ToUpper(req.GetMethod()))
+		switch method {
+		case constanta.CARD, constanta.BANK_TRANSFER, constanta.E_WALLET, constanta.STORE_CREDIT:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown payment method %s", req.GetMethod())
+		}
+	}
+
 	transactionID := generateBase62ID(defaultLength)
-	err := p.paymentRepo.CreatePayment(ctx, entity.Payment{
-		Status:        constanta.WAITING,
-		TotalAmount:   req.TotalAmount,
+	charge, err := p.paymentProvider.CreateCharge(ctx, provider.ChargeRequest{
 		TransactionID: transactionID,
 		OrderID:       req.OrderId,
+		Amount:        req.TotalAmount,
+		Method:        method,
 	})
 	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to create charge: %v", err)
+	}
+
+	payment := entity.Payment{
+		Status:           constanta.WAITING,
+		TotalAmount:      req.TotalAmount,
+		TransactionID:    transactionID,
+		OrderID:          req.OrderId,
+		Method:           method,
+		Provider:         p.paymentProvider.Name(),
+		ProviderChargeID: charge.ID,
+		PaymentURL:       charge.PaymentURL,
+	}
+	err = p.paymentRepo.CreatePayment(ctx, payment, req.OrderAmount)
+	if err != nil {
+		// the charge is never stored, the customer must not be able to pay it
+		if cancelErr := p.cancelCharge(ctx, payment); cancelErr != nil {
+			fmt.Printf("err when cancel charge of transaction %s: %v\n", transactionID, cancelErr)
+		}
+
+		if errors.Is(err, entity.ErrPaymentExceedsOrder) {
+			return nil, status.Errorf(codes.FailedPrecondition, "order %s is already paid or waiting for its payments", req.OrderId)
+		}
 		return nil, err
 	}
 
@@ -65,6 +139,10 @@ func (p *PaymentService) ProcessPayment(ctx context.Context, req *gen.ProcessPay
 }
 
 func (p *PaymentService) RollbackPayment(ctx context.Context, req *gen.RollbackPaymentRequest) (*gen.Empty, error) {
+	if req.GetOrderId() != "" {
+		return p.rollbackOrderPayments(ctx, req.OrderId, req.Reason)
+	}
+
 	payment, err := p.paymentRepo.GetPaymentByTransactionID(ctx, req.TransactionId)
 	if err != nil {
 		return nil, err
@@ -74,6 +152,19 @@ func (p *PaymentService) RollbackPayment(ctx context.Context, req *gen.RollbackP
 		return nil, fmt.Errorf("payment must be waiting rollback the payment")
 	}
 
+	err = p.cancelCharge(ctx, *payment)
+	if err != nil {
+		if !errors.Is(err, provider.ErrChargePaid) {
+			return nil, status.Errorf(codes.Unavailable, "failed to cancel charge: %v", err)
+		}
+
+		// the customer paid the charge while it is cancelled, the order is notified that it is paid
+		if _, err := p.settlePayment(ctx, payment.TransactionID, constanta.PAID); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err.Error())
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "payment %s is paid before it is cancelled", payment.TransactionID)
+	}
+
 	err = p.paymentRepo.UpdatePaymentStatusByTransactionID(ctx, constanta.CANCELLED, req.TransactionId)
 	if err != nil {
 		return nil, err
@@ -82,6 +173,118 @@ func (p *PaymentService) RollbackPayment(ctx context.Context, req *gen.RollbackP
 	return &gen.Empty{}, nil
 }
 
+// rollbackOrderPayments cancels the WAITING payments of the order and refunds the paid ones with the provider,
+// the refund id is derived from the transaction so the rollback can be retried
+func (p *PaymentService) rollbackOrderPayments(ctx context.Context, orderID, reason string) (*gen.Empty, error) {
+	payments, err := p.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	for _, payment := range payments {
+		if payment.Status == constanta.WAITING {
+			err = p.cancelCharge(ctx, payment)
+			switch {
+			case errors.Is(err, provider.ErrChargePaid):
+				// the customer paid the charge while it is cancelled, it is refunded below
+				_, err = p.settlePayment(ctx, payment.TransactionID, constanta.PAID)
+			case err != nil:
+				return nil, status.Errorf(codes.Unavailable, "failed to cancel charge: %v", err)
+			default:
+				err = p.paymentRepo.CancelPayment(ctx, payment.TransactionID)
+				if err == nil {
+					continue
+				}
+			}
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.Internal, "%s", err.Error())
+			}
+
+			// the payment is settled while it is cancelled
+			settled, err := p.paymentRepo.GetPaymentByTransactionID(ctx, payment.TransactionID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s", err.Error())
+			}
+			payment = *settled
+		}
+
+		// the refunded payment is checked too, the refund of the rollback may be recorded without being sent to the provider
+		if !payment.IsPaid() {
+			continue
+		}
+
+		refundID := "rollback:" + payment.TransactionID
+		if payment.Status != constanta.REFUNDED {
+			amount, err := money.Subtract(payment.TotalAmount, payment.RefundedAmount)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s", err.Error())
+			}
+
+			err = p.paymentRepo.RefundPayment(ctx, entity.Refund{
+				ID:            refundID,
+				TransactionID: payment.TransactionID,
+				Amount:        amount,
+				Reason:        reason,
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s", err.Error())
+			}
+		}
+
+		err = p.refundCharges(ctx, []entity.Payment{payment}, refundID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &gen.Empty{}, nil
+}
+
+// cancelCharge stops the charge of the WAITING payment so the customer cannot pay it after it is cancelled
+func (p *PaymentService) cancelCharge(ctx context.Context, payment entity.Payment) error {
+	if payment.Provider != p.paymentProvider.Name() {
+		return fmt.Errorf("provider %s of transaction %s is not configured", payment.Provider, payment.TransactionID)
+	}
+
+	return p.paymentProvider.CancelCharge(ctx, payment.ProviderChargeID)
+}
+
+// refundCharges pays back the recorded refunds of the refund id with the provider that charged their payments.
+// The refund is recorded before so it is never more than the payment, the provider refunds the same refund only once
+// so the refund that fails here is sent again when the caller retries it
+func (p *PaymentService) refundCharges(ctx context.Context, payments []entity.Payment, refundID string) error {
+	refunds, err := p.paymentRepo.GetRefunds(ctx, refundID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	for _, refund := range refunds {
+		idx := slices.IndexFunc(payments, func(payment entity.Payment) bool {
+			return payment.TransactionID == refund.TransactionID
+		})
+		if idx < 0 {
+			return status.Errorf(codes.Internal, "payment of refund %s not found", refund.ID)
+		}
+
+		payment := payments[idx]
+		if payment.Provider != p.paymentProvider.Name() {
+			return status.Errorf(codes.Unavailable, "provider %s of transaction %s is not configured", payment.Provider, payment.TransactionID)
+		}
+
+		err = p.paymentProvider.RefundCharge(ctx, provider.RefundRequest{
+			RefundID: refund.ID,
+			ChargeID: payment.ProviderChargeID,
+			Amount:   refund.Amount,
+			Reason:   refund.Reason,
+		})
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to refund charge: %v", err)
+		}
+	}
+
+	return nil
+}
+
 const (
 	defaultLength = 8
 	base62Chars   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
@@ -105,12 +308,183 @@ func (p *PaymentService) GetPayment(ctx context.Context, req *gen.GetPaymentRequ
 		return nil, status.Errorf(codes.Internal, "%s", err.Error())
 	}
 
+	return p.getGenPayment(*payment), nil
+}
+
+// ListPayments returns every payment of the order, the order is paid when the paid amount covers its total amount
+func (p *PaymentService) ListPayments(ctx context.Context, req *gen.ListPaymentsRequest) (*gen.ListPaymentsResponse, error) {
+	if req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	payments, err := p.paymentRepo.GetPaymentsByOrderID(ctx, req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	res := &gen.ListPaymentsResponse{
+		Payments: []*gen.GetPaymentResponse{},
+	}
+	for _, payment := range payments {
+		res.Payments = append(res.Payments, p.getGenPayment(payment))
+	}
+
+	if len(payments) > 0 {
+		res.PaidAmount, err = entity.PaidAmount(payments, payments[0].TotalAmount.GetCurrencyCode())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err.Error())
+		}
+	}
+
+	return res, nil
+}
+
+func (p *PaymentService) getGenPayment(payment entity.Payment) *gen.GetPaymentResponse {
 	return &gen.GetPaymentResponse{
-		TransactionId: payment.TransactionID,
-		Status:        string(payment.Status),
-		CreatedAt:     payment.CreatedAt.Format(time.DateTime),
-		ExpiredAt:     payment.CreatedAt.Add(p.maxTimeToBeExpired).Format(time.DateTime),
-		TotalAmount:   payment.TotalAmount,
+		TransactionId:  payment.TransactionID,
+		Status:         string(payment.Status),
+		CreatedAt:      payment.CreatedAt.Format(time.DateTime),
+		ExpiredAt:      payment.CreatedAt.Add(p.maxTimeToBeExpired).Format(time.DateTime),
+		TotalAmount:    payment.TotalAmount,
+		RefundedAmount: payment.RefundedAmount,
+		PaymentUrl:     payment.PaymentURL,
+		Method:         string(payment.Method),
+		OrderId:        payment.OrderID,
+	}
+}
+
+// RefundPayment pays back the whole or a part of the paid payment.
+// The refund with the same id is only paid back once, so the caller can retry it
+func (p *PaymentService) RefundPayment(ctx context.Context, req *gen.RefundPaymentRequest) (*gen.RefundPaymentResponse, error) {
+	if req.GetRefundId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "refund_id is required")
+	}
+
+	if req.GetAmount().GetUnits() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be greater than 0")
+	}
+
+	if req.GetOrderId() != "" {
+		return p.refundOrder(ctx, req)
+	}
+
+	payment, err := p.paymentRepo.GetPaymentByTransactionID(ctx, req.TransactionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "transaction not found")
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	switch payment.Status {
+	case constanta.PAID, constanta.PARTIALLY_REFUNDED, constanta.REFUNDED:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "payment with status %s cannot be refunded", payment.Status)
+	}
+
+	if payment.TotalAmount.GetCurrencyCode() != req.Amount.GetCurrencyCode() {
+		return nil, status.Error(codes.InvalidArgument, "currency code not match")
+	}
+
+	err = p.paymentRepo.RefundPayment(ctx, entity.Refund{
+		ID:            req.RefundId,
+		TransactionID: req.TransactionId,
+		Amount:        req.Amount,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrRefundExceedsPayment) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	err = p.refundCharges(ctx, []entity.Payment{*payment}, req.RefundId)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err = p.paymentRepo.GetPaymentByTransactionID(ctx, req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	return &gen.RefundPaymentResponse{
+		RefundId:       req.RefundId,
+		Status:         string(payment.Status),
+		RefundedAmount: payment.RefundedAmount,
+	}, nil
+}
+
+// refundOrder spreads the refund over the paid payments of the order
+func (p *PaymentService) refundOrder(ctx context.Context, req *gen.RefundPaymentRequest) (*gen.RefundPaymentResponse, error) {
+	payments, err := p.paymentRepo.GetPaymentsByOrderID(ctx, req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	paid := false
+	for _, payment := range payments {
+		if !payment.IsPaid() {
+			continue
+		}
+		if payment.TotalAmount.GetCurrencyCode() != req.Amount.GetCurrencyCode() {
+			return nil, status.Error(codes.InvalidArgument, "currency code not match")
+		}
+		paid = true
+	}
+
+	if !paid {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s has no paid payment to be refunded", req.OrderId)
+	}
+
+	err = p.paymentRepo.RefundOrder(ctx, req.OrderId, entity.Refund{
+		ID:     req.RefundId,
+		Amount: req.Amount,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrRefundExceedsPayment) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	err = p.refundCharges(ctx, payments, req.RefundId)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err = p.paymentRepo.GetPaymentsByOrderID(ctx, req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	refundedAmount, err := money.New(0, req.Amount.GetCurrencyCode())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	paymentStatus := constanta.REFUNDED
+	for _, payment := range payments {
+		if !payment.IsPaid() {
+			continue
+		}
+
+		refundedAmount, err = money.Add(refundedAmount, payment.RefundedAmount)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err.Error())
+		}
+
+		if payment.Status != constanta.REFUNDED {
+			paymentStatus = constanta.PARTIALLY_REFUNDED
+		}
+	}
+
+	return &gen.RefundPaymentResponse{
+		RefundId:       req.RefundId,
+		Status:         string(paymentStatus),
+		RefundedAmount: refundedAmount,
 	}, nil
 }
 
@@ -126,45 +500,34 @@ func (p *PaymentService) UpdatePayment(ctx context.Context, req *gen.UpdatePayme
 		}, nil
 	}
 
+	// the amount is only entered for the mock provider, the real provider notifies the status with its webhook
+	if payment.Provider != provider.MockProviderName {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment of provider %s cannot be updated manually", payment.Provider)
+	}
+
 	if payment.TotalAmount.GetCurrencyCode() != req.TotalAmount.GetCurrencyCode() {
 		return nil, status.Error(codes.InvalidArgument, "currency code not match")
 	}
 
 	if req.TotalAmount.Units > payment.TotalAmount.Units || req.TotalAmount.Units < payment.TotalAmount.Units {
-		err = p.paymentRepo.UpdatePaymentStatusByTransactionID(ctx, constanta.FAILED, req.TransactionId)
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = p.orderService.CallbackTransaction(ctx, &gen.CallbackTransactionRequest{
-			TransactionId: req.TransactionId,
-			PaymentStatus: constanta.FAILED.String(),
-		})
+		paymentStatus, err := p.settlePayment(ctx, req.TransactionId, constanta.FAILED)
 		if err != nil {
 			return nil, err
 		}
 
 		return &gen.UpdatePaymentResponse{
-			Status: string(constanta.FAILED),
+			Status: string(paymentStatus),
 		}, nil
 	}
 
 	if req.TotalAmount.Units == payment.TotalAmount.Units {
-		err = p.paymentRepo.UpdatePaymentStatusByTransactionID(ctx, constanta.PAID, req.TransactionId)
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = p.orderService.CallbackTransaction(ctx, &gen.CallbackTransactionRequest{
-			TransactionId: req.TransactionId,
-			PaymentStatus: constanta.PAID.String(),
-		})
+		paymentStatus, err := p.settlePayment(ctx, req.TransactionId, constanta.PAID)
 		if err != nil {
 			return nil, err
 		}
 
 		return &gen.UpdatePaymentResponse{
-			Status: string(constanta.PAID),
+			Status: string(paymentStatus),
 		}, nil
 	}
 
@@ -183,19 +546,211 @@ func (p *PaymentService) RemoveExpiredPayment(ctx context.Context, duration time
 		if payment.Status != constanta.WAITING {
 			continue
 		}
-		err = p.paymentRepo.UpdatePaymentStatusByTransactionID(ctx, constanta.FAILED, payment.TransactionID)
+
+		// the charge may be paid although its webhook is not received yet,
+		// the payment is kept when the provider cannot be asked and checked again later
+		paymentStatus := constanta.FAILED
+		if payment.Provider == p.paymentProvider.Name() {
+			chargeStatus, err := p.paymentProvider.GetChargeStatus(ctx, payment.ProviderChargeID)
+			if err != nil {
+				fmt.Printf("err when get charge status of transaction %s: %v\n", payment.TransactionID, err)
+				continue
+			}
+			if chargeStatus == constanta.PAID {
+				paymentStatus = constanta.PAID
+			}
+		}
+
+		_, err = p.settlePayment(ctx, payment.TransactionID, paymentStatus)
 		if err != nil {
 			fmt.Println("err when Update status", err)
 		}
+	}
+
+	return len(payments), nil
+}
+
+// HandleWebhook applies the status of the charge that is notified by the provider with the same path as UpdatePayment.
+// The event that is already handled is returned as a duplicate without changing anything,
+// it is recorded after the payment is settled so the event that fails is handled again when the provider retries it
+func (p *PaymentService) HandleWebhook(ctx context.Context, providerName string, header http.Header, body []byte) (*entity.WebhookEvent, error) {
+	if providerName != p.paymentProvider.Name() {
+		return nil, status.Errorf(codes.NotFound, "provider %s not found", providerName)
+	}
+
+	event, err := p.paymentProvider.VerifyWebhook(header, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, provider.ErrInvalidWebhook):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, provider.ErrWebhookNotSupported):
+			return nil, status.Errorf(codes.Unimplemented, "provider %s does not send webhook", providerName)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify webhook: %v", err)
+	}
+
+	handled, err := p.paymentRepo.GetWebhookEvent(ctx, providerName, event.ID)
+	if err == nil {
+		handled.Duplicate = true
+		return handled, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	payment, err := p.paymentRepo.GetPaymentByTransactionID(ctx, event.TransactionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "transaction not found")
+		}
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	if payment.Provider != providerName || payment.ProviderChargeID != event.ChargeID {
+		return nil, status.Errorf(codes.InvalidArgument, "charge %s is not the charge of transaction %s", event.ChargeID, event.TransactionID)
+	}
+
+	paymentStatus := payment.Status
+	switch {
+	case payment.Status == constanta.WAITING && event.Status != constanta.WAITING:
+		paymentStatus, err = p.settlePayment(ctx, payment.TransactionID, event.Status)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", err.Error())
+		}
+	case event.Status == constanta.PAID && (payment.Status == constanta.CANCELLED || payment.Status == constanta.FAILED):
+		// the customer paid the charge after the payment is cancelled or expired, the order does not wait for it anymore
+		err = p.refundLatePayment(ctx, *payment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	handled = &entity.WebhookEvent{
+		Provider:      providerName,
+		EventID:       event.ID,
+		TransactionID: payment.TransactionID,
+		Status:        paymentStatus,
+		CreatedAt:     time.Now(),
+	}
+
+	err = p.paymentRepo.CreateWebhookEvent(ctx, *handled)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	return handled, nil
+}
+
+// refundLatePayment pays back the whole charge that is paid after its payment is cancelled or failed.
+// The refund id is derived from the transaction, so the webhook that is retried does not refund the charge twice
+func (p *PaymentService) refundLatePayment(ctx context.Context, payment entity.Payment) error {
+	refundID := "late:" + payment.TransactionID
+	err := p.paymentRepo.RefundPayment(ctx, entity.Refund{
+		ID:            refundID,
+		TransactionID: payment.TransactionID,
+		Amount:        payment.TotalAmount,
+		Reason:        fmt.Sprintf("charge is paid after the payment is %s", payment.Status),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", err.Error())
+	}
+
+	return p.refundCharges(ctx, []entity.Payment{payment}, refundID)
+}
+
+// settlePayment moves the WAITING payment into PAID or FAILED and notifies order service with the callback.
+// The payment that is settled first by another request keeps its status, the status of the payment is returned
+func (p *PaymentService) settlePayment(ctx context.Context, transactionID string, paymentStatus constanta.PaymentStatus) (constanta.PaymentStatus, error) {
+	err := p.paymentRepo.UpdatePaymentStatusWithCallback(ctx, paymentStatus, transactionID)
+	if err == nil {
+		return paymentStatus, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	payment, err := p.paymentRepo.GetPaymentByTransactionID(ctx, transactionID)
+	if err != nil {
+		return "", err
+	}
+
+	return payment.Status, nil
+}
+
+const (
+	callbackBatchSize = 50
+	callbackBaseDelay = 5 * time.Second
+	callbackMaxDelay  = 5 * time.Minute
+)
+
+// DispatchCallbacks delivers the pending callbacks from outbox to order service.
+// A callback is retried until order service accepts or refuses it,
+// so order service may receive the same callback more than once.
+func (p *PaymentService) DispatchCallbacks(ctx context.Context) (int, error) {
+	callbacks, err := p.paymentRepo.GetPendingCallbacks(ctx, time.Now(), callbackBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, callback := range callbacks {
+		// the paid amount is read at delivery, so the order sees every payment that is paid before
+		paidAmount, err := p.getPaidAmount(ctx, callback.OrderID)
+		if err != nil {
+			fmt.Printf("err when get paid amount of order %s: %v\n", callback.OrderID, err)
+			continue
+		}
 
 		_, err = p.orderService.CallbackTransaction(ctx, &gen.CallbackTransactionRequest{
-			TransactionId: payment.TransactionID,
-			PaymentStatus: constanta.FAILED.String(),
+			TransactionId: callback.TransactionID,
+			PaymentStatus: callback.PaymentStatus.String(),
+			OrderId:       callback.OrderID,
+			PaidAmount:    paidAmount,
 		})
-		if err != nil {
-			fmt.Println("err when callback transaction", err)
+		if err == nil {
+			delivered++
+			if err := p.paymentRepo.MarkCallbackDelivered(ctx, callback.ID); err != nil {
+				fmt.Println("err when mark callback delivered", err)
+			}
+			continue
+		}
+
+		callbackStatus := constanta.CallbackPending
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+			callbackStatus = constanta.CallbackRejected
+			fmt.Printf("callback of transaction %s is rejected: %v\n", callback.TransactionID, err)
+		}
+
+		nextAttemptAt := time.Now().Add(callbackBackoff(callback.Attempt + 1))
+		if err := p.paymentRepo.FailCallback(ctx, callback.ID, callbackStatus, err.Error(), nextAttemptAt); err != nil {
+			fmt.Println("err when record failed callback", err)
 		}
 	}
 
-	return len(payments), nil
+	return delivered, nil
+}
+
+func (p *PaymentService) getPaidAmount(ctx context.Context, orderID string) (*gen.Money, error) {
+	payments, err := p.paymentRepo.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payments) == 0 {
+		return nil, fmt.Errorf("order %s has no payment", orderID)
+	}
+
+	return entity.PaidAmount(payments, payments[0].TotalAmount.GetCurrencyCode())
+}
+
+// callbackBackoff doubles the delay on every attempt up to callbackMaxDelay
+func callbackBackoff(attempt int64) time.Duration {
+	delay := callbackBaseDelay
+	for i := int64(1); i < attempt && delay < callbackMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, callbackMaxDelay)
 }
@@ -2,17 +2,24 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/constanta"
 	"github.com/elangreza/e-commerce/payment/internal/entity"
+	"github.com/elangreza/e-commerce/payment/internal/provider"
 	"github.com/elangreza/e-commerce/payment/internal/service"
 	"github.com/elangreza/e-commerce/payment/internal/service/mock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type PaymentServiceTestSuite struct {
@@ -21,6 +28,7 @@ type PaymentServiceTestSuite struct {
 	svc                    *service.PaymentService
 	mockPaymentRepo        *mock.MockpaymentRepo
 	mockOrderServiceClient *mock.MockOrderServiceClient
+	mockPaymentProvider    *mock.MockPaymentProvider
 }
 
 func (s *PaymentServiceTestSuite) SetupTest() {
@@ -28,11 +36,14 @@ func (s *PaymentServiceTestSuite) SetupTest() {
 
 	s.mockPaymentRepo = mock.NewMockpaymentRepo(s.ctrl)
 	s.mockOrderServiceClient = mock.NewMockOrderServiceClient(s.ctrl)
+	s.mockPaymentProvider = mock.NewMockPaymentProvider(s.ctrl)
+	s.mockPaymentProvider.EXPECT().Name().Return(provider.MockProviderName).AnyTimes()
 
 	s.svc = service.NewPaymentService(
 		s.mockPaymentRepo,
 		1*time.Second,
 		s.mockOrderServiceClient,
+		s.mockPaymentProvider,
 	)
 }
 
@@ -59,14 +70,183 @@ func (s *PaymentServiceTestSuite) TestProcessPayment() {
 				TotalAmount: &gen.Money{
 					Units: 1000,
 				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
 			},
 			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Return(&provider.Charge{
+						ID:         "ch_1",
+						Status:     constanta.WAITING,
+						PaymentURL: "http://provider/pay/ch_1",
+					}, nil)
 				s.mockPaymentRepo.EXPECT().
-					CreatePayment(gomock.Any(), gomock.Any()).
-					Return(nil)
+					CreatePayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payment entity.Payment, orderAmount *gen.Money) error {
+						s.Equal(constanta.WAITING, payment.Status)
+						s.Equal(provider.MockProviderName, payment.Provider)
+						s.Equal("ch_1", payment.ProviderChargeID)
+						s.Equal("http://provider/pay/ch_1", payment.PaymentURL)
+						s.Equal(constanta.CARD, payment.Method)
+						s.Equal(int64(1000), orderAmount.Units)
+						return nil
+					})
 			},
 			expectedError: "",
 		},
+		{
+			name: "Success with payment method",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 400,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+				Method: "e_wallet",
+			},
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req provider.ChargeRequest) (*provider.Charge, error) {
+						s.Equal(constanta.E_WALLET, req.Method)
+						return &provider.Charge{ID: "ch_2", Status: constanta.WAITING}, nil
+					})
+				s.mockPaymentRepo.EXPECT().
+					CreatePayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payment entity.Payment, orderAmount *gen.Money) error {
+						s.Equal(constanta.E_WALLET, payment.Method)
+						s.Equal(int64(400), payment.TotalAmount.Units)
+						return nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error unknown payment method",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 1000,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+				Method: "CHEQUE",
+			},
+			setupMock:     func() {},
+			expectedError: "unknown payment method CHEQUE",
+		},
+		{
+			name: "Error amount must be positive",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 0,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock:     func() {},
+			expectedError: "total_amount must be greater than 0",
+		},
+		{
+			name: "Error payments of the order would exceed the order amount",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 600,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Return(&provider.Charge{ID: "ch_3", Status: constanta.WAITING}, nil)
+				// another payment of the order is created first
+				s.mockPaymentRepo.EXPECT().
+					CreatePayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: 1000 of order 1", entity.ErrPaymentExceedsOrder))
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_3").
+					Return(nil)
+			},
+			expectedError: "order 1 is already paid or waiting for its payments",
+		},
+		{
+			name: "Error when storing the payment cancels the charge",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 600,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Return(&provider.Charge{ID: "ch_4", Status: constanta.WAITING}, nil)
+				s.mockPaymentRepo.EXPECT().
+					CreatePayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database is locked"))
+				// the customer must not be able to pay the charge that is not stored
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_4").
+					Return(nil)
+			},
+			expectedError: "database is locked",
+		},
+		{
+			name: "Error order amount is required",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock:     func() {},
+			expectedError: "order_amount must be greater than 0",
+		},
+		{
+			name: "Error amount is more than the order amount",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 1200,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock:     func() {},
+			expectedError: "total_amount cannot be more than order_amount",
+		},
+		{
+			name: "Error failed to create charge",
+			req: &gen.ProcessPaymentRequest{
+				OrderId: "1",
+				TotalAmount: &gen.Money{
+					Units: 1000,
+				},
+				OrderAmount: &gen.Money{
+					Units: 1000,
+				},
+			},
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					CreateCharge(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("provider stub responds 503"))
+			},
+			expectedError: "failed to create charge: provider stub responds 503",
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,6 +268,16 @@ func (s *PaymentServiceTestSuite) TestProcessPayment() {
 }
 
 func (s *PaymentServiceTestSuite) TestRollbackPayment() {
+	paid := func(transactionID string, units int64) entity.Payment {
+		return entity.Payment{
+			TransactionID:    transactionID,
+			Status:           constanta.PAID,
+			TotalAmount:      &gen.Money{Units: units, CurrencyCode: "IDR"},
+			RefundedAmount:   &gen.Money{CurrencyCode: "IDR"},
+			Provider:         provider.MockProviderName,
+			ProviderChargeID: "ch_" + transactionID,
+		}
+	}
 
 	tests := []struct {
 		name          string
@@ -105,16 +295,264 @@ func (s *PaymentServiceTestSuite) TestRollbackPayment() {
 				s.mockPaymentRepo.EXPECT().
 					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
 					Return(&entity.Payment{
-						ID:     uuid.New(),
-						Status: constanta.WAITING,
+						ID:               uuid.New(),
+						TransactionID:    "aaaa",
+						Status:           constanta.WAITING,
+						Provider:         provider.MockProviderName,
+						ProviderChargeID: "ch_aaaa",
 					}, nil)
 
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_aaaa").
+					Return(nil)
+
 				s.mockPaymentRepo.EXPECT().
 					UpdatePaymentStatusByTransactionID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			expectedError: "",
 		},
+		{
+			name: "Error payment is paid before its charge is cancelled",
+			req: &gen.RollbackPaymentRequest{
+				TransactionId: "aaaa",
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Payment{
+						TransactionID:    "aaaa",
+						Status:           constanta.WAITING,
+						Provider:         provider.MockProviderName,
+						ProviderChargeID: "ch_aaaa",
+					}, nil)
+
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_aaaa").
+					Return(provider.ErrChargePaid)
+
+				// the order is notified that the payment is paid
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "aaaa").
+					Return(nil)
+			},
+			expectedError: "payment aaaa is paid before it is cancelled",
+		},
+		{
+			name: "Success every payment of the order",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+				Reason:  "order expired",
+			},
+			setupMock: func() {
+				partiallyRefunded := paid("bbbb", 600)
+				partiallyRefunded.Status = constanta.PARTIALLY_REFUNDED
+				partiallyRefunded.RefundedAmount = &gen.Money{Units: 100, CurrencyCode: "IDR"}
+				waiting := paid("cccc", 400)
+				waiting.Status = constanta.WAITING
+				failed := paid("aaaa", 1000)
+				failed.Status = constanta.FAILED
+
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{failed, partiallyRefunded, waiting}, nil)
+
+				refund := entity.Refund{
+					ID:            "rollback:bbbb",
+					TransactionID: "bbbb",
+					Amount:        &gen.Money{Units: 500, CurrencyCode: "IDR"},
+					Reason:        "order expired",
+				}
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), refund).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "rollback:bbbb").
+					Return([]entity.Refund{refund}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "rollback:bbbb",
+						ChargeID: "ch_bbbb",
+						Amount:   &gen.Money{Units: 500, CurrencyCode: "IDR"},
+						Reason:   "order expired",
+					}).
+					Return(nil)
+
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_cccc").
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					CancelPayment(gomock.Any(), "cccc").
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success payment of the order is paid while it is cancelled",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				waiting := paid("cccc", 400)
+				waiting.Status = constanta.WAITING
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{waiting}, nil)
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_cccc").
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					CancelPayment(gomock.Any(), "cccc").
+					Return(sql.ErrNoRows)
+
+				settled := paid("cccc", 400)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "cccc").
+					Return(&settled, nil)
+				refund := entity.Refund{
+					ID:            "rollback:cccc",
+					TransactionID: "cccc",
+					Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+				}
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), refund).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "rollback:cccc").
+					Return([]entity.Refund{refund}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success charge of the order is paid before it is cancelled",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				waiting := paid("cccc", 400)
+				waiting.Status = constanta.WAITING
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{waiting}, nil)
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_cccc").
+					Return(fmt.Errorf("%w: charge ch_cccc", provider.ErrChargePaid))
+
+				// the payment is paid instead, then refunded
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "cccc").
+					Return(nil)
+				settled := paid("cccc", 400)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "cccc").
+					Return(&settled, nil)
+				refund := entity.Refund{
+					ID:            "rollback:cccc",
+					TransactionID: "cccc",
+					Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+				}
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), refund).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "rollback:cccc").
+					Return([]entity.Refund{refund}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "rollback:cccc",
+						ChargeID: "ch_cccc",
+						Amount:   &gen.Money{Units: 400, CurrencyCode: "IDR"},
+					}).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Success recorded refund of the rollback is sent to the provider again",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				refunded := paid("aaaa", 1000)
+				refunded.Status = constanta.REFUNDED
+				refunded.RefundedAmount = &gen.Money{Units: 1000, CurrencyCode: "IDR"}
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{refunded}, nil)
+
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "rollback:aaaa").
+					Return([]entity.Refund{{
+						ID:            "rollback:aaaa",
+						TransactionID: "aaaa",
+						Amount:        &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "rollback:aaaa",
+						ChargeID: "ch_aaaa",
+						Amount:   &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error when cancelling the charge of the order",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				waiting := paid("cccc", 400)
+				waiting.Status = constanta.WAITING
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{waiting}, nil)
+				s.mockPaymentProvider.EXPECT().
+					CancelCharge(gomock.Any(), "ch_cccc").
+					Return(errors.New("provider is down"))
+			},
+			expectedError: "failed to cancel charge: provider is down",
+		},
+		{
+			name: "Error when refunding the paid payment of the order",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{paid("aaaa", 1000)}, nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+		{
+			name: "Error when refunding the charge of the order",
+			req: &gen.RollbackPaymentRequest{
+				OrderId: "order-1",
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{paid("aaaa", 1000)}, nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "rollback:aaaa").
+					Return([]entity.Refund{{ID: "rollback:aaaa", TransactionID: "aaaa", Amount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}}}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), gomock.Any()).
+					Return(errors.New("provider is down"))
+			},
+			expectedError: "failed to refund charge: provider is down",
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +617,344 @@ func (s *PaymentServiceTestSuite) TestGetPayment() {
 	}
 }
 
+func (s *PaymentServiceTestSuite) TestListPayments() {
+	s.Run("Success", func() {
+		s.mockPaymentRepo.EXPECT().
+			GetPaymentsByOrderID(gomock.Any(), "order-1").
+			Return([]entity.Payment{
+				{TransactionID: "aaaa", OrderID: "order-1", Status: constanta.FAILED, Method: constanta.CARD, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{CurrencyCode: "IDR"}},
+				{TransactionID: "bbbb", OrderID: "order-1", Status: constanta.PAID, Method: constanta.STORE_CREDIT, TotalAmount: &gen.Money{Units: 300, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{CurrencyCode: "IDR"}},
+				{TransactionID: "cccc", OrderID: "order-1", Status: constanta.PARTIALLY_REFUNDED, Method: constanta.E_WALLET, TotalAmount: &gen.Money{Units: 700, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{Units: 200, CurrencyCode: "IDR"}},
+			}, nil)
+
+		resp, err := s.svc.ListPayments(context.Background(), &gen.ListPaymentsRequest{OrderId: "order-1"})
+		s.NoError(err)
+		s.Len(resp.Payments, 3)
+		s.Equal("STORE_CREDIT", resp.Payments[1].Method)
+		s.Equal("order-1", resp.Payments[1].OrderId)
+		s.Equal(int64(1000), resp.PaidAmount.Units)
+		s.Equal("IDR", resp.PaidAmount.CurrencyCode)
+	})
+
+	s.Run("Success order without payment", func() {
+		s.mockPaymentRepo.EXPECT().
+			GetPaymentsByOrderID(gomock.Any(), "order-2").
+			Return([]entity.Payment{}, nil)
+
+		resp, err := s.svc.ListPayments(context.Background(), &gen.ListPaymentsRequest{OrderId: "order-2"})
+		s.NoError(err)
+		s.Empty(resp.Payments)
+		s.Nil(resp.PaidAmount)
+	})
+
+	s.Run("Error order id is required", func() {
+		_, err := s.svc.ListPayments(context.Background(), &gen.ListPaymentsRequest{})
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment() {
+	paidPayment := func(paymentStatus constanta.PaymentStatus, refunded int64) *entity.Payment {
+		return &entity.Payment{
+			ID:               uuid.New(),
+			Status:           paymentStatus,
+			TransactionID:    "aaaa",
+			TotalAmount:      &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+			RefundedAmount:   &gen.Money{Units: refunded, CurrencyCode: "IDR"},
+			Provider:         provider.MockProviderName,
+			ProviderChargeID: "ch_aaaa",
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            *gen.RefundPaymentRequest
+		setupMock      func()
+		expectedError  string
+		expectedStatus string
+	}{
+		{
+			name: "Partial refund",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-1",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+				Reason:        "damaged item",
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.PAID, 0), nil)
+				refund := entity.Refund{
+					ID:            "refund-1",
+					TransactionID: "aaaa",
+					Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+					Reason:        "damaged item",
+				}
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), refund).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "refund-1").
+					Return([]entity.Refund{refund}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "refund-1",
+						ChargeID: "ch_aaaa",
+						Amount:   &gen.Money{Units: 400, CurrencyCode: "IDR"},
+						Reason:   "damaged item",
+					}).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.PARTIALLY_REFUNDED, 400), nil)
+			},
+			expectedStatus: "PARTIALLY_REFUNDED",
+		},
+		{
+			name: "Retried refund of the fully refunded payment",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-2",
+				Amount:        &gen.Money{Units: 600, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.REFUNDED, 1000), nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil)
+				// the recorded refund is sent again, the provider refunds it only once
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "refund-2").
+					Return([]entity.Refund{{ID: "refund-2", TransactionID: "aaaa", Amount: &gen.Money{Units: 600, CurrencyCode: "IDR"}}}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "refund-2",
+						ChargeID: "ch_aaaa",
+						Amount:   &gen.Money{Units: 600, CurrencyCode: "IDR"},
+					}).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.REFUNDED, 1000), nil)
+			},
+			expectedStatus: "REFUNDED",
+		},
+		{
+			name: "Refund exceeds the paid amount",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-3",
+				Amount:        &gen.Money{Units: 700, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.PARTIALLY_REFUNDED, 400), nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(entity.ErrRefundExceedsPayment)
+			},
+			expectedError: "refund exceeds the paid amount",
+		},
+		{
+			name: "Provider fails to refund the charge",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-11",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.PAID, 0), nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "refund-11").
+					Return([]entity.Refund{{ID: "refund-11", TransactionID: "aaaa", Amount: &gen.Money{Units: 400, CurrencyCode: "IDR"}}}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), gomock.Any()).
+					Return(errors.New("provider is down"))
+			},
+			expectedError: "failed to refund charge: provider is down",
+		},
+		{
+			name: "Waiting payment cannot be refunded",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-4",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.WAITING, 0), nil)
+			},
+			expectedError: "payment with status WAITING cannot be refunded",
+		},
+		{
+			name: "Currency not match",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-5",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "USD"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(paidPayment(constanta.PAID, 0), nil)
+			},
+			expectedError: "currency code not match",
+		},
+		{
+			name: "Transaction not found",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "bbbb",
+				RefundId:      "refund-6",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "bbbb").
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "transaction not found",
+		},
+		{
+			name: "Refund of the order is spread over its payments",
+			req: &gen.RefundPaymentRequest{
+				OrderId:  "order-1",
+				RefundId: "refund-8",
+				Amount:   &gen.Money{Units: 800, CurrencyCode: "IDR"},
+				Reason:   "returned",
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{
+						{TransactionID: "aaaa", Status: constanta.FAILED, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+						{TransactionID: "bbbb", Status: constanta.PAID, TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}, Provider: provider.MockProviderName, ProviderChargeID: "ch_bbbb"},
+						{TransactionID: "cccc", Status: constanta.PAID, TotalAmount: &gen.Money{Units: 400, CurrencyCode: "IDR"}, Provider: provider.MockProviderName, ProviderChargeID: "ch_cccc"},
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundOrder(gomock.Any(), "order-1", entity.Refund{
+						ID:     "refund-8",
+						Amount: &gen.Money{Units: 800, CurrencyCode: "IDR"},
+						Reason: "returned",
+					}).
+					Return(nil)
+				// every part of the refund is paid back by the charge of its payment
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "refund-8").
+					Return([]entity.Refund{
+						{ID: "refund-8:bbbb", TransactionID: "bbbb", Amount: &gen.Money{Units: 600, CurrencyCode: "IDR"}, Reason: "returned", OrderRefundID: "refund-8"},
+						{ID: "refund-8:cccc", TransactionID: "cccc", Amount: &gen.Money{Units: 200, CurrencyCode: "IDR"}, Reason: "returned", OrderRefundID: "refund-8"},
+					}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "refund-8:bbbb",
+						ChargeID: "ch_bbbb",
+						Amount:   &gen.Money{Units: 600, CurrencyCode: "IDR"},
+						Reason:   "returned",
+					}).
+					Return(nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "refund-8:cccc",
+						ChargeID: "ch_cccc",
+						Amount:   &gen.Money{Units: 200, CurrencyCode: "IDR"},
+						Reason:   "returned",
+					}).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{
+						{TransactionID: "aaaa", Status: constanta.FAILED, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{CurrencyCode: "IDR"}},
+						{TransactionID: "bbbb", Status: constanta.REFUNDED, TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}},
+						{TransactionID: "cccc", Status: constanta.PARTIALLY_REFUNDED, TotalAmount: &gen.Money{Units: 400, CurrencyCode: "IDR"}, RefundedAmount: &gen.Money{Units: 200, CurrencyCode: "IDR"}},
+					}, nil)
+			},
+			expectedStatus: "PARTIALLY_REFUNDED",
+		},
+		{
+			name: "Refund of the order exceeds its paid payments",
+			req: &gen.RefundPaymentRequest{
+				OrderId:  "order-1",
+				RefundId: "refund-9",
+				Amount:   &gen.Money{Units: 1200, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{
+						{TransactionID: "bbbb", Status: constanta.PAID, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundOrder(gomock.Any(), "order-1", gomock.Any()).
+					Return(entity.ErrRefundExceedsPayment)
+			},
+			expectedError: "refund exceeds the paid amount",
+		},
+		{
+			name: "Order without paid payment cannot be refunded",
+			req: &gen.RefundPaymentRequest{
+				OrderId:  "order-2",
+				RefundId: "refund-10",
+				Amount:   &gen.Money{Units: 100, CurrencyCode: "IDR"},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-2").
+					Return([]entity.Payment{
+						{TransactionID: "aaaa", Status: constanta.WAITING, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+					}, nil)
+			},
+			expectedError: "order order-2 has no paid payment to be refunded",
+		},
+		{
+			name: "Refund id is required",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				Amount:        &gen.Money{Units: 400, CurrencyCode: "IDR"},
+			},
+			setupMock:     func() {},
+			expectedError: "refund_id is required",
+		},
+		{
+			name: "Amount must be positive",
+			req: &gen.RefundPaymentRequest{
+				TransactionId: "aaaa",
+				RefundId:      "refund-7",
+				Amount:        &gen.Money{Units: 0, CurrencyCode: "IDR"},
+			},
+			setupMock:     func() {},
+			expectedError: "amount must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.RefundPayment(context.Background(), tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.req.RefundId, resp.RefundId)
+				s.Equal(tt.expectedStatus, resp.Status)
+			}
+		})
+	}
+}
+
 func (s *PaymentServiceTestSuite) TestUpdatePayment() {
 
 	tests := []struct {
@@ -201,21 +977,18 @@ func (s *PaymentServiceTestSuite) TestUpdatePayment() {
 				s.mockPaymentRepo.EXPECT().
 					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
 					Return(&entity.Payment{
-						ID:     uuid.New(),
-						Status: constanta.WAITING,
+						ID:       uuid.New(),
+						Status:   constanta.WAITING,
+						Provider: provider.MockProviderName,
 						TotalAmount: &gen.Money{
 							Units:        10000,
 							CurrencyCode: "IDR",
 						},
 					}, nil)
 				s.mockPaymentRepo.EXPECT().
-					UpdatePaymentStatusByTransactionID(gomock.Any(), constanta.PAID, gomock.Any()).
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, gomock.Any()).
 					Return(nil)
 
-				s.mockOrderServiceClient.EXPECT().
-					CallbackTransaction(gomock.Any(), gomock.Any()).
-					Return(&gen.Empty{}, nil)
-
 			},
 			expectedError: "",
 			expectedResp: &gen.UpdatePaymentResponse{
@@ -235,33 +1008,83 @@ func (s *PaymentServiceTestSuite) TestUpdatePayment() {
 				s.mockPaymentRepo.EXPECT().
 					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
 					Return(&entity.Payment{
-						ID:     uuid.New(),
-						Status: constanta.WAITING,
+						ID:       uuid.New(),
+						Status:   constanta.WAITING,
+						Provider: provider.MockProviderName,
+						TotalAmount: &gen.Money{
+							Units:        10000,
+							CurrencyCode: "IDR",
+						},
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.FAILED, gomock.Any()).
+					Return(nil)
+
+			},
+			expectedError: "",
+			expectedResp: &gen.UpdatePaymentResponse{
+				Status: string(constanta.FAILED),
+			},
+		},
+		{
+			name: "Already processed, return early",
+			req: &gen.UpdatePaymentRequest{
+				TransactionId: "aaaa",
+				TotalAmount: &gen.Money{
+					Units:        9000,
+					CurrencyCode: "IDR",
+				},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Payment{
+						ID:     uuid.New(),
+						Status: constanta.PAID,
+						TotalAmount: &gen.Money{
+							Units:        10000,
+							CurrencyCode: "IDR",
+						},
+					}, nil)
+
+			},
+			expectedError: "",
+			expectedResp: &gen.UpdatePaymentResponse{
+				Status: string(constanta.PAID),
+			},
+		},
+		{
+			name: "currency code not match",
+			req: &gen.UpdatePaymentRequest{
+				TransactionId: "aaaa",
+				TotalAmount: &gen.Money{
+					Units:        9000,
+					CurrencyCode: "USD",
+				},
+			},
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Payment{
+						ID:       uuid.New(),
+						Status:   constanta.WAITING,
+						Provider: provider.MockProviderName,
 						TotalAmount: &gen.Money{
 							Units:        10000,
 							CurrencyCode: "IDR",
 						},
 					}, nil)
-				s.mockPaymentRepo.EXPECT().
-					UpdatePaymentStatusByTransactionID(gomock.Any(), constanta.FAILED, gomock.Any()).
-					Return(nil)
-
-				s.mockOrderServiceClient.EXPECT().
-					CallbackTransaction(gomock.Any(), gomock.Any()).
-					Return(&gen.Empty{}, nil)
 
 			},
-			expectedError: "",
-			expectedResp: &gen.UpdatePaymentResponse{
-				Status: string(constanta.FAILED),
-			},
+			expectedError: "currency code not match",
+			expectedResp:  nil,
 		},
 		{
-			name: "Already processed, return early",
+			name: "Success payment is settled by another request first",
 			req: &gen.UpdatePaymentRequest{
 				TransactionId: "aaaa",
 				TotalAmount: &gen.Money{
-					Units:        9000,
+					Units:        10000,
 					CurrencyCode: "IDR",
 				},
 			},
@@ -269,43 +1092,52 @@ func (s *PaymentServiceTestSuite) TestUpdatePayment() {
 				s.mockPaymentRepo.EXPECT().
 					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
 					Return(&entity.Payment{
-						ID:     uuid.New(),
-						Status: constanta.PAID,
+						ID:       uuid.New(),
+						Status:   constanta.WAITING,
+						Provider: provider.MockProviderName,
 						TotalAmount: &gen.Money{
 							Units:        10000,
 							CurrencyCode: "IDR",
 						},
 					}, nil)
-
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, gomock.Any()).
+					Return(sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
+					Return(&entity.Payment{
+						ID:     uuid.New(),
+						Status: constanta.FAILED,
+					}, nil)
 			},
 			expectedError: "",
 			expectedResp: &gen.UpdatePaymentResponse{
-				Status: string(constanta.PAID),
+				Status: string(constanta.FAILED),
 			},
 		},
 		{
-			name: "currency code not match",
+			name: "Error payment of real provider",
 			req: &gen.UpdatePaymentRequest{
 				TransactionId: "aaaa",
 				TotalAmount: &gen.Money{
-					Units:        9000,
-					CurrencyCode: "USD",
+					Units:        10000,
+					CurrencyCode: "IDR",
 				},
 			},
 			setupMock: func() {
 				s.mockPaymentRepo.EXPECT().
 					GetPaymentByTransactionID(gomock.Any(), gomock.Any()).
 					Return(&entity.Payment{
-						ID:     uuid.New(),
-						Status: constanta.WAITING,
+						ID:       uuid.New(),
+						Status:   constanta.WAITING,
+						Provider: "stub",
 						TotalAmount: &gen.Money{
 							Units:        10000,
 							CurrencyCode: "IDR",
 						},
 					}, nil)
-
 			},
-			expectedError: "currency code not match",
+			expectedError: "payment of provider stub cannot be updated manually",
 			expectedResp:  nil,
 		},
 	}
@@ -370,13 +1202,54 @@ func (s *PaymentServiceTestSuite) TestRemoveExpiredPayment() {
 						},
 					}, nil)
 				s.mockPaymentRepo.EXPECT().
-					UpdatePaymentStatusByTransactionID(gomock.Any(), constanta.FAILED, gomock.Any()).
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.FAILED, gomock.Any()).
 					Return(nil).Times(2)
 
-				s.mockOrderServiceClient.EXPECT().
-					CallbackTransaction(gomock.Any(), gomock.Any()).
-					Return(&gen.Empty{}, nil).Times(2)
-
+			},
+			expectedError: "",
+			expectedResp:  3,
+		},
+		{
+			name: "Success the status of the charge is asked to the provider",
+			req:  1 * time.Minute,
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetExpiredPayments(gomock.Any(), gomock.Any()).
+					Return([]entity.Payment{
+						{
+							TransactionID:    "paid",
+							Status:           constanta.WAITING,
+							Provider:         provider.MockProviderName,
+							ProviderChargeID: "ch_1",
+						},
+						{
+							TransactionID:    "abandoned",
+							Status:           constanta.WAITING,
+							Provider:         provider.MockProviderName,
+							ProviderChargeID: "ch_2",
+						},
+						{
+							TransactionID:    "unreachable",
+							Status:           constanta.WAITING,
+							Provider:         provider.MockProviderName,
+							ProviderChargeID: "ch_3",
+						},
+					}, nil)
+				s.mockPaymentProvider.EXPECT().
+					GetChargeStatus(gomock.Any(), "ch_1").
+					Return(constanta.PAID, nil)
+				s.mockPaymentProvider.EXPECT().
+					GetChargeStatus(gomock.Any(), "ch_2").
+					Return(constanta.WAITING, nil)
+				s.mockPaymentProvider.EXPECT().
+					GetChargeStatus(gomock.Any(), "ch_3").
+					Return(constanta.PaymentStatus(""), errors.New("provider stub responds 503"))
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "paid").
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.FAILED, "abandoned").
+					Return(nil)
 			},
 			expectedError: "",
 			expectedResp:  3,
@@ -401,3 +1274,438 @@ func (s *PaymentServiceTestSuite) TestRemoveExpiredPayment() {
 		})
 	}
 }
+
+func (s *PaymentServiceTestSuite) TestDispatchCallbacks() {
+	deliveredID := uuid.New()
+	failedID := uuid.New()
+	rejectedID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedError string
+		expectedResp  int
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPendingCallbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]entity.CallbackOutbox{
+						{
+							ID:            deliveredID,
+							TransactionID: "aaaa",
+							OrderID:       "order-1",
+							PaymentStatus: constanta.PAID,
+							Status:        constanta.CallbackPending,
+						},
+						{
+							ID:            failedID,
+							TransactionID: "bbbb",
+							OrderID:       "order-2",
+							PaymentStatus: constanta.FAILED,
+							Status:        constanta.CallbackPending,
+							Attempt:       1,
+						},
+						{
+							ID:            rejectedID,
+							TransactionID: "cccc",
+							OrderID:       "order-3",
+							PaymentStatus: constanta.PAID,
+							Status:        constanta.CallbackPending,
+						},
+					}, nil)
+
+				// the order is paid by two payments, only one of them is paid
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return([]entity.Payment{
+						{TransactionID: "aaaa", Status: constanta.PAID, TotalAmount: &gen.Money{Units: 600, CurrencyCode: "IDR"}},
+						{TransactionID: "dddd", Status: constanta.WAITING, TotalAmount: &gen.Money{Units: 400, CurrencyCode: "IDR"}},
+					}, nil)
+				s.mockOrderServiceClient.EXPECT().
+					CallbackTransaction(gomock.Any(), &gen.CallbackTransactionRequest{
+						TransactionId: "aaaa",
+						PaymentStatus: constanta.PAID.String(),
+						OrderId:       "order-1",
+						PaidAmount:    &gen.Money{Units: 600, CurrencyCode: "IDR"},
+					}).
+					Return(&gen.Empty{}, nil)
+				s.mockPaymentRepo.EXPECT().
+					MarkCallbackDelivered(gomock.Any(), deliveredID).
+					Return(nil)
+
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-2").
+					Return([]entity.Payment{
+						{TransactionID: "bbbb", Status: constanta.FAILED, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+					}, nil)
+				s.mockOrderServiceClient.EXPECT().
+					CallbackTransaction(gomock.Any(), &gen.CallbackTransactionRequest{
+						TransactionId: "bbbb",
+						PaymentStatus: constanta.FAILED.String(),
+						OrderId:       "order-2",
+						PaidAmount:    &gen.Money{Units: 0, CurrencyCode: "IDR"},
+					}).
+					Return(nil, status.Error(codes.Unavailable, "order service unavailable"))
+				s.mockPaymentRepo.EXPECT().
+					FailCallback(gomock.Any(), failedID, constanta.CallbackPending, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, callbackStatus constanta.CallbackStatus, errMessage string, nextAttemptAt time.Time) error {
+						s.Contains(errMessage, "order service unavailable")
+						s.True(nextAttemptAt.After(time.Now()))
+						return nil
+					})
+
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-3").
+					Return([]entity.Payment{
+						{TransactionID: "cccc", Status: constanta.PAID, TotalAmount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}},
+					}, nil)
+				s.mockOrderServiceClient.EXPECT().
+					CallbackTransaction(gomock.Any(), &gen.CallbackTransactionRequest{
+						TransactionId: "cccc",
+						PaymentStatus: constanta.PAID.String(),
+						OrderId:       "order-3",
+						PaidAmount:    &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					}).
+					Return(nil, status.Error(codes.NotFound, "order not found"))
+				s.mockPaymentRepo.EXPECT().
+					FailCallback(gomock.Any(), rejectedID, constanta.CallbackRejected, gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedResp: 1,
+		},
+		{
+			name: "Success callback waits when the paid amount cannot be read",
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPendingCallbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]entity.CallbackOutbox{
+						{
+							ID:            deliveredID,
+							TransactionID: "aaaa",
+							OrderID:       "order-1",
+							PaymentStatus: constanta.PAID,
+							Status:        constanta.CallbackPending,
+						},
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentsByOrderID(gomock.Any(), "order-1").
+					Return(nil, errors.New("db error"))
+			},
+			expectedResp: 0,
+		},
+		{
+			name: "Error when getting pending callbacks",
+			setupMock: func() {
+				s.mockPaymentRepo.EXPECT().
+					GetPendingCallbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.DispatchCallbacks(context.Background())
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Zero(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedResp, resp)
+			}
+		})
+	}
+}
+
+func (s *PaymentServiceTestSuite) TestHandleWebhook() {
+	payment := &entity.Payment{
+		TransactionID:    "aaaa",
+		Status:           constanta.WAITING,
+		Provider:         provider.MockProviderName,
+		ProviderChargeID: "ch_1",
+	}
+
+	verifyWebhook := func(chargeID string, paymentStatus constanta.PaymentStatus) {
+		s.mockPaymentProvider.EXPECT().
+			VerifyWebhook(gomock.Any(), gomock.Any()).
+			Return(&provider.WebhookEvent{ID: "evt_1", ChargeID: chargeID, TransactionID: "aaaa", Status: paymentStatus}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		provider      string
+		setupMock     func()
+		expectedError string
+		expectedEvent *entity.WebhookEvent
+	}{
+		{
+			name:     "Success",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(payment, nil)
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "aaaa").
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.PAID},
+		},
+		{
+			name:     "Success duplicate event",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(&entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.PAID}, nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.PAID, Duplicate: true},
+		},
+		{
+			name:     "Success payment is settled by another request first",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(payment, nil)
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "aaaa").
+					Return(sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(&entity.Payment{TransactionID: "aaaa", Status: constanta.FAILED}, nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.FAILED},
+		},
+		{
+			name:     "Success payment is not waiting anymore",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.FAILED)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(&entity.Payment{
+						TransactionID:    "aaaa",
+						Status:           constanta.PAID,
+						Provider:         provider.MockProviderName,
+						ProviderChargeID: "ch_1",
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.PAID},
+		},
+		{
+			name:     "Success charge paid after the payment is cancelled is refunded",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(&entity.Payment{
+						TransactionID:    "aaaa",
+						Status:           constanta.CANCELLED,
+						TotalAmount:      &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+						Provider:         provider.MockProviderName,
+						ProviderChargeID: "ch_1",
+					}, nil)
+
+				// the order is not notified, it does not wait for the payment anymore
+				refund := entity.Refund{
+					ID:            "late:aaaa",
+					TransactionID: "aaaa",
+					Amount:        &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+					Reason:        "charge is paid after the payment is CANCELLED",
+				}
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), refund).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "late:aaaa").
+					Return([]entity.Refund{refund}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), provider.RefundRequest{
+						RefundID: "late:aaaa",
+						ChargeID: "ch_1",
+						Amount:   &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+						Reason:   "charge is paid after the payment is CANCELLED",
+					}).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.CANCELLED},
+		},
+		{
+			name:     "Error charge paid after the payment is cancelled cannot be refunded",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(&entity.Payment{
+						TransactionID:    "aaaa",
+						Status:           constanta.FAILED,
+						TotalAmount:      &gen.Money{Units: 1000, CurrencyCode: "IDR"},
+						Provider:         provider.MockProviderName,
+						ProviderChargeID: "ch_1",
+					}, nil)
+				s.mockPaymentRepo.EXPECT().
+					RefundPayment(gomock.Any(), gomock.Any()).
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					GetRefunds(gomock.Any(), "late:aaaa").
+					Return([]entity.Refund{{ID: "late:aaaa", TransactionID: "aaaa", Amount: &gen.Money{Units: 1000, CurrencyCode: "IDR"}}}, nil)
+				s.mockPaymentProvider.EXPECT().
+					RefundCharge(gomock.Any(), gomock.Any()).
+					Return(errors.New("provider is down"))
+				// the event is not recorded, so the provider sends it again
+			},
+			expectedError: "rpc error: code = Unavailable desc = failed to refund charge: provider is down",
+		},
+		{
+			name:     "Success charge is still waiting",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.WAITING)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(payment, nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedEvent: &entity.WebhookEvent{Provider: provider.MockProviderName, EventID: "evt_1", TransactionID: "aaaa", Status: constanta.WAITING},
+		},
+		{
+			name:          "Error unknown provider",
+			provider:      "stub",
+			setupMock:     func() {},
+			expectedError: "rpc error: code = NotFound desc = provider stub not found",
+		},
+		{
+			name:     "Error invalid signature",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					VerifyWebhook(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: invalid signature", provider.ErrInvalidWebhook))
+			},
+			expectedError: "rpc error: code = Unauthenticated desc = invalid webhook: invalid signature",
+		},
+		{
+			name:     "Error webhook is not supported",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				s.mockPaymentProvider.EXPECT().
+					VerifyWebhook(gomock.Any(), gomock.Any()).
+					Return(nil, provider.ErrWebhookNotSupported)
+			},
+			expectedError: "rpc error: code = Unimplemented desc = provider mock does not send webhook",
+		},
+		{
+			name:     "Error transaction not found",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "rpc error: code = NotFound desc = transaction not found",
+		},
+		{
+			name:     "Error charge of another transaction",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_2", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(payment, nil)
+			},
+			expectedError: "rpc error: code = InvalidArgument desc = charge ch_2 is not the charge of transaction aaaa",
+		},
+		{
+			name:     "Error event is not recorded",
+			provider: provider.MockProviderName,
+			setupMock: func() {
+				verifyWebhook("ch_1", constanta.PAID)
+				s.mockPaymentRepo.EXPECT().
+					GetWebhookEvent(gomock.Any(), provider.MockProviderName, "evt_1").
+					Return(nil, sql.ErrNoRows)
+				s.mockPaymentRepo.EXPECT().
+					GetPaymentByTransactionID(gomock.Any(), "aaaa").
+					Return(payment, nil)
+				s.mockPaymentRepo.EXPECT().
+					UpdatePaymentStatusWithCallback(gomock.Any(), constanta.PAID, "aaaa").
+					Return(nil)
+				s.mockPaymentRepo.EXPECT().
+					CreateWebhookEvent(gomock.Any(), gomock.Any()).
+					Return(errors.New("database is locked"))
+			},
+			expectedError: "rpc error: code = Internal desc = database is locked",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			event, err := s.svc.HandleWebhook(context.Background(), tt.provider, http.Header{}, []byte(`{}`))
+
+			if tt.expectedError != "" {
+				s.EqualError(err, tt.expectedError)
+				s.Nil(event)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedEvent.EventID, event.EventID)
+				s.Equal(tt.expectedEvent.Provider, event.Provider)
+				s.Equal(tt.expectedEvent.TransactionID, event.TransactionID)
+				s.Equal(tt.expectedEvent.Status, event.Status)
+				s.Equal(tt.expectedEvent.Duplicate, event.Duplicate)
+			}
+		})
+	}
+}
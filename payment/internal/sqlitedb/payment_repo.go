@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/payment/internal/constanta"
 	"github.com/elangreza/e-commerce/payment/internal/entity"
+	"github.com/elangreza/e-commerce/pkg/dbsql"
 	"github.com/elangreza/e-commerce/pkg/money"
 	"github.com/google/uuid"
 )
@@ -22,27 +24,51 @@ func NewPaymentRepository(db *sql.DB) *PaymentRepository {
 	}
 }
 
-func (p *PaymentRepository) CreatePayment(ctx context.Context, payment entity.Payment) error {
-	q := `INSERT INTO payments(id, status, total_amount, currency, transaction_id, order_id)
-	VALUES (?, ?, ?, ?, ?, ?);`
+// CreatePayment inserts the payment only when the waiting and paid payments of the order stay within the order amount.
+// The sum is checked by the insert itself, so the payments that are created at the same time for the same order are serialised,
+// entity.ErrPaymentExceedsOrder is returned when the payment would be more than the unpaid part of the order
+func (p *PaymentRepository) CreatePayment(ctx context.Context, payment entity.Payment, orderAmount *gen.Money) error {
+	q := `INSERT INTO payments(id, status, total_amount, currency, transaction_id, order_id, method, provider, provider_charge_id, payment_url)
+	SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+	WHERE (SELECT COALESCE(SUM(total_amount), 0) FROM payments WHERE order_id = ? AND status IN (?, ?, ?, ?)) + ? <= ?;`
 
 	id, err := uuid.NewV7()
 	if err != nil {
 		return err
 	}
 
-	_, err = p.db.ExecContext(ctx, q,
+	res, err := p.db.ExecContext(ctx, q,
 		id,
 		payment.Status,
 		payment.TotalAmount.Units,
 		payment.TotalAmount.CurrencyCode,
 		payment.TransactionID,
 		payment.OrderID,
+		payment.Method,
+		payment.Provider,
+		payment.ProviderChargeID,
+		payment.PaymentURL,
+		payment.OrderID,
+		constanta.WAITING,
+		constanta.PAID,
+		constanta.PARTIALLY_REFUNDED,
+		constanta.REFUNDED,
+		payment.TotalAmount.Units,
+		orderAmount.GetUnits(),
 	)
 	if err != nil {
 		return err
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: %d of order %s", entity.ErrPaymentExceedsOrder, orderAmount.GetUnits(), payment.OrderID)
+	}
+
 	return nil
 }
 
@@ -65,6 +91,53 @@ func (p *PaymentRepository) UpdatePaymentStatusByTransactionID(ctx context.Conte
 	return nil
 }
 
+// UpdatePaymentStatusWithCallback updates the WAITING payment status and writes the callback into outbox in a single transaction,
+// so the order service is always notified about the new status.
+// sql.ErrNoRows is returned when the payment is not WAITING anymore
+func (p *PaymentRepository) UpdatePaymentStatusWithCallback(ctx context.Context, paymentStatus constanta.PaymentStatus, transactionID string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	return dbsql.WithTransaction(p.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE payments
+			SET status = ?, updated_at = ?
+			WHERE transaction_id = ? AND status = ?;`,
+			paymentStatus.String(),
+			time.Now(),
+			transactionID,
+			constanta.WAITING,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO callback_outbox(id, transaction_id, payment_status, status, next_attempt_at)
+			VALUES (?, ?, ?, ?, DATETIME(?));`,
+			id,
+			transactionID,
+			paymentStatus,
+			constanta.CallbackPending,
+			time.Now().UTC(),
+		)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
 func (p *PaymentRepository) GetPaymentByTransactionID(ctx context.Context, transactionID string) (*entity.Payment, error) {
 	q := `SELECT 
 	id,
@@ -73,13 +146,18 @@ func (p *PaymentRepository) GetPaymentByTransactionID(ctx context.Context, trans
 	currency,
 	transaction_id,
 	order_id,
+	method,
+	provider,
+	provider_charge_id,
+	payment_url,
 	created_at,
-	updated_at
+	updated_at,
+	COALESCE((SELECT SUM(r.amount) FROM refunds r WHERE r.transaction_id = payments.transaction_id), 0)
 	FROM payments WHERE transaction_id = ?
 	`
 
 	var payment entity.Payment
-	var totalAmount int64
+	var totalAmount, refundedAmount int64
 	var currency string
 	err := p.db.QueryRowContext(ctx, q, transactionID).Scan(
 		&payment.ID,
@@ -88,8 +166,13 @@ func (p *PaymentRepository) GetPaymentByTransactionID(ctx context.Context, trans
 		&currency,
 		&payment.TransactionID,
 		&payment.OrderID,
+		&payment.Method,
+		&payment.Provider,
+		&payment.ProviderChargeID,
+		&payment.PaymentURL,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&refundedAmount,
 	)
 	if err != nil {
 		return nil, err
@@ -100,6 +183,11 @@ func (p *PaymentRepository) GetPaymentByTransactionID(ctx context.Context, trans
 		return nil, err
 	}
 
+	payment.RefundedAmount, err = money.New(refundedAmount, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	return &payment, nil
 }
 
@@ -111,6 +199,10 @@ func (p *PaymentRepository) GetExpiredPayments(ctx context.Context, duration tim
 	currency,
 	transaction_id,
 	order_id,
+	method,
+	provider,
+	provider_charge_id,
+	payment_url,
 	created_at,
 	updated_at
 	FROM payments WHERE status = ? AND updated_at < DATETIME(?);`
@@ -139,8 +231,72 @@ func (p *PaymentRepository) GetExpiredPayments(ctx context.Context, duration tim
 			&currency,
 			&payment.TransactionID,
 			&payment.OrderID,
+			&payment.Method,
+			&payment.Provider,
+			&payment.ProviderChargeID,
+			&payment.PaymentURL,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		payment.TotalAmount, err = money.New(totalAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+// GetPaymentsByOrderID returns every payment of the order with its refunded amount, the oldest first
+func (p *PaymentRepository) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]entity.Payment, error) {
+	q := `SELECT 
+	id,
+	status,
+	total_amount,
+	currency,
+	transaction_id,
+	order_id,
+	method,
+	provider,
+	provider_charge_id,
+	payment_url,
+	created_at,
+	updated_at,
+	COALESCE((SELECT SUM(r.amount) FROM refunds r WHERE r.transaction_id = payments.transaction_id), 0)
+	FROM payments WHERE order_id = ?
+	ORDER BY created_at, id;`
+
+	rows, err := p.db.QueryContext(ctx, q, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []entity.Payment{}
+	for rows.Next() {
+		var payment entity.Payment
+		var totalAmount, refundedAmount int64
+		var currency string
+		err := rows.Scan(
+			&payment.ID,
+			&payment.Status,
+			&totalAmount,
+			&currency,
+			&payment.TransactionID,
+			&payment.OrderID,
+			&payment.Method,
+			&payment.Provider,
+			&payment.ProviderChargeID,
+			&payment.PaymentURL,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
+			&refundedAmount,
 		)
 		if err != nil {
 			return nil, err
@@ -151,8 +307,389 @@ func (p *PaymentRepository) GetExpiredPayments(ctx context.Context, duration tim
 			return nil, err
 		}
 
+		payment.RefundedAmount, err = money.New(refundedAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+
 		payments = append(payments, payment)
 	}
 
 	return payments, nil
 }
+
+// CancelPayment cancels the WAITING payment without a callback, the order is the one that asks for it.
+// sql.ErrNoRows is returned when the payment is not WAITING anymore
+func (p *PaymentRepository) CancelPayment(ctx context.Context, transactionID string) error {
+	res, err := p.db.ExecContext(ctx, `UPDATE payments
+		SET status = ?, updated_at = ?
+		WHERE transaction_id = ? AND status = ?;`,
+		constanta.CANCELLED,
+		time.Now(),
+		transactionID,
+		constanta.WAITING,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetPendingCallbacks returns the callbacks that are ready to be delivered, the oldest first
+func (p *PaymentRepository) GetPendingCallbacks(ctx context.Context, now time.Time, limit int) ([]entity.CallbackOutbox, error) {
+	q := `SELECT 
+	c.id,
+	c.transaction_id,
+	p.order_id,
+	c.payment_status,
+	c.status,
+	c.attempt,
+	COALESCE(c.last_error, ''),
+	c.next_attempt_at,
+	c.created_at
+	FROM callback_outbox c
+	JOIN payments p ON p.transaction_id = c.transaction_id
+	WHERE c.status = ? AND c.next_attempt_at <= DATETIME(?)
+	ORDER BY c.created_at, c.id
+	LIMIT ?;`
+
+	rows, err := p.db.QueryContext(ctx, q,
+		constanta.CallbackPending,
+		now.UTC(),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	callbacks := []entity.CallbackOutbox{}
+	for rows.Next() {
+		var callback entity.CallbackOutbox
+		err := rows.Scan(
+			&callback.ID,
+			&callback.TransactionID,
+			&callback.OrderID,
+			&callback.PaymentStatus,
+			&callback.Status,
+			&callback.Attempt,
+			&callback.LastError,
+			&callback.NextAttemptAt,
+			&callback.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		callbacks = append(callbacks, callback)
+	}
+
+	return callbacks, nil
+}
+
+func (p *PaymentRepository) MarkCallbackDelivered(ctx context.Context, id uuid.UUID) error {
+	q := `UPDATE callback_outbox
+		SET status = ?, attempt = attempt + 1, last_error = NULL, updated_at = ?
+		WHERE id = ?;`
+
+	_, err := p.db.ExecContext(ctx, q,
+		constanta.CallbackDelivered,
+		time.Now(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FailCallback records the failed delivery.
+// Pending callback is delivered again after nextAttemptAt.
+func (p *PaymentRepository) FailCallback(ctx context.Context, id uuid.UUID, status constanta.CallbackStatus, errMessage string, nextAttemptAt time.Time) error {
+	q := `UPDATE callback_outbox
+		SET status = ?, attempt = attempt + 1, last_error = ?, next_attempt_at = DATETIME(?), updated_at = ?
+		WHERE id = ?;`
+
+	_, err := p.db.ExecContext(ctx, q,
+		status,
+		errMessage,
+		nextAttemptAt.UTC(),
+		time.Now(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RefundPayment records the refund and moves the payment into PARTIALLY_REFUNDED or REFUNDED in a single transaction.
+// The charge that is paid after its payment is cancelled or failed is refunded too, the payment keeps its status so it is never counted as paid.
+// The refund that is already recorded is not paid back again,
+// entity.ErrRefundExceedsPayment is returned when the sum of the refunds is more than the paid amount
+func (p *PaymentRepository) RefundPayment(ctx context.Context, refund entity.Refund) error {
+	return dbsql.WithTransaction(p.db, func(tx *sql.Tx) error {
+		var recorded int64
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(id) FROM refunds WHERE id = ?;`, refund.ID).Scan(&recorded)
+		if err != nil {
+			return err
+		}
+
+		if recorded > 0 {
+			return nil
+		}
+
+		var totalAmount, refundedAmount int64
+		err = tx.QueryRowContext(ctx, `SELECT 
+			total_amount,
+			COALESCE((SELECT SUM(r.amount) FROM refunds r WHERE r.transaction_id = payments.transaction_id), 0)
+			FROM payments WHERE transaction_id = ?;`,
+			refund.TransactionID,
+		).Scan(&totalAmount, &refundedAmount)
+		if err != nil {
+			return err
+		}
+
+		refundedAmount += refund.Amount.GetUnits()
+		if refundedAmount > totalAmount {
+			return fmt.Errorf("%w: %d is refunded of %d", entity.ErrRefundExceedsPayment, refundedAmount-refund.Amount.GetUnits(), totalAmount)
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO refunds(id, transaction_id, amount, currency, reason)
+			VALUES (?, ?, ?, ?, ?);`,
+			refund.ID,
+			refund.TransactionID,
+			refund.Amount.GetUnits(),
+			refund.Amount.GetCurrencyCode(),
+			refund.Reason,
+		)
+		if err != nil {
+			return err
+		}
+
+		paymentStatus := constanta.PARTIALLY_REFUNDED
+		if refundedAmount == totalAmount {
+			paymentStatus = constanta.REFUNDED
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE payments
+			SET status = ?, updated_at = ?
+			WHERE transaction_id = ? AND status IN (?, ?, ?);`,
+			paymentStatus.String(),
+			time.Now(),
+			refund.TransactionID,
+			constanta.PAID,
+			constanta.PARTIALLY_REFUNDED,
+			constanta.REFUNDED,
+		)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// RefundOrder spreads the refund over the paid payments of the order, the oldest first, in a single transaction.
+// Every part is recorded as the refund of its payment with the id of the order refund,
+// so the order refund that is already recorded is not paid back again.
+// entity.ErrRefundExceedsPayment is returned when the paid payments cannot cover the refund
+func (p *PaymentRepository) RefundOrder(ctx context.Context, orderID string, refund entity.Refund) error {
+	return dbsql.WithTransaction(p.db, func(tx *sql.Tx) error {
+		var recorded int64
+		// the refund that is recorded for a single payment before has the same id
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(id) FROM refunds WHERE order_refund_id = ? OR id = ?;`, refund.ID, refund.ID).Scan(&recorded)
+		if err != nil {
+			return err
+		}
+
+		if recorded > 0 {
+			return nil
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT 
+			transaction_id,
+			total_amount,
+			COALESCE((SELECT SUM(r.amount) FROM refunds r WHERE r.transaction_id = payments.transaction_id), 0)
+			FROM payments WHERE order_id = ? AND status IN (?, ?)
+			ORDER BY created_at, id;`,
+			orderID,
+			constanta.PAID,
+			constanta.PARTIALLY_REFUNDED,
+		)
+		if err != nil {
+			return err
+		}
+
+		type refundable struct {
+			transactionID  string
+			totalAmount    int64
+			refundedAmount int64
+		}
+
+		payments := []refundable{}
+		for rows.Next() {
+			var payment refundable
+			if err := rows.Scan(&payment.transactionID, &payment.totalAmount, &payment.refundedAmount); err != nil {
+				rows.Close()
+				return err
+			}
+			payments = append(payments, payment)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		remaining := refund.Amount.GetUnits()
+		for _, payment := range payments {
+			if remaining == 0 {
+				break
+			}
+
+			amount := min(payment.totalAmount-payment.refundedAmount, remaining)
+			if amount <= 0 {
+				continue
+			}
+			remaining -= amount
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO refunds(id, transaction_id, amount, currency, reason, order_refund_id)
+				VALUES (?, ?, ?, ?, ?, ?);`,
+				refund.ID+":"+payment.transactionID,
+				payment.transactionID,
+				amount,
+				refund.Amount.GetCurrencyCode(),
+				refund.Reason,
+				refund.ID,
+			)
+			if err != nil {
+				return err
+			}
+
+			paymentStatus := constanta.PARTIALLY_REFUNDED
+			if payment.refundedAmount+amount == payment.totalAmount {
+				paymentStatus = constanta.REFUNDED
+			}
+
+			_, err = tx.ExecContext(ctx, `UPDATE payments
+				SET status = ?, updated_at = ?
+				WHERE transaction_id = ?;`,
+				paymentStatus.String(),
+				time.Now(),
+				payment.transactionID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			return fmt.Errorf("%w: %d of %d cannot be refunded", entity.ErrRefundExceedsPayment, remaining, refund.Amount.GetUnits())
+		}
+
+		return nil
+	})
+}
+
+// GetRefunds returns the refund with the id, or the refunds that the refund of the order with the id is spread into
+func (p *PaymentRepository) GetRefunds(ctx context.Context, refundID string) ([]entity.Refund, error) {
+	q := `SELECT 
+	id,
+	transaction_id,
+	amount,
+	currency,
+	reason,
+	order_refund_id,
+	created_at
+	FROM refunds WHERE id = ? OR order_refund_id = ?
+	ORDER BY created_at, id;`
+
+	rows, err := p.db.QueryContext(ctx, q, refundID, refundID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []entity.Refund{}
+	for rows.Next() {
+		var refund entity.Refund
+		var amount int64
+		var currency string
+		err := rows.Scan(
+			&refund.ID,
+			&refund.TransactionID,
+			&amount,
+			&currency,
+			&refund.Reason,
+			&refund.OrderRefundID,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refund.Amount, err = money.New(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, nil
+}
+
+func (p *PaymentRepository) GetWebhookEvent(ctx context.Context, provider, eventID string) (*entity.WebhookEvent, error) {
+	q := `SELECT 
+	provider,
+	event_id,
+	transaction_id,
+	status,
+	created_at
+	FROM webhook_events WHERE provider = ? AND event_id = ?;`
+
+	var event entity.WebhookEvent
+	err := p.db.QueryRowContext(ctx, q, provider, eventID).Scan(
+		&event.Provider,
+		&event.EventID,
+		&event.TransactionID,
+		&event.Status,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// CreateWebhookEvent records the handled webhook, the event that is already recorded is kept as is
+func (p *PaymentRepository) CreateWebhookEvent(ctx context.Context, event entity.WebhookEvent) error {
+	q := `INSERT INTO webhook_events(provider, event_id, transaction_id, status)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT (provider, event_id) DO NOTHING;`
+
+	_, err := p.db.ExecContext(ctx, q,
+		event.Provider,
+		event.EventID,
+		event.TransactionID,
+		event.Status,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
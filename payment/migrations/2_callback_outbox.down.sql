@@ -0,0 +1 @@
+DROP TABLE IF EXISTS callback_outbox;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- callback to order service is written in the same transaction as payment status,
+-- then delivered by the dispatcher at least once
+CREATE TABLE callback_outbox (
+    id TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL REFERENCES payments(transaction_id),
+    payment_status TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_callback_outbox_status_next_attempt_at ON callback_outbox(status, next_attempt_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS refunds;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- every refund of the paid payment, the id is given by the caller so the same refund is only paid back once
+CREATE TABLE refunds (
+    id TEXT PRIMARY KEY,
+    transaction_id TEXT NOT NULL REFERENCES payments(transaction_id),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refunds_transaction_id ON refunds(transaction_id);
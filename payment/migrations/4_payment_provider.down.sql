@@ -0,0 +1,3 @@
+ALTER TABLE payments DROP COLUMN payment_url;
+ALTER TABLE payments DROP COLUMN provider_charge_id;
+ALTER TABLE payments DROP COLUMN provider;
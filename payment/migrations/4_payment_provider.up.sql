@@ -0,0 +1,4 @@
+-- the provider that charges the payment, the payment that is created before is charged by the mock provider
+ALTER TABLE payments ADD COLUMN provider TEXT NOT NULL DEFAULT 'mock';
+ALTER TABLE payments ADD COLUMN provider_charge_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN payment_url TEXT NOT NULL DEFAULT '';
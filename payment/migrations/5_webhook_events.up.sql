@@ -0,0 +1,9 @@
+-- every webhook that is handled, the provider may deliver the same event more than once
+CREATE TABLE webhook_events (
+    provider TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    transaction_id TEXT NOT NULL REFERENCES payments(transaction_id),
+    status TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, event_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_refunds_order_refund_id;
+ALTER TABLE refunds DROP COLUMN order_refund_id;
+DROP INDEX IF EXISTS idx_payments_order_id;
+ALTER TABLE payments DROP COLUMN method;
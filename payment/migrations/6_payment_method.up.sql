@@ -0,0 +1,9 @@
+-- an order can be paid by several payments with different methods, the payment that is created before is paid by card
+ALTER TABLE payments ADD COLUMN method TEXT NOT NULL DEFAULT 'CARD';
+
+CREATE INDEX idx_payments_order_id ON payments(order_id);
+
+-- the refund of the order is spread over its paid payments, every part is recorded with the id of the order refund
+ALTER TABLE refunds ADD COLUMN order_refund_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_refunds_order_refund_id ON refunds(order_refund_id);
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type (
+	callbackService interface {
+		DispatchCallbacks(ctx context.Context) (int, error)
+	}
+
+	// CallbackDispatcher delivers the payment callbacks written in outbox to order service
+	CallbackDispatcher struct {
+		closeChan chan struct{}
+		svc       callbackService
+		interval  time.Duration
+	}
+)
+
+func NewCallbackDispatcher(callbackService callbackService, interval time.Duration) *CallbackDispatcher {
+	cd := &CallbackDispatcher{
+		closeChan: make(chan struct{}),
+		svc:       callbackService,
+		interval:  interval,
+	}
+
+	go cd.backgroundJobs()
+
+	return cd
+}
+
+func (cd *CallbackDispatcher) dispatchCallbacks() error {
+	delivered, err := cd.svc.DispatchCallbacks(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if delivered > 0 {
+		fmt.Printf("delivering %d callback(s)\n", delivered)
+	}
+
+	return nil
+}
+
+func (cd *CallbackDispatcher) Close() {
+	cd.closeChan <- struct{}{}
+}
+
+func (cd *CallbackDispatcher) backgroundJobs() {
+	fmt.Println("running callback dispatcher")
+	ticker := time.NewTicker(cd.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := cd.dispatchCallbacks()
+			if err != nil {
+				fmt.Println("getting error from DispatchCallbacks", err)
+			}
+
+		case <-cd.closeChan:
+			fmt.Println("callback dispatcher closed")
+			return
+		}
+	}
+}
@@ -12,3 +12,18 @@ func AppendUserIDintoContextGrpcClient(ctx context.Context, userID uuid.UUID) co
 	md := metadata.New(map[string]string{string(globalcontanta.UserIDKey): userID.String()})
 	return metadata.NewOutgoingContext(ctx, md)
 }
+
+func AppendCartTokenIntoContextGrpcClient(ctx context.Context, cartToken string) context.Context {
+	md := metadata.New(map[string]string{string(globalcontanta.CartTokenKey): cartToken})
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// AppendCurrencyIntoContextGrpcClient keeps the metadata that is already set, e.g., user_id
+func AppendCurrencyIntoContextGrpcClient(ctx context.Context, currency string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, string(globalcontanta.CurrencyKey), currency)
+}
+
+// AppendRoleIntoContextGrpcClient keeps the metadata that is already set, e.g., user_id
+func AppendRoleIntoContextGrpcClient(ctx context.Context, role globalcontanta.Role) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, string(globalcontanta.RoleKey), string(role))
+}
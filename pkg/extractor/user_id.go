@@ -2,6 +2,7 @@ package extractor
 
 import (
 	"context"
+	"strings"
 
 	"github.com/elangreza/e-commerce/pkg/globalcontanta"
 
@@ -29,3 +30,74 @@ func ExtractUserIDFromMetadata(ctx context.Context) (uuid.UUID, error) {
 
 	return userID, nil
 }
+
+func ExtractCartTokenFromMetadata(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Errorf(codes.Unauthenticated, "unauthorized")
+	}
+	rawCartToken := md.Get(string(globalcontanta.CartTokenKey))
+
+	if len(rawCartToken) == 0 || rawCartToken[0] == "" {
+		return "", status.Errorf(codes.Unauthenticated, "not valid cart token")
+	}
+
+	return rawCartToken[0], nil
+}
+
+// ExtractCurrencyFromMetadata returns the display and settlement currency of the user,
+// empty when it is not sent
+func ExtractCurrencyFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	rawCurrency := md.Get(string(globalcontanta.CurrencyKey))
+	if len(rawCurrency) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(rawCurrency[0])
+}
+
+// ExtractAdminIDFromMetadata returns the id of the user only when the user has the admin role,
+// it is used by the back-office methods, e.g. the warehouse management
+func ExtractAdminIDFromMetadata(ctx context.Context) (uuid.UUID, error) {
+	userID, err := ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	rawRole := md.Get(string(globalcontanta.RoleKey))
+	if len(rawRole) == 0 || globalcontanta.Role(strings.ToUpper(rawRole[0])) != globalcontanta.RoleAdmin {
+		return uuid.Nil, status.Errorf(codes.PermissionDenied, "admin role is required")
+	}
+
+	return userID, nil
+}
+
+// ExtractBackOfficeRoleFromMetadata returns the role of the caller only when it is the admin or another service,
+// it is used by the back-office methods that are also called by the background jobs of the other services
+func ExtractBackOfficeRoleFromMetadata(ctx context.Context) (globalcontanta.Role, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Errorf(codes.Unauthenticated, "unauthorized")
+	}
+
+	rawRole := md.Get(string(globalcontanta.RoleKey))
+	if len(rawRole) > 0 && globalcontanta.Role(strings.ToUpper(rawRole[0])) == globalcontanta.RoleService {
+		return globalcontanta.RoleService, nil
+	}
+
+	_, err := ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return "", status.Errorf(codes.PermissionDenied, "admin or service role is required")
+		}
+		return "", err
+	}
+
+	return globalcontanta.RoleAdmin, nil
+}
@@ -2,4 +2,9 @@ package globalcontanta
 
 type ContextKey string
 
-const UserIDKey ContextKey = "user_id"
+const (
+	UserIDKey    ContextKey = "user_id"
+	CartTokenKey ContextKey = "cart_token"
+	CurrencyKey  ContextKey = "currency"
+	RoleKey      ContextKey = "role"
+)
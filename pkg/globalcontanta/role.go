@@ -0,0 +1,11 @@
+package globalcontanta
+
+// Role is the role of the user, it is kept in the api service and sent to the other services in the metadata
+type Role string
+
+const (
+	RoleCustomer Role = "CUSTOMER"
+	RoleAdmin    Role = "ADMIN"
+	// RoleService is the service that calls another service on its own, e.g. from its background job
+	RoleService Role = "SERVICE"
+)
@@ -45,6 +45,12 @@ var currencyFractionDigits = map[string]int{
 	// Add more as needed
 }
 
+// IsKnownCurrency reports whether the currency code is listed in the ISO 4217 table above
+func IsKnownCurrency(currencyCode string) bool {
+	_, ok := currencyFractionDigits[strings.ToUpper(currencyCode)]
+	return ok
+}
+
 // ValidateCurrency returns error if currency code is unknown or invalid format
 func ValidateCurrency(currencyCode string) error {
 	if len(currencyCode) != 3 {
@@ -0,0 +1,179 @@
+// pkg/money/exchange_rate.go
+package money
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/shopspring/decimal"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// ExchangeRateProvider returns the rate to convert one major unit of the from currency
+// into major units of the to currency (e.g., USD to IDR is 16000)
+type ExchangeRateProvider interface {
+	GetRate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
+type ExchangeRate struct {
+	From string          `json:"from"`
+	To   string          `json:"to"`
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// StaticRateProvider serves rates that are loaded once, e.g., from a file
+type StaticRateProvider struct {
+	rates map[string]decimal.Decimal
+}
+
+func NewStaticRateProvider(rates []ExchangeRate) (*StaticRateProvider, error) {
+	p := &StaticRateProvider{
+		rates: make(map[string]decimal.Decimal),
+	}
+
+	for _, rate := range rates {
+		if err := ValidateCurrency(rate.From); err != nil {
+			return nil, err
+		}
+		if err := ValidateCurrency(rate.To); err != nil {
+			return nil, err
+		}
+		if !rate.Rate.IsPositive() {
+			return nil, fmt.Errorf("rate of %s to %s must be positive", rate.From, rate.To)
+		}
+
+		p.rates[ratePair(rate.From, rate.To)] = rate.Rate
+	}
+
+	return p, nil
+}
+
+// NewStaticRateProviderFromFile loads the rates from a json file
+// with the format [{"from": "USD", "to": "IDR", "rate": "16000"}]
+func NewStaticRateProviderFromFile(path string) (*StaticRateProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []ExchangeRate{}
+	err = json.Unmarshal(b, &rates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate file: %w", err)
+	}
+
+	return NewStaticRateProvider(rates)
+}
+
+func (p *StaticRateProvider) GetRate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	return resolveRate(from, to, func(from, to string) (decimal.Decimal, bool, error) {
+		rate, ok := p.rates[ratePair(from, to)]
+		return rate, ok, nil
+	})
+}
+
+// DBRateProvider reads the rates from the exchange_rates table
+// (from_currency, to_currency, rate) of the service database
+type DBRateProvider struct {
+	db *sql.DB
+}
+
+func NewDBRateProvider(db *sql.DB) *DBRateProvider {
+	return &DBRateProvider{
+		db: db,
+	}
+}
+
+func (p *DBRateProvider) GetRate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	return resolveRate(from, to, func(from, to string) (decimal.Decimal, bool, error) {
+		q := `SELECT rate FROM exchange_rates WHERE from_currency = ? AND to_currency = ?;`
+
+		var rate decimal.Decimal
+		err := p.db.QueryRowContext(ctx, q, from, to).Scan(&rate)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return decimal.Zero, false, nil
+			}
+			return decimal.Zero, false, err
+		}
+
+		return rate, true, nil
+	})
+}
+
+// resolveRate looks up the direct rate, then the inverse of the opposite pair
+func resolveRate(from, to string, lookup func(from, to string) (decimal.Decimal, bool, error)) (decimal.Decimal, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	rate, ok, err := lookup(from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if ok {
+		return rate, nil
+	}
+
+	rate, ok, err = lookup(to, from)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if ok && rate.IsPositive() {
+		return decimal.NewFromInt(1).Div(rate), nil
+	}
+
+	return decimal.Zero, fmt.Errorf("%w: %s to %s", ErrRateNotFound, from, to)
+}
+
+func ratePair(from, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
+
+// ConvertWithRate converts money into the currency with the rate of one major unit.
+// The result is rounded half away from zero to the minor unit of the target currency
+func ConvertWithRate(m *gen.Money, to string, rate decimal.Decimal) (*gen.Money, error) {
+	m, err := FromProto(m)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateCurrency(to); err != nil {
+		return nil, err
+	}
+	if !rate.IsPositive() {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+
+	shift := FractionalDigits(to) - FractionalDigits(m.CurrencyCode)
+	units := decimal.NewFromInt(m.Units).Mul(rate).Shift(int32(shift)).Round(0)
+
+	return New(units.IntPart(), to)
+}
+
+// Convert converts money into the currency with the rate of the provider, the rate used is returned
+func Convert(ctx context.Context, provider ExchangeRateProvider, m *gen.Money, to string) (*gen.Money, decimal.Decimal, error) {
+	if m == nil {
+		return nil, decimal.Zero, fmt.Errorf("money is nil")
+	}
+
+	rate, err := provider.GetRate(ctx, m.CurrencyCode, to)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	converted, err := ConvertWithRate(m, to, rate)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	return converted, rate, nil
+}
@@ -0,0 +1,165 @@
+package money_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertWithRate(t *testing.T) {
+	tests := []struct {
+		name          string
+		money         *gen.Money
+		to            string
+		rate          string
+		expected      *gen.Money
+		expectedError string
+	}{
+		{
+			name:     "into the currency without minor unit",
+			money:    &gen.Money{Units: 100, CurrencyCode: "USD"},
+			to:       "IDR",
+			rate:     "16000",
+			expected: &gen.Money{Units: 16000, CurrencyCode: "IDR"},
+		},
+		{
+			name:     "half is rounded away from zero",
+			money:    &gen.Money{Units: 1, CurrencyCode: "USD"},
+			to:       "IDR",
+			rate:     "16250",
+			expected: &gen.Money{Units: 163, CurrencyCode: "IDR"},
+		},
+		{
+			name:     "into the currency with minor unit",
+			money:    &gen.Money{Units: 16000, CurrencyCode: "IDR"},
+			to:       "USD",
+			rate:     "0.0000625",
+			expected: &gen.Money{Units: 100, CurrencyCode: "USD"},
+		},
+		{
+			name:     "below half of the minor unit is rounded down",
+			money:    &gen.Money{Units: 79, CurrencyCode: "IDR"},
+			to:       "USD",
+			rate:     "0.0000625",
+			expected: &gen.Money{Units: 0, CurrencyCode: "USD"},
+		},
+		{
+			name:     "half of the minor unit is rounded up",
+			money:    &gen.Money{Units: 80, CurrencyCode: "IDR"},
+			to:       "USD",
+			rate:     "0.0000625",
+			expected: &gen.Money{Units: 1, CurrencyCode: "USD"},
+		},
+		{
+			name:     "into the currency with three digits minor unit",
+			money:    &gen.Money{Units: 1000, CurrencyCode: "USD"},
+			to:       "kwd",
+			rate:     "0.3075",
+			expected: &gen.Money{Units: 3075, CurrencyCode: "KWD"},
+		},
+		{
+			name:          "zero rate",
+			money:         &gen.Money{Units: 100, CurrencyCode: "USD"},
+			to:            "IDR",
+			rate:          "0",
+			expectedError: "rate must be positive",
+		},
+		{
+			name:          "negative rate",
+			money:         &gen.Money{Units: 100, CurrencyCode: "USD"},
+			to:            "IDR",
+			rate:          "-16000",
+			expectedError: "rate must be positive",
+		},
+		{
+			name:          "invalid target currency",
+			money:         &gen.Money{Units: 100, CurrencyCode: "USD"},
+			to:            "RUPIAH",
+			rate:          "16000",
+			expectedError: "currency code must be 3 letters",
+		},
+		{
+			name:          "nil money",
+			to:            "IDR",
+			rate:          "16000",
+			expectedError: "money is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := money.ConvertWithRate(tt.money, tt.to, decimal.RequireFromString(tt.rate))
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, money.Equals(tt.expected, res), "expected %v, got %v", tt.expected, res)
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	provider, err := money.NewStaticRateProvider([]money.ExchangeRate{
+		{From: "USD", To: "IDR", Rate: decimal.NewFromInt(16000)},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		money         *gen.Money
+		to            string
+		expected      *gen.Money
+		expectedRate  string
+		expectedError error
+	}{
+		{
+			name:         "direct rate",
+			money:        &gen.Money{Units: 250, CurrencyCode: "USD"},
+			to:           "IDR",
+			expected:     &gen.Money{Units: 40000, CurrencyCode: "IDR"},
+			expectedRate: "16000",
+		},
+		{
+			name:         "inverse of the opposite rate",
+			money:        &gen.Money{Units: 32000, CurrencyCode: "IDR"},
+			to:           "usd",
+			expected:     &gen.Money{Units: 200, CurrencyCode: "USD"},
+			expectedRate: "0.0000625",
+		},
+		{
+			name:         "same currency",
+			money:        &gen.Money{Units: 250, CurrencyCode: "USD"},
+			to:           "USD",
+			expected:     &gen.Money{Units: 250, CurrencyCode: "USD"},
+			expectedRate: "1",
+		},
+		{
+			name:          "missing rate",
+			money:         &gen.Money{Units: 250, CurrencyCode: "USD"},
+			to:            "EUR",
+			expectedError: money.ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, rate, err := money.Convert(context.Background(), provider, tt.money, tt.to)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, money.Equals(tt.expected, res), "expected %v, got %v", tt.expected, res)
+			require.True(t, decimal.RequireFromString(tt.expectedRate).Equal(rate), "expected rate %s, got %s", tt.expectedRate, rate)
+		})
+	}
+}
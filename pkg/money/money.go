@@ -3,6 +3,7 @@ package money
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/elangreza/e-commerce/gen" // adjust import as needed
@@ -107,3 +108,73 @@ func MultiplyByInt(m *gen.Money, n int64) (*gen.Money, error) {
 	}
 	return New(m.Units*n, m.CurrencyCode)
 }
+
+// Subtract subtracts b from a (must have same currency), the result cannot be negative
+func Subtract(a, b *gen.Money) (*gen.Money, error) {
+	if !strings.EqualFold(a.CurrencyCode, b.CurrencyCode) {
+		return nil, fmt.Errorf("cannot subtract different currencies: %s vs %s", a.CurrencyCode, b.CurrencyCode)
+	}
+	return New(a.Units-b.Units, a.CurrencyCode)
+}
+
+// MultiplyByDecimal multiplies money by a decimal factor (e.g., 0.15 for 15% off).
+// The result is rounded half away from zero to the minor unit
+func MultiplyByDecimal(m *gen.Money, factor decimal.Decimal) (*gen.Money, error) {
+	if factor.IsNegative() {
+		return nil, fmt.Errorf("multiplier must be non-negative")
+	}
+	units := decimal.NewFromInt(m.Units).Mul(factor).Round(0)
+	return New(units.IntPart(), m.CurrencyCode)
+}
+
+// Allocate splits money by the weights with the largest remainder method,
+// so the parts always sum up to the original amount without losing a minor unit
+func Allocate(m *gen.Money, weights []int64) ([]*gen.Money, error) {
+	var totalWeight int64
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("weight must be non-negative")
+		}
+		if weight > math.MaxInt64-totalWeight {
+			return nil, fmt.Errorf("total weight overflows int64")
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("total weight must be positive")
+	}
+
+	units := make([]int64, len(weights))
+	remainders := make([]int64, len(weights))
+	var allocated int64
+	for i, weight := range weights {
+		// m.Units * weight can overflow int64, so the share is calculated with decimal
+		share := decimal.NewFromInt(m.Units).Mul(decimal.NewFromInt(weight))
+		units[i] = share.Div(decimal.NewFromInt(totalWeight)).Floor().IntPart()
+		remainders[i] = share.Mod(decimal.NewFromInt(totalWeight)).IntPart()
+		allocated += units[i]
+	}
+
+	// the leftover minor units go to the largest remainders, the first one wins on ties
+	for left := m.Units - allocated; left > 0; left-- {
+		largest := -1
+		for i := range remainders {
+			if remainders[i] >= 0 && (largest == -1 || remainders[i] > remainders[largest]) {
+				largest = i
+			}
+		}
+		units[largest]++
+		remainders[largest] = -1
+	}
+
+	res := make([]*gen.Money, len(weights))
+	for i := range units {
+		part, err := New(units[i], m.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = part
+	}
+
+	return res, nil
+}
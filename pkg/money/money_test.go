@@ -0,0 +1,191 @@
+package money_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiplyByDecimal(t *testing.T) {
+	tests := []struct {
+		name          string
+		money         *gen.Money
+		factor        string
+		expectedUnits int64
+		expectedError string
+	}{
+		{
+			name:          "exact result",
+			money:         &gen.Money{Units: 1000, CurrencyCode: "USD"},
+			factor:        "0.15",
+			expectedUnits: 150,
+		},
+		{
+			name:          "half is rounded away from zero",
+			money:         &gen.Money{Units: 25, CurrencyCode: "USD"},
+			factor:        "0.1",
+			expectedUnits: 3,
+		},
+		{
+			name:          "odd half is rounded away from zero",
+			money:         &gen.Money{Units: 15, CurrencyCode: "USD"},
+			factor:        "0.1",
+			expectedUnits: 2,
+		},
+		{
+			name:          "below half is rounded down",
+			money:         &gen.Money{Units: 333, CurrencyCode: "IDR"},
+			factor:        "0.3333",
+			expectedUnits: 111,
+		},
+		{
+			name:          "above half is rounded up",
+			money:         &gen.Money{Units: 333, CurrencyCode: "IDR"},
+			factor:        "0.335",
+			expectedUnits: 112,
+		},
+		{
+			name:          "zero factor",
+			money:         &gen.Money{Units: 1000, CurrencyCode: "USD"},
+			factor:        "0",
+			expectedUnits: 0,
+		},
+		{
+			name:          "negative factor",
+			money:         &gen.Money{Units: 1000, CurrencyCode: "USD"},
+			factor:        "-0.1",
+			expectedError: "multiplier must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := money.MultiplyByDecimal(tt.money, decimal.RequireFromString(tt.factor))
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				require.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedUnits, res.Units)
+			require.Equal(t, tt.money.CurrencyCode, res.CurrencyCode)
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name          string
+		units         int64
+		weights       []int64
+		expectedUnits []int64
+		expectedError string
+	}{
+		{
+			name:          "even split",
+			units:         100,
+			weights:       []int64{1, 1},
+			expectedUnits: []int64{50, 50},
+		},
+		{
+			name:          "the first part wins on ties",
+			units:         100,
+			weights:       []int64{1, 1, 1},
+			expectedUnits: []int64{34, 33, 33},
+		},
+		{
+			name:          "the leftovers go to the largest remainders",
+			units:         100,
+			weights:       []int64{1, 2, 3},
+			expectedUnits: []int64{17, 33, 50},
+		},
+		{
+			name:          "the largest remainder wins over the first part",
+			units:         10,
+			weights:       []int64{1, 2},
+			expectedUnits: []int64{3, 7},
+		},
+		{
+			name:          "more parts than units",
+			units:         2,
+			weights:       []int64{1, 1, 1},
+			expectedUnits: []int64{1, 1, 0},
+		},
+		{
+			name:          "zero weight gets nothing",
+			units:         10,
+			weights:       []int64{3, 0, 7},
+			expectedUnits: []int64{3, 0, 7},
+		},
+		{
+			name:          "zero amount",
+			units:         0,
+			weights:       []int64{1, 2},
+			expectedUnits: []int64{0, 0},
+		},
+		{
+			name:          "amount times weight overflows int64",
+			units:         math.MaxInt64,
+			weights:       []int64{1, 1},
+			expectedUnits: []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2},
+		},
+		{
+			name:          "large weights",
+			units:         1000,
+			weights:       []int64{math.MaxInt64 / 2, math.MaxInt64 / 2},
+			expectedUnits: []int64{500, 500},
+		},
+		{
+			name:          "total weight overflows int64",
+			units:         1000,
+			weights:       []int64{math.MaxInt64, math.MaxInt64, 3},
+			expectedError: "total weight overflows",
+		},
+		{
+			name:          "negative weight",
+			units:         100,
+			weights:       []int64{1, -1},
+			expectedError: "weight must be non-negative",
+		},
+		{
+			name:          "all weights are zero",
+			units:         100,
+			weights:       []int64{0, 0},
+			expectedError: "total weight must be positive",
+		},
+		{
+			name:          "no weights",
+			units:         100,
+			weights:       nil,
+			expectedError: "total weight must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := money.Allocate(&gen.Money{Units: tt.units, CurrencyCode: "USD"}, tt.weights)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				require.Nil(t, parts)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, parts, len(tt.weights))
+
+			var total int64
+			for i, part := range parts {
+				require.Equal(t, tt.expectedUnits[i], part.Units)
+				require.Equal(t, "USD", part.CurrencyCode)
+				total += part.Units
+			}
+			// no minor unit is lost or created
+			require.Equal(t, tt.units, total)
+		})
+	}
+}
@@ -14,6 +14,7 @@ type Product struct {
 	CreatedAt   string     `json:"created_at"`
 	UpdatedAt   string     `json:"updated_at"`
 	ShopID      int64      `json:"shop_id"`
+	Category    string     `json:"category"`
 }
 
 type ListProductRequest struct {
@@ -95,6 +95,7 @@ func (p *ProductService) ListProducts(ctx context.Context, req *gen.ListProducts
 			ImageUrl:    product.ImageUrl,
 			Stock:       stock,
 			ShopId:      product.ShopID,
+			Category:    product.Category,
 		}
 	}
 
@@ -150,6 +151,7 @@ func (p *ProductService) GetProducts(ctx context.Context, req *gen.GetProductsRe
 			ImageUrl:    product.ImageUrl,
 			Stock:       stock,
 			ShopId:      product.ShopID,
+			Category:    product.Category,
 		}
 	}
 
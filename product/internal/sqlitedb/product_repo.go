@@ -39,7 +39,7 @@ func (pm *ProductRepository) ListProducts(ctx context.Context, req entity.ListPr
 	}
 
 	// Build final query
-	query := `SELECT id, name, description, price, currency, image_url, created_at, updated_at, shop_id
+	query := `SELECT id, name, description, price, currency, image_url, created_at, updated_at, shop_id, category
               FROM products
               WHERE ` + strings.Join(whereClauses, " AND ") + orderClause + ` LIMIT ? OFFSET ?`
 
@@ -67,6 +67,7 @@ func (pm *ProductRepository) ListProducts(ctx context.Context, req entity.ListPr
 			&p.CreatedAt,
 			&p.UpdatedAt,
 			&p.ShopID,
+			&p.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -108,7 +109,8 @@ func (pm *ProductRepository) GetProductByIDs(ctx context.Context, productID ...u
 		image_url,
 		created_at,
 		updated_at,
-		shop_id
+		shop_id,
+		category
 	from products
 	where id = ?`
 	args := []any{}
@@ -128,7 +130,8 @@ func (pm *ProductRepository) GetProductByIDs(ctx context.Context, productID ...u
 		image_url,
 		created_at,
 		updated_at,
-		shop_id
+		shop_id,
+		category
 	from products
 	where id IN (` + qMarks + `)`
 	}
@@ -152,7 +155,8 @@ func (pm *ProductRepository) GetProductByIDs(ctx context.Context, productID ...u
 			&p.ImageUrl,
 			&p.CreatedAt,
 			&p.UpdatedAt,
-			&p.ShopID)
+			&p.ShopID,
+			&p.Category)
 		if err != nil {
 			return nil, err
 		}
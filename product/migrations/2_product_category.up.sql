@@ -0,0 +1,2 @@
+-- category is used by the tax rules of the order service, empty is uncategorized
+ALTER TABLE products ADD COLUMN category TEXT NOT NULL DEFAULT '';
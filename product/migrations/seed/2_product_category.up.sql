@@ -0,0 +1,28 @@
+UPDATE products SET category = 'bags' WHERE id = '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9a';
+
+UPDATE products SET category = 'clothing' WHERE id IN (
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9b',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9c',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9d',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca8',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca9',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5caa',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5cab',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5cac',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5cad'
+);
+
+UPDATE products SET category = 'jewelery' WHERE id IN (
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9e',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5c9f',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca1'
+);
+
+UPDATE products SET category = 'electronics' WHERE id IN (
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca2',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca3',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca4',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca5',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca6',
+    '019394d0-4d5e-7d6a-9c4b-8a3f2e1d5ca7'
+);
@@ -6,20 +6,27 @@ import (
 	"log"
 	"time"
 
+	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/warehouse/internal/server"
 	"github.com/elangreza/e-commerce/warehouse/internal/service"
 	"github.com/elangreza/e-commerce/warehouse/internal/sqlitedb"
+	"github.com/elangreza/e-commerce/warehouse/internal/task"
 
 	"github.com/elangreza/e-commerce/pkg/config"
 	"github.com/elangreza/e-commerce/pkg/dbsql"
 	"github.com/elangreza/e-commerce/pkg/gracefulshutdown"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
 type Config struct {
-	ServicePort string `koanf:"SERVICE_PORT"`
-	DBPath      string `koanf:"DB_PATH"`
+	ServicePort      string `koanf:"SERVICE_PORT"`
+	DBPath           string `koanf:"DB_PATH"`
+	OrderServiceAddr string `koanf:"ORDER_SERVICE_ADDR"`
+	// ReservationTTL is how long the stock is reserved when the order does not set the expiry
+	ReservationTTL time.Duration `koanf:"RESERVATION_TTL"`
 }
 
 func main() {
@@ -41,8 +48,17 @@ func main() {
 	)
 	errChecker(err)
 
+	// order client
+	grpcClientOrder, err := grpc.NewClient(cfg.OrderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	errChecker(err)
+
+	reservationTTL := cfg.ReservationTTL
+	if reservationTTL <= 0 {
+		reservationTTL = 15 * time.Minute
+	}
+
 	warehouseRepo := sqlitedb.NewWarehouseRepo(db)
-	warehouseService := service.NewWarehouseService(warehouseRepo)
+	warehouseService := service.NewWarehouseService(warehouseRepo, gen.NewOrderServiceClient(grpcClientOrder), reservationTTL)
 
 	addr := fmt.Sprintf(":%s", cfg.ServicePort)
 
@@ -56,6 +72,8 @@ func main() {
 
 	fmt.Printf("WAREHOUSE-service running at %s\n", addr)
 
+	taskWarehouse := task.NewTaskWarehouse(warehouseService)
+
 	gs := gracefulshutdown.New(context.Background(), 5*time.Second,
 		gracefulshutdown.Operation{
 			Name: "grpc",
@@ -64,6 +82,13 @@ func main() {
 				return nil
 			},
 		},
+		gracefulshutdown.Operation{
+			Name: "task warehouse",
+			ShutdownFunc: func(ctx context.Context) error {
+				taskWarehouse.Close()
+				return nil
+			},
+		},
 		gracefulshutdown.Operation{
 			Name: "sqlite",
 			ShutdownFunc: func(ctx context.Context) error {
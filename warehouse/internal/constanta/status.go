@@ -7,4 +7,6 @@ type (
 const (
 	ReservedStockStatusReserved ReservedStockStatus = "reserved"
 	ReservedStockStatusReleased ReservedStockStatus = "released"
+	// the order is paid, the stock is sold and cannot be released
+	ReservedStockStatusConfirmed ReservedStockStatus = "confirmed"
 )
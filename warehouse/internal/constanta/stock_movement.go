@@ -0,0 +1,65 @@
+package constanta
+
+type (
+	StockMovementType string
+)
+
+const (
+	// the stock that exists before the ledger is recorded with its balance
+	StockMovementOpening StockMovementType = "OPENING"
+	StockMovementReceive StockMovementType = "RECEIVE"
+	StockMovementReserve StockMovementType = "RESERVE"
+	StockMovementRelease StockMovementType = "RELEASE"
+	// the reserved stock is sold, it is already taken from the balance when it is reserved
+	StockMovementConfirm     StockMovementType = "CONFIRM"
+	StockMovementTransferOut StockMovementType = "TRANSFER_OUT"
+	StockMovementTransferIn  StockMovementType = "TRANSFER_IN"
+	// the refunded stock is returned into the warehouse
+	StockMovementRestock    StockMovementType = "RESTOCK"
+	StockMovementAdjustment StockMovementType = "ADJUSTMENT"
+)
+
+// StockMovementActorSystem is the actor of the movement that is not requested by a user, e.g. the expired reservation
+const StockMovementActorSystem = "SYSTEM"
+
+func (t StockMovementType) IsValid() bool {
+	switch t {
+	case StockMovementOpening,
+		StockMovementReceive,
+		StockMovementReserve,
+		StockMovementRelease,
+		StockMovementConfirm,
+		StockMovementTransferOut,
+		StockMovementTransferIn,
+		StockMovementRestock,
+		StockMovementAdjustment:
+		return true
+	}
+
+	return false
+}
+
+type (
+	StockAdjustmentReason string
+)
+
+const (
+	StockAdjustmentReasonDamage     StockAdjustmentReason = "DAMAGE"
+	StockAdjustmentReasonLoss       StockAdjustmentReason = "LOSS"
+	StockAdjustmentReasonCycleCount StockAdjustmentReason = "CYCLE_COUNT"
+)
+
+func (r StockAdjustmentReason) IsValid() bool {
+	switch r {
+	case StockAdjustmentReasonDamage, StockAdjustmentReasonLoss, StockAdjustmentReasonCycleCount:
+		return true
+	}
+
+	return false
+}
+
+// IsDecreaseOnly reports whether the stock can only be taken by the adjustment of the reason,
+// the difference of a cycle count can be either way
+func (r StockAdjustmentReason) IsDecreaseOnly() bool {
+	return r == StockAdjustmentReasonDamage || r == StockAdjustmentReasonLoss
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrReservationMismatch is returned when the reserved order is reserved again with different quantities
+	ErrReservationMismatch = errors.New("reservation does not match")
+	// ErrReservationConfirmed is returned when the stock of the paid order is released
+	ErrReservationConfirmed = errors.New("reservation is confirmed")
+	// ErrNoReservedStock is returned when the order has no reserved stock to confirm, e.g. it is released
+	ErrNoReservedStock = errors.New("no reserved stock")
+)
+
+// ExtendReservation moves the expiry of the reserved stock of the order, the expiry is never moved earlier
+type ExtendReservation struct {
+	OrderID   string    `json:"order_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Reservation is the stock that is reserved for an order, every order is reserved once
+type Reservation struct {
+	OrderID string                        `json:"order_id"`
+	UserID  uuid.UUID                     `json:"user_id"`
+	Status  constanta.ReservedStockStatus `json:"status"`
+	// Stocks is the quantity of the products that is requested by the order
+	Stocks         []Stock         `json:"stocks"`
+	ReservedStocks []ReservedStock `json:"reserved_stocks"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// ReservedStock is the quantity of the order that is taken from a stock
+type ReservedStock struct {
+	ID          int64                         `json:"id"`
+	StockID     int64                         `json:"stock_id"`
+	WarehouseID int64                         `json:"warehouse_id"`
+	ProductID   uuid.UUID                     `json:"product_id"`
+	Quantity    int64                         `json:"quantity"`
+	Status      constanta.ReservedStockStatus `json:"status"`
+	// ExpiresAt is zero for the stock that is reserved before the reservation can expire
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MergeStocks sums the quantity of the same product, the products keep the order they are requested
+func MergeStocks(stocks []Stock) []Stock {
+	merged := []Stock{}
+	index := map[uuid.UUID]int{}
+	for _, stock := range stocks {
+		if i, ok := index[stock.ProductID]; ok {
+			merged[i].Quantity += stock.Quantity
+			continue
+		}
+		index[stock.ProductID] = len(merged)
+		merged = append(merged, Stock{ProductID: stock.ProductID, Quantity: stock.Quantity})
+	}
+
+	return merged
+}
+
+// HasSameStocks reports whether the reservation is requested with the same quantity of every product
+func (r *Reservation) HasSameStocks(stocks []Stock) bool {
+	merged := MergeStocks(stocks)
+	if len(merged) != len(r.Stocks) {
+		return false
+	}
+
+	quantities := map[uuid.UUID]int64{}
+	for _, stock := range r.Stocks {
+		quantities[stock.ProductID] = stock.Quantity
+	}
+
+	for _, stock := range merged {
+		if quantities[stock.ProductID] != stock.Quantity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ReservedStockIDs returns the id of every reserved stock with the status, or of every reserved stock when the status is empty
+func (r *Reservation) ReservedStockIDs(status constanta.ReservedStockStatus) []int64 {
+	ids := []int64{}
+	for _, reservedStock := range r.ReservedStocks {
+		if status == "" || reservedStock.Status == status {
+			ids = append(ids, reservedStock.ID)
+		}
+	}
+
+	return ids
+}
+
+// ExpiresAt is the latest expiry of the stock that is still reserved
+func (r *Reservation) ExpiresAt() time.Time {
+	var expiresAt time.Time
+	for _, reservedStock := range r.ReservedStocks {
+		if reservedStock.Status == constanta.ReservedStockStatusReserved && reservedStock.ExpiresAt.After(expiresAt) {
+			expiresAt = reservedStock.ExpiresAt
+		}
+	}
+
+	return expiresAt
+}
+
+func (r *Reservation) GetGenReservation() *gen.Reservation {
+	stocks := []*gen.Stock{}
+	for _, stock := range r.Stocks {
+		stocks = append(stocks, &gen.Stock{
+			ProductId: stock.ProductID.String(),
+			Quantity:  stock.Quantity,
+		})
+	}
+
+	reservedStocks := []*gen.ReservedStock{}
+	for _, reservedStock := range r.ReservedStocks {
+		reservedStocks = append(reservedStocks, &gen.ReservedStock{
+			Id:          reservedStock.ID,
+			StockId:     reservedStock.StockID,
+			WarehouseId: reservedStock.WarehouseID,
+			ProductId:   reservedStock.ProductID.String(),
+			Quantity:    reservedStock.Quantity,
+			Status:      string(reservedStock.Status),
+			ExpiresAt:   FormatTime(reservedStock.ExpiresAt),
+		})
+	}
+
+	return &gen.Reservation{
+		OrderId:        r.OrderID,
+		Status:         string(r.Status),
+		Stocks:         stocks,
+		ReservedStocks: reservedStocks,
+		CreatedAt:      FormatTime(r.CreatedAt),
+	}
+}
+
+// FormatTime returns the time in RFC3339, the zero time is empty
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
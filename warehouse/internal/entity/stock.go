@@ -1,6 +1,11 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/google/uuid"
+)
 
 type Stock struct {
 	ID        int64     `json:"id"`
@@ -9,12 +14,63 @@ type Stock struct {
 }
 
 type ReserveStock struct {
-	Stocks  []Stock   `json:"stocks"`
+	Stocks    []Stock   `json:"stocks"`
+	OrderID   string    `json:"order_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ReleaseStock struct {
 	OrderID string    `json:"order_id"`
 	UserID  uuid.UUID `json:"user_id"`
+	// ExpiredBefore only releases the reserved stock that expires before it, every reserved stock is released when it is zero
+	ExpiredBefore time.Time `json:"expired_before"`
+	// Actor is recorded in the ledger, it is the user or SYSTEM when the reservation is expired
+	Actor string `json:"actor"`
 }
 
-type ReleaseStock struct {
+type ConfirmStock struct {
 	OrderID string    `json:"order_id"`
 	UserID  uuid.UUID `json:"user_id"`
 }
+
+// RestockStock returns the refunded quantity of the products into the warehouses of the confirmed stock
+type RestockStock struct {
+	Stocks   []Stock   `json:"stocks"`
+	OrderID  string    `json:"order_id"`
+	RefundID string    `json:"refund_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	// WarehouseID is the warehouse that receives the stock, 0 returns it into the warehouse it is taken from
+	WarehouseID int64 `json:"warehouse_id"`
+}
+
+// ReservedOrder is the order that still holds reserved stock
+type ReservedOrder struct {
+	OrderID     string    `json:"order_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	WarehouseID int64     `json:"warehouse_id"`
+}
+
+// StockLot is the stock of a lot with its expiry
+type StockLot struct {
+	StockID     int64     `json:"stock_id"`
+	WarehouseID int64     `json:"warehouse_id"`
+	ProductID   string    `json:"product_id"`
+	ShopID      int64     `json:"shop_id"`
+	LotNumber   string    `json:"lot_number"`
+	Quantity    int64     `json:"quantity"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (l StockLot) GetGenStockLot(now time.Time) *gen.StockLot {
+	return &gen.StockLot{
+		StockId:     l.StockID,
+		WarehouseId: l.WarehouseID,
+		ProductId:   l.ProductID,
+		ShopId:      l.ShopID,
+		LotNumber:   l.LotNumber,
+		Quantity:    l.Quantity,
+		ExpiresAt:   FormatTime(l.ExpiresAt),
+		Expired:     !l.ExpiresAt.After(now),
+	}
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elangreza/e-commerce/gen"
+	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrWarehouseInactive is returned when the goods are received into an inactive warehouse
+	ErrWarehouseInactive = errors.New("warehouse is inactive")
+	// ErrInsufficientStock is returned when the adjustment takes more than the quantity of the stock
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrLotMismatch is returned when the lot is received again with another expiry
+	ErrLotMismatch = errors.New("lot does not match")
+)
+
+// StockMovement is a record of the append-only ledger of the stock
+type StockMovement struct {
+	ID          int64                       `json:"id"`
+	StockID     int64                       `json:"stock_id"`
+	WarehouseID int64                       `json:"warehouse_id"`
+	ProductID   string                      `json:"product_id"`
+	Type        constanta.StockMovementType `json:"type"`
+	// Quantity is the change of the quantity of the stock, negative when it is taken from the stock
+	Quantity int64 `json:"quantity"`
+	// Balance is the quantity of the stock after the movement
+	Balance   int64     `json:"balance"`
+	Actor     string    `json:"actor"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (m StockMovement) GetGenStockMovement() *gen.StockMovement {
+	return &gen.StockMovement{
+		Id:          m.ID,
+		StockId:     m.StockID,
+		WarehouseId: m.WarehouseID,
+		ProductId:   m.ProductID,
+		Type:        string(m.Type),
+		Quantity:    m.Quantity,
+		Balance:     m.Balance,
+		Actor:       m.Actor,
+		Reference:   m.Reference,
+		CreatedAt:   FormatTime(m.CreatedAt),
+	}
+}
+
+// ListStockMovements filters the ledger, the empty field is not filtered
+type ListStockMovements struct {
+	ProductID   string
+	WarehouseID int64
+	Type        constanta.StockMovementType
+	Reference   string
+	// From includes the movements at the time, To excludes them
+	From  time.Time
+	To    time.Time
+	Limit int64
+	Page  int64
+}
+
+func (l ListStockMovements) GetTotalPages(total int64) int64 {
+	if l.Limit == 0 {
+		return 0
+	}
+	totalPages := total / l.Limit
+	if total%l.Limit != 0 {
+		totalPages++
+	}
+	return totalPages
+}
+
+// TransferStock moves the quantity of the product from a warehouse into another
+type TransferStock struct {
+	TransferID      string `json:"transfer_id"`
+	FromWarehouseID int64  `json:"from_warehouse_id"`
+	ToWarehouseID   int64  `json:"to_warehouse_id"`
+	ProductID       string `json:"product_id"`
+	Quantity        int64  `json:"quantity"`
+	Actor           string `json:"actor"`
+}
+
+// ReceiveStock puts the received goods of the shop into the stock of the warehouse
+type ReceiveStock struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID int64     `json:"warehouse_id"`
+	ShopID      int64     `json:"shop_id"`
+	Quantity    int64     `json:"quantity"`
+	LotNumber   string    `json:"lot_number"`
+	ReceivedAt  time.Time `json:"received_at"`
+	// ExpiresAt is the expiry of the lot, it is zero when the goods do not expire
+	ExpiresAt time.Time `json:"expires_at"`
+	Actor     string    `json:"actor"`
+}
+
+// AdjustStock corrects the quantity of the stock of the shop in the warehouse
+type AdjustStock struct {
+	ProductID   uuid.UUID                       `json:"product_id"`
+	WarehouseID int64                           `json:"warehouse_id"`
+	ShopID      int64                           `json:"shop_id"`
+	LotNumber   string                          `json:"lot_number"`
+	Quantity    int64                           `json:"quantity"`
+	Reason      constanta.StockAdjustmentReason `json:"reason"`
+	Note        string                          `json:"note"`
+	Actor       string                          `json:"actor"`
+}
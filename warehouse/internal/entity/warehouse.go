@@ -4,4 +4,6 @@ type Warehouse struct {
 	ID       int64
 	Name     string
 	IsActive bool
+	// Region is the location code of the warehouse, e.g., JKT
+	Region string
 }
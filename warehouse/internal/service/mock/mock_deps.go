@@ -0,0 +1,483 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/elangreza/e-commerce/gen (interfaces: OrderServiceClient)
+//
+// Generated by this command:
+//
+//	mockgen -package=mock -destination=mock/mock_deps.go github.com/elangreza/e-commerce/gen OrderServiceClient
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gen "github.com/elangreza/e-commerce/gen"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockOrderServiceClient is a mock of OrderServiceClient interface.
+type MockOrderServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderServiceClientMockRecorder is the mock recorder for MockOrderServiceClient.
+type MockOrderServiceClientMockRecorder struct {
+	mock *MockOrderServiceClient
+}
+
+// NewMockOrderServiceClient creates a new mock instance.
+func NewMockOrderServiceClient(ctrl *gomock.Controller) *MockOrderServiceClient {
+	mock := &MockOrderServiceClient{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderServiceClient) EXPECT() *MockOrderServiceClientMockRecorder {
+	return m.recorder
+}
+
+// AddOrderPayment mocks base method.
+func (m *MockOrderServiceClient) AddOrderPayment(ctx context.Context, in *gen.AddOrderPaymentRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddOrderPayment", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrderPayment indicates an expected call of AddOrderPayment.
+func (mr *MockOrderServiceClientMockRecorder) AddOrderPayment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderPayment", reflect.TypeOf((*MockOrderServiceClient)(nil).AddOrderPayment), varargs...)
+}
+
+// AddProductToCart mocks base method.
+func (m *MockOrderServiceClient) AddProductToCart(ctx context.Context, in *gen.AddCartItemRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddProductToCart", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProductToCart indicates an expected call of AddProductToCart.
+func (mr *MockOrderServiceClientMockRecorder) AddProductToCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductToCart", reflect.TypeOf((*MockOrderServiceClient)(nil).AddProductToCart), varargs...)
+}
+
+// ApplyCoupon mocks base method.
+func (m *MockOrderServiceClient) ApplyCoupon(ctx context.Context, in *gen.ApplyCouponRequest, opts ...grpc.CallOption) (*gen.Cart, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApplyCoupon", varargs...)
+	ret0, _ := ret[0].(*gen.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCoupon indicates an expected call of ApplyCoupon.
+func (mr *MockOrderServiceClientMockRecorder) ApplyCoupon(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCoupon", reflect.TypeOf((*MockOrderServiceClient)(nil).ApplyCoupon), varargs...)
+}
+
+// CallbackTransaction mocks base method.
+func (m *MockOrderServiceClient) CallbackTransaction(ctx context.Context, in *gen.CallbackTransactionRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CallbackTransaction", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallbackTransaction indicates an expected call of CallbackTransaction.
+func (mr *MockOrderServiceClientMockRecorder) CallbackTransaction(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallbackTransaction", reflect.TypeOf((*MockOrderServiceClient)(nil).CallbackTransaction), varargs...)
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderServiceClient) CancelOrder(ctx context.Context, in *gen.CancelOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderServiceClientMockRecorder) CancelOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderServiceClient)(nil).CancelOrder), varargs...)
+}
+
+// CancelReservedOrder mocks base method.
+func (m *MockOrderServiceClient) CancelReservedOrder(ctx context.Context, in *gen.CancelReservedOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelReservedOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReservedOrder indicates an expected call of CancelReservedOrder.
+func (mr *MockOrderServiceClientMockRecorder) CancelReservedOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservedOrder", reflect.TypeOf((*MockOrderServiceClient)(nil).CancelReservedOrder), varargs...)
+}
+
+// ClearCart mocks base method.
+func (m *MockOrderServiceClient) ClearCart(ctx context.Context, in *gen.Empty, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClearCart", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockOrderServiceClientMockRecorder) ClearCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*MockOrderServiceClient)(nil).ClearCart), varargs...)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderServiceClient) CreateOrder(ctx context.Context, in *gen.CreateOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderServiceClientMockRecorder) CreateOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderServiceClient)(nil).CreateOrder), varargs...)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockOrderServiceClient) CreatePromotion(ctx context.Context, in *gen.Promotion, opts ...grpc.CallOption) (*gen.Promotion, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePromotion", varargs...)
+	ret0, _ := ret[0].(*gen.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockOrderServiceClientMockRecorder) CreatePromotion(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockOrderServiceClient)(nil).CreatePromotion), varargs...)
+}
+
+// CreateReturn mocks base method.
+func (m *MockOrderServiceClient) CreateReturn(ctx context.Context, in *gen.CreateReturnRequest, opts ...grpc.CallOption) (*gen.Return, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateReturn", varargs...)
+	ret0, _ := ret[0].(*gen.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockOrderServiceClientMockRecorder) CreateReturn(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockOrderServiceClient)(nil).CreateReturn), varargs...)
+}
+
+// GetCart mocks base method.
+func (m *MockOrderServiceClient) GetCart(ctx context.Context, in *gen.Empty, opts ...grpc.CallOption) (*gen.Cart, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCart", varargs...)
+	ret0, _ := ret[0].(*gen.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockOrderServiceClientMockRecorder) GetCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockOrderServiceClient)(nil).GetCart), varargs...)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderServiceClient) GetOrder(ctx context.Context, in *gen.GetOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderServiceClientMockRecorder) GetOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderServiceClient)(nil).GetOrder), varargs...)
+}
+
+// GetOrderList mocks base method.
+func (m *MockOrderServiceClient) GetOrderList(ctx context.Context, in *gen.GetOrderListRequest, opts ...grpc.CallOption) (*gen.Orders, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrderList", varargs...)
+	ret0, _ := ret[0].(*gen.Orders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderList indicates an expected call of GetOrderList.
+func (mr *MockOrderServiceClientMockRecorder) GetOrderList(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderList", reflect.TypeOf((*MockOrderServiceClient)(nil).GetOrderList), varargs...)
+}
+
+// ListDeadLetterCompensations mocks base method.
+func (m *MockOrderServiceClient) ListDeadLetterCompensations(ctx context.Context, in *gen.Empty, opts ...grpc.CallOption) (*gen.DeadLetterCompensations, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDeadLetterCompensations", varargs...)
+	ret0, _ := ret[0].(*gen.DeadLetterCompensations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetterCompensations indicates an expected call of ListDeadLetterCompensations.
+func (mr *MockOrderServiceClientMockRecorder) ListDeadLetterCompensations(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetterCompensations", reflect.TypeOf((*MockOrderServiceClient)(nil).ListDeadLetterCompensations), varargs...)
+}
+
+// MergeCart mocks base method.
+func (m *MockOrderServiceClient) MergeCart(ctx context.Context, in *gen.MergeCartRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MergeCart", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCart indicates an expected call of MergeCart.
+func (mr *MockOrderServiceClientMockRecorder) MergeCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCart", reflect.TypeOf((*MockOrderServiceClient)(nil).MergeCart), varargs...)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockOrderServiceClient) ReceiveReturn(ctx context.Context, in *gen.ReceiveReturnRequest, opts ...grpc.CallOption) (*gen.Return, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReceiveReturn", varargs...)
+	ret0, _ := ret[0].(*gen.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockOrderServiceClientMockRecorder) ReceiveReturn(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockOrderServiceClient)(nil).ReceiveReturn), varargs...)
+}
+
+// RefundOrder mocks base method.
+func (m *MockOrderServiceClient) RefundOrder(ctx context.Context, in *gen.RefundOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefundOrder", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockOrderServiceClientMockRecorder) RefundOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockOrderServiceClient)(nil).RefundOrder), varargs...)
+}
+
+// RemoveCartItem mocks base method.
+func (m *MockOrderServiceClient) RemoveCartItem(ctx context.Context, in *gen.RemoveCartItemRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveCartItem", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCartItem indicates an expected call of RemoveCartItem.
+func (mr *MockOrderServiceClientMockRecorder) RemoveCartItem(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockOrderServiceClient)(nil).RemoveCartItem), varargs...)
+}
+
+// RemoveCoupon mocks base method.
+func (m *MockOrderServiceClient) RemoveCoupon(ctx context.Context, in *gen.Empty, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveCoupon", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCoupon indicates an expected call of RemoveCoupon.
+func (mr *MockOrderServiceClientMockRecorder) RemoveCoupon(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoupon", reflect.TypeOf((*MockOrderServiceClient)(nil).RemoveCoupon), varargs...)
+}
+
+// ReviewReturn mocks base method.
+func (m *MockOrderServiceClient) ReviewReturn(ctx context.Context, in *gen.ReviewReturnRequest, opts ...grpc.CallOption) (*gen.Return, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReviewReturn", varargs...)
+	ret0, _ := ret[0].(*gen.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewReturn indicates an expected call of ReviewReturn.
+func (mr *MockOrderServiceClientMockRecorder) ReviewReturn(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewReturn", reflect.TypeOf((*MockOrderServiceClient)(nil).ReviewReturn), varargs...)
+}
+
+// SetCartItemQuantity mocks base method.
+func (m *MockOrderServiceClient) SetCartItemQuantity(ctx context.Context, in *gen.SetCartItemQuantityRequest, opts ...grpc.CallOption) (*gen.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetCartItemQuantity", varargs...)
+	ret0, _ := ret[0].(*gen.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCartItemQuantity indicates an expected call of SetCartItemQuantity.
+func (mr *MockOrderServiceClientMockRecorder) SetCartItemQuantity(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartItemQuantity", reflect.TypeOf((*MockOrderServiceClient)(nil).SetCartItemQuantity), varargs...)
+}
+
+// UpdateFulfillment mocks base method.
+func (m *MockOrderServiceClient) UpdateFulfillment(ctx context.Context, in *gen.UpdateFulfillmentRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFulfillment", varargs...)
+	ret0, _ := ret[0].(*gen.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFulfillment indicates an expected call of UpdateFulfillment.
+func (mr *MockOrderServiceClientMockRecorder) UpdateFulfillment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFulfillment", reflect.TypeOf((*MockOrderServiceClient)(nil).UpdateFulfillment), varargs...)
+}
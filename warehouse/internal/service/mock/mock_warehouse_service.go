@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/elangreza/e-commerce/warehouse/internal/entity"
 	uuid "github.com/google/uuid"
@@ -42,6 +43,126 @@ func (m *MockwarehouseRepo) EXPECT() *MockwarehouseRepoMockRecorder {
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockwarehouseRepo) AdjustStock(ctx context.Context, adjust entity.AdjustStock) (*entity.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, adjust)
+	ret0, _ := ret[0].(*entity.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockwarehouseRepoMockRecorder) AdjustStock(ctx, adjust any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockwarehouseRepo)(nil).AdjustStock), ctx, adjust)
+}
+
+// ConfirmStock mocks base method.
+func (m *MockwarehouseRepo) ConfirmStock(ctx context.Context, confirmStock entity.ConfirmStock) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmStock", ctx, confirmStock)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmStock indicates an expected call of ConfirmStock.
+func (mr *MockwarehouseRepoMockRecorder) ConfirmStock(ctx, confirmStock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmStock", reflect.TypeOf((*MockwarehouseRepo)(nil).ConfirmStock), ctx, confirmStock)
+}
+
+// ExtendReservation mocks base method.
+func (m *MockwarehouseRepo) ExtendReservation(ctx context.Context, extendReservation entity.ExtendReservation) (*entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendReservation", ctx, extendReservation)
+	ret0, _ := ret[0].(*entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendReservation indicates an expected call of ExtendReservation.
+func (mr *MockwarehouseRepoMockRecorder) ExtendReservation(ctx, extendReservation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendReservation", reflect.TypeOf((*MockwarehouseRepo)(nil).ExtendReservation), ctx, extendReservation)
+}
+
+// GetExpiredReservedOrders mocks base method.
+func (m *MockwarehouseRepo) GetExpiredReservedOrders(ctx context.Context, expiredBefore time.Time) ([]entity.ReservedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredReservedOrders", ctx, expiredBefore)
+	ret0, _ := ret[0].([]entity.ReservedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredReservedOrders indicates an expected call of GetExpiredReservedOrders.
+func (mr *MockwarehouseRepoMockRecorder) GetExpiredReservedOrders(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredReservedOrders", reflect.TypeOf((*MockwarehouseRepo)(nil).GetExpiredReservedOrders), ctx, expiredBefore)
+}
+
+// GetReservation mocks base method.
+func (m *MockwarehouseRepo) GetReservation(ctx context.Context, orderID string, userID uuid.UUID) (*entity.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, orderID, userID)
+	ret0, _ := ret[0].(*entity.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockwarehouseRepoMockRecorder) GetReservation(ctx, orderID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockwarehouseRepo)(nil).GetReservation), ctx, orderID, userID)
+}
+
+// GetReservedOrdersByWarehouseID mocks base method.
+func (m *MockwarehouseRepo) GetReservedOrdersByWarehouseID(ctx context.Context, warehouseID int64) ([]entity.ReservedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedOrdersByWarehouseID", ctx, warehouseID)
+	ret0, _ := ret[0].([]entity.ReservedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedOrdersByWarehouseID indicates an expected call of GetReservedOrdersByWarehouseID.
+func (mr *MockwarehouseRepoMockRecorder) GetReservedOrdersByWarehouseID(ctx, warehouseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedOrdersByWarehouseID", reflect.TypeOf((*MockwarehouseRepo)(nil).GetReservedOrdersByWarehouseID), ctx, warehouseID)
+}
+
+// GetReservedOrdersOfInactiveWarehouses mocks base method.
+func (m *MockwarehouseRepo) GetReservedOrdersOfInactiveWarehouses(ctx context.Context) ([]entity.ReservedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedOrdersOfInactiveWarehouses", ctx)
+	ret0, _ := ret[0].([]entity.ReservedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedOrdersOfInactiveWarehouses indicates an expected call of GetReservedOrdersOfInactiveWarehouses.
+func (mr *MockwarehouseRepoMockRecorder) GetReservedOrdersOfInactiveWarehouses(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedOrdersOfInactiveWarehouses", reflect.TypeOf((*MockwarehouseRepo)(nil).GetReservedOrdersOfInactiveWarehouses), ctx)
+}
+
+// GetStockBalance mocks base method.
+func (m *MockwarehouseRepo) GetStockBalance(ctx context.Context, productID string, warehouseID int64, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockBalance", ctx, productID, warehouseID, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockBalance indicates an expected call of GetStockBalance.
+func (mr *MockwarehouseRepoMockRecorder) GetStockBalance(ctx, productID, warehouseID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockBalance", reflect.TypeOf((*MockwarehouseRepo)(nil).GetStockBalance), ctx, productID, warehouseID, before)
+}
+
 // GetStocks mocks base method.
 func (m *MockwarehouseRepo) GetStocks(ctx context.Context, productIDs []string) ([]*entity.Stock, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +213,67 @@ func (mr *MockwarehouseRepoMockRecorder) GetWarehouseByShopID(ctx, shopID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouseByShopID", reflect.TypeOf((*MockwarehouseRepo)(nil).GetWarehouseByShopID), ctx, shopID)
 }
 
+// IsOrderConfirmedInWarehouse mocks base method.
+func (m *MockwarehouseRepo) IsOrderConfirmedInWarehouse(ctx context.Context, orderID string, warehouseID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOrderConfirmedInWarehouse", ctx, orderID, warehouseID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsOrderConfirmedInWarehouse indicates an expected call of IsOrderConfirmedInWarehouse.
+func (mr *MockwarehouseRepoMockRecorder) IsOrderConfirmedInWarehouse(ctx, orderID, warehouseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOrderConfirmedInWarehouse", reflect.TypeOf((*MockwarehouseRepo)(nil).IsOrderConfirmedInWarehouse), ctx, orderID, warehouseID)
+}
+
+// ListExpiringLots mocks base method.
+func (m *MockwarehouseRepo) ListExpiringLots(ctx context.Context, expiresBefore time.Time, warehouseID int64, productID string) ([]entity.StockLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringLots", ctx, expiresBefore, warehouseID, productID)
+	ret0, _ := ret[0].([]entity.StockLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringLots indicates an expected call of ListExpiringLots.
+func (mr *MockwarehouseRepoMockRecorder) ListExpiringLots(ctx, expiresBefore, warehouseID, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringLots", reflect.TypeOf((*MockwarehouseRepo)(nil).ListExpiringLots), ctx, expiresBefore, warehouseID, productID)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockwarehouseRepo) ListStockMovements(ctx context.Context, filter entity.ListStockMovements) ([]entity.StockMovement, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockMovements", ctx, filter)
+	ret0, _ := ret[0].([]entity.StockMovement)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockwarehouseRepoMockRecorder) ListStockMovements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockwarehouseRepo)(nil).ListStockMovements), ctx, filter)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockwarehouseRepo) ReceiveStock(ctx context.Context, receive entity.ReceiveStock) (*entity.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveStock", ctx, receive)
+	ret0, _ := ret[0].(*entity.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockwarehouseRepoMockRecorder) ReceiveStock(ctx, receive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockwarehouseRepo)(nil).ReceiveStock), ctx, receive)
+}
+
 // ReleaseStock mocks base method.
 func (m *MockwarehouseRepo) ReleaseStock(ctx context.Context, releaseStock entity.ReleaseStock) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -108,10 +290,10 @@ func (mr *MockwarehouseRepoMockRecorder) ReleaseStock(ctx, releaseStock any) *go
 }
 
 // ReserveStock mocks base method.
-func (m *MockwarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) ([]int64, error) {
+func (m *MockwarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) (*entity.Reservation, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReserveStock", ctx, reserveStock)
-	ret0, _ := ret[0].([]int64)
+	ret0, _ := ret[0].(*entity.Reservation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -122,6 +304,21 @@ func (mr *MockwarehouseRepoMockRecorder) ReserveStock(ctx, reserveStock any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockwarehouseRepo)(nil).ReserveStock), ctx, reserveStock)
 }
 
+// RestockStock mocks base method.
+func (m *MockwarehouseRepo) RestockStock(ctx context.Context, restockStock entity.RestockStock) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestockStock", ctx, restockStock)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestockStock indicates an expected call of RestockStock.
+func (mr *MockwarehouseRepoMockRecorder) RestockStock(ctx, restockStock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestockStock", reflect.TypeOf((*MockwarehouseRepo)(nil).RestockStock), ctx, restockStock)
+}
+
 // SetWarehouseStatus mocks base method.
 func (m *MockwarehouseRepo) SetWarehouseStatus(ctx context.Context, warehouseID int64, isActive bool) error {
 	m.ctrl.T.Helper()
@@ -137,15 +334,15 @@ func (mr *MockwarehouseRepoMockRecorder) SetWarehouseStatus(ctx, warehouseID, is
 }
 
 // TransferStockBetweenWarehouse mocks base method.
-func (m *MockwarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, fromWarehouseID, toWarehouseID int64, productID string, quantity int64) error {
+func (m *MockwarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, transferStock entity.TransferStock) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransferStockBetweenWarehouse", ctx, fromWarehouseID, toWarehouseID, productID, quantity)
+	ret := m.ctrl.Call(m, "TransferStockBetweenWarehouse", ctx, transferStock)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // TransferStockBetweenWarehouse indicates an expected call of TransferStockBetweenWarehouse.
-func (mr *MockwarehouseRepoMockRecorder) TransferStockBetweenWarehouse(ctx, fromWarehouseID, toWarehouseID, productID, quantity any) *gomock.Call {
+func (mr *MockwarehouseRepoMockRecorder) TransferStockBetweenWarehouse(ctx, transferStock any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferStockBetweenWarehouse", reflect.TypeOf((*MockwarehouseRepo)(nil).TransferStockBetweenWarehouse), ctx, fromWarehouseID, toWarehouseID, productID, quantity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferStockBetweenWarehouse", reflect.TypeOf((*MockwarehouseRepo)(nil).TransferStockBetweenWarehouse), ctx, transferStock)
 }
@@ -1,38 +1,70 @@
 //go:generate mockgen -source=warehouse_service.go -destination=mock/mock_warehouse_service.go -package=mock
+//go:generate mockgen -package=mock -destination=mock/mock_deps.go github.com/elangreza/e-commerce/gen OrderServiceClient
 
 package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
 	"github.com/elangreza/e-commerce/warehouse/internal/entity"
 
+	"github.com/elangreza/e-commerce/pkg/contextrequest"
 	"github.com/elangreza/e-commerce/pkg/extractor"
+	"github.com/elangreza/e-commerce/pkg/globalcontanta"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type (
 	warehouseRepo interface {
 		GetStocks(ctx context.Context, productIDs []string) ([]*entity.Stock, error)
-		ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) ([]int64, error)
+		ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) (*entity.Reservation, error)
 		ReleaseStock(ctx context.Context, releaseStock entity.ReleaseStock) ([]int64, error)
+		ExtendReservation(ctx context.Context, extendReservation entity.ExtendReservation) (*entity.Reservation, error)
+		GetReservation(ctx context.Context, orderID string, userID uuid.UUID) (*entity.Reservation, error)
+		GetExpiredReservedOrders(ctx context.Context, expiredBefore time.Time) ([]entity.ReservedOrder, error)
+		ConfirmStock(ctx context.Context, confirmStock entity.ConfirmStock) ([]int64, error)
+		RestockStock(ctx context.Context, restockStock entity.RestockStock) ([]int64, error)
 		SetWarehouseStatus(ctx context.Context, warehouseID int64, isActive bool) error
-		TransferStockBetweenWarehouse(ctx context.Context, fromWarehouseID, toWarehouseID int64, productID string, quantity int64) error
+		TransferStockBetweenWarehouse(ctx context.Context, transferStock entity.TransferStock) error
+		ReceiveStock(ctx context.Context, receive entity.ReceiveStock) (*entity.StockMovement, error)
+		AdjustStock(ctx context.Context, adjust entity.AdjustStock) (*entity.StockMovement, error)
+		ListExpiringLots(ctx context.Context, expiresBefore time.Time, warehouseID int64, productID string) ([]entity.StockLot, error)
+		ListStockMovements(ctx context.Context, filter entity.ListStockMovements) ([]entity.StockMovement, int64, error)
+		GetStockBalance(ctx context.Context, productID string, warehouseID int64, before time.Time) (int64, error)
 		GetWarehouseByIDs(ctx context.Context, productID ...uuid.UUID) ([]entity.Warehouse, error)
 		GetWarehouseByShopID(ctx context.Context, shopID int64) ([]entity.Warehouse, error)
+		GetReservedOrdersByWarehouseID(ctx context.Context, warehouseID int64) ([]entity.ReservedOrder, error)
+		GetReservedOrdersOfInactiveWarehouses(ctx context.Context) ([]entity.ReservedOrder, error)
+		IsOrderConfirmedInWarehouse(ctx context.Context, orderID string, warehouseID int64) (bool, error)
 	}
 
 	WarehouseService struct {
-		repo warehouseRepo
+		repo               warehouseRepo
+		orderServiceClient gen.OrderServiceClient
+		// reservationTTL is how long the stock is reserved when the order does not set the expiry
+		reservationTTL time.Duration
 		gen.UnimplementedWarehouseServiceServer
 	}
 )
 
-func NewWarehouseService(repo warehouseRepo) *WarehouseService {
+// maxReservationTTL limits how long the stock can be held by an order that is not paid
+const maxReservationTTL = 24 * time.Hour
+
+func NewWarehouseService(repo warehouseRepo, orderServiceClient gen.OrderServiceClient, reservationTTL time.Duration) *WarehouseService {
 	return &WarehouseService{
-		repo: repo,
+		repo:               repo,
+		orderServiceClient: orderServiceClient,
+		reservationTTL:     reservationTTL,
 	}
 }
 
@@ -53,12 +85,18 @@ func (s *WarehouseService) GetStocks(ctx context.Context, req *gen.GetStockReque
 	}, nil
 }
 
+// ReserveStock reserves the stock of the order once, so order service can retry it.
+// The reserved order returns its reservation again and is refused when the quantities are different
 func (s *WarehouseService) ReserveStock(ctx context.Context, req *gen.ReserveStockRequest) (*gen.ReserveStockResponse, error) {
 	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
 	stocks := make([]entity.Stock, len(req.Stocks))
 	for i, stock := range req.Stocks {
 		productID, err := uuid.Parse(stock.ProductId)
@@ -66,26 +104,45 @@ func (s *WarehouseService) ReserveStock(ctx context.Context, req *gen.ReserveSto
 			return nil, err
 		}
 
+		if stock.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity of product %s must be greater than 0", stock.ProductId)
+		}
+
 		stocks[i] = entity.Stock{
 			ProductID: productID,
 			Quantity:  stock.Quantity,
 		}
 	}
 
-	reservedStockIDs, err := s.repo.ReserveStock(ctx, entity.ReserveStock{
-		Stocks:  stocks,
-		UserID:  userID,
-		OrderID: req.OrderId,
+	expiresAt := time.Now().Add(s.reservationTTL)
+	if req.GetExpiresAt() != "" {
+		expiresAt, err = parseReservationExpiry(req.GetExpiresAt())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reservation, err := s.repo.ReserveStock(ctx, entity.ReserveStock{
+		Stocks:    stocks,
+		UserID:    userID,
+		OrderID:   req.OrderId,
+		ExpiresAt: expiresAt,
 	})
 	if err != nil {
+		if errors.Is(err, entity.ErrReservationMismatch) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		return nil, err
 	}
 
 	return &gen.ReserveStockResponse{
-		ReservedStockIds: reservedStockIDs,
+		ReservedStockIds: reservation.ReservedStockIDs(""),
+		ExpiresAt:        entity.FormatTime(reservation.ExpiresAt()),
 	}, nil
 }
 
+// ReleaseStock releases the reserved stock of the order, the released order returns its released stock again.
+// The order that is never reserved is not found, the stock of the paid order cannot be released
 func (s *WarehouseService) ReleaseStock(ctx context.Context, req *gen.ReleaseStockRequest) (*gen.ReleaseStockResponse, error) {
 	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
 	if err != nil {
@@ -95,8 +152,15 @@ func (s *WarehouseService) ReleaseStock(ctx context.Context, req *gen.ReleaseSto
 	releasedStockIDs, err := s.repo.ReleaseStock(ctx, entity.ReleaseStock{
 		OrderID: req.OrderId,
 		UserID:  userID,
+		Actor:   userID.String(),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order %s is not reserved", req.GetOrderId())
+		}
+		if errors.Is(err, entity.ErrReservationConfirmed) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, err
 	}
 
@@ -105,8 +169,295 @@ func (s *WarehouseService) ReleaseStock(ctx context.Context, req *gen.ReleaseSto
 	}, nil
 }
 
+func (s *WarehouseService) GetReservation(ctx context.Context, req *gen.GetReservationRequest) (*gen.Reservation, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	reservation, err := s.repo.GetReservation(ctx, req.GetOrderId(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order %s is not reserved", req.GetOrderId())
+		}
+		return nil, err
+	}
+
+	return reservation.GetGenReservation(), nil
+}
+
+// ExtendReservation keeps the reserved stock of the order longer, for the checkout that takes longer than the reservation ttl.
+// The expiry is never moved earlier, the current expiry is returned then
+func (s *WarehouseService) ExtendReservation(ctx context.Context, req *gen.ExtendReservationRequest) (*gen.ExtendReservationResponse, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetOrderId() == "" || req.GetExpiresAt() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and expires_at are required")
+	}
+
+	expiresAt, err := parseReservationExpiry(req.GetExpiresAt())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.repo.ExtendReservation(ctx, entity.ExtendReservation{
+		OrderID:   req.GetOrderId(),
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "order %s is not reserved", req.GetOrderId())
+		}
+		return nil, err
+	}
+
+	if reservation.Status != constanta.ReservedStockStatusReserved {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s has no reserved stock", req.GetOrderId())
+	}
+
+	return &gen.ExtendReservationResponse{
+		ReservedStockIds: reservation.ReservedStockIDs(constanta.ReservedStockStatusReserved),
+		ExpiresAt:        entity.FormatTime(reservation.ExpiresAt()),
+	}, nil
+}
+
+// parseReservationExpiry reads the RFC3339 expiry of the reservation, it must be in the future and within maxReservationTTL
+func parseReservationExpiry(value string) (time.Time, error) {
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid expires_at format, must be RFC3339")
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "expires_at must be in the future")
+	}
+
+	if expiresAt.After(now.Add(maxReservationTTL)) {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "expires_at cannot be more than %s from now", maxReservationTTL)
+	}
+
+	return expiresAt, nil
+}
+
+// ReleaseExpiredReservations releases the reserved stock that is expired, so the stock is not held forever when order service cannot release it.
+// The order that is paid after its stock is released cannot confirm the stock, it expires in order service and its payment is refunded
+func (s *WarehouseService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	now := time.Now()
+	orders, err := s.repo.GetExpiredReservedOrders(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, order := range orders {
+		releasedStockIDs, err := s.repo.ReleaseStock(ctx, entity.ReleaseStock{
+			OrderID:       order.OrderID,
+			UserID:        order.UserID,
+			ExpiredBefore: now,
+			Actor:         constanta.StockMovementActorSystem,
+		})
+		if err != nil {
+			fmt.Printf("Error when releasing expired reservation of order %s: %v\n", order.OrderID, err)
+			continue
+		}
+
+		if len(releasedStockIDs) > 0 {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+func (s *WarehouseService) ConfirmStock(ctx context.Context, req *gen.ConfirmStockRequest) (*gen.ConfirmStockResponse, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmedStockIDs, err := s.repo.ConfirmStock(ctx, entity.ConfirmStock{
+		OrderID: req.OrderId,
+		UserID:  userID,
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrNoReservedStock) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	return &gen.ConfirmStockResponse{
+		ConfirmedStockIds: confirmedStockIDs,
+	}, nil
+}
+
+// RestockStock returns the refunded items of the paid order into the warehouse they are taken from.
+// The same refund is only restocked once, so order service can retry it
+func (s *WarehouseService) RestockStock(ctx context.Context, req *gen.RestockStockRequest) (*gen.RestockStockResponse, error) {
+	userID, err := extractor.ExtractUserIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetOrderId() == "" || req.GetRefundId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and refund_id are required")
+	}
+
+	if req.GetWarehouseId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id cannot be negative")
+	}
+
+	stocks := make([]entity.Stock, len(req.Stocks))
+	for i, stock := range req.Stocks {
+		productID, err := uuid.Parse(stock.ProductId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid product_id %s", stock.ProductId)
+		}
+
+		if stock.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity of product %s must be greater than 0", stock.ProductId)
+		}
+
+		stocks[i] = entity.Stock{
+			ProductID: productID,
+			Quantity:  stock.Quantity,
+		}
+	}
+
+	restockedStockIDs, err := s.repo.RestockStock(ctx, entity.RestockStock{
+		Stocks:      stocks,
+		OrderID:     req.OrderId,
+		RefundID:    req.RefundId,
+		UserID:      userID,
+		WarehouseID: req.GetWarehouseId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.RestockStockResponse{
+		RestockedStockIds: restockedStockIDs,
+	}, nil
+}
+
 func (s *WarehouseService) SetWarehouseStatus(ctx context.Context, req *gen.SetWarehouseStatusRequest) (*gen.Empty, error) {
-	err := s.repo.SetWarehouseStatus(ctx, req.WarehouseId, req.GetIsActive())
+	adminID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the orders are loaded before the warehouse is deactivated, so the warehouse is not deactivated when they cannot be loaded
+	orders := []entity.ReservedOrder{}
+	if !req.GetIsActive() {
+		orders, err = s.repo.GetReservedOrdersByWarehouseID(ctx, req.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repo.SetWarehouseStatus(ctx, req.WarehouseId, req.GetIsActive())
+	if err != nil {
+		return nil, err
+	}
+
+	s.cancelReservedOrders(newAdminContext(ctx, adminID), orders)
+
+	return &gen.Empty{}, nil
+}
+
+// CancelReservedOrdersOfInactiveWarehouses cancels the orders that still hold reserved stock of the deactivated warehouses.
+// It is run in the background, so the order that cannot be cancelled yet, e.g. it is still PENDING, is cancelled by the next run
+func (s *WarehouseService) CancelReservedOrdersOfInactiveWarehouses(ctx context.Context) (int, error) {
+	orders, err := s.repo.GetReservedOrdersOfInactiveWarehouses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.cancelReservedOrders(newServiceContext(ctx), orders), nil
+}
+
+// cancelReservedOrders asks order service to cancel every order that holds reserved stock of the deactivated warehouse,
+// ctx carries the admin or the service role of the caller.
+// The order service rolls back the payment and releases the stock.
+// An order that cannot be cancelled, e.g. it is already paid, is only logged.
+func (s *WarehouseService) cancelReservedOrders(ctx context.Context, orders []entity.ReservedOrder) int {
+	cancelled := 0
+	for _, order := range orders {
+		_, err := s.orderServiceClient.CancelReservedOrder(ctx, &gen.CancelReservedOrderRequest{
+			OrderId:     order.OrderID,
+			WarehouseId: order.WarehouseID,
+			Reason:      fmt.Sprintf("warehouse %d is deactivated", order.WarehouseID),
+		})
+		if err != nil {
+			fmt.Printf("Error when cancelling order %s of deactivated warehouse %d: %v\n", order.OrderID, order.WarehouseID, err)
+			continue
+		}
+
+		cancelled++
+	}
+
+	return cancelled
+}
+
+// FulfillOrder records the fulfillment step of a paid order shipped from the warehouse into the order service.
+// Only the warehouse that holds the sold stock of the order can fulfill it
+func (s *WarehouseService) FulfillOrder(ctx context.Context, req *gen.FulfillOrderRequest) (*gen.Empty, error) {
+	adminID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetWarehouseId() <= 0 || req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id and order_id are required")
+	}
+
+	confirmed, err := s.repo.IsOrderConfirmedInWarehouse(ctx, req.GetOrderId(), req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !confirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s has no confirmed stock in warehouse %d", req.GetOrderId(), req.GetWarehouseId())
+	}
+
+	_, err = s.orderServiceClient.UpdateFulfillment(newAdminContext(ctx, adminID), &gen.UpdateFulfillmentRequest{
+		OrderId:        req.GetOrderId(),
+		Status:         req.GetStatus(),
+		Carrier:        req.GetCarrier(),
+		TrackingNumber: req.GetTrackingNumber(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.Empty{}, nil
+}
+
+// ReceiveReturnedStock receives the items of the approved return into the warehouse, it is called by the admin.
+// The order service puts the items into the warehouse, then refunds them
+func (s *WarehouseService) ReceiveReturnedStock(ctx context.Context, req *gen.ReceiveReturnedStockRequest) (*gen.Empty, error) {
+	adminID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetWarehouseId() <= 0 || req.GetReturnId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id and return_id are required")
+	}
+
+	_, err = s.orderServiceClient.ReceiveReturn(newAdminContext(ctx, adminID), &gen.ReceiveReturnRequest{
+		ReturnId:    req.GetReturnId(),
+		WarehouseId: req.GetWarehouseId(),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +466,19 @@ func (s *WarehouseService) SetWarehouseStatus(ctx context.Context, req *gen.SetW
 }
 
 func (s *WarehouseService) TransferStockBetweenWarehouse(ctx context.Context, req *gen.TransferStockBetweenWarehouseRequest) (*gen.Empty, error) {
-	err := s.repo.TransferStockBetweenWarehouse(ctx, req.FromWarehouseId, req.ToWarehouseId, req.ProductId, req.Quantity)
+	userID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.TransferStockBetweenWarehouse(ctx, entity.TransferStock{
+		TransferID:      uuid.NewString(),
+		FromWarehouseID: req.FromWarehouseId,
+		ToWarehouseID:   req.ToWarehouseId,
+		ProductID:       req.ProductId,
+		Quantity:        req.Quantity,
+		Actor:           userID.String(),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +486,218 @@ func (s *WarehouseService) TransferStockBetweenWarehouse(ctx context.Context, re
 	return &gen.Empty{}, nil
 }
 
+// ReceiveStock puts the goods that arrive in the warehouse into the stock of the lot of the shop, the receiving time is kept in the receipt.
+// The lot with the earliest expiry is reserved first, the expired lot is not available anymore
+func (s *WarehouseService) ReceiveStock(ctx context.Context, req *gen.ReceiveStockRequest) (*gen.StockMovement, error) {
+	userID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product_id %s", req.GetProductId())
+	}
+
+	if req.GetWarehouseId() <= 0 || req.GetShopId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id and shop_id are required")
+	}
+
+	if req.GetQuantity() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be greater than 0")
+	}
+
+	receivedAt := time.Now()
+	if req.GetReceivedAt() != "" {
+		receivedAt, err = time.Parse(time.RFC3339, req.GetReceivedAt())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid received_at format, must be RFC3339")
+		}
+
+		if receivedAt.After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "received_at cannot be in the future")
+		}
+	}
+
+	var expiresAt time.Time
+	if req.GetExpiresAt() != "" {
+		expiresAt, err = time.Parse(time.RFC3339, req.GetExpiresAt())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at format, must be RFC3339")
+		}
+
+		if !expiresAt.After(time.Now()) {
+			return nil, status.Errorf(codes.InvalidArgument, "expired goods cannot be received")
+		}
+	}
+
+	movement, err := s.repo.ReceiveStock(ctx, entity.ReceiveStock{
+		ProductID:   productID,
+		WarehouseID: req.GetWarehouseId(),
+		ShopID:      req.GetShopId(),
+		Quantity:    req.GetQuantity(),
+		LotNumber:   strings.TrimSpace(req.GetLotNumber()),
+		ReceivedAt:  receivedAt,
+		ExpiresAt:   expiresAt,
+		Actor:       userID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "warehouse %d is not found", req.GetWarehouseId())
+		}
+		if errors.Is(err, entity.ErrWarehouseInactive) || errors.Is(err, entity.ErrLotMismatch) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	return movement.GetGenStockMovement(), nil
+}
+
+// AdjustStock corrects the quantity of the stock of the shop with the reason, e.g. the damaged goods or the difference of a cycle count
+func (s *WarehouseService) AdjustStock(ctx context.Context, req *gen.AdjustStockRequest) (*gen.StockMovement, error) {
+	userID, err := extractor.ExtractAdminIDFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product_id %s", req.GetProductId())
+	}
+
+	if req.GetWarehouseId() <= 0 || req.GetShopId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "warehouse_id and shop_id are required")
+	}
+
+	reason := constanta.StockAdjustmentReason(strings.ToUpper(req.GetReason()))
+	if !reason.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be one of %s, %s or %s",
+			constanta.StockAdjustmentReasonDamage, constanta.StockAdjustmentReasonLoss, constanta.StockAdjustmentReasonCycleCount)
+	}
+
+	if req.GetQuantity() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity cannot be 0")
+	}
+
+	if reason.IsDecreaseOnly() && req.GetQuantity() > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity of %s must be negative", reason)
+	}
+
+	movement, err := s.repo.AdjustStock(ctx, entity.AdjustStock{
+		ProductID:   productID,
+		WarehouseID: req.GetWarehouseId(),
+		ShopID:      req.GetShopId(),
+		LotNumber:   strings.TrimSpace(req.GetLotNumber()),
+		Quantity:    req.GetQuantity(),
+		Reason:      reason,
+		Note:        strings.TrimSpace(req.GetNote()),
+		Actor:       userID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "shop %d has no stock of lot %q of product %s in warehouse %d", req.GetShopId(), strings.TrimSpace(req.GetLotNumber()), req.GetProductId(), req.GetWarehouseId())
+		}
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	return movement.GetGenStockMovement(), nil
+}
+
+// ListExpiringLots returns the lots in stock that expire within the days, including the expired lots that are not taken from the stock yet
+func (s *WarehouseService) ListExpiringLots(ctx context.Context, req *gen.ListExpiringLotsRequest) (*gen.ListExpiringLotsResponse, error) {
+	if req.GetDays() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "days cannot be negative")
+	}
+
+	now := time.Now()
+	lots, err := s.repo.ListExpiringLots(ctx, now.AddDate(0, 0, int(req.GetDays())), req.GetWarehouseId(), req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*gen.StockLot{}
+	for _, lot := range lots {
+		res = append(res, lot.GetGenStockLot(now))
+	}
+
+	return &gen.ListExpiringLotsResponse{
+		Lots: res,
+	}, nil
+}
+
+// ListStockMovements returns the ledger of the stock, oldest first.
+// When the product is set, the balance is the quantity of the product before the to time, so the balance on any date can be rebuilt
+func (s *WarehouseService) ListStockMovements(ctx context.Context, req *gen.ListStockMovementsRequest) (*gen.ListStockMovementsResponse, error) {
+	filter := entity.ListStockMovements{
+		ProductID:   req.GetProductId(),
+		WarehouseID: req.GetWarehouseId(),
+		Type:        constanta.StockMovementType(req.GetType()),
+		Reference:   req.GetReference(),
+		Limit:       req.GetLimit(),
+		Page:        req.GetPage(),
+	}
+
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid type %s", req.GetType())
+	}
+
+	var err error
+	if req.GetFrom() != "" {
+		filter.From, err = time.Parse(time.RFC3339, req.GetFrom())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from format, must be RFC3339")
+		}
+	}
+
+	if req.GetTo() != "" {
+		filter.To, err = time.Parse(time.RFC3339, req.GetTo())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to format, must be RFC3339")
+		}
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, status.Errorf(codes.InvalidArgument, "to must be after from")
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = 10
+	}
+
+	movements, total, err := s.repo.ListStockMovements(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance int64
+	if filter.ProductID != "" {
+		balance, err = s.repo.GetStockBalance(ctx, filter.ProductID, filter.WarehouseID, filter.To)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := []*gen.StockMovement{}
+	for _, movement := range movements {
+		res = append(res, movement.GetGenStockMovement())
+	}
+
+	return &gen.ListStockMovementsResponse{
+		Movements:  res,
+		Total:      total,
+		TotalPages: filter.GetTotalPages(total),
+		Balance:    balance,
+	}, nil
+}
+
 func (s *WarehouseService) GetWarehouseByShopID(ctx context.Context, req *gen.GetWarehouseByShopIDRequest) (*gen.GetWarehouseByShopIDResponse, error) {
 
 	warehouses, err := s.repo.GetWarehouseByShopID(ctx, req.ShopId)
@@ -136,6 +711,7 @@ func (s *WarehouseService) GetWarehouseByShopID(ctx context.Context, req *gen.Ge
 			Id:       warehouse.ID,
 			Name:     warehouse.Name,
 			IsActive: warehouse.IsActive,
+			Region:   warehouse.Region,
 		})
 	}
 
@@ -143,3 +719,14 @@ func (s *WarehouseService) GetWarehouseByShopID(ctx context.Context, req *gen.Ge
 		Warehouses: res,
 	}, nil
 }
+
+// newAdminContext sends the admin to the back-office methods of the order service, the role is checked again there
+func newAdminContext(ctx context.Context, adminID uuid.UUID) context.Context {
+	ctx = contextrequest.AppendUserIDintoContextGrpcClient(ctx, adminID)
+	return contextrequest.AppendRoleIntoContextGrpcClient(ctx, globalcontanta.RoleAdmin)
+}
+
+// newServiceContext is the context of the call that the warehouse service makes on its own, e.g. from its background job
+func newServiceContext(ctx context.Context) context.Context {
+	return contextrequest.AppendRoleIntoContextGrpcClient(ctx, globalcontanta.RoleService)
+}
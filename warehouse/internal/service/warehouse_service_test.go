@@ -2,17 +2,25 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/elangreza/e-commerce/gen"
 	"github.com/elangreza/e-commerce/pkg/globalcontanta"
+	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
 	"github.com/elangreza/e-commerce/warehouse/internal/entity"
 	"github.com/elangreza/e-commerce/warehouse/internal/service"
 	"github.com/elangreza/e-commerce/warehouse/internal/service/mock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type WarehouseServiceTestSuite struct {
@@ -20,15 +28,19 @@ type WarehouseServiceTestSuite struct {
 	ctrl              *gomock.Controller
 	svc               *service.WarehouseService
 	mockWarehouseRepo *mock.MockwarehouseRepo
+	mockOrderClient   *mock.MockOrderServiceClient
 }
 
 func (s *WarehouseServiceTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 
 	s.mockWarehouseRepo = mock.NewMockwarehouseRepo(s.ctrl)
+	s.mockOrderClient = mock.NewMockOrderServiceClient(s.ctrl)
 
 	s.svc = service.NewWarehouseService(
 		s.mockWarehouseRepo,
+		s.mockOrderClient,
+		15*time.Minute,
 	)
 }
 
@@ -40,6 +52,24 @@ func TestWarehouseServiceSuite(t *testing.T) {
 	suite.Run(t, new(WarehouseServiceTestSuite))
 }
 
+// newReservation returns the reservation of order 1 with the reserved stock of the ids in the status
+func newReservation(status constanta.ReservedStockStatus, expiresAt time.Time, ids ...int64) *entity.Reservation {
+	reservation := &entity.Reservation{
+		OrderID: "1",
+		Status:  status,
+	}
+	for _, id := range ids {
+		reservation.ReservedStocks = append(reservation.ReservedStocks, entity.ReservedStock{
+			ID:        id,
+			Quantity:  1,
+			Status:    status,
+			ExpiresAt: expiresAt,
+		})
+	}
+
+	return reservation
+}
+
 func (s *WarehouseServiceTestSuite) TestGetStocks() {
 	productID := uuid.New()
 
@@ -103,6 +133,7 @@ func (s *WarehouseServiceTestSuite) TestReserveStock() {
 		string(globalcontanta.UserIDKey): userID.String(),
 	})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
 
 	tests := []struct {
 		name          string
@@ -120,18 +151,113 @@ func (s *WarehouseServiceTestSuite) TestReserveStock() {
 						Quantity:  10,
 					},
 				},
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Cond(func(reserveStock entity.ReserveStock) bool {
+						return reserveStock.ExpiresAt.Equal(expiresAt)
+					})).
+					Return(newReservation(constanta.ReservedStockStatusReserved, expiresAt, 1), nil)
+			},
+			expectedError: "",
+			expectedRes: &gen.ReserveStockResponse{
+				ReservedStockIds: []int64{1},
+				ExpiresAt:        expiresAt.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "Success reserved order returns its reservation",
+			req: &gen.ReserveStockRequest{
+				Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
 				OrderId: "1",
 			},
 			setupMock: func() {
 				s.mockWarehouseRepo.EXPECT().
 					ReserveStock(gomock.Any(), gomock.Any()).
-					Return([]int64{1}, nil)
+					Return(newReservation(constanta.ReservedStockStatusReserved, expiresAt, 1, 2), nil)
+			},
+			expectedRes: &gen.ReserveStockResponse{
+				ReservedStockIds: []int64{1, 2},
+				ExpiresAt:        expiresAt.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "Success released order has no expiry",
+			req: &gen.ReserveStockRequest{
+				Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(newReservation(constanta.ReservedStockStatusReleased, expiresAt, 1), nil)
 			},
-			expectedError: "",
 			expectedRes: &gen.ReserveStockResponse{
 				ReservedStockIds: []int64{1},
 			},
 		},
+		{
+			name: "Error reserved order with different quantities",
+			req: &gen.ReserveStockRequest{
+				Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 5}},
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: order_id 1 is reserved with different quantities", entity.ErrReservationMismatch))
+			},
+			expectedError: "order_id 1 is reserved with different quantities",
+		},
+		{
+			name: "Error order_id is required",
+			req: &gen.ReserveStockRequest{
+				Stocks: []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+			},
+			setupMock:     func() {},
+			expectedError: "order_id is required",
+		},
+		{
+			name: "Error quantity is not positive",
+			req: &gen.ReserveStockRequest{
+				Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 0}},
+				OrderId: "1",
+			},
+			setupMock:     func() {},
+			expectedError: "quantity of product " + productID.String() + " must be greater than 0",
+		},
+		{
+			name: "Error invalid expires_at",
+			req: &gen.ReserveStockRequest{
+				Stocks:    []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+				OrderId:   "1",
+				ExpiresAt: "tomorrow",
+			},
+			setupMock:     func() {},
+			expectedError: "invalid expires_at format, must be RFC3339",
+		},
+		{
+			name: "Error expires_at in the past",
+			req: &gen.ReserveStockRequest{
+				Stocks:    []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+				OrderId:   "1",
+				ExpiresAt: time.Now().Add(-time.Minute).Format(time.RFC3339),
+			},
+			setupMock:     func() {},
+			expectedError: "expires_at must be in the future",
+		},
+		{
+			name: "Error expires_at is too far",
+			req: &gen.ReserveStockRequest{
+				Stocks:    []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+				OrderId:   "1",
+				ExpiresAt: time.Now().Add(48 * time.Hour).Format(time.RFC3339),
+			},
+			setupMock:     func() {},
+			expectedError: "expires_at cannot be more than 24h0m0s from now",
+		},
 	}
 
 	for _, tt := range tests {
@@ -151,36 +277,131 @@ func (s *WarehouseServiceTestSuite) TestReserveStock() {
 			}
 		})
 	}
+
+	s.Run("Success default reservation ttl", func() {
+		before := time.Now()
+		s.mockWarehouseRepo.EXPECT().
+			ReserveStock(gomock.Any(), gomock.Cond(func(reserveStock entity.ReserveStock) bool {
+				return !reserveStock.ExpiresAt.Before(before.Add(15*time.Minute)) &&
+					!reserveStock.ExpiresAt.After(time.Now().Add(15*time.Minute))
+			})).
+			Return(newReservation(constanta.ReservedStockStatusReserved, time.Now().Add(15*time.Minute), 1), nil)
+
+		resp, err := s.svc.ReserveStock(ctx, &gen.ReserveStockRequest{
+			Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 10}},
+			OrderId: "1",
+		})
+		s.NoError(err)
+		s.Equal([]int64{1}, resp.ReservedStockIds)
+		s.NotEmpty(resp.ExpiresAt)
+	})
 }
 
-func (s *WarehouseServiceTestSuite) TestReleaseStock() {
+func (s *WarehouseServiceTestSuite) TestExtendReservation() {
 	userID := uuid.New()
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
 	})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	laterExpiresAt := expiresAt.Add(time.Hour)
 
 	tests := []struct {
 		name          string
-		req           *gen.ReleaseStockRequest
+		req           *gen.ExtendReservationRequest
 		setupMock     func()
 		expectedError string
-		expectedRes   *gen.ReleaseStockResponse
+		expectedRes   *gen.ExtendReservationResponse
 	}{
 		{
 			name: "Success",
-			req: &gen.ReleaseStockRequest{
-				OrderId: "1",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
 			},
 			setupMock: func() {
 				s.mockWarehouseRepo.EXPECT().
-					ReleaseStock(gomock.Any(), gomock.Any()).
-					Return([]int64{1}, nil)
+					ExtendReservation(gomock.Any(), entity.ExtendReservation{
+						OrderID:   "1",
+						UserID:    userID,
+						ExpiresAt: expiresAt,
+					}).
+					Return(newReservation(constanta.ReservedStockStatusReserved, expiresAt, 1, 2), nil)
 			},
-			expectedError: "",
-			expectedRes: &gen.ReleaseStockResponse{
-				ReleasedStockIds: []int64{1},
+			expectedRes: &gen.ExtendReservationResponse{
+				ReservedStockIds: []int64{1, 2},
+				ExpiresAt:        expiresAt.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "Success expiry is not moved earlier",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ExtendReservation(gomock.Any(), gomock.Any()).
+					Return(newReservation(constanta.ReservedStockStatusReserved, laterExpiresAt, 1), nil)
+			},
+			expectedRes: &gen.ExtendReservationResponse{
+				ReservedStockIds: []int64{1},
+				ExpiresAt:        laterExpiresAt.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "Error no reserved stock",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ExtendReservation(gomock.Any(), gomock.Any()).
+					Return(newReservation(constanta.ReservedStockStatusConfirmed, expiresAt, 1), nil)
+			},
+			expectedError: "order 1 has no reserved stock",
+		},
+		{
+			name: "Error order is not reserved",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ExtendReservation(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "order 1 is not reserved",
+		},
+		{
+			name: "Error repo",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ExtendReservation(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+		{
+			name:          "Error expires_at is required",
+			req:           &gen.ExtendReservationRequest{OrderId: "1"},
+			setupMock:     func() {},
+			expectedError: "order_id and expires_at are required",
+		},
+		{
+			name: "Error expires_at in the past",
+			req: &gen.ExtendReservationRequest{
+				OrderId:   "1",
+				ExpiresAt: time.Now().Add(-time.Minute).Format(time.RFC3339),
 			},
+			setupMock:     func() {},
+			expectedError: "expires_at must be in the future",
 		},
 	}
 
@@ -188,7 +409,7 @@ func (s *WarehouseServiceTestSuite) TestReleaseStock() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.ReleaseStock(ctx, tt.req)
+			resp, err := s.svc.ExtendReservation(ctx, tt.req)
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -196,14 +417,64 @@ func (s *WarehouseServiceTestSuite) TestReleaseStock() {
 				s.Nil(resp)
 			} else {
 				s.NoError(err)
-				s.NotNil(resp)
-				s.Equal(resp, tt.expectedRes)
+				s.Equal(tt.expectedRes, resp)
 			}
 		})
 	}
 }
 
-func (s *WarehouseServiceTestSuite) TestSetWarehouseStatus() {
+func (s *WarehouseServiceTestSuite) TestReleaseExpiredReservations() {
+	userID := uuid.New()
+
+	s.Run("Success", func() {
+		s.mockWarehouseRepo.EXPECT().
+			GetExpiredReservedOrders(gomock.Any(), gomock.Any()).
+			Return([]entity.ReservedOrder{
+				{OrderID: "order-1", UserID: userID},
+				{OrderID: "order-2", UserID: userID},
+				{OrderID: "order-3", UserID: userID},
+			}, nil)
+
+		expired := gomock.Cond(func(releaseStock entity.ReleaseStock) bool {
+			return !releaseStock.ExpiredBefore.IsZero() && releaseStock.Actor == constanta.StockMovementActorSystem
+		})
+		gomock.InOrder(
+			s.mockWarehouseRepo.EXPECT().
+				ReleaseStock(gomock.Any(), gomock.All(expired, gomock.Cond(func(releaseStock entity.ReleaseStock) bool {
+					return releaseStock.OrderID == "order-1" && releaseStock.UserID == userID
+				}))).
+				Return([]int64{1, 2}, nil),
+			// failed release is retried by the next run
+			s.mockWarehouseRepo.EXPECT().
+				ReleaseStock(gomock.Any(), gomock.All(expired, gomock.Cond(func(releaseStock entity.ReleaseStock) bool {
+					return releaseStock.OrderID == "order-2"
+				}))).
+				Return([]int64{}, errors.New("db error")),
+			// extended in the meantime
+			s.mockWarehouseRepo.EXPECT().
+				ReleaseStock(gomock.Any(), gomock.All(expired, gomock.Cond(func(releaseStock entity.ReleaseStock) bool {
+					return releaseStock.OrderID == "order-3"
+				}))).
+				Return([]int64{}, nil),
+		)
+
+		released, err := s.svc.ReleaseExpiredReservations(context.Background())
+		s.NoError(err)
+		s.Equal(1, released)
+	})
+
+	s.Run("Error get expired reserved orders", func() {
+		s.mockWarehouseRepo.EXPECT().
+			GetExpiredReservedOrders(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		released, err := s.svc.ReleaseExpiredReservations(context.Background())
+		s.EqualError(err, "db error")
+		s.Equal(0, released)
+	})
+}
+
+func (s *WarehouseServiceTestSuite) TestReleaseStock() {
 	userID := uuid.New()
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
@@ -212,22 +483,53 @@ func (s *WarehouseServiceTestSuite) TestSetWarehouseStatus() {
 
 	tests := []struct {
 		name          string
-		req           *gen.SetWarehouseStatusRequest
+		req           *gen.ReleaseStockRequest
 		setupMock     func()
 		expectedError string
+		expectedRes   *gen.ReleaseStockResponse
 	}{
 		{
 			name: "Success",
-			req: &gen.SetWarehouseStatusRequest{
-				WarehouseId: 1,
-				IsActive:    false,
+			req: &gen.ReleaseStockRequest{
+				OrderId: "1",
 			},
 			setupMock: func() {
 				s.mockWarehouseRepo.EXPECT().
-					SetWarehouseStatus(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					ReleaseStock(gomock.Any(), entity.ReleaseStock{
+						OrderID: "1",
+						UserID:  userID,
+						Actor:   userID.String(),
+					}).
+					Return([]int64{1}, nil)
 			},
 			expectedError: "",
+			expectedRes: &gen.ReleaseStockResponse{
+				ReleasedStockIds: []int64{1},
+			},
+		},
+		{
+			name: "Error order is not reserved",
+			req: &gen.ReleaseStockRequest{
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return([]int64{}, sql.ErrNoRows)
+			},
+			expectedError: "order 1 is not reserved",
+		},
+		{
+			name: "Error order is paid",
+			req: &gen.ReleaseStockRequest{
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReleaseStock(gomock.Any(), gomock.Any()).
+					Return([]int64{}, fmt.Errorf("%w: order_id 1 is paid, its stock cannot be released", entity.ErrReservationConfirmed))
+			},
+			expectedError: "order_id 1 is paid, its stock cannot be released",
 		},
 	}
 
@@ -235,7 +537,7 @@ func (s *WarehouseServiceTestSuite) TestSetWarehouseStatus() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.SetWarehouseStatus(ctx, tt.req)
+			resp, err := s.svc.ReleaseStock(ctx, tt.req)
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -244,38 +546,73 @@ func (s *WarehouseServiceTestSuite) TestSetWarehouseStatus() {
 			} else {
 				s.NoError(err)
 				s.NotNil(resp)
+				s.Equal(resp, tt.expectedRes)
 			}
 		})
 	}
 }
 
-func (s *WarehouseServiceTestSuite) TestTransferStockBetweenWarehouse() {
+func (s *WarehouseServiceTestSuite) TestGetReservation() {
 	userID := uuid.New()
+	productID := uuid.New()
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
 	})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	expiresAt := createdAt.Add(15 * time.Minute)
 
 	tests := []struct {
 		name          string
-		req           *gen.TransferStockBetweenWarehouseRequest
+		req           *gen.GetReservationRequest
 		setupMock     func()
 		expectedError string
+		expectedRes   *gen.Reservation
 	}{
 		{
 			name: "Success",
-			req: &gen.TransferStockBetweenWarehouseRequest{
-				FromWarehouseId: 1,
-				ToWarehouseId:   2,
-				ProductId:       "1",
-				Quantity:        10,
+			req:  &gen.GetReservationRequest{OrderId: "1"},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					GetReservation(gomock.Any(), "1", userID).
+					Return(&entity.Reservation{
+						OrderID: "1",
+						UserID:  userID,
+						Status:  constanta.ReservedStockStatusReserved,
+						Stocks:  []entity.Stock{{ProductID: productID, Quantity: 3}},
+						ReservedStocks: []entity.ReservedStock{
+							{ID: 1, StockID: 10, WarehouseID: 1, ProductID: productID, Quantity: 2, Status: constanta.ReservedStockStatusReserved, ExpiresAt: expiresAt},
+							{ID: 2, StockID: 11, WarehouseID: 2, ProductID: productID, Quantity: 1, Status: constanta.ReservedStockStatusReserved, ExpiresAt: expiresAt},
+						},
+						CreatedAt: createdAt,
+					}, nil)
 			},
+			expectedRes: &gen.Reservation{
+				OrderId: "1",
+				Status:  "reserved",
+				Stocks:  []*gen.Stock{{ProductId: productID.String(), Quantity: 3}},
+				ReservedStocks: []*gen.ReservedStock{
+					{Id: 1, StockId: 10, WarehouseId: 1, ProductId: productID.String(), Quantity: 2, Status: "reserved", ExpiresAt: expiresAt.Format(time.RFC3339)},
+					{Id: 2, StockId: 11, WarehouseId: 2, ProductId: productID.String(), Quantity: 1, Status: "reserved", ExpiresAt: expiresAt.Format(time.RFC3339)},
+				},
+				CreatedAt: createdAt.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "Error order is not reserved",
+			req:  &gen.GetReservationRequest{OrderId: "1"},
 			setupMock: func() {
 				s.mockWarehouseRepo.EXPECT().
-					TransferStockBetweenWarehouse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					GetReservation(gomock.Any(), "1", userID).
+					Return(nil, sql.ErrNoRows)
 			},
-			expectedError: "",
+			expectedError: "order 1 is not reserved",
+		},
+		{
+			name:          "Error order_id is required",
+			req:           &gen.GetReservationRequest{},
+			setupMock:     func() {},
+			expectedError: "order_id is required",
 		},
 	}
 
@@ -283,21 +620,20 @@ func (s *WarehouseServiceTestSuite) TestTransferStockBetweenWarehouse() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.TransferStockBetweenWarehouse(ctx, tt.req)
+			resp, err := s.svc.GetReservation(ctx, tt.req)
 
 			if tt.expectedError != "" {
-				s.Error(err)
-				s.Contains(err.Error(), tt.expectedError)
+				s.ErrorContains(err, tt.expectedError)
 				s.Nil(resp)
 			} else {
 				s.NoError(err)
-				s.NotNil(resp)
+				s.Equal(tt.expectedRes, resp)
 			}
 		})
 	}
 }
 
-func (s *WarehouseServiceTestSuite) TestGetWarehouseByShopID() {
+func (s *WarehouseServiceTestSuite) TestConfirmStock() {
 	userID := uuid.New()
 	md := metadata.New(map[string]string{
 		string(globalcontanta.UserIDKey): userID.String(),
@@ -306,37 +642,52 @@ func (s *WarehouseServiceTestSuite) TestGetWarehouseByShopID() {
 
 	tests := []struct {
 		name          string
-		req           *gen.GetWarehouseByShopIDRequest
+		req           *gen.ConfirmStockRequest
 		setupMock     func()
 		expectedError string
-		expectedRes   *gen.GetWarehouseByShopIDResponse
+		expectedRes   *gen.ConfirmStockResponse
 	}{
 		{
 			name: "Success",
-			req: &gen.GetWarehouseByShopIDRequest{
-				ShopId: 1,
+			req: &gen.ConfirmStockRequest{
+				OrderId: "1",
 			},
 			setupMock: func() {
 				s.mockWarehouseRepo.EXPECT().
-					GetWarehouseByShopID(gomock.Any(), gomock.Any()).
-					Return([]entity.Warehouse{
-						{
-							ID:       1,
-							Name:     "a",
-							IsActive: true,
-						},
-					}, nil)
+					ConfirmStock(gomock.Any(), entity.ConfirmStock{
+						OrderID: "1",
+						UserID:  userID,
+					}).
+					Return([]int64{1, 2}, nil)
 			},
 			expectedError: "",
-			expectedRes: &gen.GetWarehouseByShopIDResponse{
-				Warehouses: []*gen.Warehouse{
-					{
-						Id:       1,
-						Name:     "a",
-						IsActive: true,
-					},
-				},
+			expectedRes: &gen.ConfirmStockResponse{
+				ConfirmedStockIds: []int64{1, 2},
+			},
+		},
+		{
+			name: "No reserved stock",
+			req: &gen.ConfirmStockRequest{
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ConfirmStock(gomock.Any(), gomock.Any()).
+					Return([]int64{}, fmt.Errorf("%w for order_id 1", entity.ErrNoReservedStock))
+			},
+			expectedError: "rpc error: code = FailedPrecondition desc = no reserved stock for order_id 1",
+		},
+		{
+			name: "Error when confirming stock",
+			req: &gen.ConfirmStockRequest{
+				OrderId: "1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ConfirmStock(gomock.Any(), gomock.Any()).
+					Return([]int64{}, errors.New("db error"))
 			},
+			expectedError: "db error",
 		},
 	}
 
@@ -344,7 +695,7 @@ func (s *WarehouseServiceTestSuite) TestGetWarehouseByShopID() {
 		s.Run(tt.name, func() {
 			tt.setupMock()
 
-			resp, err := s.svc.GetWarehouseByShopID(ctx, tt.req)
+			resp, err := s.svc.ConfirmStock(ctx, tt.req)
 
 			if tt.expectedError != "" {
 				s.Error(err)
@@ -358,3 +709,1272 @@ func (s *WarehouseServiceTestSuite) TestGetWarehouseByShopID() {
 		})
 	}
 }
+
+func (s *WarehouseServiceTestSuite) TestRestockStock() {
+	userID := uuid.New()
+	productID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.RestockStockRequest
+		setupMock     func()
+		expectedError string
+		expectedRes   *gen.RestockStockResponse
+	}{
+		{
+			name: "Success",
+			req: &gen.RestockStockRequest{
+				OrderId:  "1",
+				RefundId: "refund-1",
+				Stocks: []*gen.Stock{
+					{ProductId: productID.String(), Quantity: 2},
+				},
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					RestockStock(gomock.Any(), entity.RestockStock{
+						Stocks: []entity.Stock{
+							{ProductID: productID, Quantity: 2},
+						},
+						OrderID:  "1",
+						RefundID: "refund-1",
+						UserID:   userID,
+					}).
+					Return([]int64{1}, nil)
+			},
+			expectedError: "",
+			expectedRes: &gen.RestockStockResponse{
+				RestockedStockIds: []int64{1},
+			},
+		},
+		{
+			name: "Success into the chosen warehouse",
+			req: &gen.RestockStockRequest{
+				OrderId:     "1",
+				RefundId:    "refund-2",
+				WarehouseId: 3,
+				Stocks: []*gen.Stock{
+					{ProductId: productID.String(), Quantity: 1},
+				},
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					RestockStock(gomock.Any(), entity.RestockStock{
+						Stocks: []entity.Stock{
+							{ProductID: productID, Quantity: 1},
+						},
+						OrderID:     "1",
+						RefundID:    "refund-2",
+						UserID:      userID,
+						WarehouseID: 3,
+					}).
+					Return([]int64{2}, nil)
+			},
+			expectedError: "",
+			expectedRes: &gen.RestockStockResponse{
+				RestockedStockIds: []int64{2},
+			},
+		},
+		{
+			name: "Quantity is more than the confirmed stock",
+			req: &gen.RestockStockRequest{
+				OrderId:  "1",
+				RefundId: "refund-1",
+				Stocks: []*gen.Stock{
+					{ProductId: productID.String(), Quantity: 5},
+				},
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					RestockStock(gomock.Any(), gomock.Any()).
+					Return([]int64{}, errors.New("quantity of product is more than the confirmed stock of order_id 1"))
+			},
+			expectedError: "more than the confirmed stock",
+		},
+		{
+			name: "Refund id is required",
+			req: &gen.RestockStockRequest{
+				OrderId: "1",
+			},
+			setupMock:     func() {},
+			expectedError: "order_id and refund_id are required",
+		},
+		{
+			name: "Invalid quantity",
+			req: &gen.RestockStockRequest{
+				OrderId:  "1",
+				RefundId: "refund-1",
+				Stocks: []*gen.Stock{
+					{ProductId: productID.String(), Quantity: 0},
+				},
+			},
+			setupMock:     func() {},
+			expectedError: "must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.RestockStock(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+				s.Equal(resp, tt.expectedRes)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestSetWarehouseStatus() {
+	userID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.SetWarehouseStatusRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success",
+			req: &gen.SetWarehouseStatusRequest{
+				WarehouseId: 1,
+				IsActive:    true,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					SetWarehouseStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Deactivate warehouse cancels reserved orders",
+			req: &gen.SetWarehouseStatusRequest{
+				WarehouseId: 1,
+				IsActive:    false,
+			},
+			setupMock: func() {
+				gomock.InOrder(
+					s.mockWarehouseRepo.EXPECT().
+						GetReservedOrdersByWarehouseID(gomock.Any(), int64(1)).
+						Return([]entity.ReservedOrder{
+							{OrderID: "order-1", UserID: userID, WarehouseID: 1},
+							{OrderID: "order-2", UserID: userID, WarehouseID: 1},
+						}, nil),
+					s.mockWarehouseRepo.EXPECT().
+						SetWarehouseStatus(gomock.Any(), int64(1), false).
+						Return(nil),
+				)
+				// the admin cancels the order on behalf of the warehouse, not as the customer
+				s.mockOrderClient.EXPECT().
+					CancelReservedOrder(gomock.Any(), &gen.CancelReservedOrderRequest{
+						OrderId:     "order-1",
+						WarehouseId: 1,
+						Reason:      "warehouse 1 is deactivated",
+					}).
+					DoAndReturn(func(ctx context.Context, req *gen.CancelReservedOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{userID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						s.Equal([]string{string(globalcontanta.RoleAdmin)}, md.Get(string(globalcontanta.RoleKey)))
+						return &gen.Order{Id: "order-1", Status: "CANCELLED"}, nil
+					})
+				// the paid order cannot be cancelled, the deactivation still succeeds
+				s.mockOrderClient.EXPECT().
+					CancelReservedOrder(gomock.Any(), &gen.CancelReservedOrderRequest{
+						OrderId:     "order-2",
+						WarehouseId: 1,
+						Reason:      "warehouse 1 is deactivated",
+					}).
+					Return(nil, errors.New("order with status COMPLETED cannot be cancelled"))
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error when getting reserved orders",
+			req: &gen.SetWarehouseStatusRequest{
+				WarehouseId: 1,
+				IsActive:    false,
+			},
+			setupMock: func() {
+				// the warehouse is not deactivated
+				s.mockWarehouseRepo.EXPECT().
+					GetReservedOrdersByWarehouseID(gomock.Any(), int64(1)).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.SetWarehouseStatus(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestCancelReservedOrdersOfInactiveWarehouses() {
+	userID := uuid.New()
+
+	s.Run("Success", func() {
+		s.mockWarehouseRepo.EXPECT().
+			GetReservedOrdersOfInactiveWarehouses(gomock.Any()).
+			Return([]entity.ReservedOrder{
+				{OrderID: "order-1", UserID: userID, WarehouseID: 1},
+				{OrderID: "order-2", UserID: userID, WarehouseID: 2},
+			}, nil)
+		// the background job calls order service with the service role
+		s.mockOrderClient.EXPECT().
+			CancelReservedOrder(gomock.Any(), &gen.CancelReservedOrderRequest{
+				OrderId:     "order-1",
+				WarehouseId: 1,
+				Reason:      "warehouse 1 is deactivated",
+			}).
+			DoAndReturn(func(ctx context.Context, req *gen.CancelReservedOrderRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				s.Equal([]string{string(globalcontanta.RoleService)}, md.Get(string(globalcontanta.RoleKey)))
+				return &gen.Order{Id: "order-1", Status: "CANCELLED"}, nil
+			})
+		// the order is still created by order service, it is cancelled by the next run
+		s.mockOrderClient.EXPECT().
+			CancelReservedOrder(gomock.Any(), &gen.CancelReservedOrderRequest{
+				OrderId:     "order-2",
+				WarehouseId: 2,
+				Reason:      "warehouse 2 is deactivated",
+			}).
+			Return(nil, errors.New("order with status PENDING cannot be cancelled"))
+
+		cancelled, err := s.svc.CancelReservedOrdersOfInactiveWarehouses(context.Background())
+		s.NoError(err)
+		s.Equal(1, cancelled)
+	})
+
+	s.Run("Error get reserved orders", func() {
+		s.mockWarehouseRepo.EXPECT().
+			GetReservedOrdersOfInactiveWarehouses(gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		cancelled, err := s.svc.CancelReservedOrdersOfInactiveWarehouses(context.Background())
+		s.EqualError(err, "db error")
+		s.Equal(0, cancelled)
+	})
+}
+
+func (s *WarehouseServiceTestSuite) TestBackOfficeRequiresAdmin() {
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): uuid.NewString(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleCustomer),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	// the repo must not be called
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "SetWarehouseStatus",
+			call: func() error {
+				_, err := s.svc.SetWarehouseStatus(ctx, &gen.SetWarehouseStatusRequest{WarehouseId: 1})
+				return err
+			},
+		},
+		{
+			name: "TransferStockBetweenWarehouse",
+			call: func() error {
+				_, err := s.svc.TransferStockBetweenWarehouse(ctx, &gen.TransferStockBetweenWarehouseRequest{FromWarehouseId: 1, ToWarehouseId: 2, ProductId: "1", Quantity: 1})
+				return err
+			},
+		},
+		{
+			name: "ReceiveStock",
+			call: func() error {
+				_, err := s.svc.ReceiveStock(ctx, &gen.ReceiveStockRequest{ProductId: uuid.NewString(), WarehouseId: 1, ShopId: 1, Quantity: 1})
+				return err
+			},
+		},
+		{
+			name: "AdjustStock",
+			call: func() error {
+				_, err := s.svc.AdjustStock(ctx, &gen.AdjustStockRequest{ProductId: uuid.NewString(), WarehouseId: 1, ShopId: 1, Quantity: -1, Reason: "DAMAGE"})
+				return err
+			},
+		},
+		{
+			name: "FulfillOrder",
+			call: func() error {
+				_, err := s.svc.FulfillOrder(ctx, &gen.FulfillOrderRequest{WarehouseId: 1, OrderId: uuid.NewString(), Status: "PACKED"})
+				return err
+			},
+		},
+		{
+			name: "ReceiveReturnedStock",
+			call: func() error {
+				_, err := s.svc.ReceiveReturnedStock(ctx, &gen.ReceiveReturnedStockRequest{WarehouseId: 1, ReturnId: uuid.NewString()})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := tt.call()
+
+			s.Error(err)
+			s.Equal(codes.PermissionDenied, status.Code(err))
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestTransferStockBetweenWarehouse() {
+	userID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.TransferStockBetweenWarehouseRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success",
+			req: &gen.TransferStockBetweenWarehouseRequest{
+				FromWarehouseId: 1,
+				ToWarehouseId:   2,
+				ProductId:       "1",
+				Quantity:        10,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					TransferStockBetweenWarehouse(gomock.Any(), gomock.Cond(func(transferStock entity.TransferStock) bool {
+						return transferStock.TransferID != "" &&
+							transferStock.FromWarehouseID == 1 &&
+							transferStock.ToWarehouseID == 2 &&
+							transferStock.ProductID == "1" &&
+							transferStock.Quantity == 10 &&
+							transferStock.Actor == userID.String()
+					})).
+					Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Error available stock is less than the quantity",
+			req: &gen.TransferStockBetweenWarehouseRequest{
+				FromWarehouseId: 1,
+				ToWarehouseId:   2,
+				ProductId:       "1",
+				Quantity:        10,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					TransferStockBetweenWarehouse(gomock.Any(), gomock.Any()).
+					Return(errors.New("available stocks is less than request quantity"))
+			},
+			expectedError: "available stocks is less than request quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.TransferStockBetweenWarehouse(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestReceiveStock() {
+	userID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	productID := uuid.New()
+	receivedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := time.Now().AddDate(0, 1, 0).UTC().Truncate(time.Second)
+	movement := &entity.StockMovement{
+		ID:          5,
+		StockID:     1,
+		WarehouseID: 1,
+		ProductID:   productID.String(),
+		Type:        constanta.StockMovementReceive,
+		Quantity:    20,
+		Balance:     30,
+		Actor:       userID.String(),
+		Reference:   "1",
+		CreatedAt:   receivedAt,
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ReceiveStockRequest
+		setupMock     func()
+		expectedError string
+		expectedRes   *gen.StockMovement
+	}{
+		{
+			name: "Success",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+				LotNumber:   " LOT-001 ",
+				ReceivedAt:  receivedAt.Format(time.RFC3339),
+				ExpiresAt:   expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReceiveStock(gomock.Any(), entity.ReceiveStock{
+						ProductID:   productID,
+						WarehouseID: 1,
+						ShopID:      1,
+						Quantity:    20,
+						LotNumber:   "LOT-001",
+						ReceivedAt:  receivedAt,
+						ExpiresAt:   expiresAt,
+						Actor:       userID.String(),
+					}).
+					Return(movement, nil)
+			},
+			expectedRes: &gen.StockMovement{
+				Id:          5,
+				StockId:     1,
+				WarehouseId: 1,
+				ProductId:   productID.String(),
+				Type:        "RECEIVE",
+				Quantity:    20,
+				Balance:     30,
+				Actor:       userID.String(),
+				Reference:   "1",
+				CreatedAt:   "2025-01-02T03:04:05Z",
+			},
+		},
+		{
+			name: "Success received now",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReceiveStock(gomock.Any(), gomock.Cond(func(receive entity.ReceiveStock) bool {
+						return time.Since(receive.ReceivedAt) < time.Minute
+					})).
+					Return(movement, nil)
+			},
+			expectedRes: movement.GetGenStockMovement(),
+		},
+		{
+			name: "Error invalid product_id",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   "1",
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+			},
+			setupMock:     func() {},
+			expectedError: "invalid product_id 1",
+		},
+		{
+			name: "Error quantity is not positive",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+			},
+			setupMock:     func() {},
+			expectedError: "quantity must be greater than 0",
+		},
+		{
+			name: "Error received in the future",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+				ReceivedAt:  time.Now().Add(time.Hour).Format(time.RFC3339),
+			},
+			setupMock:     func() {},
+			expectedError: "received_at cannot be in the future",
+		},
+		{
+			name: "Error goods are expired",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+				ExpiresAt:   time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			setupMock:     func() {},
+			expectedError: "expired goods cannot be received",
+		},
+		{
+			name: "Error lot is received with another expiry",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    20,
+				LotNumber:   "LOT-001",
+				ExpiresAt:   expiresAt.Format(time.RFC3339),
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReceiveStock(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: lot \"LOT-001\" of product %s does not expire", entity.ErrLotMismatch, productID))
+			},
+			expectedError: `lot "LOT-001" of product`,
+		},
+		{
+			name: "Error warehouse is not found",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 9,
+				ShopId:      1,
+				Quantity:    20,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReceiveStock(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: "warehouse 9 is not found",
+		},
+		{
+			name: "Error warehouse is inactive",
+			req: &gen.ReceiveStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 2,
+				ShopId:      1,
+				Quantity:    20,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ReceiveStock(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: warehouse 2 cannot receive the stock", entity.ErrWarehouseInactive))
+			},
+			expectedError: "warehouse 2 cannot receive the stock",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ReceiveStock(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedRes, resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestAdjustStock() {
+	userID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	productID := uuid.New()
+	movement := &entity.StockMovement{
+		ID:          6,
+		StockID:     1,
+		WarehouseID: 1,
+		ProductID:   productID.String(),
+		Type:        constanta.StockMovementAdjustment,
+		Quantity:    -2,
+		Balance:     8,
+		Actor:       userID.String(),
+		Reference:   "1",
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.AdjustStockRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success damage",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    -2,
+				Reason:      "damage",
+				Note:        "broken on the shelf",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					AdjustStock(gomock.Any(), entity.AdjustStock{
+						ProductID:   productID,
+						WarehouseID: 1,
+						ShopID:      1,
+						Quantity:    -2,
+						Reason:      constanta.StockAdjustmentReasonDamage,
+						Note:        "broken on the shelf",
+						Actor:       userID.String(),
+					}).
+					Return(movement, nil)
+			},
+		},
+		{
+			name: "Success cycle count finds more stock",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    3,
+				Reason:      "CYCLE_COUNT",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Any()).
+					Return(movement, nil)
+			},
+		},
+		{
+			name: "Error invalid reason",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    -2,
+				Reason:      "THEFT",
+			},
+			setupMock:     func() {},
+			expectedError: "reason must be one of DAMAGE, LOSS or CYCLE_COUNT",
+		},
+		{
+			name: "Error loss adds stock",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    2,
+				Reason:      "LOSS",
+			},
+			setupMock:     func() {},
+			expectedError: "quantity of LOSS must be negative",
+		},
+		{
+			name: "Error quantity is 0",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Reason:      "CYCLE_COUNT",
+			},
+			setupMock:     func() {},
+			expectedError: "quantity cannot be 0",
+		},
+		{
+			name: "Error stock is not found",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      2,
+				LotNumber:   "LOT-001",
+				Quantity:    -2,
+				Reason:      "LOSS",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Cond(func(adjust entity.AdjustStock) bool {
+						return adjust.LotNumber == "LOT-001"
+					})).
+					Return(nil, sql.ErrNoRows)
+			},
+			expectedError: fmt.Sprintf(`shop 2 has no stock of lot "LOT-001" of product %s in warehouse 1`, productID),
+		},
+		{
+			name: "Error insufficient stock",
+			req: &gen.AdjustStockRequest{
+				ProductId:   productID.String(),
+				WarehouseId: 1,
+				ShopId:      1,
+				Quantity:    -20,
+				Reason:      "LOSS",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: cannot take 20 from the stock of product %s, available 10", entity.ErrInsufficientStock, productID))
+			},
+			expectedError: "cannot take 20 from the stock",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.AdjustStock(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(movement.GetGenStockMovement(), resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestListExpiringLots() {
+	productID := uuid.NewString()
+	expiredAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	expiresAt := time.Now().AddDate(0, 0, 3).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		req           *gen.ListExpiringLotsRequest
+		setupMock     func()
+		expectedError string
+		expectedRes   *gen.ListExpiringLotsResponse
+	}{
+		{
+			name: "Success",
+			req: &gen.ListExpiringLotsRequest{
+				Days:        7,
+				WarehouseId: 1,
+				ProductId:   productID,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListExpiringLots(gomock.Any(), gomock.Cond(func(expiresBefore time.Time) bool {
+						return expiresBefore.Sub(time.Now().AddDate(0, 0, 7)).Abs() < time.Minute
+					}), int64(1), productID).
+					Return([]entity.StockLot{
+						{StockID: 1, WarehouseID: 1, ProductID: productID, ShopID: 1, LotNumber: "LOT-001", Quantity: 2, ExpiresAt: expiredAt},
+						{StockID: 2, WarehouseID: 1, ProductID: productID, ShopID: 1, LotNumber: "LOT-002", Quantity: 5, ExpiresAt: expiresAt},
+					}, nil)
+			},
+			expectedRes: &gen.ListExpiringLotsResponse{
+				Lots: []*gen.StockLot{
+					{StockId: 1, WarehouseId: 1, ProductId: productID, ShopId: 1, LotNumber: "LOT-001", Quantity: 2, ExpiresAt: expiredAt.Format(time.RFC3339), Expired: true},
+					{StockId: 2, WarehouseId: 1, ProductId: productID, ShopId: 1, LotNumber: "LOT-002", Quantity: 5, ExpiresAt: expiresAt.Format(time.RFC3339)},
+				},
+			},
+		},
+		{
+			name: "Success no expiring lot",
+			req:  &gen.ListExpiringLotsRequest{},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListExpiringLots(gomock.Any(), gomock.Any(), int64(0), "").
+					Return([]entity.StockLot{}, nil)
+			},
+			expectedRes: &gen.ListExpiringLotsResponse{
+				Lots: []*gen.StockLot{},
+			},
+		},
+		{
+			name: "Error negative days",
+			req: &gen.ListExpiringLotsRequest{
+				Days: -1,
+			},
+			setupMock:     func() {},
+			expectedError: "days cannot be negative",
+		},
+		{
+			name: "Error list expiring lots",
+			req: &gen.ListExpiringLotsRequest{
+				Days: 7,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListExpiringLots(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ListExpiringLots(context.Background(), tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedRes, resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestListStockMovements() {
+	productID := uuid.NewString()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	movements := []entity.StockMovement{
+		{
+			ID:          1,
+			StockID:     1,
+			WarehouseID: 1,
+			ProductID:   productID,
+			Type:        constanta.StockMovementOpening,
+			Quantity:    10,
+			Balance:     10,
+			Actor:       constanta.StockMovementActorSystem,
+			CreatedAt:   createdAt,
+		},
+		{
+			ID:          2,
+			StockID:     1,
+			WarehouseID: 1,
+			ProductID:   productID,
+			Type:        constanta.StockMovementReserve,
+			Quantity:    -3,
+			Balance:     7,
+			Actor:       "user-1",
+			Reference:   "order-1",
+			CreatedAt:   createdAt,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		req           *gen.ListStockMovementsRequest
+		setupMock     func()
+		expectedError string
+		expectedRes   *gen.ListStockMovementsResponse
+	}{
+		{
+			name: "Success with the balance of the product",
+			req: &gen.ListStockMovementsRequest{
+				ProductId:   productID,
+				WarehouseId: 1,
+				To:          to.Format(time.RFC3339),
+				Limit:       1,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListStockMovements(gomock.Any(), entity.ListStockMovements{
+						ProductID:   productID,
+						WarehouseID: 1,
+						To:          to,
+						Limit:       1,
+						Page:        1,
+					}).
+					Return(movements[:1], int64(2), nil)
+				s.mockWarehouseRepo.EXPECT().
+					GetStockBalance(gomock.Any(), productID, int64(1), to).
+					Return(int64(7), nil)
+			},
+			expectedRes: &gen.ListStockMovementsResponse{
+				Movements: []*gen.StockMovement{
+					{
+						Id:          1,
+						StockId:     1,
+						WarehouseId: 1,
+						ProductId:   productID,
+						Type:        "OPENING",
+						Quantity:    10,
+						Balance:     10,
+						Actor:       "SYSTEM",
+						CreatedAt:   "2025-01-02T03:04:05Z",
+					},
+				},
+				Total:      2,
+				TotalPages: 2,
+				Balance:    7,
+			},
+		},
+		{
+			name: "Success without product has no balance",
+			req: &gen.ListStockMovementsRequest{
+				Type:      "RESERVE",
+				Reference: "order-1",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListStockMovements(gomock.Any(), entity.ListStockMovements{
+						Type:      constanta.StockMovementReserve,
+						Reference: "order-1",
+						Limit:     10,
+						Page:      1,
+					}).
+					Return(movements[1:], int64(1), nil)
+			},
+			expectedRes: &gen.ListStockMovementsResponse{
+				Movements: []*gen.StockMovement{
+					{
+						Id:          2,
+						StockId:     1,
+						WarehouseId: 1,
+						ProductId:   productID,
+						Type:        "RESERVE",
+						Quantity:    -3,
+						Balance:     7,
+						Actor:       "user-1",
+						Reference:   "order-1",
+						CreatedAt:   "2025-01-02T03:04:05Z",
+					},
+				},
+				Total:      1,
+				TotalPages: 1,
+			},
+		},
+		{
+			name: "Error invalid type",
+			req: &gen.ListStockMovementsRequest{
+				Type: "SOLD",
+			},
+			setupMock:     func() {},
+			expectedError: "invalid type SOLD",
+		},
+		{
+			name: "Error invalid from",
+			req: &gen.ListStockMovementsRequest{
+				From: "2025-01-01",
+			},
+			setupMock:     func() {},
+			expectedError: "invalid from format, must be RFC3339",
+		},
+		{
+			name: "Error to is before from",
+			req: &gen.ListStockMovementsRequest{
+				From: to.Format(time.RFC3339),
+				To:   createdAt.Format(time.RFC3339),
+			},
+			setupMock:     func() {},
+			expectedError: "to must be after from",
+		},
+		{
+			name: "Error list stock movements",
+			req:  &gen.ListStockMovementsRequest{},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					ListStockMovements(gomock.Any(), gomock.Any()).
+					Return(nil, int64(0), errors.New("db error"))
+			},
+			expectedError: "db error",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ListStockMovements(context.Background(), tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.expectedRes, resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestGetWarehouseByShopID() {
+	userID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): userID.String(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.GetWarehouseByShopIDRequest
+		setupMock     func()
+		expectedError string
+		expectedRes   *gen.GetWarehouseByShopIDResponse
+	}{
+		{
+			name: "Success",
+			req: &gen.GetWarehouseByShopIDRequest{
+				ShopId: 1,
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					GetWarehouseByShopID(gomock.Any(), gomock.Any()).
+					Return([]entity.Warehouse{
+						{
+							ID:       1,
+							Name:     "a",
+							IsActive: true,
+						},
+					}, nil)
+			},
+			expectedError: "",
+			expectedRes: &gen.GetWarehouseByShopIDResponse{
+				Warehouses: []*gen.Warehouse{
+					{
+						Id:       1,
+						Name:     "a",
+						IsActive: true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.GetWarehouseByShopID(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+				s.Equal(resp, tt.expectedRes)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestFulfillOrder() {
+	orderID := uuid.New().String()
+	adminID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): adminID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.FulfillOrderRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success",
+			req: &gen.FulfillOrderRequest{
+				WarehouseId:    1,
+				OrderId:        orderID,
+				Status:         "SHIPPED",
+				Carrier:        "JNE",
+				TrackingNumber: "JNE123",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					IsOrderConfirmedInWarehouse(gomock.Any(), orderID, int64(1)).
+					Return(true, nil)
+				s.mockOrderClient.EXPECT().
+					UpdateFulfillment(gomock.Any(), &gen.UpdateFulfillmentRequest{
+						OrderId:        orderID,
+						Status:         "SHIPPED",
+						Carrier:        "JNE",
+						TrackingNumber: "JNE123",
+					}).
+					DoAndReturn(func(ctx context.Context, req *gen.UpdateFulfillmentRequest, opts ...grpc.CallOption) (*gen.Order, error) {
+						// the order service authenticates the admin again
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{adminID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						s.Equal([]string{string(globalcontanta.RoleAdmin)}, md.Get(string(globalcontanta.RoleKey)))
+						return &gen.Order{Id: orderID, Status: "SHIPPED"}, nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Failed order is not sold from the warehouse",
+			req: &gen.FulfillOrderRequest{
+				WarehouseId: 2,
+				OrderId:     orderID,
+				Status:      "PACKED",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					IsOrderConfirmedInWarehouse(gomock.Any(), orderID, int64(2)).
+					Return(false, nil)
+			},
+			expectedError: "has no confirmed stock in warehouse 2",
+		},
+		{
+			name: "Failed order service rejects the step",
+			req: &gen.FulfillOrderRequest{
+				WarehouseId: 1,
+				OrderId:     orderID,
+				Status:      "DELIVERED",
+			},
+			setupMock: func() {
+				s.mockWarehouseRepo.EXPECT().
+					IsOrderConfirmedInWarehouse(gomock.Any(), orderID, int64(1)).
+					Return(true, nil)
+				s.mockOrderClient.EXPECT().
+					UpdateFulfillment(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("order with status PACKED cannot be DELIVERED"))
+			},
+			expectedError: "cannot be DELIVERED",
+		},
+		{
+			name: "Failed order_id is required",
+			req: &gen.FulfillOrderRequest{
+				WarehouseId: 1,
+				Status:      "PACKED",
+			},
+			setupMock:     func() {},
+			expectedError: "warehouse_id and order_id are required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.FulfillOrder(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
+
+func (s *WarehouseServiceTestSuite) TestReceiveReturnedStock() {
+	returnID := uuid.New().String()
+	adminID := uuid.New()
+	md := metadata.New(map[string]string{
+		string(globalcontanta.UserIDKey): adminID.String(),
+		string(globalcontanta.RoleKey):   string(globalcontanta.RoleAdmin),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	tests := []struct {
+		name          string
+		req           *gen.ReceiveReturnedStockRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name: "Success",
+			req: &gen.ReceiveReturnedStockRequest{
+				WarehouseId: 2,
+				ReturnId:    returnID,
+			},
+			setupMock: func() {
+				s.mockOrderClient.EXPECT().
+					ReceiveReturn(gomock.Any(), &gen.ReceiveReturnRequest{
+						ReturnId:    returnID,
+						WarehouseId: 2,
+					}).
+					DoAndReturn(func(ctx context.Context, req *gen.ReceiveReturnRequest, opts ...grpc.CallOption) (*gen.Return, error) {
+						// the order service authenticates the admin again
+						md, _ := metadata.FromOutgoingContext(ctx)
+						s.Equal([]string{adminID.String()}, md.Get(string(globalcontanta.UserIDKey)))
+						s.Equal([]string{string(globalcontanta.RoleAdmin)}, md.Get(string(globalcontanta.RoleKey)))
+						return &gen.Return{Id: returnID, Status: "REFUNDED"}, nil
+					})
+			},
+			expectedError: "",
+		},
+		{
+			name: "Failed order service rejects the return",
+			req: &gen.ReceiveReturnedStockRequest{
+				WarehouseId: 2,
+				ReturnId:    returnID,
+			},
+			setupMock: func() {
+				s.mockOrderClient.EXPECT().
+					ReceiveReturn(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("return with status REQUESTED cannot be received"))
+			},
+			expectedError: "cannot be received",
+		},
+		{
+			name: "Failed warehouse_id is required",
+			req: &gen.ReceiveReturnedStockRequest{
+				ReturnId: returnID,
+			},
+			setupMock:     func() {},
+			expectedError: "warehouse_id and return_id are required",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.setupMock()
+
+			resp, err := s.svc.ReceiveReturnedStock(ctx, tt.req)
+
+			if tt.expectedError != "" {
+				s.Error(err)
+				s.Contains(err.Error(), tt.expectedError)
+				s.Nil(resp)
+			} else {
+				s.NoError(err)
+				s.NotNil(resp)
+			}
+		})
+	}
+}
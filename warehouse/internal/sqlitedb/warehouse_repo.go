@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
 	"github.com/elangreza/e-commerce/warehouse/internal/entity"
@@ -23,7 +25,7 @@ func NewWarehouseRepo(db *sql.DB) *WarehouseRepo {
 	return &WarehouseRepo{db: db}
 }
 
-// GetStocks retrieves stock information for the given product IDs.
+// GetStocks retrieves stock information for the given product IDs, the expired lots are not available.
 func (r *WarehouseRepo) GetStocks(ctx context.Context, productIDs []string) ([]*entity.Stock, error) {
 	if len(productIDs) == 0 {
 		return []*entity.Stock{}, nil
@@ -37,7 +39,8 @@ func (r *WarehouseRepo) GetStocks(ctx context.Context, productIDs []string) ([]*
 			stocks s
 		LEFT JOIN warehouses w ON w.id = s.warehouse_id 
 		WHERE 
-			w.is_active IS TRUE AND s.product_id IN (%s) AND s.quantity > 0`, placeholders)
+			w.is_active IS TRUE AND s.product_id IN (%s) AND s.quantity > 0
+			AND (s.expires_at IS NULL OR DATETIME(s.expires_at) > DATETIME('now'))`, placeholders)
 	args := make([]any, len(productIDs))
 	for i, id := range productIDs {
 		args[i] = id
@@ -61,16 +64,57 @@ func (r *WarehouseRepo) GetStocks(ctx context.Context, productIDs []string) ([]*
 	return stocks, nil
 }
 
-func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) ([]int64, error) {
-	reservedStockIDs := []int64{}
+// ReserveStock reserves the stock of the order once.
+// Reserving the same order again returns the reserved stock, it is refused when the quantities are different
+func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.ReserveStock) (*entity.Reservation, error) {
+	var reservation *entity.Reservation
 	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
-		for _, reqStock := range reserveStock.Stocks {
+		// the reservation is inserted first, so the order that is reserved at the same time waits for the first one
+		// and is checked against it like the order that is reserved again
+		res, err := tx.ExecContext(ctx, `INSERT INTO reservations (order_id, user_id) VALUES (?, ?) ON CONFLICT (order_id) DO NOTHING`,
+			reserveStock.OrderID, reserveStock.UserID)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if inserted == 0 {
+			reservation, err = getReservation(ctx, tx, reserveStock.OrderID, reserveStock.UserID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("%w: order_id %s is reserved by another user", entity.ErrReservationMismatch, reserveStock.OrderID)
+				}
+				return err
+			}
+
+			if !reservation.HasSameStocks(reserveStock.Stocks) {
+				return fmt.Errorf("%w: order_id %s is reserved with different quantities", entity.ErrReservationMismatch, reserveStock.OrderID)
+			}
+
+			return nil
+		}
+
+		stocks := entity.MergeStocks(reserveStock.Stocks)
+		for _, reqStock := range stocks {
+			_, err = tx.ExecContext(ctx, `INSERT INTO reservation_items (order_id, product_id, quantity) VALUES (?, ?, ?)`,
+				reserveStock.OrderID, reqStock.ProductID, reqStock.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, reqStock := range stocks {
 			var currQuantity int64
 			err := tx.QueryRowContext(ctx, `
 			SELECT SUM(s.quantity) as total_qty
 				FROM stocks s
 			LEFT JOIN warehouses w ON w.id = s.warehouse_id 
-			WHERE w.is_active IS TRUE AND s.product_id = ?;`,
+			WHERE w.is_active IS TRUE AND s.product_id = ?
+			AND (s.expires_at IS NULL OR DATETIME(s.expires_at) > DATETIME('now'));`,
 				reqStock.ProductID).Scan(&currQuantity)
 			if err != nil {
 				if err == sql.ErrNoRows {
@@ -98,11 +142,13 @@ func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.Re
 					SELECT 
 						s.id, 
 						s.quantity, 
+						s.expires_at,
 						s.created_at,
-						SUM(s.quantity) OVER (ORDER BY s.created_at, s.id ASC) AS running_total
+						SUM(s.quantity) OVER (ORDER BY s.expires_at IS NULL, DATETIME(s.expires_at), s.created_at, s.id ASC) AS running_total
 					FROM stocks s
 					LEFT JOIN warehouses w ON w.id = s.warehouse_id 
-					WHERE w.is_active IS TRUE AND s.product_id = ?
+					WHERE w.is_active IS TRUE AND s.product_id = ? AND s.quantity > 0
+					AND (s.expires_at IS NULL OR DATETIME(s.expires_at) > DATETIME('now'))
 				) s
 				WHERE 
 					running_total <= ? 
@@ -110,7 +156,7 @@ func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.Re
 					running_total > ? 
 					AND (running_total - quantity) < ?
 				)
-				ORDER BY created_at, id;
+				ORDER BY expires_at IS NULL, DATETIME(expires_at), created_at, id;
 			`, reqStock.ProductID, reqStock.Quantity, reqStock.Quantity, reqStock.Quantity)
 
 			if err != nil {
@@ -128,12 +174,12 @@ func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.Re
 				return err
 			}
 
-			// this reserve stock is using FIFO method
-			// meaning the oldest stock will be reserved first
+			// this reserve stock is using FEFO method
+			// meaning the lot that expires first will be reserved first
 			// this is done to prevent stock from being expired
-			// before it is sold
-			// so we need to reserve the oldest stock first.
-			// Allocate requested stock quantity by iterating through available stock entries (ordered by creation date).
+			// before it is sold, the expired lot is never reserved.
+			// The stock that does not expire is reserved last, the oldest stock first.
+			// Allocate requested stock quantity by iterating through available stock entries (ordered by expiry, then creation date).
 			// For each stock entry, reserve as much as possible (up to the remaining requested quantity),
 			// update the stock quantity, and record the reservation until the request is fulfilled.
 
@@ -146,43 +192,62 @@ func (r *WarehouseRepo) ReserveStock(ctx context.Context, reserveStock entity.Re
 					return err
 				}
 
-				result, err := tx.ExecContext(ctx, `INSERT INTO reserved_stocks (stock_id, quantity, user_id, status, order_id) VALUES (?, ?, ?, ?, ?)`,
-					currStock.ID,
-					qty,
-					reserveStock.UserID,
-					constanta.ReservedStockStatusReserved,
-					reserveStock.OrderID)
+				_, err = recordStockMovement(ctx, tx, currStock.ID, constanta.StockMovementReserve, -qty, reserveStock.UserID.String(), reserveStock.OrderID)
 				if err != nil {
 					return err
 				}
 
-				insertedID, err := result.LastInsertId()
+				_, err = tx.ExecContext(ctx, `INSERT INTO reserved_stocks (stock_id, quantity, user_id, status, order_id, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
+					currStock.ID,
+					qty,
+					reserveStock.UserID,
+					constanta.ReservedStockStatusReserved,
+					reserveStock.OrderID,
+					reserveStock.ExpiresAt.UTC())
 				if err != nil {
 					return err
 				}
 
-				reservedStockIDs = append(reservedStockIDs, insertedID)
-
 				currReqStock -= qty
 			}
 
 		}
-		return nil
+
+		reservation, err = getReservation(ctx, tx, reserveStock.OrderID, reserveStock.UserID)
+		return err
 	})
 
 	if err != nil {
-		return []int64{}, err
+		return nil, err
 	}
 
-	return reservedStockIDs, nil
+	return reservation, nil
 }
 
+// ReleaseStock returns the reserved stock of the order into the stock.
+// Releasing the released order again returns its released stock, the order that is never reserved returns sql.ErrNoRows
 func (r *WarehouseRepo) ReleaseStock(ctx context.Context, releaseStock entity.ReleaseStock) ([]int64, error) {
 	releasedStockIDs := []int64{}
 	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		reservation, err := getReservation(ctx, tx, releaseStock.OrderID, releaseStock.UserID)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status == constanta.ReservedStockStatusConfirmed {
+			return fmt.Errorf("%w: order_id %s is paid, its stock cannot be released", entity.ErrReservationConfirmed, releaseStock.OrderID)
+		}
+
+		q := `SELECT id FROM reserved_stocks WHERE user_id = ? AND order_id = ? AND status = ?`
+		args := []any{releaseStock.UserID, releaseStock.OrderID, constanta.ReservedStockStatusReserved}
+		if !releaseStock.ExpiredBefore.IsZero() {
+			// the reservation that is extended in the meantime is kept
+			q += ` AND expires_at IS NOT NULL AND DATETIME(expires_at) <= DATETIME(?)`
+			args = append(args, releaseStock.ExpiredBefore.UTC())
+		}
 
 		reversedStockIDs := []int64{}
-		rows, err := tx.QueryContext(ctx, `SELECT id FROM reserved_stocks WHERE user_id = ? AND order_id = ?`, releaseStock.UserID, releaseStock.OrderID)
+		rows, err := tx.QueryContext(ctx, q, args...)
 		if err != nil {
 			return err
 		}
@@ -198,7 +263,7 @@ func (r *WarehouseRepo) ReleaseStock(ctx context.Context, releaseStock entity.Re
 		}
 
 		for _, reservedStockID := range reversedStockIDs {
-			var quantity, stockID int
+			var quantity, stockID int64
 			err := tx.QueryRowContext(ctx, `SELECT quantity, stock_id FROM reserved_stocks WHERE id = ? AND user_id = ? AND status = ?`, reservedStockID, releaseStock.UserID, constanta.ReservedStockStatusReserved).Scan(&quantity, &stockID)
 			if err != nil {
 				return err
@@ -209,23 +274,42 @@ func (r *WarehouseRepo) ReleaseStock(ctx context.Context, releaseStock entity.Re
 				return err
 			}
 
-			result, err := tx.ExecContext(ctx, `INSERT INTO released_stocks (stock_id, quantity, user_id, reserved_stock_id) VALUES (?, ?, ?, ?)`, stockID, quantity, releaseStock.UserID, reservedStockID)
+			_, err = recordStockMovement(ctx, tx, stockID, constanta.StockMovementRelease, quantity, releaseStock.Actor, releaseStock.OrderID)
 			if err != nil {
 				return err
 			}
 
-			insertedID, err := result.LastInsertId()
+			_, err = tx.ExecContext(ctx, `INSERT INTO released_stocks (stock_id, quantity, user_id, reserved_stock_id) VALUES (?, ?, ?, ?)`, stockID, quantity, releaseStock.UserID, reservedStockID)
 			if err != nil {
 				return err
 			}
-			releasedStockIDs = append(releasedStockIDs, insertedID)
 
 			_, err = tx.ExecContext(ctx, `UPDATE reserved_stocks SET status = ? WHERE id = ? AND status = ?`, constanta.ReservedStockStatusReleased, reservedStockID, constanta.ReservedStockStatusReserved)
 			if err != nil {
 				return err
 			}
 		}
-		return nil
+
+		// the stock that is released before is returned too
+		releasedRows, err := tx.QueryContext(ctx, `SELECT rl.id
+			FROM released_stocks rl
+			JOIN reserved_stocks rs ON rs.id = rl.reserved_stock_id
+			WHERE rs.user_id = ? AND rs.order_id = ?
+			ORDER BY rl.id`, releaseStock.UserID, releaseStock.OrderID)
+		if err != nil {
+			return err
+		}
+		defer releasedRows.Close()
+
+		for releasedRows.Next() {
+			var id int64
+			if err := releasedRows.Scan(&id); err != nil {
+				return err
+			}
+			releasedStockIDs = append(releasedStockIDs, id)
+		}
+
+		return releasedRows.Err()
 	})
 	if err != nil {
 		return []int64{}, err
@@ -233,6 +317,531 @@ func (r *WarehouseRepo) ReleaseStock(ctx context.Context, releaseStock entity.Re
 	return releasedStockIDs, nil
 }
 
+// ExtendReservation moves the expiry of the reserved stock of the order into the given time when it is later.
+// The reservation has no reserved stock when it is already released or confirmed, sql.ErrNoRows is returned when the order is never reserved
+func (r *WarehouseRepo) ExtendReservation(ctx context.Context, extendReservation entity.ExtendReservation) (*entity.Reservation, error) {
+	var reservation *entity.Reservation
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE reserved_stocks SET expires_at = ?
+			WHERE user_id = ? AND order_id = ? AND status = ? AND (expires_at IS NULL OR DATETIME(expires_at) < DATETIME(?))`,
+			extendReservation.ExpiresAt.UTC(),
+			extendReservation.UserID,
+			extendReservation.OrderID,
+			constanta.ReservedStockStatusReserved,
+			extendReservation.ExpiresAt.UTC())
+		if err != nil {
+			return err
+		}
+
+		reservation, err = getReservation(ctx, tx, extendReservation.OrderID, extendReservation.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// GetReservation returns the reservation of the order, sql.ErrNoRows is returned when the order is never reserved
+func (r *WarehouseRepo) GetReservation(ctx context.Context, orderID string, userID uuid.UUID) (*entity.Reservation, error) {
+	var reservation *entity.Reservation
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		var err error
+		reservation, err = getReservation(ctx, tx, orderID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// getReservation returns the reservation of the order with the requested quantity and the reserved stock.
+// The reservation is reserved while any of its stock is reserved, then confirmed or released
+func getReservation(ctx context.Context, tx *sql.Tx, orderID string, userID uuid.UUID) (*entity.Reservation, error) {
+	reservation := &entity.Reservation{
+		Stocks:         []entity.Stock{},
+		ReservedStocks: []entity.ReservedStock{},
+	}
+	err := tx.QueryRowContext(ctx, `SELECT order_id, user_id, created_at FROM reservations WHERE order_id = ? AND user_id = ?`, orderID, userID).
+		Scan(&reservation.OrderID, &reservation.UserID, &reservation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	itemRows, err := tx.QueryContext(ctx, `SELECT product_id, quantity FROM reservation_items WHERE order_id = ? ORDER BY rowid`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var stock entity.Stock
+		if err := itemRows.Scan(&stock.ProductID, &stock.Quantity); err != nil {
+			return nil, err
+		}
+		reservation.Stocks = append(reservation.Stocks, stock)
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT rs.id, rs.stock_id, s.warehouse_id, s.product_id, rs.quantity, rs.status, rs.expires_at
+		FROM reserved_stocks rs
+		JOIN stocks s ON s.id = rs.stock_id
+		WHERE rs.order_id = ? AND rs.user_id = ?
+		ORDER BY rs.id`, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reservedStock entity.ReservedStock
+		var expiresAt sql.NullTime
+		err := rows.Scan(
+			&reservedStock.ID,
+			&reservedStock.StockID,
+			&reservedStock.WarehouseID,
+			&reservedStock.ProductID,
+			&reservedStock.Quantity,
+			&reservedStock.Status,
+			&expiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reservedStock.ExpiresAt = expiresAt.Time
+		reservation.ReservedStocks = append(reservation.ReservedStocks, reservedStock)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(reservation.ReservedStockIDs(constanta.ReservedStockStatusReserved)) > 0:
+		reservation.Status = constanta.ReservedStockStatusReserved
+	case len(reservation.ReservedStockIDs(constanta.ReservedStockStatusConfirmed)) > 0:
+		reservation.Status = constanta.ReservedStockStatusConfirmed
+	default:
+		reservation.Status = constanta.ReservedStockStatusReleased
+	}
+
+	return reservation, nil
+}
+
+// GetExpiredReservedOrders returns the orders that hold reserved stock which expires before the given time
+func (r *WarehouseRepo) GetExpiredReservedOrders(ctx context.Context, expiredBefore time.Time) ([]entity.ReservedOrder, error) {
+	q := `SELECT DISTINCT order_id, user_id
+		FROM reserved_stocks
+		WHERE status = ? AND expires_at IS NOT NULL AND DATETIME(expires_at) <= DATETIME(?)`
+
+	rows, err := r.db.QueryContext(ctx, q, constanta.ReservedStockStatusReserved, expiredBefore.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []entity.ReservedOrder{}
+	for rows.Next() {
+		var order entity.ReservedOrder
+		if err := rows.Scan(&order.OrderID, &order.UserID); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// ConfirmStock moves the reserved stock of the order into confirmed.
+// Confirming the same order again returns the already confirmed stock.
+func (r *WarehouseRepo) ConfirmStock(ctx context.Context, confirmStock entity.ConfirmStock) ([]int64, error) {
+	confirmedStockIDs := []int64{}
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		reservedRows, err := tx.QueryContext(ctx, `SELECT stock_id FROM reserved_stocks WHERE user_id = ? AND order_id = ? AND status = ? ORDER BY id`,
+			confirmStock.UserID,
+			confirmStock.OrderID,
+			constanta.ReservedStockStatusReserved)
+		if err != nil {
+			return err
+		}
+		defer reservedRows.Close()
+
+		reservedStockIDs := []int64{}
+		for reservedRows.Next() {
+			var stockID int64
+			if err := reservedRows.Scan(&stockID); err != nil {
+				return err
+			}
+			reservedStockIDs = append(reservedStockIDs, stockID)
+		}
+		if err := reservedRows.Err(); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE reserved_stocks SET status = ? WHERE user_id = ? AND order_id = ? AND status = ?`,
+			constanta.ReservedStockStatusConfirmed,
+			confirmStock.UserID,
+			confirmStock.OrderID,
+			constanta.ReservedStockStatusReserved)
+		if err != nil {
+			return err
+		}
+
+		// the quantity is taken from the stock when it is reserved, the confirmation does not change the balance
+		for _, stockID := range reservedStockIDs {
+			_, err = recordStockMovement(ctx, tx, stockID, constanta.StockMovementConfirm, 0, confirmStock.UserID.String(), confirmStock.OrderID)
+			if err != nil {
+				return err
+			}
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM reserved_stocks WHERE user_id = ? AND order_id = ? AND status = ? ORDER BY id`,
+			confirmStock.UserID,
+			confirmStock.OrderID,
+			constanta.ReservedStockStatusConfirmed)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			confirmedStockIDs = append(confirmedStockIDs, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(confirmedStockIDs) == 0 {
+			return fmt.Errorf("%w for order_id %s", entity.ErrNoReservedStock, confirmStock.OrderID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []int64{}, err
+	}
+
+	return confirmedStockIDs, nil
+}
+
+// RestockStock returns the refunded quantity into the stock the order is confirmed from, the oldest confirmed stock first.
+// When the warehouse is set, the quantity is put into the stock of the product in that warehouse instead.
+// Restocking the same refund again returns the already restocked stock.
+func (r *WarehouseRepo) RestockStock(ctx context.Context, restockStock entity.RestockStock) ([]int64, error) {
+	restockedStockIDs := []int64{}
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM restocked_stocks WHERE refund_id = ? ORDER BY id`, restockStock.RefundID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			restockedStockIDs = append(restockedStockIDs, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(restockedStockIDs) > 0 {
+			return nil
+		}
+
+		if restockStock.WarehouseID > 0 {
+			var isActive bool
+			err = tx.QueryRowContext(ctx, `SELECT is_active FROM warehouses WHERE id = ?`, restockStock.WarehouseID).Scan(&isActive)
+			if err != nil {
+				return err
+			}
+
+			if !isActive {
+				return fmt.Errorf("warehouse %d is inactive", restockStock.WarehouseID)
+			}
+		}
+
+		for _, stock := range restockStock.Stocks {
+			confirmedStocks, err := getRestockableStocks(ctx, tx, restockStock.OrderID, restockStock.UserID, stock.ProductID.String())
+			if err != nil {
+				return err
+			}
+
+			currReqStock := stock.Quantity
+			for _, confirmedStock := range confirmedStocks {
+				if currReqStock == 0 {
+					break
+				}
+
+				qty := min(currReqStock, confirmedStock.Quantity)
+
+				stockID := confirmedStock.StockID
+				if restockStock.WarehouseID > 0 {
+					stockID, err = receiveStock(ctx, tx, restockStock.WarehouseID, stock.ProductID.String(), confirmedStock.ShopID, confirmedStock.LotNumber, confirmedStock.ExpiresAt, qty)
+				} else {
+					_, err = tx.ExecContext(ctx, `UPDATE stocks SET quantity = quantity + ? WHERE id = ?`, qty, confirmedStock.StockID)
+				}
+				if err != nil {
+					return err
+				}
+
+				_, err = recordStockMovement(ctx, tx, stockID, constanta.StockMovementRestock, qty, restockStock.UserID.String(), restockStock.RefundID)
+				if err != nil {
+					return err
+				}
+
+				result, err := tx.ExecContext(ctx, `INSERT INTO restocked_stocks (stock_id, reserved_stock_id, quantity, user_id, refund_id) VALUES (?, ?, ?, ?, ?)`,
+					stockID, confirmedStock.ID, qty, restockStock.UserID, restockStock.RefundID)
+				if err != nil {
+					return err
+				}
+
+				insertedID, err := result.LastInsertId()
+				if err != nil {
+					return err
+				}
+				restockedStockIDs = append(restockedStockIDs, insertedID)
+
+				currReqStock -= qty
+			}
+
+			if currReqStock > 0 {
+				return fmt.Errorf("quantity of product %s is more than the confirmed stock of order_id %s", stock.ProductID, restockStock.OrderID)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []int64{}, err
+	}
+
+	return restockedStockIDs, nil
+}
+
+// ReceiveStock puts the received goods into the stock of the lot in the active warehouse and returns the RECEIVE movement,
+// the reference of the movement is the id of the receipt. The lot that is received again must have the same expiry
+func (r *WarehouseRepo) ReceiveStock(ctx context.Context, receive entity.ReceiveStock) (*entity.StockMovement, error) {
+	var movement *entity.StockMovement
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		var isActive bool
+		err := tx.QueryRowContext(ctx, `SELECT is_active FROM warehouses WHERE id = ?`, receive.WarehouseID).Scan(&isActive)
+		if err != nil {
+			return err
+		}
+
+		if !isActive {
+			return fmt.Errorf("%w: warehouse %d cannot receive the stock", entity.ErrWarehouseInactive, receive.WarehouseID)
+		}
+
+		shopID := strconv.FormatInt(receive.ShopID, 10)
+
+		// the goods of the same lot have the same expiry
+		var lotExpiresAt sql.NullTime
+		err = tx.QueryRowContext(ctx, `SELECT expires_at FROM stocks WHERE product_id = ? AND shop_id = ? AND warehouse_id = ? AND lot_number = ?`,
+			receive.ProductID, shopID, receive.WarehouseID, receive.LotNumber).Scan(&lotExpiresAt)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil && !lotExpiresAt.Time.Equal(receive.ExpiresAt) {
+			expiry := "does not expire"
+			if lotExpiresAt.Valid {
+				expiry = "expires at " + entity.FormatTime(lotExpiresAt.Time)
+			}
+			return fmt.Errorf("%w: lot %q of product %s %s", entity.ErrLotMismatch, receive.LotNumber, receive.ProductID, expiry)
+		}
+
+		stockID, err := receiveStock(ctx, tx, receive.WarehouseID, receive.ProductID.String(), shopID, receive.LotNumber, receive.ExpiresAt, receive.Quantity)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `INSERT INTO stock_receipts (stock_id, quantity, lot_number, received_at, actor) VALUES (?, ?, ?, ?, ?)`,
+			stockID, receive.Quantity, receive.LotNumber, receive.ReceivedAt.UTC(), receive.Actor)
+		if err != nil {
+			return err
+		}
+
+		receiptID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		movementID, err := recordStockMovement(ctx, tx, stockID, constanta.StockMovementReceive, receive.Quantity, receive.Actor, strconv.FormatInt(receiptID, 10))
+		if err != nil {
+			return err
+		}
+
+		movement, err = getStockMovement(ctx, tx, movementID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// AdjustStock corrects the quantity of the stock and returns the ADJUSTMENT movement, the reference of the movement is the id of the adjustment.
+// sql.ErrNoRows is returned when the shop has no stock of the lot of the product in the warehouse
+func (r *WarehouseRepo) AdjustStock(ctx context.Context, adjust entity.AdjustStock) (*entity.StockMovement, error) {
+	var movement *entity.StockMovement
+	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
+		var stockID, quantity int64
+		err := tx.QueryRowContext(ctx, `SELECT id, quantity FROM stocks WHERE product_id = ? AND shop_id = ? AND warehouse_id = ? AND lot_number = ?`,
+			adjust.ProductID, strconv.FormatInt(adjust.ShopID, 10), adjust.WarehouseID, adjust.LotNumber).Scan(&stockID, &quantity)
+		if err != nil {
+			return err
+		}
+
+		if quantity+adjust.Quantity < 0 {
+			return fmt.Errorf("%w: cannot take %d from the stock of product %s, available %d", entity.ErrInsufficientStock, -adjust.Quantity, adjust.ProductID, quantity)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE stocks SET quantity = quantity + ? WHERE id = ?`, adjust.Quantity, stockID)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `INSERT INTO stock_adjustments (stock_id, quantity, reason, note, actor) VALUES (?, ?, ?, ?, ?)`,
+			stockID, adjust.Quantity, adjust.Reason, adjust.Note, adjust.Actor)
+		if err != nil {
+			return err
+		}
+
+		adjustmentID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		movementID, err := recordStockMovement(ctx, tx, stockID, constanta.StockMovementAdjustment, adjust.Quantity, adjust.Actor, strconv.FormatInt(adjustmentID, 10))
+		if err != nil {
+			return err
+		}
+
+		movement, err = getStockMovement(ctx, tx, movementID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// receiveStock adds the quantity into the stock of the lot of the product of the shop in the warehouse,
+// the stock is created with the expiry when it does not exist, the expiry of the existing lot is kept
+func receiveStock(ctx context.Context, tx *sql.Tx, warehouseID int64, productID, shopID, lotNumber string, expiresAt time.Time, quantity int64) (int64, error) {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO stocks (product_id, warehouse_id, shop_id, lot_number, expires_at, quantity)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(product_id, shop_id, warehouse_id, lot_number)
+		 DO UPDATE SET quantity = quantity + excluded.quantity`,
+		productID, warehouseID, shopID, lotNumber, nullTime(expiresAt), quantity)
+	if err != nil {
+		return 0, err
+	}
+
+	var stockID int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM stocks WHERE product_id = ? AND shop_id = ? AND warehouse_id = ? AND lot_number = ?`,
+		productID, shopID, warehouseID, lotNumber).Scan(&stockID)
+	if err != nil {
+		return 0, err
+	}
+
+	return stockID, nil
+}
+
+// recordStockMovement appends the change of the stock into the ledger with the quantity of the stock after the change,
+// it is called after the stock is updated in the same transaction
+func recordStockMovement(ctx context.Context, tx *sql.Tx, stockID int64, movementType constanta.StockMovementType, quantity int64, actor, reference string) (int64, error) {
+	result, err := tx.ExecContext(ctx, `INSERT INTO stock_movements (stock_id, warehouse_id, product_id, type, quantity, balance, actor, reference)
+		SELECT id, warehouse_id, product_id, ?, ?, quantity, ?, ? FROM stocks WHERE id = ?`,
+		movementType, quantity, actor, reference, stockID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+func getStockMovement(ctx context.Context, tx *sql.Tx, movementID int64) (*entity.StockMovement, error) {
+	var movement entity.StockMovement
+	err := tx.QueryRowContext(ctx, `SELECT id, stock_id, warehouse_id, product_id, type, quantity, balance, actor, reference, created_at
+		FROM stock_movements WHERE id = ?`, movementID).Scan(
+		&movement.ID,
+		&movement.StockID,
+		&movement.WarehouseID,
+		&movement.ProductID,
+		&movement.Type,
+		&movement.Quantity,
+		&movement.Balance,
+		&movement.Actor,
+		&movement.Reference,
+		&movement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &movement, nil
+}
+
+type restockableStock struct {
+	ID        int64
+	StockID   int64
+	ShopID    string
+	LotNumber string
+	ExpiresAt time.Time
+	Quantity  int64
+}
+
+// getRestockableStocks returns the confirmed stock of the product in the order with the quantity that is not restocked yet
+func getRestockableStocks(ctx context.Context, tx *sql.Tx, orderID string, userID uuid.UUID, productID string) ([]restockableStock, error) {
+	q := `SELECT rs.id, rs.stock_id, s.shop_id, s.lot_number, s.expires_at, rs.quantity - COALESCE((SELECT SUM(rst.quantity) FROM restocked_stocks rst WHERE rst.reserved_stock_id = rs.id), 0)
+		FROM reserved_stocks rs
+		JOIN stocks s ON s.id = rs.stock_id
+		WHERE rs.order_id = ? AND rs.user_id = ? AND rs.status = ? AND s.product_id = ?
+		ORDER BY rs.id`
+
+	rows, err := tx.QueryContext(ctx, q, orderID, userID, constanta.ReservedStockStatusConfirmed, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := []restockableStock{}
+	for rows.Next() {
+		var stock restockableStock
+		var expiresAt sql.NullTime
+		if err := rows.Scan(&stock.ID, &stock.StockID, &stock.ShopID, &stock.LotNumber, &expiresAt, &stock.Quantity); err != nil {
+			return nil, err
+		}
+		stock.ExpiresAt = expiresAt.Time
+		if stock.Quantity > 0 {
+			stocks = append(stocks, stock)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
 func (r *WarehouseRepo) SetWarehouseStatus(ctx context.Context, warehouseID int64, isActive bool) error {
 	_, err := r.db.ExecContext(ctx, `UPDATE warehouses SET is_active = ? WHERE id = ?`, isActive, warehouseID)
 	if err != nil {
@@ -242,24 +851,91 @@ func (r *WarehouseRepo) SetWarehouseStatus(ctx context.Context, warehouseID int6
 	return nil
 }
 
-func (r *WarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, fromWarehouseID, toWarehouseID int64, productID string, quantity int64) error {
+// GetReservedOrdersByWarehouseID returns the orders that hold reserved stock of the warehouse
+func (r *WarehouseRepo) GetReservedOrdersByWarehouseID(ctx context.Context, warehouseID int64) ([]entity.ReservedOrder, error) {
+	q := `SELECT DISTINCT rs.order_id, rs.user_id, s.warehouse_id
+		FROM reserved_stocks rs
+		JOIN stocks s ON s.id = rs.stock_id
+		WHERE s.warehouse_id = ? AND rs.status = ?`
+
+	return r.getReservedOrders(ctx, q, warehouseID, constanta.ReservedStockStatusReserved)
+}
+
+// GetReservedOrdersOfInactiveWarehouses returns the orders that still hold reserved stock of the deactivated warehouses
+func (r *WarehouseRepo) GetReservedOrdersOfInactiveWarehouses(ctx context.Context) ([]entity.ReservedOrder, error) {
+	q := `SELECT DISTINCT rs.order_id, rs.user_id, s.warehouse_id
+		FROM reserved_stocks rs
+		JOIN stocks s ON s.id = rs.stock_id
+		JOIN warehouses w ON w.id = s.warehouse_id
+		WHERE w.is_active IS FALSE AND rs.status = ?`
+
+	return r.getReservedOrders(ctx, q, constanta.ReservedStockStatusReserved)
+}
+
+func (r *WarehouseRepo) getReservedOrders(ctx context.Context, q string, args ...any) ([]entity.ReservedOrder, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []entity.ReservedOrder{}
+	for rows.Next() {
+		var order entity.ReservedOrder
+		if err := rows.Scan(&order.OrderID, &order.UserID, &order.WarehouseID); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// IsOrderConfirmedInWarehouse reports whether the warehouse holds confirmed (sold) stock of the order
+func (r *WarehouseRepo) IsOrderConfirmedInWarehouse(ctx context.Context, orderID string, warehouseID int64) (bool, error) {
+	q := `SELECT COUNT(rs.id)
+		FROM reserved_stocks rs
+		JOIN stocks s ON s.id = rs.stock_id
+		WHERE rs.order_id = ? AND s.warehouse_id = ? AND rs.status = ?`
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, q, orderID, warehouseID, constanta.ReservedStockStatusConfirmed).Scan(&total)
+	if err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+// TransferStockBetweenWarehouse moves the stock of the lot of the product that expires first into the other warehouse with its lot and expiry,
+// both sides are recorded in the ledger with the transfer id as the reference
+func (r *WarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, transferStock entity.TransferStock) error {
 	err := dbsql.WithTransaction(r.db, func(tx *sql.Tx) error {
 		var err error
+		var stockID int64
 		var availableStock int64
-		var shopID int64
-		query := `SELECT quantity, shop_id FROM stocks WHERE product_id = ? AND warehouse_id = ?`
-		err = tx.QueryRowContext(ctx, query, productID, fromWarehouseID).Scan(&availableStock, &shopID)
+		var shopID, lotNumber string
+		var expiresAt sql.NullTime
+		query := `SELECT id, quantity, shop_id, lot_number, expires_at FROM stocks
+			WHERE product_id = ? AND warehouse_id = ? AND quantity > 0
+			AND (expires_at IS NULL OR DATETIME(expires_at) > DATETIME('now'))
+			ORDER BY expires_at IS NULL, DATETIME(expires_at), created_at, id
+			LIMIT 1`
+		err = tx.QueryRowContext(ctx, query, transferStock.ProductID, transferStock.FromWarehouseID).Scan(&stockID, &availableStock, &shopID, &lotNumber, &expiresAt)
 		if err != nil {
 			return err
 		}
 
-		if availableStock < quantity {
+		if availableStock < transferStock.Quantity {
 			return fmt.Errorf("available stocks is less than request quantity")
 		}
 
 		qCheckWarehouse := "select is_active from warehouses where id = ?"
 		var sourceWareHouseIsActive bool
-		err = tx.QueryRowContext(ctx, qCheckWarehouse, fromWarehouseID).Scan(&sourceWareHouseIsActive)
+		err = tx.QueryRowContext(ctx, qCheckWarehouse, transferStock.FromWarehouseID).Scan(&sourceWareHouseIsActive)
 		if err != nil {
 			return err
 		}
@@ -269,7 +945,7 @@ func (r *WarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, fromW
 		}
 
 		var destinationWareHouseIsActive bool
-		err = tx.QueryRowContext(ctx, qCheckWarehouse, toWarehouseID).Scan(&destinationWareHouseIsActive)
+		err = tx.QueryRowContext(ctx, qCheckWarehouse, transferStock.ToWarehouseID).Scan(&destinationWareHouseIsActive)
 		if err != nil {
 			return err
 		}
@@ -284,25 +960,24 @@ func (r *WarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, fromW
 			SET
 				quantity = quantity - ? 
 			WHERE 
-				product_id = ? 
-			AND 
-				warehouse_id = ?`,
-			quantity, productID, fromWarehouseID)
+				id = ?`,
+			transferStock.Quantity, stockID)
 		if err != nil {
 			return err
 		}
 
-		_, err = tx.ExecContext(ctx,
-			`INSERT INTO stocks (product_id, warehouse_id, shop_id, quantity)
-		 VALUES (?, ?, ?, ?)
-		 ON CONFLICT(product_id, shop_id, warehouse_id)
-		 DO UPDATE SET quantity = quantity + excluded.quantity`,
-			productID, toWarehouseID, shopID, quantity)
+		_, err = recordStockMovement(ctx, tx, stockID, constanta.StockMovementTransferOut, -transferStock.Quantity, transferStock.Actor, transferStock.TransferID)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		destinationStockID, err := receiveStock(ctx, tx, transferStock.ToWarehouseID, transferStock.ProductID, shopID, lotNumber, expiresAt.Time, transferStock.Quantity)
+		if err != nil {
+			return err
+		}
+
+		_, err = recordStockMovement(ctx, tx, destinationStockID, constanta.StockMovementTransferIn, transferStock.Quantity, transferStock.Actor, transferStock.TransferID)
+		return err
 	})
 
 	if err != nil {
@@ -312,11 +987,163 @@ func (r *WarehouseRepo) TransferStockBetweenWarehouse(ctx context.Context, fromW
 	return nil
 }
 
+// ListExpiringLots returns the lots in stock that expire before the given time, the earliest expiry first.
+// The product and the warehouse are only filtered when they are set
+func (r *WarehouseRepo) ListExpiringLots(ctx context.Context, expiresBefore time.Time, warehouseID int64, productID string) ([]entity.StockLot, error) {
+	q := `SELECT id, warehouse_id, product_id, shop_id, lot_number, quantity, expires_at
+		FROM stocks
+		WHERE quantity > 0 AND expires_at IS NOT NULL AND DATETIME(expires_at) <= DATETIME(?)`
+	args := []any{expiresBefore.UTC()}
+	if warehouseID > 0 {
+		q += ` AND warehouse_id = ?`
+		args = append(args, warehouseID)
+	}
+	if productID != "" {
+		q += ` AND product_id = ?`
+		args = append(args, productID)
+	}
+	q += ` ORDER BY DATETIME(expires_at), id`
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lots := []entity.StockLot{}
+	for rows.Next() {
+		var lot entity.StockLot
+		err := rows.Scan(
+			&lot.StockID,
+			&lot.WarehouseID,
+			&lot.ProductID,
+			&lot.ShopID,
+			&lot.LotNumber,
+			&lot.Quantity,
+			&lot.ExpiresAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, lot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+// ListStockMovements returns the movements of the ledger that match the filter, oldest first, with the total of the matched movements
+func (r *WarehouseRepo) ListStockMovements(ctx context.Context, filter entity.ListStockMovements) ([]entity.StockMovement, int64, error) {
+	where, args := stockMovementFilter(filter.ProductID, filter.WarehouseID, filter.From, filter.To)
+	if filter.Type != "" {
+		where = append(where, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.Reference != "" {
+		where = append(where, "reference = ?")
+		args = append(args, filter.Reference)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(id) FROM stock_movements`+whereClause, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := `SELECT id, stock_id, warehouse_id, product_id, type, quantity, balance, actor, reference, created_at
+		FROM stock_movements` + whereClause + `
+		ORDER BY created_at, id
+		LIMIT ? OFFSET ?`
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	movements := []entity.StockMovement{}
+	for rows.Next() {
+		var movement entity.StockMovement
+		err := rows.Scan(
+			&movement.ID,
+			&movement.StockID,
+			&movement.WarehouseID,
+			&movement.ProductID,
+			&movement.Type,
+			&movement.Quantity,
+			&movement.Balance,
+			&movement.Actor,
+			&movement.Reference,
+			&movement.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+// GetStockBalance sums the movements of the product before the given time, the zero time sums every movement.
+// The warehouse is only filtered when it is set
+func (r *WarehouseRepo) GetStockBalance(ctx context.Context, productID string, warehouseID int64, before time.Time) (int64, error) {
+	where, args := stockMovementFilter(productID, warehouseID, time.Time{}, before)
+
+	q := `SELECT COALESCE(SUM(quantity), 0) FROM stock_movements`
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var balance int64
+	err := r.db.QueryRowContext(ctx, q, args...).Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func stockMovementFilter(productID string, warehouseID int64, from, to time.Time) ([]string, []any) {
+	where := []string{}
+	args := []any{}
+	if productID != "" {
+		where = append(where, "product_id = ?")
+		args = append(args, productID)
+	}
+	if warehouseID > 0 {
+		where = append(where, "warehouse_id = ?")
+		args = append(args, warehouseID)
+	}
+	if !from.IsZero() {
+		where = append(where, "DATETIME(created_at) >= DATETIME(?)")
+		args = append(args, from.UTC())
+	}
+	if !to.IsZero() {
+		where = append(where, "DATETIME(created_at) < DATETIME(?)")
+		args = append(args, to.UTC())
+	}
+
+	return where, args
+}
+
 func (pm *WarehouseRepo) GetWarehouseByIDs(ctx context.Context, productID ...uuid.UUID) ([]entity.Warehouse, error) {
 	q := `select
 		id,
 		name,
-		is_active
+		is_active,
+		region
 	from warehouses
 	where id = ?`
 	args := []any{}
@@ -330,7 +1157,8 @@ func (pm *WarehouseRepo) GetWarehouseByIDs(ctx context.Context, productID ...uui
 		q = `select
 		id,
 		name,
-		is_active
+		is_active,
+		region
 	from warehouses
 	where id IN (` + qMarks + `)`
 	}
@@ -347,6 +1175,7 @@ func (pm *WarehouseRepo) GetWarehouseByIDs(ctx context.Context, productID ...uui
 			&w.ID,
 			&w.Name,
 			&w.IsActive,
+			&w.Region,
 		)
 		if err != nil {
 			return nil, err
@@ -361,7 +1190,7 @@ func (pm *WarehouseRepo) GetWarehouseByIDs(ctx context.Context, productID ...uui
 func (pm *WarehouseRepo) GetWarehouseByShopID(ctx context.Context, shopID int64) ([]entity.Warehouse, error) {
 
 	q := `
-	SELECT w.id, w.name, w.is_active FROM stocks s 
+	SELECT w.id, w.name, w.is_active, w.region FROM stocks s 
 	LEFT JOIN warehouses w ON w.id=s.warehouse_id 
 	WHERE s.shop_id = ? GROUP BY w.id`
 
@@ -378,6 +1207,7 @@ func (pm *WarehouseRepo) GetWarehouseByShopID(ctx context.Context, shopID int64)
 			&w.ID,
 			&w.Name,
 			&w.IsActive,
+			&w.Region,
 		)
 		if err != nil {
 			return nil, err
@@ -389,6 +1219,11 @@ func (pm *WarehouseRepo) GetWarehouseByShopID(ctx context.Context, shopID int64)
 	return warehouses, nil
 }
 
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
 func buildPlaceHoldersInClause(lenitems int) string {
 	if lenitems == 0 {
 		return ""
@@ -0,0 +1,336 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elangreza/e-commerce/pkg/dbsql"
+	"github.com/elangreza/e-commerce/warehouse/internal/constanta"
+	"github.com/elangreza/e-commerce/warehouse/internal/entity"
+	"github.com/elangreza/e-commerce/warehouse/internal/sqlitedb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := dbsql.NewDbSql(
+		dbsql.WithSqliteDB(filepath.Join(t.TempDir(), "warehouse.db")),
+		dbsql.WithSqliteDBWalMode(),
+		dbsql.WithAutoMigrate("file://../../migrations"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func insertStock(t *testing.T, db *sql.DB, productID string, quantity int64) {
+	t.Helper()
+
+	_, err := db.Exec(`INSERT OR IGNORE INTO warehouses(id, name, is_active) VALUES (1, 'main', TRUE);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO stocks(warehouse_id, product_id, shop_id, quantity) VALUES (1, ?, '1', ?);`, productID, quantity)
+	require.NoError(t, err)
+}
+
+func TestReserveStockOfTheSameOrderAtTheSameTime(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewWarehouseRepo(db)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	insertStock(t, db, productID.String(), 100)
+
+	orderID := uuid.NewString()
+	userID := uuid.New()
+	reserve := func(quantity int64) entity.ReserveStock {
+		return entity.ReserveStock{
+			Stocks:    []entity.Stock{{ProductID: productID, Quantity: quantity}},
+			OrderID:   orderID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	// the order saga retries the reservation while the first one is still running,
+	// the last request has different quantities
+	requests := []entity.ReserveStock{}
+	for range 9 {
+		requests = append(requests, reserve(2))
+	}
+	requests = append(requests, reserve(3))
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(requests))
+	for i, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = repo.ReserveStock(ctx, req)
+		}()
+	}
+	wg.Wait()
+
+	// only the requests with the quantities of the first reservation succeed, the rest are refused as a mismatch
+	reserved := map[int64]bool{}
+	for i, err := range errs {
+		if err == nil {
+			reserved[requests[i].Stocks[0].Quantity] = true
+			continue
+		}
+		require.ErrorIs(t, err, entity.ErrReservationMismatch)
+	}
+	require.Len(t, reserved, 1)
+
+	// the stock is only taken once
+	var quantity int64
+	err := db.QueryRow(`SELECT quantity FROM stocks WHERE product_id = ?;`, productID).Scan(&quantity)
+	require.NoError(t, err)
+	for reservedQuantity := range reserved {
+		require.Equal(t, 100-reservedQuantity, quantity)
+	}
+}
+
+func TestStockMovementsRebuildTheBalance(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewWarehouseRepo(db)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	insertStock(t, db, productID.String(), 20)
+	_, err := db.Exec(`INSERT INTO warehouses(id, name, is_active) VALUES (2, 'second', TRUE);`)
+	require.NoError(t, err)
+
+	// the stock that exists before the ledger is recorded as OPENING by the migration, 3 days ago
+	_, err = db.Exec(`INSERT INTO stock_movements (stock_id, warehouse_id, product_id, type, quantity, balance, actor, created_at)
+		SELECT id, warehouse_id, product_id, 'OPENING', quantity, quantity, 'SYSTEM', DATETIME('now', '-3 days') FROM stocks;`)
+	require.NoError(t, err)
+
+	_, err = repo.ReceiveStock(ctx, entity.ReceiveStock{
+		ProductID:   productID,
+		WarehouseID: 1,
+		ShopID:      1,
+		Quantity:    10,
+		LotNumber:   "LOT-1",
+		ReceivedAt:  time.Now(),
+		ExpiresAt:   time.Now().Add(30 * 24 * time.Hour),
+		Actor:       "admin",
+	})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	paidOrderID := uuid.NewString()
+	cancelledOrderID := uuid.NewString()
+	for _, reserve := range []struct {
+		orderID  string
+		quantity int64
+	}{
+		{orderID: paidOrderID, quantity: 12},
+		{orderID: cancelledOrderID, quantity: 5},
+	} {
+		_, err = repo.ReserveStock(ctx, entity.ReserveStock{
+			Stocks:    []entity.Stock{{ProductID: productID, Quantity: reserve.quantity}},
+			OrderID:   reserve.orderID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = repo.ReleaseStock(ctx, entity.ReleaseStock{OrderID: cancelledOrderID, UserID: userID, Actor: userID.String()})
+	require.NoError(t, err)
+
+	_, err = repo.ConfirmStock(ctx, entity.ConfirmStock{OrderID: paidOrderID, UserID: userID})
+	require.NoError(t, err)
+
+	_, err = repo.RestockStock(ctx, entity.RestockStock{
+		Stocks:   []entity.Stock{{ProductID: productID, Quantity: 4}},
+		OrderID:  paidOrderID,
+		RefundID: uuid.NewString(),
+		UserID:   userID,
+	})
+	require.NoError(t, err)
+
+	err = repo.TransferStockBetweenWarehouse(ctx, entity.TransferStock{
+		TransferID:      uuid.NewString(),
+		FromWarehouseID: 1,
+		ToWarehouseID:   2,
+		ProductID:       productID.String(),
+		Quantity:        3,
+		Actor:           "admin",
+	})
+	require.NoError(t, err)
+
+	_, err = repo.AdjustStock(ctx, entity.AdjustStock{
+		ProductID:   productID,
+		WarehouseID: 1,
+		ShopID:      1,
+		Quantity:    -2,
+		Reason:      constanta.StockAdjustmentReasonDamage,
+		Actor:       "admin",
+	})
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT DISTINCT type FROM stock_movements WHERE product_id = ?;`, productID.String())
+	require.NoError(t, err)
+	movementTypes := []constanta.StockMovementType{}
+	for rows.Next() {
+		var movementType constanta.StockMovementType
+		require.NoError(t, rows.Scan(&movementType))
+		movementTypes = append(movementTypes, movementType)
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	require.ElementsMatch(t, []constanta.StockMovementType{
+		constanta.StockMovementOpening,
+		constanta.StockMovementReceive,
+		constanta.StockMovementReserve,
+		constanta.StockMovementRelease,
+		constanta.StockMovementConfirm,
+		constanta.StockMovementTransferOut,
+		constanta.StockMovementTransferIn,
+		constanta.StockMovementRestock,
+		constanta.StockMovementAdjustment,
+	}, movementTypes)
+
+	// every movement records the running balance of its stock, which ends at the quantity of the stock
+	rows, err = db.Query(`SELECT stock_id, quantity, balance FROM stock_movements WHERE product_id = ? ORDER BY id;`, productID.String())
+	require.NoError(t, err)
+	balances := map[int64]int64{}
+	for rows.Next() {
+		var stockID, quantity, balance int64
+		require.NoError(t, rows.Scan(&stockID, &quantity, &balance))
+		balances[stockID] += quantity
+		require.Equal(t, balances[stockID], balance, "balance of stock %d", stockID)
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+
+	stockQuantities := map[int64]int64{}
+	warehouseQuantities := map[int64]int64{}
+	rows, err = db.Query(`SELECT id, warehouse_id, quantity FROM stocks WHERE product_id = ?;`, productID.String())
+	require.NoError(t, err)
+	for rows.Next() {
+		var stockID, warehouseID, quantity int64
+		require.NoError(t, rows.Scan(&stockID, &warehouseID, &quantity))
+		stockQuantities[stockID] = quantity
+		warehouseQuantities[warehouseID] += quantity
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	require.Equal(t, stockQuantities, balances)
+	require.Equal(t, map[int64]int64{1: 17, 2: 3}, warehouseQuantities)
+
+	balance, err := repo.GetStockBalance(ctx, productID.String(), 0, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), balance)
+
+	for warehouseID, quantity := range warehouseQuantities {
+		balance, err = repo.GetStockBalance(ctx, productID.String(), warehouseID, time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, quantity, balance, "balance of warehouse %d", warehouseID)
+	}
+
+	// only the opening stock exists 2 days ago, nothing exists before it
+	balance, err = repo.GetStockBalance(ctx, productID.String(), 1, time.Now().Add(-2*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(20), balance)
+
+	balance, err = repo.GetStockBalance(ctx, productID.String(), 2, time.Now().Add(-2*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance)
+
+	balance, err = repo.GetStockBalance(ctx, productID.String(), 0, time.Now().Add(-4*24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance)
+}
+
+func TestReserveStockTakesTheLotThatExpiresFirst(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlitedb.NewWarehouseRepo(db)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO warehouses(id, name, is_active) VALUES (1, 'main', TRUE);`)
+	require.NoError(t, err)
+
+	productID := uuid.New()
+	now := time.Now()
+	receive := func(lotNumber string, expiresAt time.Time, quantity int64) int64 {
+		movement, err := repo.ReceiveStock(ctx, entity.ReceiveStock{
+			ProductID:   productID,
+			WarehouseID: 1,
+			ShopID:      1,
+			Quantity:    quantity,
+			LotNumber:   lotNumber,
+			ReceivedAt:  now,
+			ExpiresAt:   expiresAt,
+			Actor:       "admin",
+		})
+		require.NoError(t, err)
+		return movement.StockID
+	}
+
+	expiredLot := receive("LOT-EXPIRED", now.Add(-time.Hour), 10)
+	soonLot := receive("LOT-SOON", now.Add(24*time.Hour), 2)
+	laterLot := receive("LOT-LATER", now.Add(48*time.Hour), 3)
+	// the stock that does not expire is taken last
+	untrackedLot := receive("", time.Time{}, 10)
+
+	reserve := func(quantity int64) (*entity.Reservation, error) {
+		return repo.ReserveStock(ctx, entity.ReserveStock{
+			Stocks:    []entity.Stock{{ProductID: productID, Quantity: quantity}},
+			OrderID:   uuid.NewString(),
+			UserID:    uuid.New(),
+			ExpiresAt: now.Add(time.Hour),
+		})
+	}
+
+	reservedQuantities := func(reservation *entity.Reservation) map[int64]int64 {
+		quantities := map[int64]int64{}
+		for _, reserved := range reservation.ReservedStocks {
+			quantities[reserved.StockID] += reserved.Quantity
+		}
+		return quantities
+	}
+
+	stockQuantity := func(stockID int64) int64 {
+		var quantity int64
+		err := db.QueryRow(`SELECT quantity FROM stocks WHERE id = ?;`, stockID).Scan(&quantity)
+		require.NoError(t, err)
+		return quantity
+	}
+
+	// the lot that expires first is sold out
+	reservation, err := reserve(2)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int64{soonLot: 2}, reservedQuantities(reservation))
+	require.Equal(t, int64(0), stockQuantity(soonLot))
+
+	// the sold out lot is skipped, the next lot is taken before the stock that does not expire
+	reservation, err = reserve(4)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int64{laterLot: 3, untrackedLot: 1}, reservedQuantities(reservation))
+
+	// the expired lot is never taken
+	_, err = reserve(10)
+	require.ErrorContains(t, err, "insufficient stock")
+
+	reservation, err = reserve(9)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]int64{untrackedLot: 9}, reservedQuantities(reservation))
+
+	require.Equal(t, int64(10), stockQuantity(expiredLot))
+	require.Equal(t, int64(0), stockQuantity(laterLot))
+	require.Equal(t, int64(0), stockQuantity(untrackedLot))
+
+	// only the expired lot is left
+	_, err = reserve(1)
+	require.ErrorContains(t, err, "is empty")
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type (
+	warehouseService interface {
+		ReleaseExpiredReservations(ctx context.Context) (int, error)
+		CancelReservedOrdersOfInactiveWarehouses(ctx context.Context) (int, error)
+	}
+
+	TaskWarehouse struct {
+		closeChan chan struct{}
+		svc       warehouseService
+	}
+)
+
+func NewTaskWarehouse(warehouseService warehouseService) *TaskWarehouse {
+	tw := &TaskWarehouse{
+		closeChan: make(chan struct{}),
+		svc:       warehouseService,
+	}
+
+	go tw.backgroundJobs()
+
+	return tw
+}
+
+func (tw *TaskWarehouse) releaseExpiredReservations() error {
+	released, err := tw.svc.ReleaseExpiredReservations(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if released > 0 {
+		fmt.Printf("releasing %d expired reservation(s)\n", released)
+	}
+
+	return nil
+}
+
+func (tw *TaskWarehouse) cancelReservedOrdersOfInactiveWarehouses() error {
+	cancelled, err := tw.svc.CancelReservedOrdersOfInactiveWarehouses(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if cancelled > 0 {
+		fmt.Printf("cancelling %d order(s) of deactivated warehouses\n", cancelled)
+	}
+
+	return nil
+}
+
+func (tw *TaskWarehouse) Close() {
+	tw.closeChan <- struct{}{}
+}
+
+func (tw *TaskWarehouse) backgroundJobs() {
+	fmt.Println("running warehouse backgroundJobs")
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := tw.releaseExpiredReservations()
+			if err != nil {
+				fmt.Println("getting error from ReleaseExpiredReservations", err)
+			}
+
+			err = tw.cancelReservedOrdersOfInactiveWarehouses()
+			if err != nil {
+				fmt.Println("getting error from CancelReservedOrdersOfInactiveWarehouses", err)
+			}
+
+		case <-tw.closeChan:
+			fmt.Println("warehouse task closed")
+			return
+		}
+	}
+}
@@ -0,0 +1,27 @@
+-- the goods that are received into the stock
+CREATE TABLE stock_receipts (
+    id INTEGER PRIMARY KEY,
+    stock_id INTEGER NOT NULL REFERENCES stocks(id),
+    quantity INTEGER NOT NULL,
+    lot_number TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMP NOT NULL,
+    actor TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_receipts_stock_id ON stock_receipts(stock_id);
+
+-- the corrections of the stock, e.g. damaged goods or the difference of a cycle count
+CREATE TABLE stock_adjustments (
+    id INTEGER PRIMARY KEY,
+    stock_id INTEGER NOT NULL REFERENCES stocks(id),
+    -- negative when it is taken from the stock
+    quantity INTEGER NOT NULL,
+    -- DAMAGE, LOSS or CYCLE_COUNT
+    reason TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_adjustments_stock_id ON stock_adjustments(stock_id);
@@ -0,0 +1,22 @@
+-- the lots of the same stock are merged into the oldest one
+CREATE TABLE stocks_no_lot (
+    id INTEGER PRIMARY KEY,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    product_id TEXT NOT NULL,
+    shop_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, shop_id, warehouse_id)
+);
+
+INSERT INTO stocks_no_lot (id, warehouse_id, product_id, shop_id, quantity, created_at, updated_at)
+SELECT MIN(id), warehouse_id, product_id, shop_id, SUM(quantity), MIN(created_at), MAX(updated_at)
+FROM stocks
+GROUP BY product_id, shop_id, warehouse_id;
+
+DROP INDEX IF EXISTS idx_stocks_expires_at;
+
+DROP TABLE stocks;
+
+ALTER TABLE stocks_no_lot RENAME TO stocks;
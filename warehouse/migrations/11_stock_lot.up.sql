@@ -0,0 +1,25 @@
+-- the stock is kept per lot, so the lot with the earliest expiry is sold first.
+-- sqlite cannot change the unique constraint, the table is rebuilt
+CREATE TABLE stocks_lot (
+    id INTEGER PRIMARY KEY,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    product_id TEXT NOT NULL,
+    shop_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    -- empty for the stock that is not tracked by lot
+    lot_number TEXT NOT NULL DEFAULT '',
+    -- NULL for the stock that does not expire
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(product_id, shop_id, warehouse_id, lot_number)
+);
+
+INSERT INTO stocks_lot (id, warehouse_id, product_id, shop_id, quantity, created_at, updated_at)
+SELECT id, warehouse_id, product_id, shop_id, quantity, created_at, updated_at FROM stocks;
+
+DROP TABLE stocks;
+
+ALTER TABLE stocks_lot RENAME TO stocks;
+
+CREATE INDEX idx_stocks_expires_at ON stocks(expires_at);
@@ -0,0 +1,2 @@
+-- region is the shipping origin of the order service, e.g., JKT
+ALTER TABLE warehouses ADD COLUMN region TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS restocked_stocks;
\ No newline at end of file
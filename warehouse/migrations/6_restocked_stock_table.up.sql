@@ -0,0 +1,12 @@
+-- confirmed stock of the refunded order that is returned into the warehouse it is taken from
+CREATE TABLE restocked_stocks (
+    id INTEGER PRIMARY KEY,
+    stock_id INTEGER NOT NULL REFERENCES stocks(id),
+    reserved_stock_id INTEGER NOT NULL REFERENCES reserved_stocks(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    user_id TEXT NOT NULL,
+    refund_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_restocked_stocks_refund_id ON restocked_stocks(refund_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reserved_stocks_status_expires_at;
+ALTER TABLE reserved_stocks DROP COLUMN expires_at;
@@ -0,0 +1,7 @@
+-- the reserved stock is released by the warehouse service once it expires,
+-- the reservation that is made before expires 15 minutes after it is created
+ALTER TABLE reserved_stocks ADD COLUMN expires_at TIMESTAMP;
+
+UPDATE reserved_stocks SET expires_at = DATETIME(created_at, '+15 minutes') WHERE status = 'reserved';
+
+CREATE INDEX idx_reserved_stocks_status_expires_at ON reserved_stocks(status, expires_at);
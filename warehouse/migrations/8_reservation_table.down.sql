@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reserved_stocks_order_id;
+DROP TABLE IF EXISTS reservation_items;
+DROP TABLE IF EXISTS reservations;
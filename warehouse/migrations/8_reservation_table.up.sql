@@ -0,0 +1,25 @@
+-- one reservation per order, the order saga can reserve the stock of the same order again.
+-- the requested quantity is kept so the same order with different quantities is refused
+CREATE TABLE reservations (
+    order_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE reservation_items (
+    order_id TEXT NOT NULL REFERENCES reservations(order_id),
+    product_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_id, product_id)
+);
+
+INSERT OR IGNORE INTO reservations (order_id, user_id, created_at)
+SELECT order_id, user_id, MIN(created_at) FROM reserved_stocks GROUP BY order_id;
+
+INSERT OR IGNORE INTO reservation_items (order_id, product_id, quantity)
+SELECT rs.order_id, s.product_id, SUM(rs.quantity)
+FROM reserved_stocks rs
+JOIN stocks s ON s.id = rs.stock_id
+GROUP BY rs.order_id, s.product_id;
+
+CREATE INDEX idx_reserved_stocks_order_id ON reserved_stocks(order_id);
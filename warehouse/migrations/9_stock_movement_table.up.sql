@@ -0,0 +1,36 @@
+-- append-only ledger of every change of the stock,
+-- the balance of a product at any time is the sum of the quantity of its movements before that time
+CREATE TABLE stock_movements (
+    id INTEGER PRIMARY KEY,
+    stock_id INTEGER NOT NULL REFERENCES stocks(id),
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id),
+    product_id TEXT NOT NULL,
+    -- OPENING, RECEIVE, RESERVE, RELEASE, CONFIRM, TRANSFER_OUT, TRANSFER_IN, RESTOCK or ADJUSTMENT
+    type TEXT NOT NULL,
+    -- the change of the quantity of the stock, negative when it is taken from the stock
+    quantity INTEGER NOT NULL,
+    -- the quantity of the stock after the movement
+    balance INTEGER NOT NULL,
+    -- the user that requests the change, or SYSTEM
+    actor TEXT NOT NULL,
+    -- the order, refund, transfer, receipt or adjustment id
+    reference TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product_id_created_at ON stock_movements(product_id, created_at);
+CREATE INDEX idx_stock_movements_warehouse_id_created_at ON stock_movements(warehouse_id, created_at);
+CREATE INDEX idx_stock_movements_reference ON stock_movements(reference);
+
+CREATE TRIGGER stock_movements_no_update BEFORE UPDATE ON stock_movements
+BEGIN
+    SELECT RAISE(ABORT, 'stock_movements is append-only');
+END;
+
+CREATE TRIGGER stock_movements_no_delete BEFORE DELETE ON stock_movements
+BEGIN
+    SELECT RAISE(ABORT, 'stock_movements is append-only');
+END;
+
+INSERT INTO stock_movements (stock_id, warehouse_id, product_id, type, quantity, balance, actor)
+SELECT id, warehouse_id, product_id, 'OPENING', quantity, quantity, 'SYSTEM' FROM stocks;
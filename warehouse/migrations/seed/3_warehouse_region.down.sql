@@ -0,0 +1 @@
+UPDATE warehouses SET region = '' WHERE id IN (1, 2);
@@ -0,0 +1,2 @@
+UPDATE warehouses SET region = 'JKT' WHERE id = 1;
+UPDATE warehouses SET region = 'BDG' WHERE id = 2;
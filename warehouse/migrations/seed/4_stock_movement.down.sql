@@ -0,0 +1 @@
+-- stock_movements is append-only, the opening movements are dropped with the table
@@ -0,0 +1,4 @@
+-- the seeded stocks are recorded as the opening balance of the ledger
+INSERT INTO stock_movements (stock_id, warehouse_id, product_id, type, quantity, balance, actor)
+SELECT s.id, s.warehouse_id, s.product_id, 'OPENING', s.quantity, s.quantity, 'SYSTEM' FROM stocks s
+WHERE NOT EXISTS (SELECT 1 FROM stock_movements sm WHERE sm.stock_id = s.id);